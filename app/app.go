@@ -7,9 +7,13 @@ package app
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -26,6 +30,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"go.uber.org/automaxprocs/maxprocs"
 
+	"github.com/obolnetwork/charon/app/archive"
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/featureset"
@@ -38,6 +43,7 @@ import (
 	"github.com/obolnetwork/charon/app/retry"
 	"github.com/obolnetwork/charon/app/tracer"
 	"github.com/obolnetwork/charon/app/version"
+	"github.com/obolnetwork/charon/app/wal"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/cluster/manifest"
@@ -47,6 +53,7 @@ import (
 	"github.com/obolnetwork/charon/core/bcast"
 	"github.com/obolnetwork/charon/core/consensus"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/doppelganger"
 	"github.com/obolnetwork/charon/core/dutydb"
 	"github.com/obolnetwork/charon/core/fetcher"
 	"github.com/obolnetwork/charon/core/infosync"
@@ -56,9 +63,11 @@ import (
 	"github.com/obolnetwork/charon/core/priority"
 	"github.com/obolnetwork/charon/core/scheduler"
 	"github.com/obolnetwork/charon/core/sigagg"
+	"github.com/obolnetwork/charon/core/signer"
 	"github.com/obolnetwork/charon/core/tracker"
 	"github.com/obolnetwork/charon/core/validatorapi"
 	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/registration"
 	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
@@ -78,7 +87,13 @@ type Config struct {
 	PrivKeyLocking          bool
 	MonitoringAddr          string
 	ValidatorAPIAddr        string
+	ValidatorAPITLS         ValidatorAPITLSConfig
+	ValidatorAPITokensFile  string
+	Signer                  string
+	ValidatorKeysDir        string
+	Web3SignerAddr          string
 	BeaconNodeAddrs         []string
+	BeaconNodeCrossCheck    []string
 	JaegerAddr              string
 	JaegerService           string
 	SimnetBMock             bool
@@ -87,11 +102,26 @@ type Config struct {
 	SimnetSlotDuration      time.Duration
 	SyntheticBlockProposals bool
 	BuilderAPI              bool
+	BuilderMinBidGwei       uint64
 	SimnetBMockFuzz         bool
+	DataDir                 string
+	DoppelgangerEpochs      int
+	ArchiveSizeMB           int
+	WAL                     bool
 
 	TestConfig TestConfig
 }
 
+// ValidatorAPITLSConfig defines the optional TLS config of the validator API.
+type ValidatorAPITLSConfig struct {
+	// CertFile is the path to the server certificate, enables TLS if not empty.
+	CertFile string
+	// KeyFile is the path to the server certificate private key.
+	KeyFile string
+	// ClientCAFile is the path to the CA certificates used to verify client certificates, enables mTLS if not empty.
+	ClientCAFile string
+}
+
 // TestConfig defines additional test-only config.
 type TestConfig struct {
 	p2p.TestPingConfig
@@ -104,7 +134,7 @@ type TestConfig struct {
 	ParSigExFunc func() core.ParSigEx
 	// LcastTransportFunc provides an in-memory leader cast transport.
 	LcastTransportFunc func() leadercast.Transport
-	// SimnetKeys provides private key shares for the simnet validatormock signer.
+	// SimnetKeys provides private key shares for the simnet validatormock and embedded signers.
 	SimnetKeys []tbls.PrivateKey
 	// SimnetBMockOpts defines additional simnet beacon mock options.
 	SimnetBMockOpts []beaconmock.Option
@@ -235,6 +265,13 @@ func Run(ctx context.Context, conf Config) (err error) {
 
 	qbftDebug := newQBFTDebugger()
 
+	arch, err := newArchive(conf)
+	if err != nil {
+		return err
+	} else if arch != nil {
+		life.RegisterStop(lifecycle.StopArchive, lifecycle.HookFuncErr(arch.Close))
+	}
+
 	// seenPubkeys channel to send seen public keys from validatorapi to monitoringapi.
 	seenPubkeys := make(chan core.PubKey)
 	seenPubkeysFunc := func(pk core.PubKey) {
@@ -261,7 +298,7 @@ func Run(ctx context.Context, conf Config) (err error) {
 		promRegistry, qbftDebug, pubkeys, seenPubkeys, vapiCalls)
 
 	err = wireCoreWorkflow(ctx, life, conf, cluster, nodeIdx, tcpNode, p2pKey, eth2Cl,
-		peerIDs, sender, qbftDebug.AddInstance, seenPubkeysFunc, vapiCallsFunc)
+		peerIDs, sender, newQBFTSniffer(ctx, qbftDebug, arch), arch, seenPubkeysFunc, vapiCallsFunc)
 	if err != nil {
 		return err
 	}
@@ -333,7 +370,7 @@ func wireP2P(ctx context.Context, life *lifecycle.Manager, conf Config,
 func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 	cluster *manifestpb.Cluster, nodeIdx cluster.NodeIdx, tcpNode host.Host, p2pKey *k1.PrivateKey,
 	eth2Cl eth2wrap.Client, peerIDs []peer.ID, sender *p2p.Sender,
-	qbftSniffer func(*pbv1.SniffedConsensusInstance), seenPubkeys func(core.PubKey),
+	qbftSniffer func(*pbv1.SniffedConsensusInstance), arch *archive.Archive, seenPubkeys func(core.PubKey),
 	vapiCalls func(),
 ) error {
 	// Convert and prep public keys and public shares
@@ -426,12 +463,20 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return err
 	}
 
-	fetch, err := fetcher.New(eth2Cl, feeRecipientFunc)
+	fetch, err := fetcher.New(eth2Cl, feeRecipientFunc, eth2p0.Gwei(conf.BuilderMinBidGwei))
 	if err != nil {
 		return err
 	}
 
-	dutyDB := dutydb.NewMemDB(deadlinerFunc("dutydb"))
+	memDutyDB := dutydb.NewMemDB(deadlinerFunc("dutydb"))
+
+	var dutyDB core.DutyDB = memDutyDB
+	if conf.DataDir != "" {
+		dutyDB, err = dutydb.NewDiskDB(conf.DataDir, memDutyDB, deadlinerFunc("dutydb_disk"))
+		if err != nil {
+			return err
+		}
+	}
 
 	vapi, err := validatorapi.NewComponent(eth2Cl, allPubSharesByKey, nodeIdx.ShareIdx, feeRecipientFunc,
 		mutableConf.BuilderAPI, seenPubkeys)
@@ -439,11 +484,15 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return err
 	}
 
-	if err := wireVAPIRouter(ctx, life, conf.ValidatorAPIAddr, eth2Cl, vapi, vapiCalls); err != nil {
+	if err := wireVAPIRouter(ctx, life, conf, eth2Cl, vapi, vapiCalls); err != nil {
 		return err
 	}
 
-	parSigDB := parsigdb.NewMemDB(int(cluster.Threshold), deadlinerFunc("parsigdb"))
+	var parSigDBOpts []parsigdb.Option
+	if conf.DataDir != "" {
+		parSigDBOpts = append(parSigDBOpts, parsigdb.WithEvidenceFile(filepath.Join(conf.DataDir, parsigdb.EvidenceFilename)))
+	}
+	parSigDB := parsigdb.NewMemDB(int(cluster.Threshold), deadlinerFunc("parsigdb"), parSigDBOpts...)
 
 	var parSigEx core.ParSigEx
 	if conf.TestConfig.ParSigExFunc != nil {
@@ -454,17 +503,29 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 			return err
 		}
 
-		parSigEx = parsigex.NewParSigEx(tcpNode, sender.SendAsync, nodeIdx.PeerIdx, peerIDs, verifyFunc, gaterFunc)
+		var parSigExOpts []parsigex.Option
+		if featureset.Enabled(featureset.ParSigExRelay) {
+			parSigExOpts = append(parSigExOpts, parsigex.WithRelay(parsigex.DefaultRelayHops))
+		}
+
+		ex := parsigex.NewParSigEx(tcpNode, sender.SendAsync, nodeIdx.PeerIdx, peerIDs, verifyFunc, gaterFunc, parSigExOpts...)
+		life.RegisterStop(lifecycle.StopScheduler, lifecycle.HookFuncMin(ex.Stop))
+		parSigEx = ex
 	}
 
-	sigAgg, err := sigagg.New(int(cluster.Threshold), sigagg.NewVerifier(eth2Cl))
+	sigAgg, err := sigagg.New(int(cluster.Threshold), peers, allPubSharesByKey, sigagg.NewVerifier(eth2Cl))
 	if err != nil {
 		return err
 	}
 
 	aggSigDB := aggsigdb.NewMemDB(deadlinerFunc("aggsigdb"))
 
-	broadcaster, err := bcast.New(ctx, eth2Cl)
+	walStore, err := newWAL(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	broadcaster, err := bcast.New(ctx, eth2Cl, dutyDB.AwaitBlindedBeaconBlock)
 	if err != nil {
 		return err
 	}
@@ -477,22 +538,22 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		return err
 	}
 
-	err = wirePrioritise(ctx, conf, life, tcpNode, peerIDs, int(cluster.Threshold),
-		sender.SendReceive, cons, sched, p2pKey, deadlineFunc, mutableConf)
+	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl, arch)
 	if err != nil {
 		return err
 	}
 
-	if err = wireRecaster(ctx, eth2Cl, sched, sigAgg, broadcaster, cluster.Validators,
-		conf.BuilderAPI, conf.TestConfig.BroadcastCallback); err != nil {
-		return errors.Wrap(err, "wire recaster")
-	}
-
-	track, err := newTracker(ctx, life, deadlineFunc, peers, eth2Cl)
+	prio, err := wirePrioritise(ctx, conf, life, tcpNode, peerIDs, nodeIdx.PeerIdx, int(cluster.Threshold),
+		sender.SendReceive, cons, sched, p2pKey, deadlineFunc, mutableConf, track)
 	if err != nil {
 		return err
 	}
 
+	if err = wireRecaster(ctx, eth2Cl, sched, sigAgg, broadcaster, cluster.Validators,
+		conf.BuilderAPI, conf.TestConfig.BroadcastCallback, walStore); err != nil {
+		return errors.Wrap(err, "wire recaster")
+	}
+
 	inclusion, err := tracker.NewInclusion(ctx, eth2Cl, track.InclusionChecked)
 	if err != nil {
 		return err
@@ -503,6 +564,17 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		core.WithTracking(track, inclusion),
 		core.WithAsyncRetry(retryer),
 	}
+	if conf.Signer == SignerEmbedded || conf.Signer == SignerWeb3Signer {
+		embeddedSigner, err := newEmbeddedSigner(ctx, conf, cluster, nodeIdx.ShareIdx, eth2Cl,
+			signer.WithBuilderRegistrations(corePubkeys, mutableConf.BuilderAPI, feeRecipientFunc, registration.DefaultGasLimit))
+		if err != nil {
+			return err
+		}
+		opts = append(opts, core.WithEmbeddedSigner(embeddedSigner))
+	}
+	if walStore != nil {
+		wireWAL(walStore, parSigDB, sigAgg)
+	}
 	core.Wire(sched, fetch, cons, dutyDB, vapi, parSigDB, parSigEx, sigAgg, aggSigDB, broadcaster, opts...)
 
 	err = wireValidatorMock(conf, pubshares, sched)
@@ -514,32 +586,62 @@ func wireCoreWorkflow(ctx context.Context, life *lifecycle.Manager, conf Config,
 		sigAgg.Subscribe(conf.TestConfig.BroadcastCallback)
 	}
 
-	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartScheduler, lifecycle.HookFuncErr(sched.Run))
+	wireScheduler(life, conf, eth2Cl, prio, sched)
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartP2PConsensus, startCons)
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartAggSigDB, lifecycle.HookFuncCtx(aggSigDB.Run))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartParSigDB, lifecycle.HookFuncCtx(parSigDB.Trim))
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartTracker, lifecycle.HookFuncCtx(inclusion.Run))
+	if walStore != nil {
+		life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartWALReplay, lifecycle.HookFuncCtx(func(ctx context.Context) {
+			replayWAL(ctx, walStore.Replay(), deadlineFunc, aggSigDB, parSigDB)
+		}))
+		life.RegisterStop(lifecycle.StopWAL, lifecycle.HookFuncErr(walStore.Close))
+	}
 	life.RegisterStop(lifecycle.StopScheduler, lifecycle.HookFuncMin(sched.Stop))
-	life.RegisterStop(lifecycle.StopDutyDB, lifecycle.HookFuncMin(dutyDB.Shutdown))
+	life.RegisterStop(lifecycle.StopDutyDB, lifecycle.HookFuncMin(memDutyDB.Shutdown))
 	life.RegisterStop(lifecycle.StopRetryer, lifecycle.HookFuncCtx(retryer.Shutdown))
 
 	return nil
 }
 
+// wireScheduler registers the scheduler start hook, delaying it until doppelganger detection completes if enabled.
+func wireScheduler(life *lifecycle.Manager, conf Config, eth2Cl eth2wrap.Client, prio *priority.Component, sched *scheduler.Scheduler) {
+	if conf.DoppelgangerEpochs <= 0 {
+		life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartScheduler, lifecycle.HookFuncErr(sched.Run))
+		return
+	}
+
+	var prioritiser doppelganger.Prioritiser // Nil interface if disabled, since a nil *priority.Component isn't a nil interface.
+	if prio != nil {
+		prioritiser = prio
+	}
+	doppel := doppelganger.New(eth2Cl, prioritiser, conf.DoppelgangerEpochs)
+
+	// Use the app context so shutdown isn't blocked by doppelganger detection, the scheduler is stopped explicitly.
+	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartScheduler, lifecycle.HookFunc(func(ctx context.Context) error {
+		if err := doppel.Run(ctx); err != nil {
+			return err
+		}
+
+		return sched.Run()
+	}))
+}
+
 // wirePrioritise wires the priority protocol which determines cluster wide priorities for the next epoch.
+// It returns nil if the priority protocol is not enabled.
 func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, tcpNode host.Host,
-	peers []peer.ID, threshold int, sendFunc p2p.SendReceiveFunc, coreCons core.Consensus,
+	peers []peer.ID, peerIdx int, threshold int, sendFunc p2p.SendReceiveFunc, coreCons core.Consensus,
 	sched core.Scheduler, p2pKey *k1.PrivateKey, deadlineFunc func(duty core.Duty) (time.Time, bool),
-	mutableConf *mutableConfig,
-) error {
+	mutableConf *mutableConfig, track *tracker.Tracker,
+) (*priority.Component, error) {
 	if !featureset.Enabled(featureset.Priority) {
-		return nil
+		return nil, nil
 	}
 
 	cons, ok := coreCons.(*consensus.Component)
 	if !ok {
 		// Priority protocol not supported for leader cast.
-		return nil
+		return nil, nil
 	}
 
 	// exchangeTimeout of 6 seconds (half a slot) is a good thumb suck.
@@ -549,15 +651,25 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 	prio, err := priority.NewComponent(ctx, tcpNode, peers, threshold,
 		sendFunc, p2p.RegisterHandler, cons, exchangeTimeout, p2pKey, deadlineFunc)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	var peerRanking func() []int64
+	if featureset.Enabled(featureset.LatencyLeader) {
+		peerRanking = newPeerRanking(tcpNode, peers, peerIdx, track)
 	}
 
 	isync := infosync.New(prio,
 		version.Supported(),
 		Protocols(),
 		ProposalTypes(conf.BuilderAPI, conf.SyntheticBlockProposals),
+		len(peers),
+		peerRanking,
 	)
 
+	// Peers follow the agreed upon leader order even if they don't rank peers themselves.
+	cons.SetLeaderOrder(isync.LeaderOrder)
+
 	mutableConf.SetInfoSync(isync)
 
 	// Trigger info syncs in last slot of the epoch (for the next epoch).
@@ -575,14 +687,34 @@ func wirePrioritise(ctx context.Context, conf Config, life *lifecycle.Manager, t
 
 	life.RegisterStart(lifecycle.AsyncAppCtx, lifecycle.StartPeerInfo, lifecycle.HookFuncCtx(prio.Start))
 
-	return nil
+	return prio, nil
+}
+
+// newPeerRanking returns a function that ranks peers as leaders by recent ping round trip times and duty participation.
+func newPeerRanking(tcpNode host.Host, peers []peer.ID, peerIdx int, track *tracker.Tracker) func() []int64 {
+	return func() []int64 {
+		rtts := make(map[int64]time.Duration)
+		for i, p := range peers {
+			if p != tcpNode.ID() {
+				rtts[int64(i)] = tcpNode.Peerstore().LatencyEWMA(p)
+			}
+		}
+
+		participation := make(map[int64]float64)
+		for shareIdx, rate := range track.ParticipationRates() {
+			participation[int64(shareIdx-1)] = rate // Share indexes are 1-indexed.
+		}
+
+		return consensus.RankPeers(len(peers), int64(peerIdx), rtts, participation)
+	}
 }
 
 // wireRecaster wires the rebroadcaster component to scheduler, sigAgg and broadcaster.
 // This is not done in core.Wire since recaster isn't really part of the official core workflow (yet).
+// Registrations persisted in the optional write-ahead store are restored, so recasting resumes after restarts.
 func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Scheduler, sigAgg core.SigAgg,
 	broadcaster core.Broadcaster, validators []*manifestpb.Validator, builderAPI bool,
-	callback func(context.Context, core.Duty, core.SignedDataSet) error,
+	callback func(context.Context, core.Duty, core.SignedDataSet) error, walStore *wal.Store,
 ) error {
 	recaster, err := bcast.NewRecaster(func(ctx context.Context) (map[eth2p0.BLSPubKey]struct{}, error) {
 		valList, err := eth2Cl.ActiveValidators(ctx)
@@ -610,6 +742,14 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 		recaster.Subscribe(callback)
 	}
 
+	if walStore != nil {
+		for duty, set := range walStore.Replay().Registrations {
+			if err := recaster.Store(ctx, duty, set); err != nil {
+				return errors.Wrap(err, "recaster store wal registrations")
+			}
+		}
+	}
+
 	if !builderAPI || !featureset.Enabled(featureset.PreGenRegistrations) {
 		return nil
 	}
@@ -650,8 +790,8 @@ func wireRecaster(ctx context.Context, eth2Cl eth2wrap.Client, sched core.Schedu
 
 // newTracker creates and starts a new tracker instance.
 func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(duty core.Duty) (time.Time, bool),
-	peers []p2p.Peer, eth2Cl eth2wrap.Client,
-) (core.Tracker, error) {
+	peers []p2p.Peer, eth2Cl eth2wrap.Client, arch *archive.Archive,
+) (*tracker.Tracker, error) {
 	slotDuration, err := eth2Cl.SlotDuration(ctx)
 	if err != nil {
 		return nil, err
@@ -674,7 +814,12 @@ func newTracker(ctx context.Context, life *lifecycle.Manager, deadlineFunc func(
 		return nil, err
 	}
 
-	track := tracker.New(analyser, deleter, peers, trackFrom)
+	var opts []tracker.Option
+	if arch != nil {
+		opts = append(opts, tracker.WithArchiver(newTrackerArchiver(ctx, arch)))
+	}
+
+	track := tracker.New(analyser, deleter, peers, trackFrom, opts...)
 	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartTracker, lifecycle.HookFunc(track.Run))
 
 	return track, nil
@@ -803,6 +948,14 @@ func newETH2Client(ctx context.Context, conf Config, life *lifecycle.Manager,
 		return nil, errors.Wrap(err, "new eth2 http client")
 	}
 
+	if len(conf.BeaconNodeCrossCheck) > 0 {
+		log.Info(ctx, "Beacon node cross-check enabled", z.Any("groups", conf.BeaconNodeCrossCheck))
+		eth2Cl, err = eth2wrap.WithCrossCheck(eth2Cl, conf.BeaconNodeCrossCheck)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if conf.SyntheticBlockProposals {
 		log.Info(ctx, "Synthetic block proposals enabled")
 		eth2Cl = eth2wrap.WithSyntheticDuties(eth2Cl)
@@ -901,7 +1054,8 @@ func createMockValidators(pubkeys []eth2p0.BLSPubKey) beaconmock.ValidatorSet {
 }
 
 // wireVAPIRouter constructs the validator API router and registers it with the life cycle manager.
-func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, vapiAddr string, eth2Cl eth2wrap.Client,
+// It optionally serves the router over TLS and requires bearer token authentication.
+func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, conf Config, eth2Cl eth2wrap.Client,
 	handler validatorapi.Handler, vapiCalls func(),
 ) error {
 	vrouter, err := validatorapi.NewRouter(ctx, handler, eth2Cl)
@@ -909,21 +1063,85 @@ func wireVAPIRouter(ctx context.Context, life *lifecycle.Manager, vapiAddr strin
 		return errors.Wrap(err, "new monitoring server")
 	}
 
+	var vhandler http.Handler = vrouter
+	if conf.ValidatorAPITokensFile != "" {
+		tokens, err := validatorapi.LoadAuthTokens(conf.ValidatorAPITokensFile)
+		if err != nil {
+			return err
+		}
+
+		vhandler = validatorapi.NewAuthHandler(vrouter, tokens)
+		log.Info(ctx, "Validator API bearer token authentication enabled", z.Int("tokens", len(tokens)))
+	}
+
+	tlsConf, err := newVAPITLSConfig(conf.ValidatorAPITLS)
+	if err != nil {
+		return err
+	}
+
 	server := &http.Server{
-		Addr: vapiAddr,
+		Addr: conf.ValidatorAPIAddr,
 		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			vapiCalls()
-			vrouter.ServeHTTP(w, r)
+			vhandler.ServeHTTP(w, r)
 		}),
 		ReadHeaderTimeout: time.Second,
+		TLSConfig:         tlsConf,
+	}
+
+	serve := server.ListenAndServe
+	if tlsConf != nil {
+		serve = func() error {
+			// Certificates are already loaded in the TLS config.
+			return server.ListenAndServeTLS("", "")
+		}
+		log.Info(ctx, "Validator API TLS enabled", z.Bool("mtls", conf.ValidatorAPITLS.ClientCAFile != ""))
 	}
 
-	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartValidatorAPI, httpServeHook(server.ListenAndServe))
+	life.RegisterStart(lifecycle.AsyncBackground, lifecycle.StartValidatorAPI, httpServeHook(serve))
 	life.RegisterStop(lifecycle.StopValidatorAPI, lifecycle.HookFunc(server.Shutdown))
 
 	return nil
 }
 
+// newVAPITLSConfig returns the validator API TLS config or nil if TLS is disabled.
+func newVAPITLSConfig(conf ValidatorAPITLSConfig) (*tls.Config, error) {
+	if conf.CertFile == "" {
+		if conf.KeyFile != "" || conf.ClientCAFile != "" {
+			return nil, errors.New("validator api tls certificate file required")
+		}
+
+		return nil, nil //nolint:nilnil // TLS disabled.
+	}
+
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "load validator api tls certificate")
+	}
+
+	resp := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if conf.ClientCAFile != "" {
+		b, err := os.ReadFile(conf.ClientCAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read validator api tls client ca file")
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, errors.New("no certificates found in validator api tls client ca file")
+		}
+
+		resp.ClientCAs = pool
+		resp.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return resp, nil
+}
+
 // wireTracing constructs the global tracer and registers it with the life cycle manager.
 func wireTracing(life *lifecycle.Manager, conf Config) error {
 	stopjaeger, err := tracer.Init(
@@ -4,6 +4,16 @@ package app
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -84,3 +94,108 @@ func TestSlotFromTimestamp(t *testing.T) {
 		})
 	}
 }
+
+func TestNewVAPITLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCert(t, dir)
+
+	badCAFile := filepath.Join(dir, "bad_ca.pem")
+	require.NoError(t, os.WriteFile(badCAFile, []byte("not a certificate"), 0o644))
+
+	tests := []struct {
+		name       string
+		conf       ValidatorAPITLSConfig
+		disabled   bool
+		clientAuth tls.ClientAuthType
+		errStr     string
+	}{
+		{
+			name:     "disabled",
+			disabled: true,
+		},
+		{
+			name:   "missing cert file flag",
+			conf:   ValidatorAPITLSConfig{KeyFile: keyFile},
+			errStr: "validator api tls certificate file required",
+		},
+		{
+			name:   "missing cert file",
+			conf:   ValidatorAPITLSConfig{CertFile: filepath.Join(dir, "missing.pem"), KeyFile: keyFile},
+			errStr: "load validator api tls certificate",
+		},
+		{
+			name:       "tls",
+			conf:       ValidatorAPITLSConfig{CertFile: certFile, KeyFile: keyFile},
+			clientAuth: tls.NoClientCert,
+		},
+		{
+			name:   "missing ca file",
+			conf:   ValidatorAPITLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: filepath.Join(dir, "missing.pem")},
+			errStr: "read validator api tls client ca file",
+		},
+		{
+			name:   "bad ca file",
+			conf:   ValidatorAPITLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: badCAFile},
+			errStr: "no certificates found in validator api tls client ca file",
+		},
+		{
+			name:       "mtls",
+			conf:       ValidatorAPITLSConfig{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile},
+			clientAuth: tls.RequireAndVerifyClientCert,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := newVAPITLSConfig(test.conf)
+			if test.errStr != "" {
+				require.ErrorContains(t, err, test.errStr)
+				return
+			}
+			require.NoError(t, err)
+
+			if test.disabled {
+				require.Nil(t, conf)
+				return
+			}
+
+			require.Len(t, conf.Certificates, 1)
+			require.Equal(t, test.clientAuth, conf.ClientAuth)
+			require.Equal(t, test.clientAuth == tls.RequireAndVerifyClientCert, conf.ClientCAs != nil)
+		})
+	}
+}
+
+// writeTestCert writes a self-signed certificate and its private key to PEM files in the directory
+// and returns their paths.
+func writeTestCert(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "charon"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644))
+
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
@@ -0,0 +1,65 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/obolnetwork/charon/app/archive"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/tracker"
+)
+
+// newArchive returns a new rolling on-disk archive in the data directory or nil if disabled.
+func newArchive(conf Config) (*archive.Archive, error) {
+	if conf.ArchiveSizeMB <= 0 {
+		return nil, nil //nolint:nilnil // Archive disabled.
+	} else if conf.DataDir == "" {
+		return nil, errors.New("archive requires a data dir")
+	}
+
+	return archive.New(filepath.Join(conf.DataDir, archive.DirName), int64(conf.ArchiveSizeMB)<<20)
+}
+
+// newQBFTSniffer returns a qbft sniffer that adds sniffed instances to the debugger and the optional archive.
+func newQBFTSniffer(ctx context.Context, debugger *qbftDebugger, arch *archive.Archive) func(*pbv1.SniffedConsensusInstance) {
+	if arch == nil {
+		return debugger.AddInstance
+	}
+
+	return func(instance *pbv1.SniffedConsensusInstance) {
+		debugger.AddInstance(instance)
+
+		if len(instance.Msgs) == 0 || instance.Msgs[0].GetMsg().GetMsg() == nil {
+			return
+		}
+
+		duty := core.DutyFromProto(instance.Msgs[0].Msg.Msg.Duty)
+
+		b, err := protojson.Marshal(instance)
+		if err != nil {
+			log.Warn(ctx, "Marshal sniffed instance for archive", err, z.Any("duty", duty))
+			return
+		}
+
+		if err := arch.AddRaw(duty, archive.KindQBFTInstance, b); err != nil {
+			log.Warn(ctx, "Archive sniffed instance", err, z.Any("duty", duty))
+		}
+	}
+}
+
+// newTrackerArchiver returns a tracker archiver that adds the tracked history of duties to the archive.
+func newTrackerArchiver(ctx context.Context, arch *archive.Archive) func(core.Duty, tracker.ArchivedDuty) {
+	return func(duty core.Duty, archived tracker.ArchivedDuty) {
+		if err := arch.Add(duty, archive.KindTrackerEvents, archived); err != nil {
+			log.Warn(ctx, "Archive tracker events", err, z.Any("duty", duty))
+		}
+	}
+}
@@ -0,0 +1,405 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package archive provides a rolling on-disk archive of per-duty debug records, e.g. sniffed consensus
+// instances and tracker events, so that evidence is retained for analysing incidents after the fact.
+//
+// Records are appended as JSON lines to segment files that are rotated when they reach a fraction of the
+// maximum archive size, with the oldest segments deleted when the maximum size is exceeded. Each segment has
+// a companion index file mapping duties to record offsets.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+const (
+	// DirName is the name of the archive directory in the data directory.
+	DirName = "archive"
+
+	// segmentsPerArchive is the number of segments the maximum archive size is split into.
+	segmentsPerArchive = 8
+
+	segmentPrefix = "segment-"
+	segmentExt    = ".jsonl"
+	indexExt      = ".idx"
+)
+
+// Kind is the kind of archived record.
+type Kind string
+
+const (
+	// KindQBFTInstance is a protojson encoded sniffed consensus instance.
+	KindQBFTInstance Kind = "qbft_instance"
+	// KindTrackerEvents is a JSON encoded tracked duty history.
+	KindTrackerEvents Kind = "tracker_events"
+)
+
+// Record is an archived record of a duty.
+type Record struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Slot      int64           `json:"slot"`
+	DutyType  string          `json:"duty_type"`
+	Kind      Kind            `json:"kind"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Duty returns the duty of the record.
+func (r Record) Duty() string {
+	return fmt.Sprintf("%d/%s", r.Slot, r.DutyType)
+}
+
+// indexEntry is an entry in a segment index file identifying a record in the segment.
+type indexEntry struct {
+	Slot     int64  `json:"slot"`
+	DutyType string `json:"duty_type"`
+	Kind     Kind   `json:"kind"`
+	Offset   int64  `json:"offset"`
+	Length   int64  `json:"length"`
+}
+
+// segment is an archive segment consisting of a records file and an index file.
+type segment struct {
+	seq  int
+	size int64
+}
+
+// New returns a new archive in the provided directory capped at the maximum size in bytes.
+// Existing segments are retained, with new records appended to a new segment.
+func New(dir string, maxSize int64) (*Archive, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("invalid archive max size", z.I64("max_size", maxSize))
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create archive dir", z.Str("dir", dir))
+	}
+
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Archive{
+		dir:         dir,
+		maxSize:     maxSize,
+		segmentSize: maxSize / segmentsPerArchive,
+		segments:    segments,
+		now:         time.Now,
+	}
+
+	if err := a.rotateUnsafe(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Archive is a size-capped rolling on-disk archive of duty records.
+type Archive struct {
+	dir         string
+	maxSize     int64
+	segmentSize int64
+	now         func() time.Time
+
+	mu       sync.Mutex
+	segments []segment // Oldest first, the last is the current segment.
+	records  *os.File
+	index    *os.File
+	offset   int64 // Size of the current segment's records file.
+}
+
+// Add appends a record of the kind containing the JSON encoded data to the archive.
+func (a *Archive) Add(duty core.Duty, kind Kind, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "marshal archive data")
+	}
+
+	return a.AddRaw(duty, kind, b)
+}
+
+// AddRaw appends a record of the kind containing the raw JSON data to the archive.
+func (a *Archive) AddRaw(duty core.Duty, kind Kind, data json.RawMessage) error {
+	record, err := json.Marshal(Record{
+		Timestamp: a.now(),
+		Slot:      duty.Slot,
+		DutyType:  duty.Type.String(),
+		Kind:      kind,
+		Data:      data,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal archive record")
+	}
+	record = append(record, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.records == nil {
+		return errors.New("archive closed")
+	}
+
+	current := &a.segments[len(a.segments)-1]
+
+	// Write the record before the index entry, so index entries always refer to complete records.
+	if _, err := a.records.Write(record); err != nil {
+		return errors.Wrap(err, "write archive record")
+	}
+
+	entry, err := json.Marshal(indexEntry{
+		Slot:     duty.Slot,
+		DutyType: duty.Type.String(),
+		Kind:     kind,
+		Offset:   a.offset,
+		Length:   int64(len(record)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal archive index")
+	}
+	entry = append(entry, '\n')
+
+	if _, err := a.index.Write(entry); err != nil {
+		return errors.Wrap(err, "write archive index")
+	}
+
+	a.offset += int64(len(record))
+	current.size += int64(len(record) + len(entry))
+
+	if current.size >= a.segmentSize {
+		return a.rotateUnsafe()
+	}
+
+	return nil
+}
+
+// Close closes the archive.
+func (a *Archive) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.closeUnsafe()
+}
+
+// closeUnsafe closes the current segment files. It is unsafe since it assumes the lock is held.
+func (a *Archive) closeUnsafe() error {
+	if a.records == nil {
+		return nil
+	}
+
+	if err := a.records.Close(); err != nil {
+		return errors.Wrap(err, "close archive records")
+	}
+
+	if err := a.index.Close(); err != nil {
+		return errors.Wrap(err, "close archive index")
+	}
+
+	a.records, a.index = nil, nil
+
+	return nil
+}
+
+// rotateUnsafe closes the current segment, opens a new segment and deletes the oldest segments
+// while the archive would exceed its maximum size. It is unsafe since it assumes the lock is held.
+func (a *Archive) rotateUnsafe() error {
+	if err := a.closeUnsafe(); err != nil {
+		return err
+	}
+
+	var seq int
+	if len(a.segments) > 0 {
+		seq = a.segments[len(a.segments)-1].seq + 1
+	}
+
+	records, err := os.OpenFile(segmentPath(a.dir, seq, segmentExt), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "create archive segment")
+	}
+
+	index, err := os.OpenFile(segmentPath(a.dir, seq, indexExt), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		_ = records.Close()
+		return errors.Wrap(err, "create archive index")
+	}
+
+	a.records, a.index, a.offset = records, index, 0
+	a.segments = append(a.segments, segment{seq: seq})
+
+	var total int64
+	for _, s := range a.segments {
+		total += s.size
+	}
+
+	// Reserve space for the current segment.
+	for total+a.segmentSize > a.maxSize && len(a.segments) > 1 {
+		oldest := a.segments[0]
+		for _, ext := range []string{indexExt, segmentExt} {
+			if err := os.Remove(segmentPath(a.dir, oldest.seq, ext)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return errors.Wrap(err, "delete archive segment")
+			}
+		}
+
+		total -= oldest.size
+		a.segments = a.segments[1:]
+	}
+
+	return nil
+}
+
+// Query returns the archived records of the slot in the provided archive directory, optionally filtered by duty type.
+// Records are ordered by segment and then by order of addition.
+func Query(dir string, slot int64, dutyType string) ([]Record, error) {
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []Record
+	for _, s := range segments {
+		entries, err := readIndex(segmentPath(dir, s.seq, indexExt))
+		if err != nil {
+			return nil, err
+		}
+
+		var matches []indexEntry
+		for _, entry := range entries {
+			if entry.Slot == slot && (dutyType == "" || entry.DutyType == dutyType) {
+				matches = append(matches, entry)
+			}
+		}
+
+		if len(matches) == 0 {
+			continue
+		}
+
+		records, err := readRecords(segmentPath(dir, s.seq, segmentExt), matches)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // Segment deleted by a concurrent rotation.
+		} else if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, records...)
+	}
+
+	return resp, nil
+}
+
+// readIndex returns the entries of the index file, ignoring a trailing partially written entry.
+// It returns no entries if the file doesn't exist.
+func readIndex(path string) ([]indexEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "open archive index", z.Str("path", path))
+	}
+	defer f.Close()
+
+	var resp []indexEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry indexEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			break // Partially written entry.
+		}
+		resp = append(resp, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "read archive index", z.Str("path", path))
+	}
+
+	return resp, nil
+}
+
+// readRecords returns the records of the index entries in the segment file.
+func readRecords(path string, entries []indexEntry) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open archive segment", z.Str("path", path))
+	}
+	defer f.Close()
+
+	var resp []Record
+	for _, entry := range entries {
+		b := make([]byte, entry.Length)
+		if _, err := f.ReadAt(b, entry.Offset); err != nil && !errors.Is(err, io.EOF) {
+			return nil, errors.Wrap(err, "read archive record", z.Str("path", path))
+		}
+
+		var record Record
+		if err := json.Unmarshal(bytes.TrimSpace(b), &record); err != nil {
+			return nil, errors.Wrap(err, "unmarshal archive record", z.Str("path", path))
+		}
+
+		resp = append(resp, record)
+	}
+
+	return resp, nil
+}
+
+// listSegments returns the segments in the directory ordered by sequence number.
+func listSegments(dir string) ([]segment, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "read archive dir", z.Str("dir", dir))
+	}
+
+	sizes := make(map[int]int64)
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if !strings.HasPrefix(name, segmentPrefix) {
+			continue
+		}
+
+		ext := filepath.Ext(name)
+		if ext != segmentExt && ext != indexExt {
+			continue
+		}
+
+		var seq int
+		if _, err := fmt.Sscanf(strings.TrimSuffix(name, ext), segmentPrefix+"%d", &seq); err != nil {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(err, "archive file info")
+		}
+
+		sizes[seq] += info.Size()
+	}
+
+	var resp []segment
+	for seq, size := range sizes {
+		resp = append(resp, segment{seq: seq, size: size})
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].seq < resp[j].seq
+	})
+
+	return resp, nil
+}
+
+// segmentPath returns the path of the segment file with the extension.
+func segmentPath(dir string, seq int, ext string) string {
+	return filepath.Join(dir, fmt.Sprintf("%s%08d%s", segmentPrefix, seq, ext))
+}
@@ -0,0 +1,93 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package archive_test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/archive"
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+
+	a, err := archive.New(dir, 1<<20)
+	require.NoError(t, err)
+
+	type data struct {
+		Value int `json:"value"`
+	}
+
+	require.NoError(t, a.Add(core.NewAttesterDuty(1), archive.KindTrackerEvents, data{Value: 1}))
+	require.NoError(t, a.Add(core.NewProposerDuty(1), archive.KindTrackerEvents, data{Value: 2}))
+	require.NoError(t, a.AddRaw(core.NewAttesterDuty(1), archive.KindQBFTInstance, json.RawMessage(`{"value":3}`)))
+	require.NoError(t, a.Add(core.NewAttesterDuty(2), archive.KindTrackerEvents, data{Value: 4}))
+
+	records, err := archive.Query(dir, 1, "")
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	records, err = archive.Query(dir, 1, "attester")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "1/attester", records[0].Duty())
+	require.Equal(t, archive.KindTrackerEvents, records[0].Kind)
+	require.JSONEq(t, `{"value":1}`, string(records[0].Data))
+	require.Equal(t, archive.KindQBFTInstance, records[1].Kind)
+	require.JSONEq(t, `{"value":3}`, string(records[1].Data))
+
+	records, err = archive.Query(dir, 3, "")
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	// Records are retained after a restart.
+	require.NoError(t, a.Close())
+	a, err = archive.New(dir, 1<<20)
+	require.NoError(t, err)
+	require.NoError(t, a.Add(core.NewAttesterDuty(2), archive.KindTrackerEvents, data{Value: 5}))
+
+	records, err = archive.Query(dir, 2, "")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.JSONEq(t, `{"value":4}`, string(records[0].Data))
+	require.JSONEq(t, `{"value":5}`, string(records[1].Data))
+}
+
+func TestArchiveRotation(t *testing.T) {
+	const maxSize = 8 << 10
+
+	dir := t.TempDir()
+
+	a, err := archive.New(dir, maxSize)
+	require.NoError(t, err)
+
+	payload := make([]byte, 100)
+	for slot := int64(0); slot < 500; slot++ {
+		require.NoError(t, a.Add(core.NewAttesterDuty(slot), archive.KindTrackerEvents, payload))
+	}
+
+	// Archive size is capped.
+	var size int64
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		require.NoError(t, err)
+		size += info.Size()
+	}
+	require.LessOrEqual(t, size, int64(maxSize))
+
+	// Oldest records are deleted, latest records are retained.
+	records, err := archive.Query(dir, 0, "")
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	records, err = archive.Query(dir, 499, "")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+}
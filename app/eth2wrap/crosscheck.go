@@ -0,0 +1,284 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/forkjoin"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// crossCheckTimeout is the maximum duration to wait for the responses of all beacon nodes of a cross-checked request.
+	crossCheckTimeout = time.Second * 10
+	// maxDiffs is the maximum number of differences logged per divergent response.
+	maxDiffs = 10
+)
+
+// crossCheckGroups are the labels of the cross-checked endpoints by configurable group.
+var crossCheckGroups = map[string][]string{
+	"duties":           {"attester_duties", "proposer_duties", "sync_committee_duties"},
+	"validators":       {"validators", "validators_by_pub_key"},
+	"attestation_data": {"attestation_data"},
+	"genesis":          {"genesis", "genesis_time"},
+	"spec":             {"spec", "fork_schedule", "deposit_contract", "slots_per_epoch", "slot_duration"},
+}
+
+// CrossCheckGroups returns the supported cross-check endpoint groups.
+func CrossCheckGroups() []string {
+	var resp []string
+	for group := range crossCheckGroups {
+		resp = append(resp, group)
+	}
+	sort.Strings(resp)
+
+	return resp
+}
+
+// WithCrossCheck returns a copy of the multi client that compares the responses of all beacon nodes
+// for the endpoints of the groups, recording divergence metrics and logging diffs of divergent responses.
+// The first successful response is still returned without waiting for the other beacon nodes.
+func WithCrossCheck(cl Client, groups []string) (Client, error) {
+	m, ok := cl.(multi)
+	if !ok {
+		return nil, errors.New("cross-check requires a multi client")
+	}
+
+	m.crossCheck = make(map[string]bool)
+	for _, group := range groups {
+		labels, ok := crossCheckGroups[group]
+		if !ok {
+			return nil, errors.New("unknown cross-check group", z.Str("group", group))
+		}
+
+		for _, label := range labels {
+			m.crossCheck[label] = true
+		}
+	}
+
+	return m, nil
+}
+
+// crossCheckFunc compares the results of all beacon nodes of a request.
+type crossCheckFunc func(context.Context, []crossCheckResult)
+
+// crossCheckResult is the result of a beacon node of a cross-checked request.
+type crossCheckResult struct {
+	Address string
+	Output  any
+	Err     error
+}
+
+// newCrossCheckResult returns a cross-check result from the forkjoin result of the client index.
+func newCrossCheckResult[O any](clients []Client, res forkjoin.Result[int, O]) crossCheckResult {
+	return crossCheckResult{
+		Address: clients[res.Input].Address(),
+		Output:  res.Output,
+		Err:     res.Err,
+	}
+}
+
+// crossChecker returns the cross-check function of the endpoint or nil if the endpoint isn't cross-checked.
+func (m multi) crossChecker(label string) crossCheckFunc {
+	if !m.crossCheck[label] {
+		return nil
+	}
+
+	return func(ctx context.Context, results []crossCheckResult) {
+		crossCheck(ctx, label, results)
+	}
+}
+
+// crossCheck compares the successful results of all beacon nodes of the endpoint, incrementing the divergence counter
+// and logging the differences of each divergent response to the most common response. Errors are ignored since
+// they are accounted for by beacon node health scoring. Arrays are compared as sets, since beacon nodes
+// return e.g. duties in different orders.
+func crossCheck(ctx context.Context, label string, results []crossCheckResult) {
+	type response struct {
+		JSON      []byte
+		Addresses []string
+	}
+
+	var responses []*response
+	for _, res := range results {
+		if res.Err != nil {
+			continue
+		}
+
+		b, err := canonicalJSON(res.Output)
+		if err != nil {
+			log.Warn(ctx, "Failed to marshal cross-checked beacon node response", err, z.Str("endpoint", label))
+			return
+		}
+
+		var found bool
+		for _, resp := range responses {
+			if bytes.Equal(resp.JSON, b) {
+				resp.Addresses = append(resp.Addresses, redactAddress(res.Address))
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			responses = append(responses, &response{JSON: b, Addresses: []string{redactAddress(res.Address)}})
+		}
+	}
+
+	if len(responses) <= 1 {
+		return
+	}
+
+	sort.SliceStable(responses, func(i, j int) bool {
+		return len(responses[i].Addresses) > len(responses[j].Addresses)
+	})
+
+	divergenceCount.WithLabelValues(label).Inc()
+
+	common := responses[0]
+	for _, resp := range responses[1:] {
+		diffs, err := jsonDiff(common.JSON, resp.JSON)
+		if err != nil {
+			log.Warn(ctx, "Failed to diff cross-checked beacon node responses", err, z.Str("endpoint", label))
+			continue
+		}
+
+		log.Warn(ctx, "Beacon nodes returned divergent responses", nil,
+			z.Str("endpoint", label),
+			z.Any("addresses", resp.Addresses),
+			z.Any("common_addresses", common.Addresses),
+			z.Any("diff", diffs),
+		)
+	}
+}
+
+// canonicalJSON returns the value encoded as JSON with sorted object keys and arrays sorted by the JSON encoding
+// of their elements, so responses only differing in the order of array elements are equal.
+func canonicalJSON(val any) ([]byte, error) {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json")
+	}
+
+	var decoded any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil, errors.Wrap(err, "unmarshal json")
+	}
+
+	b, err = json.Marshal(sortArrays(decoded))
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal json")
+	}
+
+	return b, nil
+}
+
+// sortArrays returns the decoded JSON value with all nested arrays sorted by the compact JSON encoding of their elements.
+func sortArrays(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		for k, elem := range v {
+			v[k] = sortArrays(elem)
+		}
+	case []any:
+		for i, elem := range v {
+			v[i] = sortArrays(elem)
+		}
+
+		sort.SliceStable(v, func(i, j int) bool {
+			return compactJSON(v[i]) < compactJSON(v[j])
+		})
+	}
+
+	return val
+}
+
+// jsonDiff returns the differences between the JSON encoded values, up to maxDiffs.
+// Each difference is formatted as "<path>: <value a> != <value b>".
+func jsonDiff(a, b []byte) ([]string, error) {
+	var aVal, bVal any
+	if err := json.Unmarshal(a, &aVal); err != nil {
+		return nil, errors.Wrap(err, "unmarshal json")
+	}
+	if err := json.Unmarshal(b, &bVal); err != nil {
+		return nil, errors.Wrap(err, "unmarshal json")
+	}
+
+	return appendDiffs(nil, "$", aVal, bVal), nil
+}
+
+// appendDiffs appends the differences between the decoded JSON values at the path to diffs, up to maxDiffs.
+func appendDiffs(diffs []string, path string, a, b any) []string {
+	if len(diffs) >= maxDiffs {
+		return diffs
+	}
+
+	switch aVal := a.(type) {
+	case map[string]any:
+		bVal, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+
+		keys := make(map[string]bool)
+		for k := range aVal {
+			keys[k] = true
+		}
+		for k := range bVal {
+			keys[k] = true
+		}
+
+		var sorted []string
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			diffs = appendDiffs(diffs, path+"."+k, aVal[k], bVal[k])
+		}
+
+		return diffs
+	case []any:
+		bVal, ok := b.([]any)
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(aVal) && i < len(bVal); i++ {
+			diffs = appendDiffs(diffs, fmt.Sprintf("%s[%d]", path, i), aVal[i], bVal[i])
+		}
+
+		if len(aVal) != len(bVal) && len(diffs) < maxDiffs {
+			diffs = append(diffs, fmt.Sprintf("%s.length: %d != %d", path, len(aVal), len(bVal)))
+		}
+
+		return diffs
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return diffs
+	}
+
+	return append(diffs, fmt.Sprintf("%s: %s != %s", path, compactJSON(a), compactJSON(b)))
+}
+
+// compactJSON returns the decoded JSON value encoded as compact JSON.
+func compactJSON(val any) string {
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,122 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// dutiesClient is a test client returning fixed proposer duties.
+type dutiesClient struct {
+	testClient
+	duties []*eth2v1.ProposerDuty
+}
+
+func (c dutiesClient) ProposerDuties(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) ([]*eth2v1.ProposerDuty, error) {
+	return c.duties, nil
+}
+
+func TestCrossCheck(t *testing.T) {
+	duty := func(slot eth2p0.Slot, index eth2p0.ValidatorIndex) *eth2v1.ProposerDuty {
+		return &eth2v1.ProposerDuty{Slot: slot, ValidatorIndex: index}
+	}
+
+	clients := []Client{
+		dutiesClient{testClient: testClient{address: "http://bn0"}, duties: []*eth2v1.ProposerDuty{duty(1, 1), duty(2, 2)}},
+		dutiesClient{testClient: testClient{address: "http://bn1"}, duties: []*eth2v1.ProposerDuty{duty(1, 1), duty(2, 2)}},
+		dutiesClient{testClient: testClient{address: "http://bn2"}, duties: []*eth2v1.ProposerDuty{duty(1, 1), duty(2, 3)}},
+		dutiesClient{testClient: testClient{address: "http://bn3"}, duties: []*eth2v1.ProposerDuty{duty(2, 2), duty(1, 1)}},
+	}
+
+	cl := newMulti(clients)
+	cl.(multi).health.lastPoll = time.Now().Add(time.Hour) // Disable polling.
+
+	_, err := WithCrossCheck(cl, []string{"unknown"})
+	require.ErrorContains(t, err, "unknown cross-check group")
+
+	cl, err = WithCrossCheck(cl, []string{"duties"})
+	require.NoError(t, err)
+
+	before := testutil.ToFloat64(divergenceCount.WithLabelValues("proposer_duties"))
+
+	duties, err := cl.ProposerDuties(context.Background(), 0, nil)
+	require.NoError(t, err)
+	require.Len(t, duties, 2)
+
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(divergenceCount.WithLabelValues("proposer_duties")) == before+1
+	}, time.Second, time.Millisecond)
+}
+
+func TestCrossCheckOrder(t *testing.T) {
+	duty := func(slot eth2p0.Slot, index eth2p0.ValidatorIndex) *eth2v1.ProposerDuty {
+		return &eth2v1.ProposerDuty{Slot: slot, ValidatorIndex: index}
+	}
+
+	before := testutil.ToFloat64(divergenceCount.WithLabelValues("test_order"))
+
+	crossCheck(context.Background(), "test_order", []crossCheckResult{
+		{Address: "http://bn0", Output: []*eth2v1.ProposerDuty{duty(1, 1), duty(2, 2)}},
+		{Address: "http://bn1", Output: []*eth2v1.ProposerDuty{duty(2, 2), duty(1, 1)}},
+	})
+	require.Equal(t, before, testutil.ToFloat64(divergenceCount.WithLabelValues("test_order")))
+
+	crossCheck(context.Background(), "test_order", []crossCheckResult{
+		{Address: "http://bn0", Output: []*eth2v1.ProposerDuty{duty(1, 1), duty(2, 2)}},
+		{Address: "http://bn1", Output: []*eth2v1.ProposerDuty{duty(2, 3), duty(1, 1)}},
+	})
+	require.Equal(t, before+1, testutil.ToFloat64(divergenceCount.WithLabelValues("test_order")))
+}
+
+func TestJSONDiff(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		diffs []string
+	}{
+		{
+			name: "equal",
+			a:    `{"a":1,"b":[1,2]}`,
+			b:    `{"b":[1,2],"a":1}`,
+		},
+		{
+			name:  "nested field",
+			a:     `{"data":{"slot":"1","index":"2"}}`,
+			b:     `{"data":{"slot":"1","index":"3"}}`,
+			diffs: []string{`$.data.index: "2" != "3"`},
+		},
+		{
+			name:  "missing field",
+			a:     `{"a":1}`,
+			b:     `{"a":1,"b":true}`,
+			diffs: []string{`$.b: null != true`},
+		},
+		{
+			name:  "array length",
+			a:     `[{"slot":"1"},{"slot":"2"}]`,
+			b:     `[{"slot":"1"},{"slot":"3"},{"slot":"4"}]`,
+			diffs: []string{`$[1].slot: "2" != "3"`, `$.length: 2 != 3`},
+		},
+		{
+			name:  "type mismatch",
+			a:     `{"a":[1]}`,
+			b:     `{"a":{"b":1}}`,
+			diffs: []string{`$.a: [1] != {"b":1}`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diffs, err := jsonDiff([]byte(test.a), []byte(test.b))
+			require.NoError(t, err)
+			require.Equal(t, test.diffs, diffs)
+		})
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"context"
 	"net"
 	"net/url"
-	"sync"
+	"sort"
 	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2http "github.com/attestantio/go-eth2-client/http"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -17,6 +20,7 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/forkjoin"
+	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/promauto"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
@@ -25,8 +29,10 @@ import (
 //go:generate go run genwrap/genwrap.go
 
 const (
-	zeroLogInfo = 1           // Avoid importing zero log for this constant.
-	bestPeriod  = time.Minute // Best client selector period.
+	zeroLogInfo = 1 // Avoid importing zero log for this constant.
+
+	// proposalsDeadline is the duration to wait for block proposals from other beacon nodes after the first proposal.
+	proposalsDeadline = time.Second
 )
 
 var (
@@ -44,6 +50,20 @@ var (
 		Help:      "Total number of errors returned by eth2 beacon node requests",
 	}, []string{"endpoint"})
 
+	eventCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eth2",
+		Name:      "events_total",
+		Help:      "Total number of events received from eth2 beacon node event streams by topic",
+	}, []string{"topic"})
+
+	divergenceCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "app",
+		Subsystem: "eth2",
+		Name:      "divergence_total",
+		Help:      "Total number of cross-checked eth2 beacon node requests with divergent responses by endpoint",
+	}, []string{"endpoint"})
+
 	// Interface assertions.
 	_ Client = (*httpAdapter)(nil)
 	_ Client = multi{}
@@ -99,23 +119,19 @@ func NewMultiHTTP(timeout time.Duration, addresses ...string) (Client, error) {
 
 func newMulti(clients []Client) Client {
 	return multi{
-		clients:  clients,
-		selector: newBestSelector(len(clients), bestPeriod),
+		clients: clients,
+		health:  newHealthMonitor(clients),
 	}
 }
 
 // multi implements Client by wrapping multiple clients, calling them in parallel
 // and returning the first successful response.
 // It also adds prometheus metrics and error wrapping.
-// It also implements a best client selector.
+// It also scores the health of the clients, calling healthy clients first.
 type multi struct {
-	clients  []Client
-	selector *bestSelector
-}
-
-// bestIdx increments the selector with the best client index.
-func (m multi) bestIdx(i int) {
-	m.selector.Increment(i)
+	clients    []Client
+	health     *healthMonitor
+	crossCheck map[string]bool // Labels of cross-checked endpoints.
 }
 
 func (multi) Name() string {
@@ -123,7 +139,12 @@ func (multi) Name() string {
 }
 
 func (m multi) Address() string {
-	return m.clients[m.selector.Best()].Address()
+	return m.clients[m.health.Best()].Address()
+}
+
+// NodeHealth returns the health of each beacon node.
+func (m multi) NodeHealth() []NodeHealth {
+	return m.health.Health()
 }
 
 func (m multi) SetValidatorCache(valCache func(context.Context) (ActiveValidators, error)) {
@@ -140,7 +161,7 @@ func (m multi) ActiveValidators(ctx context.Context) (ActiveValidators, error) {
 		func(ctx context.Context, cl Client) (ActiveValidators, error) {
 			return cl.ActiveValidators(ctx)
 		},
-		nil, nil,
+		nil, nil, nil,
 	)
 	if err != nil {
 		incError(label)
@@ -158,7 +179,7 @@ func (m multi) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigRespo
 		func(ctx context.Context, cl Client) (*eth2exp.ProposerConfigResponse, error) {
 			return cl.ProposerConfig(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, nil,
 	)
 	if err != nil {
 		incError(label)
@@ -176,7 +197,7 @@ func (m multi) AggregateBeaconCommitteeSelections(ctx context.Context, selection
 		func(ctx context.Context, cl Client) ([]*eth2exp.BeaconCommitteeSelection, error) {
 			return cl.AggregateBeaconCommitteeSelections(ctx, selections)
 		},
-		nil, m.bestIdx,
+		nil, m.health, nil,
 	)
 	if err != nil {
 		incError(label)
@@ -194,7 +215,7 @@ func (m multi) AggregateSyncCommitteeSelections(ctx context.Context, selections
 		func(ctx context.Context, cl Client) ([]*eth2exp.SyncCommitteeSelection, error) {
 			return cl.AggregateSyncCommitteeSelections(ctx, selections)
 		},
-		nil, m.bestIdx,
+		nil, m.health, nil,
 	)
 	if err != nil {
 		incError(label)
@@ -212,7 +233,7 @@ func (m multi) BlockAttestations(ctx context.Context, stateID string) ([]*eth2p0
 		func(ctx context.Context, cl Client) ([]*eth2p0.Attestation, error) {
 			return cl.BlockAttestations(ctx, stateID)
 		},
-		nil, m.bestIdx,
+		nil, m.health, nil,
 	)
 	if err != nil {
 		incError(label)
@@ -230,7 +251,168 @@ func (m multi) NodePeerCount(ctx context.Context) (int, error) {
 		func(ctx context.Context, cl Client) (int, error) {
 			return cl.NodePeerCount(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, nil,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
+func (m multi) NodeSyncState(ctx context.Context) (*NodeSyncState, error) {
+	const label = "node_sync_state"
+	defer latency(label)()
+
+	res, err := provide(ctx, m.clients,
+		func(ctx context.Context, cl Client) (*NodeSyncState, error) {
+			return cl.NodeSyncState(ctx)
+		},
+		nil, m.health, nil,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
+func (m multi) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*ValidatorLiveness, error) {
+	const label = "validator_liveness"
+	defer latency(label)()
+
+	res, err := provide(ctx, m.clients,
+		func(ctx context.Context, cl Client) ([]*ValidatorLiveness, error) {
+			return cl.ValidatorLiveness(ctx, epoch, indices)
+		},
+		nil, m.health, nil,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
+// AttestationDataVotes returns the attestation data from each beacon node, calling them in parallel.
+// It returns the attestation data received by the attestation data deadline of the slot, or the first
+// attestation data if none was received by then. It only returns an error if all beacon nodes returned errors.
+// The votes are ordered by the configured beacon node order.
+func (m multi) AttestationDataVotes(ctx context.Context, slot eth2p0.Slot, committeeIndex eth2p0.CommitteeIndex) ([]AttestationDataVote, error) {
+	const label = "attestation_data_votes"
+	defer latency(label)()
+
+	deadline, err := m.attestationDataDeadline(ctx, slot)
+	if err != nil {
+		incError(label)
+		return nil, err
+	}
+
+	fork, join, cancel := forkjoin.New(ctx,
+		func(ctx context.Context, cl Client) (*eth2p0.AttestationData, error) {
+			return cl.AttestationData(ctx, slot, committeeIndex)
+		},
+		forkjoin.WithoutFailFast(),
+		forkjoin.WithWorkers(len(m.clients)),
+	)
+	for _, cl := range m.clients {
+		fork(cl)
+	}
+	defer cancel()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	var (
+		results = join()
+		timeout = timer.C
+		resp    []AttestationDataVote
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			if len(resp) > 0 {
+				return m.orderVotes(resp), nil
+			}
+			timeout = nil // Wait for the first attestation data.
+		case res, ok := <-results:
+			if !ok {
+				if len(resp) > 0 {
+					return m.orderVotes(resp), nil
+				}
+				incError(label)
+
+				return nil, wrapError(ctx, err, label)
+			}
+
+			if res.Err != nil {
+				err = res.Err
+				continue
+			} else if res.Output == nil {
+				err = errors.New("attestation data cannot be nil")
+				continue
+			}
+
+			resp = append(resp, AttestationDataVote{
+				Address: res.Input.Address(),
+				Data:    res.Output,
+			})
+
+			if timeout == nil {
+				return resp, nil // Deadline already elapsed, return the first attestation data.
+			}
+		}
+	}
+}
+
+// orderVotes returns the attestation data votes ordered by the configured beacon node order.
+func (m multi) orderVotes(votes []AttestationDataVote) []AttestationDataVote {
+	order := make(map[string]int)
+	for i := len(m.clients) - 1; i >= 0; i-- {
+		order[m.clients[i].Address()] = i // Iterate in reverse so the first client wins duplicate addresses.
+	}
+
+	sort.SliceStable(votes, func(i, j int) bool {
+		return order[votes[i].Address] < order[votes[j].Address]
+	})
+
+	return votes
+}
+
+// attestationDataDeadline returns the deadline of attestation data votes of the slot; half way into the slot.
+// Attestation data is fetched one third into the slot, this leaves time for consensus and signing
+// before aggregation two thirds into the slot.
+func (m multi) attestationDataDeadline(ctx context.Context, slot eth2p0.Slot) (time.Time, error) {
+	genesis, err := m.GenesisTime(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	slotDuration, err := m.SlotDuration(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return genesis.Add(time.Duration(slot)*slotDuration + slotDuration/2), nil
+}
+
+// BeaconBlockProposals returns the beacon block proposals from each beacon node, calling them in parallel.
+// It returns the proposals received within proposalsDeadline of the first proposal and only returns
+// an error if all beacon nodes returned errors.
+func (m multi) BeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]BeaconBlockProposal, error) {
+	const label = "beacon_block_proposals"
+	defer latency(label)()
+
+	res, err := provideAll(ctx, m.clients,
+		func(ctx context.Context, cl Client) ([]BeaconBlockProposal, error) {
+			return cl.BeaconBlockProposals(ctx, slot, randaoReveal, graffiti)
+		},
+		proposalsDeadline,
 	)
 	if err != nil {
 		incError(label)
@@ -240,71 +422,336 @@ func (m multi) NodePeerCount(ctx context.Context) (int, error) {
 	return res, err
 }
 
+// BlindedBeaconBlockProposals returns the blinded beacon block proposals from each beacon node, calling them in parallel.
+// It returns the proposals received within proposalsDeadline of the first proposal and only returns
+// an error if all beacon nodes returned errors.
+func (m multi) BlindedBeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]BlindedBeaconBlockProposal, error) {
+	const label = "blinded_beacon_block_proposals"
+	defer latency(label)()
+
+	res, err := provideAll(ctx, m.clients,
+		func(ctx context.Context, cl Client) ([]BlindedBeaconBlockProposal, error) {
+			return cl.BlindedBeaconBlockProposals(ctx, slot, randaoReveal, graffiti)
+		},
+		proposalsDeadline,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
+// BlockContentsProposal returns the beacon block proposal including the blob sidecars of the first beacon node to respond.
+func (m multi) BlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (BeaconBlockProposal, error) {
+	const label = "block_contents_proposal"
+	defer latency(label)()
+
+	res, err := provide(ctx, m.clients,
+		func(ctx context.Context, cl Client) (BeaconBlockProposal, error) {
+			return cl.BlockContentsProposal(ctx, slot, randaoReveal, graffiti)
+		},
+		nil, m.health, nil,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
+// BlindedBlockContentsProposal returns the blinded beacon block proposal including the blinded blob sidecars
+// of the first beacon node to respond.
+func (m multi) BlindedBlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (BlindedBeaconBlockProposal, error) {
+	const label = "blinded_block_contents_proposal"
+	defer latency(label)()
+
+	res, err := provide(ctx, m.clients,
+		func(ctx context.Context, cl Client) (BlindedBeaconBlockProposal, error) {
+			return cl.BlindedBlockContentsProposal(ctx, slot, randaoReveal, graffiti)
+		},
+		nil, m.health, nil,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return res, err
+}
+
+// SubmitBlockContents submits the deneb signed block contents including the signed blob sidecars.
+func (m multi) SubmitBlockContents(ctx context.Context, contents *eth2deneb.SignedBlockContents) error {
+	const label = "submit_block_contents"
+	defer latency(label)()
+
+	err := submit(ctx, m.clients,
+		func(ctx context.Context, cl Client) error {
+			return cl.SubmitBlockContents(ctx, contents)
+		},
+		m.health,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return err
+}
+
+// SubmitBlindedBlockContents submits the deneb signed blinded block contents including the signed blinded blob sidecars.
+func (m multi) SubmitBlindedBlockContents(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error {
+	const label = "submit_blinded_block_contents"
+	defer latency(label)()
+
+	err := submit(ctx, m.clients,
+		func(ctx context.Context, cl Client) error {
+			return cl.SubmitBlindedBlockContents(ctx, contents)
+		},
+		m.health,
+	)
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return err
+}
+
+// Events subscribes the handler to the event stream of each beacon node, so the same event may be received multiple times.
+// It only returns an error if all subscriptions failed.
+func (m multi) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	const label = "events"
+
+	instrumented := func(event *eth2v1.Event) {
+		eventCount.WithLabelValues(event.Topic).Inc()
+		handler(event)
+	}
+
+	var (
+		err       error
+		succeeded bool
+	)
+	for _, cl := range m.clients {
+		// Note the subscriptions outlive this call, so they are not done via provide which cancels its context.
+		if subErr := cl.Events(ctx, topics, instrumented); subErr != nil {
+			err = subErr
+			continue
+		}
+		succeeded = true
+	}
+	if !succeeded {
+		incError(label)
+		return wrapError(ctx, err, label)
+	}
+
+	return nil
+}
+
 // provide calls the work function with each client in parallel, returning the
 // first successful result or first error.
-// The bestIdxFunc is called with the index of the client returning a successful response.
+// If the health monitor is not nil, healthy clients are called first, with ejected clients
+// only called if no healthy client succeeded, and the outcome of each call is recorded.
+// If the cross-check function is not nil, it is called asynchronously with the results of all clients.
 func provide[O any](ctx context.Context, clients []Client,
-	work forkjoin.Work[Client, O], isSuccessFunc func(O) bool, bestIdxFunc func(int),
+	work forkjoin.Work[Client, O], isSuccessFunc func(O) bool, health *healthMonitor, check crossCheckFunc,
 ) (O, error) {
 	if isSuccessFunc == nil {
 		isSuccessFunc = func(O) bool { return true }
 	}
-	if bestIdxFunc == nil {
-		bestIdxFunc = func(int) {}
+
+	groups := [][]int{allIndexes(len(clients))}
+	if health != nil {
+		healthy, ejected := health.Groups()
+		groups = [][]int{healthy, ejected}
 	}
 
-	fork, join, cancel := forkjoin.New(ctx, work,
+	var (
+		nokOutput O
+		nokErr    = errors.New("bug: no forkjoin results")
+	)
+	for _, group := range groups {
+		if len(group) == 0 {
+			continue
+		}
+
+		output, ok, err := provideGroup(ctx, clients, group, work, isSuccessFunc, health, check)
+		if ok {
+			return output, nil
+		} else if ctx.Err() != nil {
+			return nokOutput, ctx.Err()
+		}
+
+		nokOutput, nokErr = output, err
+	}
+
+	return nokOutput, nokErr
+}
+
+// provideGroup calls the work function with the clients of the indexes in parallel, returning the
+// first successful result and true or the last unsuccessful result and false.
+func provideGroup[O any](ctx context.Context, clients []Client, idxs []int,
+	work forkjoin.Work[Client, O], isSuccessFunc func(O) bool, health *healthMonitor, check crossCheckFunc,
+) (O, bool, error) {
+	workCtx, cancelWork := ctx, context.CancelFunc(func() {})
+	if check != nil {
+		// Detach from the caller's context, so the responses of all clients are received for cross-checking.
+		workCtx, cancelWork = context.WithTimeout(log.CopyFields(context.Background(), ctx), crossCheckTimeout)
+	}
+
+	fork, join, cancel := forkjoin.New(workCtx,
+		func(ctx context.Context, idx int) (O, error) {
+			t0 := time.Now()
+			res, err := work(ctx, clients[idx])
+			health.Observe(ctx, idx, time.Since(t0), err)
+
+			return res, err
+		},
 		forkjoin.WithoutFailFast(),
-		forkjoin.WithWorkers(len(clients)),
+		forkjoin.WithWorkers(len(idxs)),
 	)
-	for _, client := range clients {
-		fork(client)
+	for _, idx := range idxs {
+		fork(idx)
 	}
-	defer cancel()
+
+	release := func() {
+		cancel()
+		cancelWork()
+	}
+	defer func() {
+		if release != nil {
+			release()
+		}
+	}()
 
 	var (
-		nokResp    forkjoin.Result[Client, O]
+		results    = join()
+		checks     []crossCheckResult
+		nokResp    forkjoin.Result[int, O]
 		hasNokResp bool
 		zero       O
 	)
-	for res := range join() {
-		if ctx.Err() != nil {
-			return zero, ctx.Err()
-		} else if res.Err == nil && isSuccessFunc(res.Output) {
-			// TODO(corver): Find a better way to get the index of successful client.
-			for i, client := range clients {
-				if client.Address() == res.Input.Address() {
-					bestIdxFunc(i)
-				}
+	for {
+		var (
+			res forkjoin.Result[int, O]
+			ok  bool
+		)
+		select {
+		case <-ctx.Done():
+			return zero, false, ctx.Err()
+		case res, ok = <-results:
+		}
+		if !ok {
+			break
+		}
+
+		if check != nil {
+			checks = append(checks, newCrossCheckResult(clients, res))
+		}
+
+		if res.Err == nil && isSuccessFunc(res.Output) {
+			if check != nil {
+				// Cross-check the remaining results asynchronously, releasing resources when done.
+				go func(release func()) {
+					defer release()
+					for res := range results {
+						checks = append(checks, newCrossCheckResult(clients, res))
+					}
+					check(workCtx, checks)
+				}(release)
+				release = nil
 			}
 
-			return res.Output, nil
-		} else {
-			nokResp = res
-			hasNokResp = true
+			return res.Output, true, nil
 		}
+
+		nokResp = res
+		hasNokResp = true
 	}
 
 	if ctx.Err() != nil {
-		return zero, ctx.Err()
+		return zero, false, ctx.Err()
 	} else if !hasNokResp {
-		return zero, errors.New("bug: no forkjoin results")
+		return zero, false, errors.New("bug: no forkjoin results")
 	}
 
-	return nokResp.Output, nokResp.Err
+	return nokResp.Output, false, nokResp.Err
+}
+
+// allIndexes returns the indexes of n clients.
+func allIndexes(n int) []int {
+	resp := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		resp = append(resp, i)
+	}
+
+	return resp
+}
+
+// provideAll calls the work function with each client in parallel, returning the concatenated
+// successful results received until all clients responded or the deadline after the first successful
+// result elapsed. It only returns an error if no client returned a successful result.
+func provideAll[O any](ctx context.Context, clients []Client, work forkjoin.Work[Client, []O], deadline time.Duration) ([]O, error) {
+	fork, join, cancel := forkjoin.New(ctx, work,
+		forkjoin.WithoutFailFast(),
+		forkjoin.WithWorkers(len(clients)),
+	)
+	for _, client := range clients {
+		fork(client)
+	}
+	defer cancel()
+
+	var (
+		results  = join()
+		timeout  <-chan time.Time
+		resp     []O
+		nokErr   error
+		received bool
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			return resp, nil
+		case res, ok := <-results:
+			if !ok {
+				if received {
+					return resp, nil
+				} else if nokErr == nil {
+					return nil, errors.New("bug: no forkjoin results")
+				}
+
+				return nil, nokErr
+			}
+
+			if res.Err != nil {
+				nokErr = res.Err
+				continue
+			}
+
+			resp = append(resp, res.Output...)
+			if !received {
+				received = true
+				timeout = time.After(deadline)
+			}
+		}
+	}
 }
 
 type empty struct{}
 
 // submit proxies provide, but returns nil instead of a successful result.
 func submit(ctx context.Context, clients []Client, work func(context.Context, Client) error,
-	bestIdxFunc func(int),
+	health *healthMonitor,
 ) error {
 	_, err := provide(ctx, clients,
 		func(ctx context.Context, cl Client) (empty, error) {
 			return empty{}, work(ctx, cl)
 		},
-		nil, bestIdxFunc,
+		nil, health, nil,
 	)
 
 	return err
@@ -365,54 +812,3 @@ func wrapError(ctx context.Context, err error, label string, fields ...z.Field)
 
 	return errors.Wrap(err, "beacon api "+label, append(fields, z.Str("label", label))...)
 }
-
-// newBestSelector returns a new bestSelector.
-func newBestSelector(n int, period time.Duration) *bestSelector {
-	return &bestSelector{
-		n:      n,
-		period: period,
-		counts: make([]int, n),
-	}
-}
-
-// bestSelector calculates the "best client index" per period.
-type bestSelector struct {
-	n      int
-	mu     sync.Mutex
-	start  time.Time
-	period time.Duration
-	counts []int
-}
-
-// Best returns the best index or 0 if no counts.
-func (s *bestSelector) Best() int {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	var resp, count int
-	for i, c := range s.counts {
-		if c > count {
-			resp = i
-			count = c
-		}
-	}
-
-	return resp
-}
-
-// Increment increments the counter for the given index.
-func (s *bestSelector) Increment(i int) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if i < 0 || i >= s.n {
-		panic("invalid index") // This should never happen
-	}
-
-	if time.Since(s.start) > s.period { // Reset counters after period.
-		s.counts = make([]int, s.n)
-		s.start = time.Now()
-	}
-
-	s.counts[i]++
-}
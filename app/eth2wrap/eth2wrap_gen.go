@@ -13,6 +13,7 @@ import (
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 )
@@ -25,6 +26,13 @@ type Client interface {
 	eth2exp.ProposerConfigProvider
 	BlockAttestationsProvider
 	NodePeerCountProvider
+	NodeSyncStateProvider
+	NodeHealthProvider
+	ValidatorLivenessProvider
+	AttestationDataVotesProvider
+	BlockProposalsProvider
+	BlockContentsProvider
+	eth2client.EventsProvider
 
 	ActiveValidatorsProvider
 	SetValidatorCache(func(context.Context) (ActiveValidators, error))
@@ -34,6 +42,7 @@ type Client interface {
 	eth2client.AttestationDataProvider
 	eth2client.AttestationsSubmitter
 	eth2client.AttesterDutiesProvider
+	eth2client.BLSToExecutionChangesSubmitter
 	eth2client.BeaconBlockProposalProvider
 	eth2client.BeaconBlockRootProvider
 	eth2client.BeaconBlockSubmitter
@@ -73,7 +82,7 @@ func (m multi) NodeVersion(ctx context.Context) (string, error) {
 		func(ctx context.Context, cl Client) (string, error) {
 			return cl.NodeVersion(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -93,7 +102,7 @@ func (m multi) SlotDuration(ctx context.Context) (time.Duration, error) {
 		func(ctx context.Context, cl Client) (time.Duration, error) {
 			return cl.SlotDuration(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -113,7 +122,7 @@ func (m multi) SlotsPerEpoch(ctx context.Context) (uint64, error) {
 		func(ctx context.Context, cl Client) (uint64, error) {
 			return cl.SlotsPerEpoch(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -133,7 +142,7 @@ func (m multi) DepositContract(ctx context.Context) (*apiv1.DepositContract, err
 		func(ctx context.Context, cl Client) (*apiv1.DepositContract, error) {
 			return cl.DepositContract(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -153,7 +162,7 @@ func (m multi) SignedBeaconBlock(ctx context.Context, blockID string) (*spec.Ver
 		func(ctx context.Context, cl Client) (*spec.VersionedSignedBeaconBlock, error) {
 			return cl.SignedBeaconBlock(ctx, blockID)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -173,7 +182,7 @@ func (m multi) AggregateAttestation(ctx context.Context, slot phase0.Slot, attes
 		func(ctx context.Context, cl Client) (*phase0.Attestation, error) {
 			return cl.AggregateAttestation(ctx, slot, attestationDataRoot)
 		},
-		isAggregateAttestationOk, m.bestIdx,
+		isAggregateAttestationOk, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -193,7 +202,7 @@ func (m multi) SubmitAggregateAttestations(ctx context.Context, aggregateAndProo
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitAggregateAttestations(ctx, aggregateAndProofs)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -213,7 +222,7 @@ func (m multi) AttestationData(ctx context.Context, slot phase0.Slot, committeeI
 		func(ctx context.Context, cl Client) (*phase0.AttestationData, error) {
 			return cl.AttestationData(ctx, slot, committeeIndex)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -233,7 +242,7 @@ func (m multi) SubmitAttestations(ctx context.Context, attestations []*phase0.At
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitAttestations(ctx, attestations)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -254,7 +263,7 @@ func (m multi) AttesterDuties(ctx context.Context, epoch phase0.Epoch, validator
 		func(ctx context.Context, cl Client) ([]*apiv1.AttesterDuty, error) {
 			return cl.AttesterDuties(ctx, epoch, validatorIndices)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -275,7 +284,7 @@ func (m multi) SyncCommitteeDuties(ctx context.Context, epoch phase0.Epoch, vali
 		func(ctx context.Context, cl Client) ([]*apiv1.SyncCommitteeDuty, error) {
 			return cl.SyncCommitteeDuties(ctx, epoch, validatorIndices)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -295,7 +304,7 @@ func (m multi) SubmitSyncCommitteeMessages(ctx context.Context, messages []*alta
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitSyncCommitteeMessages(ctx, messages)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -315,7 +324,7 @@ func (m multi) SubmitSyncCommitteeSubscriptions(ctx context.Context, subscriptio
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitSyncCommitteeSubscriptions(ctx, subscriptions)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -335,7 +344,7 @@ func (m multi) SyncCommitteeContribution(ctx context.Context, slot phase0.Slot,
 		func(ctx context.Context, cl Client) (*altair.SyncCommitteeContribution, error) {
 			return cl.SyncCommitteeContribution(ctx, slot, subcommitteeIndex, beaconBlockRoot)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -355,7 +364,27 @@ func (m multi) SubmitSyncCommitteeContributions(ctx context.Context, contributio
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
 		},
-		m.bestIdx,
+		m.health,
+	)
+
+	if err != nil {
+		incError(label)
+		err = wrapError(ctx, err, label)
+	}
+
+	return err
+}
+
+// SubmitBLSToExecutionChanges submits BLS to execution address change operations.
+func (m multi) SubmitBLSToExecutionChanges(ctx context.Context, blsToExecutionChanges []*capella.SignedBLSToExecutionChange) error {
+	const label = "submit_bls_to_execution_changes"
+	defer latency(label)()
+
+	err := submit(ctx, m.clients,
+		func(ctx context.Context, cl Client) error {
+			return cl.SubmitBLSToExecutionChanges(ctx, blsToExecutionChanges)
+		},
+		m.health,
 	)
 
 	if err != nil {
@@ -375,7 +404,7 @@ func (m multi) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, randao
 		func(ctx context.Context, cl Client) (*spec.VersionedBeaconBlock, error) {
 			return cl.BeaconBlockProposal(ctx, slot, randaoReveal, graffiti)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -395,7 +424,7 @@ func (m multi) BeaconBlockRoot(ctx context.Context, blockID string) (*phase0.Roo
 		func(ctx context.Context, cl Client) (*phase0.Root, error) {
 			return cl.BeaconBlockRoot(ctx, blockID)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -415,7 +444,7 @@ func (m multi) SubmitBeaconBlock(ctx context.Context, block *spec.VersionedSigne
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitBeaconBlock(ctx, block)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -435,7 +464,7 @@ func (m multi) SubmitBeaconCommitteeSubscriptions(ctx context.Context, subscript
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitBeaconCommitteeSubscriptions(ctx, subscriptions)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -455,7 +484,7 @@ func (m multi) BlindedBeaconBlockProposal(ctx context.Context, slot phase0.Slot,
 		func(ctx context.Context, cl Client) (*api.VersionedBlindedBeaconBlock, error) {
 			return cl.BlindedBeaconBlockProposal(ctx, slot, randaoReveal, graffiti)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -475,7 +504,7 @@ func (m multi) SubmitBlindedBeaconBlock(ctx context.Context, block *api.Versione
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitBlindedBeaconBlock(ctx, block)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -495,7 +524,7 @@ func (m multi) SubmitValidatorRegistrations(ctx context.Context, registrations [
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitValidatorRegistrations(ctx, registrations)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -515,7 +544,7 @@ func (m multi) Fork(ctx context.Context, stateID string) (*phase0.Fork, error) {
 		func(ctx context.Context, cl Client) (*phase0.Fork, error) {
 			return cl.Fork(ctx, stateID)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -535,7 +564,7 @@ func (m multi) ForkSchedule(ctx context.Context) ([]*phase0.Fork, error) {
 		func(ctx context.Context, cl Client) ([]*phase0.Fork, error) {
 			return cl.ForkSchedule(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -555,7 +584,7 @@ func (m multi) Genesis(ctx context.Context) (*apiv1.Genesis, error) {
 		func(ctx context.Context, cl Client) (*apiv1.Genesis, error) {
 			return cl.Genesis(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -575,7 +604,7 @@ func (m multi) NodeSyncing(ctx context.Context) (*apiv1.SyncState, error) {
 		func(ctx context.Context, cl Client) (*apiv1.SyncState, error) {
 			return cl.NodeSyncing(ctx)
 		},
-		isSyncStateOk, m.bestIdx,
+		isSyncStateOk, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -596,7 +625,7 @@ func (m multi) SubmitProposalPreparations(ctx context.Context, preparations []*a
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitProposalPreparations(ctx, preparations)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -617,7 +646,7 @@ func (m multi) ProposerDuties(ctx context.Context, epoch phase0.Epoch, validator
 		func(ctx context.Context, cl Client) ([]*apiv1.ProposerDuty, error) {
 			return cl.ProposerDuties(ctx, epoch, validatorIndices)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -637,7 +666,7 @@ func (m multi) Spec(ctx context.Context) (map[string]interface{}, error) {
 		func(ctx context.Context, cl Client) (map[string]interface{}, error) {
 			return cl.Spec(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -660,7 +689,7 @@ func (m multi) Validators(ctx context.Context, stateID string, validatorIndices
 		func(ctx context.Context, cl Client) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
 			return cl.Validators(ctx, stateID, validatorIndices)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -683,7 +712,7 @@ func (m multi) ValidatorsByPubKey(ctx context.Context, stateID string, validator
 		func(ctx context.Context, cl Client) (map[phase0.ValidatorIndex]*apiv1.Validator, error) {
 			return cl.ValidatorsByPubKey(ctx, stateID, validatorPubKeys)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -703,7 +732,7 @@ func (m multi) SubmitVoluntaryExit(ctx context.Context, voluntaryExit *phase0.Si
 		func(ctx context.Context, cl Client) error {
 			return cl.SubmitVoluntaryExit(ctx, voluntaryExit)
 		},
-		m.bestIdx,
+		m.health,
 	)
 
 	if err != nil {
@@ -723,7 +752,7 @@ func (m multi) Domain(ctx context.Context, domainType phase0.DomainType, epoch p
 		func(ctx context.Context, cl Client) (phase0.Domain, error) {
 			return cl.Domain(ctx, domainType, epoch)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -746,7 +775,7 @@ func (m multi) GenesisDomain(ctx context.Context, domainType phase0.DomainType)
 		func(ctx context.Context, cl Client) (phase0.Domain, error) {
 			return cl.GenesisDomain(ctx, domainType)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -766,7 +795,7 @@ func (m multi) GenesisTime(ctx context.Context) (time.Time, error) {
 		func(ctx context.Context, cl Client) (time.Time, error) {
 			return cl.GenesisTime(ctx)
 		},
-		nil, m.bestIdx,
+		nil, m.health, m.crossChecker(label),
 	)
 
 	if err != nil {
@@ -929,6 +958,16 @@ func (l *lazy) SubmitSyncCommitteeContributions(ctx context.Context, contributio
 	return cl.SubmitSyncCommitteeContributions(ctx, contributionAndProofs)
 }
 
+// SubmitBLSToExecutionChanges submits BLS to execution address change operations.
+func (l *lazy) SubmitBLSToExecutionChanges(ctx context.Context, blsToExecutionChanges []*capella.SignedBLSToExecutionChange) (err error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cl.SubmitBLSToExecutionChanges(ctx, blsToExecutionChanges)
+}
+
 // BeaconBlockProposal fetches a proposed beacon block for signing.
 func (l *lazy) BeaconBlockProposal(ctx context.Context, slot phase0.Slot, randaoReveal phase0.BLSSignature, graffiti []byte) (res0 *spec.VersionedBeaconBlock, err error) {
 	cl, err := l.getOrCreateClient(ctx)
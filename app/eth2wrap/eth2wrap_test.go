@@ -5,6 +5,7 @@ package eth2wrap_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -279,6 +280,187 @@ func TestBlockAttestations(t *testing.T) {
 	require.Empty(t, resp)
 }
 
+func TestValidatorLiveness(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/eth/v1/validator/liveness/3", r.URL.Path)
+
+		var indices []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&indices))
+		require.Equal(t, []string{"1", "2"}, indices)
+
+		_, _ = w.Write([]byte(`{"data":[{"index":"1","is_live":true},{"index":"2","is_live":false}]}`))
+	}))
+
+	cl := eth2wrap.NewHTTPAdapterForT(t, srv.URL, time.Hour)
+	resp, err := cl.ValidatorLiveness(context.Background(), 3, []eth2p0.ValidatorIndex{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, []*eth2wrap.ValidatorLiveness{
+		{Index: 1, IsLive: true},
+		{Index: 2, IsLive: false},
+	}, resp)
+}
+
+func TestAttestationDataVotes(t *testing.T) {
+	ctx := context.Background()
+
+	// Ensure the attestation data deadline of slot 1 didn't elapse.
+	genesis := beaconmock.WithGenesisTime(time.Now())
+
+	cl1, err := beaconmock.New(genesis)
+	require.NoError(t, err)
+	cl2, err := beaconmock.New(genesis)
+	require.NoError(t, err)
+	cl3, err := beaconmock.New(genesis)
+	require.NoError(t, err)
+
+	data1 := testutil.RandomAttestationData()
+	data2 := testutil.RandomAttestationData()
+	cl1.AttestationDataFunc = func(context.Context, eth2p0.Slot, eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
+		time.Sleep(10 * time.Millisecond) // Respond last.
+		return data1, nil
+	}
+	cl2.AttestationDataFunc = func(context.Context, eth2p0.Slot, eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
+		return data2, nil
+	}
+	cl3.AttestationDataFunc = func(context.Context, eth2p0.Slot, eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
+		return nil, errors.New("boom")
+	}
+
+	eth2Cl, err := eth2wrap.Instrument(cl1, cl2, cl3)
+	require.NoError(t, err)
+
+	votes, err := eth2Cl.AttestationDataVotes(ctx, 1, 2)
+	require.NoError(t, err)
+	// Votes are ordered by the configured beacon node order, not by response order.
+	require.Equal(t, []eth2wrap.AttestationDataVote{
+		{Address: cl1.Address(), Data: data1},
+		{Address: cl2.Address(), Data: data2},
+	}, votes)
+
+	eth2Cl, err = eth2wrap.Instrument(cl3)
+	require.NoError(t, err)
+
+	_, err = eth2Cl.AttestationDataVotes(ctx, 1, 2)
+	require.ErrorContains(t, err, "boom")
+}
+
+func TestAttestationDataVotesDeadline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The attestation data deadline of slot 1 is between 0.5s and 1.5s from now.
+	const slot = 1
+	opts := []beaconmock.Option{
+		beaconmock.WithGenesisTime(time.Now().Truncate(time.Second)),
+		beaconmock.WithSlotDuration(time.Second),
+	}
+
+	cl1, err := beaconmock.New(opts...)
+	require.NoError(t, err)
+	cl2, err := beaconmock.New(opts...)
+	require.NoError(t, err)
+
+	data := testutil.RandomAttestationData()
+	cl1.AttestationDataFunc = func(context.Context, eth2p0.Slot, eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
+		return data, nil
+	}
+	cl2.AttestationDataFunc = func(ctx context.Context, _ eth2p0.Slot, _ eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
+		<-ctx.Done() // Never respond.
+		return nil, ctx.Err()
+	}
+
+	eth2Cl, err := eth2wrap.Instrument(cl1, cl2)
+	require.NoError(t, err)
+
+	votes, err := eth2Cl.AttestationDataVotes(ctx, slot, 2)
+	require.NoError(t, err)
+	require.Equal(t, []eth2wrap.AttestationDataVote{{Address: cl1.Address(), Data: data}}, votes)
+}
+
+func TestBeaconBlockProposals(t *testing.T) {
+	block := testutil.RandomCapellaBeaconBlock()
+	block.Slot = 1
+	randao := testutil.RandomEth2Signature()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Contains(t, r.URL.Path, "/eth/v2/validator/blocks/")
+		require.Equal(t, fmt.Sprintf("%#x", randao), r.URL.Query().Get("randao_reveal"))
+
+		data, err := json.Marshal(block)
+		require.NoError(t, err)
+
+		w.Header().Set("Eth-Execution-Payload-Value", "123000000000")
+		w.Header().Set("Eth-Consensus-Block-Value", "4")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"version":"capella","data":%s}`, data)))
+	}))
+	defer srv.Close()
+
+	cl := eth2wrap.NewHTTPAdapterForT(t, srv.URL, time.Hour)
+	resp, err := cl.BeaconBlockProposals(context.Background(), 1, randao, nil)
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	require.Equal(t, srv.URL, resp[0].Address)
+	require.Equal(t, block, resp[0].Block.Capella)
+	require.EqualValues(t, 123000000000, resp[0].Values.Execution.Int64())
+	require.EqualValues(t, 4, resp[0].Values.Consensus.Int64())
+	require.EqualValues(t, 127000000000, resp[0].Values.Total().Int64())
+
+	_, err = cl.BeaconBlockProposals(context.Background(), 2, randao, nil)
+	require.ErrorContains(t, err, "not for requested slot")
+}
+
+// TestLaggingFailover tests that a lagging beacon node is ejected and no longer called even though it responds quickly.
+func TestLaggingFailover(t *testing.T) {
+	ctx := context.Background()
+
+	var calls1, calls2 atomic.Int64
+	newMock := func(headSlot eth2p0.Slot, delay time.Duration, calls *atomic.Int64) beaconmock.Mock {
+		bmock, err := beaconmock.New()
+		require.NoError(t, err)
+
+		bmock.NodeSyncStateFunc = func(context.Context) (*eth2wrap.NodeSyncState, error) {
+			return &eth2wrap.NodeSyncState{HeadSlot: headSlot}, nil
+		}
+		bmock.SlotsPerEpochFunc = func(context.Context) (uint64, error) {
+			calls.Add(1)
+			time.Sleep(delay)
+
+			return 32, nil
+		}
+
+		return bmock
+	}
+
+	bmock1 := newMock(100, time.Millisecond*10, &calls1)
+	bmock2 := newMock(90, 0, &calls2)
+
+	eth2Cl, err := eth2wrap.Instrument(bmock1, bmock2)
+	require.NoError(t, err)
+
+	// First call triggers polling the beacon nodes.
+	_, err = eth2Cl.SlotsPerEpoch(ctx)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		health := eth2Cl.NodeHealth()
+		return health[1].Ejected && !health[0].Ejected
+	}, time.Second, time.Millisecond)
+
+	calls1.Store(0)
+	calls2.Store(0)
+
+	for i := 0; i < 5; i++ {
+		_, err = eth2Cl.SlotsPerEpoch(ctx)
+		require.NoError(t, err)
+	}
+
+	require.EqualValues(t, 5, calls1.Load())
+	require.Zero(t, calls2.Load())
+	require.Equal(t, bmock1.Address(), eth2Cl.Address())
+	require.Equal(t, []string{"lagging"}, eth2Cl.NodeHealth()[1].Reasons)
+}
+
 // TestOneError tests the case where one of the servers returns errors.
 func TestOneError(t *testing.T) {
 	// Start an erroring server.
@@ -47,6 +47,13 @@ type Client interface {
     eth2exp.ProposerConfigProvider
     BlockAttestationsProvider
     NodePeerCountProvider
+    NodeSyncStateProvider
+    NodeHealthProvider
+    ValidatorLivenessProvider
+    AttestationDataVotesProvider
+    BlockProposalsProvider
+    BlockContentsProvider
+    eth2client.EventsProvider
 
     ActiveValidatorsProvider
     SetValidatorCache(func(context.Context) (ActiveValidators, error))
@@ -68,7 +75,7 @@ type Client interface {
 			func(ctx context.Context, cl Client) ({{.ResultTypes}}){
 				return cl.{{.Name}}({{.ParamNames}})
 			},
-			{{.SuccessFunc}} m.bestIdx,
+			{{.SuccessFunc}} m.health, {{.CrossCheck}}
 		)
 
 		if err != nil {
@@ -100,6 +107,7 @@ type Client interface {
 		"AttestationDataProvider":               true,
 		"AttestationsSubmitter":                 true,
 		"AttesterDutiesProvider":                true,
+		"BLSToExecutionChangesSubmitter":        true,
 		"BeaconBlockProposalProvider":           true,
 		"BeaconBlockRootProvider":               false,
 		"BeaconBlockSubmitter":                  true,
@@ -152,6 +160,7 @@ type Method struct {
 	Latency     bool
 	DoFunc      string
 	SuccessFunc string
+	CrossCheck  string
 	params      []Field
 	results     []Field
 }
@@ -415,9 +424,11 @@ func parseEth2Methods(pkg *packages.Package) ([]Method, []string, error) {
 					}
 
 					dofunc := "provide"
+					crossCheck := "m.crossChecker(label),"
 					if len(results) == 1 {
 						dofunc = "submit"
 						successFunc = ""
+						crossCheck = ""
 					}
 
 					methods = append(methods, Method{
@@ -426,6 +437,7 @@ func parseEth2Methods(pkg *packages.Package) ([]Method, []string, error) {
 						Latency:     latency,
 						DoFunc:      dofunc,
 						SuccessFunc: successFunc,
+						CrossCheck:  crossCheck,
 						params:      params,
 						results:     results,
 					})
@@ -0,0 +1,468 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2wrap
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+const (
+	// healthPollPeriod is the minimum period between polls of the beacon nodes' sync state and peer count.
+	healthPollPeriod = 12 * time.Second
+	// healthPollTimeout is the timeout of polling a beacon node.
+	healthPollTimeout = 5 * time.Second
+	// healthWindow is the number of recent requests per beacon node used to calculate error rates and latency percentiles.
+	healthWindow = 100
+	// healthMinSamples is the minimum number of recent requests required to eject a beacon node due to its error rate.
+	healthMinSamples = 10
+	// maxErrorRate is the maximum error rate of recent requests of a healthy beacon node.
+	maxErrorRate = 0.5
+	// maxHeadLag is the maximum number of slots the head of a healthy beacon node may lag behind the highest head of all beacon nodes.
+	maxHeadLag = 2
+	// maxSyncDistance is the maximum sync distance in slots of a healthy beacon node.
+	maxSyncDistance = 32
+	// minPeerCount is the peer count below which a beacon node's score is reduced.
+	minPeerCount = 10
+	// minEjectBackoff and maxEjectBackoff bound the exponential backoff of ejected beacon nodes.
+	minEjectBackoff = 12 * time.Second
+	maxEjectBackoff = 10 * time.Minute
+)
+
+// NodeHealthProvider is the interface for providing the health of the beacon nodes.
+type NodeHealthProvider interface {
+	// NodeHealth returns the health of each beacon node or nil if the beacon nodes are not health scored.
+	NodeHealth() []NodeHealth
+}
+
+// NodeHealth is the health of a beacon node as scored by the multi client.
+type NodeHealth struct {
+	Address      string     `json:"address"`
+	Score        int        `json:"score"`
+	Ejected      bool       `json:"ejected"`
+	EjectedUntil *time.Time `json:"ejected_until,omitempty"`
+	Reasons      []string   `json:"reasons,omitempty"`
+	HeadSlot     uint64     `json:"head_slot"`
+	SyncDistance uint64     `json:"sync_distance"`
+	IsSyncing    bool       `json:"is_syncing"`
+	IsOptimistic bool       `json:"is_optimistic"`
+	ELOffline    bool       `json:"el_offline"`
+	PeerCount    *int       `json:"peer_count,omitempty"`
+	Requests     int        `json:"requests"`
+	ErrorRate    float64    `json:"error_rate"`
+	LatencyP50   float64    `json:"latency_p50_seconds"`
+	LatencyP95   float64    `json:"latency_p95_seconds"`
+}
+
+// sample is the outcome of a request to a beacon node.
+type sample struct {
+	Latency time.Duration
+	Failed  bool
+	Lost    bool // Cancelled after another beacon node responded, so latency is a lower bound.
+}
+
+// nodeHealth is the health state of a beacon node.
+type nodeHealth struct {
+	samples []sample // Ring buffer of the most recent requests.
+	next    int
+
+	polled  bool
+	pollErr error
+	sync    NodeSyncState
+	peers   *int
+
+	ejected      bool
+	ejectedUntil time.Time
+	reinstated   time.Time
+	backoff      time.Duration
+}
+
+// add adds the request sample to the ring buffer.
+func (n *nodeHealth) add(s sample) {
+	if len(n.samples) < healthWindow {
+		n.samples = append(n.samples, s)
+		return
+	}
+
+	n.samples[n.next] = s
+	n.next = (n.next + 1) % healthWindow
+}
+
+// stats returns the error rate, 50th and 95th latency percentiles and number of successful recent requests.
+func (n *nodeHealth) stats() (float64, time.Duration, time.Duration, int) {
+	if len(n.samples) == 0 {
+		return 0, 0, 0, 0
+	}
+
+	var (
+		failed    int
+		successes int
+		latencies []time.Duration
+	)
+	for _, s := range n.samples {
+		latencies = append(latencies, s.Latency)
+		if s.Failed {
+			failed++
+		} else if !s.Lost {
+			successes++
+		}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	return float64(failed) / float64(len(n.samples)), percentile(latencies, 0.5), percentile(latencies, 0.95), successes
+}
+
+// score returns the health score of the node from 0 to 100 and the reasons it is unhealthy, if any.
+// The maxHead is the highest head slot of all beacon nodes.
+func (n *nodeHealth) score(maxHead eth2p0.Slot) (int, []string) {
+	var (
+		score   = 100.0
+		reasons []string
+	)
+
+	if n.pollErr != nil {
+		score -= 50
+		reasons = append(reasons, "unreachable")
+	} else if n.polled {
+		var lag eth2p0.Slot
+		if maxHead > n.sync.HeadSlot {
+			lag = maxHead - n.sync.HeadSlot
+		}
+		score -= math.Min(50, 10*float64(lag))
+
+		if n.sync.IsSyncing {
+			reasons = append(reasons, "syncing")
+		}
+		if lag > maxHeadLag {
+			reasons = append(reasons, "lagging")
+		}
+		if n.sync.SyncDistance > maxSyncDistance {
+			reasons = append(reasons, "far_behind")
+		}
+		if n.sync.IsOptimistic {
+			score -= 50
+			reasons = append(reasons, "optimistic")
+		}
+		if n.sync.ELOffline {
+			score -= 50
+			reasons = append(reasons, "el_offline")
+		}
+	}
+
+	if n.peers != nil && *n.peers == 0 {
+		score -= 50
+		reasons = append(reasons, "zero_peers")
+	} else if n.peers != nil && *n.peers < minPeerCount {
+		score -= float64(2 * (minPeerCount - *n.peers))
+	}
+
+	errRate, p50, p95, _ := n.stats()
+	score -= 50 * errRate
+	if len(n.samples) >= healthMinSamples && errRate > maxErrorRate {
+		reasons = append(reasons, "error_rate")
+	}
+
+	// Each second of median latency costs 20 points and each second of 95th percentile latency 10 points.
+	score -= math.Min(30, 20*p50.Seconds()+10*p95.Seconds())
+
+	return int(math.Max(0, math.Round(score))), reasons
+}
+
+// newHealthMonitor returns a new health monitor of the clients.
+func newHealthMonitor(clients []Client) *healthMonitor {
+	nodes := make([]*nodeHealth, len(clients))
+	for i := range nodes {
+		nodes[i] = new(nodeHealth)
+	}
+
+	return &healthMonitor{
+		clients: clients,
+		nodes:   nodes,
+		now:     time.Now,
+	}
+}
+
+// healthMonitor scores the health of beacon nodes based on the outcomes of requests and
+// periodic polls of their sync state and peer count. Unhealthy beacon nodes are ejected
+// with exponential backoff, so requests are only sent to them if no healthy beacon node succeeds.
+type healthMonitor struct {
+	clients []Client
+	now     func() time.Time
+
+	mu       sync.Mutex
+	nodes    []*nodeHealth
+	lastPoll time.Time
+	polling  bool
+}
+
+// Observe records the outcome of a request to the beacon node with the index.
+func (h *healthMonitor) Observe(ctx context.Context, idx int, latency time.Duration, err error) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// Reinstate the node before recording the outcome, so it is included in the fresh samples.
+	h.reinstateUnsafe(idx)
+	h.nodes[idx].add(sample{
+		Latency: latency,
+		Failed:  err != nil && ctx.Err() == nil,
+		Lost:    err != nil && ctx.Err() != nil,
+	})
+
+	h.evaluateUnsafe(ctx, idx)
+}
+
+// Groups returns the indexes of the healthy beacon nodes ordered by score, best first,
+// followed by the indexes of the ejected beacon nodes. It also triggers a poll if due.
+func (h *healthMonitor) Groups() ([]int, []int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.maybePollUnsafe()
+
+	var healthy, ejected []int
+	for _, idx := range h.rankUnsafe() {
+		h.evaluateUnsafe(context.Background(), idx)
+		if h.nodes[idx].ejected {
+			ejected = append(ejected, idx)
+		} else {
+			healthy = append(healthy, idx)
+		}
+	}
+
+	return healthy, ejected
+}
+
+// Best returns the index of the best healthy beacon node or the best ejected beacon node if all are ejected.
+func (h *healthMonitor) Best() int {
+	healthy, ejected := h.Groups()
+	if len(healthy) > 0 {
+		return healthy[0]
+	}
+
+	return ejected[0]
+}
+
+// Health returns the health of each beacon node.
+func (h *healthMonitor) Health() []NodeHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	maxHead := h.maxHeadUnsafe()
+
+	var resp []NodeHealth
+	for i, node := range h.nodes {
+		score, reasons := node.score(maxHead)
+		errRate, p50, p95, _ := node.stats()
+
+		health := NodeHealth{
+			Address:      redactAddress(h.clients[i].Address()),
+			Score:        score,
+			Ejected:      node.ejected,
+			Reasons:      reasons,
+			HeadSlot:     uint64(node.sync.HeadSlot),
+			SyncDistance: uint64(node.sync.SyncDistance),
+			IsSyncing:    node.sync.IsSyncing,
+			IsOptimistic: node.sync.IsOptimistic,
+			ELOffline:    node.sync.ELOffline,
+			PeerCount:    node.peers,
+			Requests:     len(node.samples),
+			ErrorRate:    errRate,
+			LatencyP50:   p50.Seconds(),
+			LatencyP95:   p95.Seconds(),
+		}
+		if node.ejected {
+			until := node.ejectedUntil
+			health.EjectedUntil = &until
+		}
+
+		resp = append(resp, health)
+	}
+
+	return resp
+}
+
+// rankUnsafe returns all beacon node indexes ordered by score and then by number of recent successful requests.
+// It is unsafe since it assumes the lock is held.
+func (h *healthMonitor) rankUnsafe() []int {
+	maxHead := h.maxHeadUnsafe()
+
+	var (
+		resp      []int
+		scores    = make(map[int]int)
+		successes = make(map[int]int)
+	)
+	for i, node := range h.nodes {
+		resp = append(resp, i)
+		scores[i], _ = node.score(maxHead)
+		_, _, _, successes[i] = node.stats()
+	}
+
+	sort.SliceStable(resp, func(i, j int) bool {
+		if si, sj := scores[resp[i]], scores[resp[j]]; si != sj {
+			return si > sj
+		}
+
+		return successes[resp[i]] > successes[resp[j]]
+	})
+
+	return resp
+}
+
+// reinstateUnsafe reinstates the beacon node if its ejection backoff elapsed, only considering requests after reinstatement.
+// It is unsafe since it assumes the lock is held.
+func (h *healthMonitor) reinstateUnsafe(idx int) {
+	node := h.nodes[idx]
+	now := h.now()
+
+	if !node.ejected || now.Before(node.ejectedUntil) {
+		return
+	}
+
+	node.ejected = false
+	node.reinstated = now
+	node.samples = nil
+	node.next = 0
+}
+
+// evaluateUnsafe reinstates the beacon node if its ejection backoff elapsed and ejects it if it is unhealthy.
+// It is unsafe since it assumes the lock is held.
+func (h *healthMonitor) evaluateUnsafe(ctx context.Context, idx int) {
+	h.reinstateUnsafe(idx)
+
+	node := h.nodes[idx]
+	if node.ejected {
+		return
+	}
+
+	now := h.now()
+
+	_, reasons := node.score(h.maxHeadUnsafe())
+	if len(reasons) == 0 {
+		return
+	}
+
+	// Double the backoff if the node was recently reinstated, otherwise start again from the minimum.
+	if node.backoff == 0 || now.Sub(node.reinstated) > maxEjectBackoff {
+		node.backoff = minEjectBackoff
+	} else if node.backoff < maxEjectBackoff {
+		node.backoff *= 2
+		if node.backoff > maxEjectBackoff {
+			node.backoff = maxEjectBackoff
+		}
+	}
+
+	node.ejected = true
+	node.ejectedUntil = now.Add(node.backoff)
+
+	log.Warn(ctx, "Ejecting unhealthy beacon node", nil,
+		z.Str("address", redactAddress(h.clients[idx].Address())),
+		z.Str("reasons", strings.Join(reasons, ",")),
+		z.Any("backoff", node.backoff),
+	)
+}
+
+// maxHeadUnsafe returns the highest head slot of all polled beacon nodes.
+// It is unsafe since it assumes the lock is held.
+func (h *healthMonitor) maxHeadUnsafe() eth2p0.Slot {
+	var resp eth2p0.Slot
+	for _, node := range h.nodes {
+		if node.polled && node.pollErr == nil && node.sync.HeadSlot > resp {
+			resp = node.sync.HeadSlot
+		}
+	}
+
+	return resp
+}
+
+// maybePollUnsafe starts an asynchronous poll of all beacon nodes if the poll period elapsed.
+// It is unsafe since it assumes the lock is held.
+func (h *healthMonitor) maybePollUnsafe() {
+	now := h.now()
+	if h.polling || now.Sub(h.lastPoll) < healthPollPeriod {
+		return
+	}
+
+	h.polling = true
+	h.lastPoll = now
+
+	go h.poll()
+}
+
+// poll polls the sync state and peer count of all beacon nodes in parallel.
+func (h *healthMonitor) poll() {
+	ctx, cancel := context.WithTimeout(context.Background(), healthPollTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i, cl := range h.clients {
+		wg.Add(1)
+		go func(idx int, cl Client) {
+			defer wg.Done()
+
+			state, err := cl.NodeSyncState(ctx)
+			if err == nil && state == nil {
+				err = errors.New("node sync state empty")
+			}
+			peers, peersErr := cl.NodePeerCount(ctx)
+
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			node := h.nodes[idx]
+			node.polled = true
+			node.pollErr = err
+			if err == nil {
+				node.sync = *state
+			}
+			if peersErr == nil {
+				node.peers = &peers
+			}
+		}(i, cl)
+	}
+	wg.Wait()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.polling = false
+	for idx := range h.nodes {
+		h.evaluateUnsafe(ctx, idx)
+	}
+}
+
+// percentile returns the percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+}
+
+// redactAddress returns the address with any password redacted.
+func redactAddress(address string) string {
+	u, err := url.Parse(address)
+	if err != nil {
+		return address
+	}
+
+	return u.Redacted()
+}
@@ -8,13 +8,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/url"
 	"sync"
 	"testing"
 	"time"
 
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2http "github.com/attestantio/go-eth2-client/http"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -37,6 +47,115 @@ type NodePeerCountProvider interface {
 	NodePeerCount(ctx context.Context) (int, error)
 }
 
+// NodeSyncStateProvider is the interface for providing the sync state of a beacon node including its execution layer status.
+// It is a standard beacon API endpoint, but eth2client doesn't support the el_offline field.
+// See https://ethereum.github.io/beacon-APIs/#/Node/getSyncingStatus.
+type NodeSyncStateProvider interface {
+	// NodeSyncState provides the sync state of the beacon node.
+	NodeSyncState(ctx context.Context) (*NodeSyncState, error)
+}
+
+// NodeSyncState is the sync state of a beacon node.
+type NodeSyncState struct {
+	HeadSlot     eth2p0.Slot `json:"head_slot,string"`
+	SyncDistance eth2p0.Slot `json:"sync_distance,string"`
+	IsSyncing    bool        `json:"is_syncing"`
+	IsOptimistic bool        `json:"is_optimistic"`
+	ELOffline    bool        `json:"el_offline"`
+}
+
+// ValidatorLivenessProvider is the interface for providing validator liveness.
+// It is a standard beacon API endpoint not implemented by eth2client.
+// See https://ethereum.github.io/beacon-APIs/#/Validator/postLiveness.
+type ValidatorLivenessProvider interface {
+	// ValidatorLiveness provides the liveness of the validators in the epoch.
+	ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*ValidatorLiveness, error)
+}
+
+// AttestationDataVotesProvider is the interface for providing attestation data from each beacon node.
+type AttestationDataVotesProvider interface {
+	// AttestationDataVotes provides the attestation data of the slot and committee index from each beacon node that returned it,
+	// ordered by the configured beacon node order, so the first vote is of the primary beacon node if it returned one.
+	AttestationDataVotes(ctx context.Context, slot eth2p0.Slot, committeeIndex eth2p0.CommitteeIndex) ([]AttestationDataVote, error)
+}
+
+// AttestationDataVote is the attestation data returned by a beacon node.
+type AttestationDataVote struct {
+	Address string
+	Data    *eth2p0.AttestationData
+}
+
+// BlockProposalsProvider is the interface for providing block proposals and their values from each beacon node.
+type BlockProposalsProvider interface {
+	// BeaconBlockProposals provides the beacon block proposals of the slot from each beacon node that returned one.
+	BeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]BeaconBlockProposal, error)
+	// BlindedBeaconBlockProposals provides the blinded beacon block proposals of the slot from each beacon node that returned one.
+	BlindedBeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]BlindedBeaconBlockProposal, error)
+}
+
+// BlockContentsProvider is the interface for proposing and submitting block contents, i.e. blocks including
+// their deneb blob sidecars, since go-eth2-client only supports deneb blocks without blob sidecars.
+type BlockContentsProvider interface {
+	// BlockContentsProposal provides the beacon block proposal of the slot including its blob sidecars
+	// from the first beacon node to respond.
+	BlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (BeaconBlockProposal, error)
+	// BlindedBlockContentsProposal provides the blinded beacon block proposal of the slot including its blinded
+	// blob sidecars from the first beacon node to respond.
+	BlindedBlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (BlindedBeaconBlockProposal, error)
+	// SubmitBlockContents submits the deneb signed block contents including the signed blob sidecars.
+	SubmitBlockContents(ctx context.Context, contents *eth2deneb.SignedBlockContents) error
+	// SubmitBlindedBlockContents submits the deneb signed blinded block contents including the signed blinded blob sidecars.
+	SubmitBlindedBlockContents(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error
+}
+
+// BeaconBlockProposal is a beacon block proposal returned by a beacon node.
+type BeaconBlockProposal struct {
+	Address      string
+	Block        *eth2spec.VersionedBeaconBlock
+	BlobSidecars []*deneb.BlobSidecar // Blob sidecars of deneb block contents, nil otherwise.
+	Values       BlockValues
+}
+
+// BlindedBeaconBlockProposal is a blinded beacon block proposal returned by a beacon node.
+type BlindedBeaconBlockProposal struct {
+	Address             string
+	Block               *eth2api.VersionedBlindedBeaconBlock
+	BlindedBlobSidecars []*eth2deneb.BlindedBlobSidecar // Blinded blob sidecars of deneb blinded block contents, nil otherwise.
+	Values              BlockValues
+}
+
+// weiPerGwei is the number of wei in a gwei.
+const weiPerGwei = 1e9
+
+// BlockValues are the values of a block proposal as reported by the beacon node, nil if not reported.
+type BlockValues struct {
+	Execution *big.Int // Execution payload value in wei paid to the fee recipient.
+	Consensus *big.Int // Consensus block value in gwei paid to the proposer.
+}
+
+// Total returns the total value of the block in wei or nil if no values were reported.
+func (v BlockValues) Total() *big.Int {
+	if v.Execution == nil && v.Consensus == nil {
+		return nil
+	}
+
+	resp := new(big.Int)
+	if v.Execution != nil {
+		resp.Add(resp, v.Execution)
+	}
+	if v.Consensus != nil {
+		resp.Add(resp, new(big.Int).Mul(v.Consensus, big.NewInt(weiPerGwei)))
+	}
+
+	return resp
+}
+
+// ValidatorLiveness defines whether a validator was observed to be live (e.g. attested or proposed) in an epoch.
+type ValidatorLiveness struct {
+	Index  eth2p0.ValidatorIndex `json:"index,string"`
+	IsLive bool                  `json:"is_live"`
+}
+
 // NewHTTPAdapterForT returns a http adapter for testing non-eth2service methods as it is nil.
 func NewHTTPAdapterForT(_ *testing.T, address string, timeout time.Duration) Client {
 	return newHTTPAdapter(nil, address, timeout)
@@ -182,6 +301,280 @@ func (h *httpAdapter) NodePeerCount(ctx context.Context) (int, error) {
 	return resp.Data.Connected, nil
 }
 
+// NodeSyncState provides the sync state of the beacon node including its execution layer status.
+// See https://ethereum.github.io/beacon-APIs/#/Node/getSyncingStatus.
+func (h *httpAdapter) NodeSyncState(ctx context.Context) (*NodeSyncState, error) {
+	const path = "/eth/v1/node/syncing"
+	respBody, statusCode, err := httpGet(ctx, h.address, path, h.timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "request beacon node sync state")
+	} else if statusCode != http.StatusOK {
+		return nil, errors.New("request beacon node sync state failed", z.Int("status", statusCode), z.Str("body", string(respBody)))
+	}
+
+	var resp syncStateJSON
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse beacon node sync state response")
+	} else if resp.Data == nil {
+		return nil, errors.New("empty beacon node sync state response")
+	}
+
+	return resp.Data, nil
+}
+
+// NodeHealth returns nil since a single beacon node is not health scored.
+func (*httpAdapter) NodeHealth() []NodeHealth {
+	return nil
+}
+
+// ValidatorLiveness returns the liveness of the validators in the epoch.
+// See https://ethereum.github.io/beacon-APIs/#/Validator/postLiveness.
+func (h *httpAdapter) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*ValidatorLiveness, error) {
+	reqIndices := make([]string, 0, len(indices))
+	for _, index := range indices {
+		reqIndices = append(reqIndices, fmt.Sprint(index))
+	}
+
+	reqBody, err := json.Marshal(reqIndices)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal validator liveness request")
+	}
+
+	path := fmt.Sprintf("/eth/v1/validator/liveness/%d", epoch)
+	respBody, err := httpPost(ctx, h.address, path, bytes.NewReader(reqBody), h.timeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "request validator liveness")
+	}
+
+	var resp livenessJSON
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse validator liveness response")
+	}
+
+	return resp.Data, nil
+}
+
+// AttestationDataVotes returns the attestation data of this beacon node as a single vote.
+func (h *httpAdapter) AttestationDataVotes(ctx context.Context, slot eth2p0.Slot, committeeIndex eth2p0.CommitteeIndex) ([]AttestationDataVote, error) {
+	data, err := h.AttestationData(ctx, slot, committeeIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return []AttestationDataVote{{Address: h.address, Data: data}}, nil
+}
+
+// BeaconBlockProposals returns the beacon block proposal of this beacon node including the values reported via response headers.
+// See https://ethereum.github.io/beacon-APIs/#/Validator/produceBlockV2.
+func (h *httpAdapter) BeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]BeaconBlockProposal, error) {
+	respBody, header, err := h.getBlockProposal(ctx, "/eth/v2/validator/blocks", slot, randaoReveal, graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp blockProposalJSON
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse beacon block proposal response")
+	}
+
+	block := &eth2spec.VersionedBeaconBlock{Version: resp.Version}
+	var (
+		data     any
+		contents *eth2deneb.BlockContents
+	)
+	switch resp.Version {
+	case eth2spec.DataVersionPhase0:
+		block.Phase0 = new(eth2p0.BeaconBlock)
+		data = block.Phase0
+	case eth2spec.DataVersionAltair:
+		block.Altair = new(altair.BeaconBlock)
+		data = block.Altair
+	case eth2spec.DataVersionBellatrix:
+		block.Bellatrix = new(bellatrix.BeaconBlock)
+		data = block.Bellatrix
+	case eth2spec.DataVersionCapella:
+		block.Capella = new(capella.BeaconBlock)
+		data = block.Capella
+	case eth2spec.DataVersionDeneb:
+		// Deneb block proposals are block contents including the blob sidecars.
+		contents = new(eth2deneb.BlockContents)
+		data = contents
+	default:
+		return nil, errors.New("unsupported block version", z.Str("version", resp.Version.String()))
+	}
+
+	if err := json.Unmarshal(resp.Data, data); err != nil {
+		return nil, errors.Wrap(err, "failed to parse beacon block proposal", z.Str("version", resp.Version.String()))
+	}
+
+	var sidecars []*deneb.BlobSidecar
+	if contents != nil {
+		block.Deneb = contents.Block
+		sidecars = contents.BlobSidecars
+	}
+
+	if blockSlot, err := block.Slot(); err != nil {
+		return nil, errors.Wrap(err, "beacon block proposal slot")
+	} else if blockSlot != slot {
+		return nil, errors.New("beacon block proposal not for requested slot", z.U64("slot", uint64(blockSlot)))
+	}
+
+	return []BeaconBlockProposal{{
+		Address:      h.address,
+		Block:        block,
+		BlobSidecars: sidecars,
+		Values:       blockValues(header),
+	}}, nil
+}
+
+// BlindedBeaconBlockProposals returns the blinded beacon block proposal of this beacon node including the values reported via response headers.
+// See https://ethereum.github.io/beacon-APIs/#/Validator/produceBlindedBlock.
+func (h *httpAdapter) BlindedBeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]BlindedBeaconBlockProposal, error) {
+	respBody, header, err := h.getBlockProposal(ctx, "/eth/v1/validator/blinded_blocks", slot, randaoReveal, graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp blockProposalJSON
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "failed to parse blinded beacon block proposal response")
+	}
+
+	block := &eth2api.VersionedBlindedBeaconBlock{Version: resp.Version}
+	var (
+		data     any
+		contents *eth2deneb.BlindedBlockContents
+	)
+	switch resp.Version {
+	case eth2spec.DataVersionBellatrix:
+		block.Bellatrix = new(eth2bellatrix.BlindedBeaconBlock)
+		data = block.Bellatrix
+	case eth2spec.DataVersionCapella:
+		block.Capella = new(eth2capella.BlindedBeaconBlock)
+		data = block.Capella
+	case eth2spec.DataVersionDeneb:
+		// Deneb blinded block proposals are blinded block contents including the blinded blob sidecars.
+		contents = new(eth2deneb.BlindedBlockContents)
+		data = contents
+	default:
+		return nil, errors.New("unsupported blinded block version", z.Str("version", resp.Version.String()))
+	}
+
+	if err := json.Unmarshal(resp.Data, data); err != nil {
+		return nil, errors.Wrap(err, "failed to parse blinded beacon block proposal", z.Str("version", resp.Version.String()))
+	}
+
+	var sidecars []*eth2deneb.BlindedBlobSidecar
+	if contents != nil {
+		block.Deneb = contents.BlindedBlock
+		sidecars = contents.BlindedBlobSidecars
+	}
+
+	if blockSlot, err := block.Slot(); err != nil {
+		return nil, errors.Wrap(err, "blinded beacon block proposal slot")
+	} else if blockSlot != slot {
+		return nil, errors.New("blinded beacon block proposal not for requested slot", z.U64("slot", uint64(blockSlot)))
+	}
+
+	return []BlindedBeaconBlockProposal{{
+		Address:             h.address,
+		Block:               block,
+		BlindedBlobSidecars: sidecars,
+		Values:              blockValues(header),
+	}}, nil
+}
+
+// BlockContentsProposal returns the beacon block proposal of this beacon node including the blob sidecars.
+func (h *httpAdapter) BlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (BeaconBlockProposal, error) {
+	proposals, err := h.BeaconBlockProposals(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return BeaconBlockProposal{}, err
+	}
+
+	return proposals[0], nil
+}
+
+// BlindedBlockContentsProposal returns the blinded beacon block proposal of this beacon node including the blinded blob sidecars.
+func (h *httpAdapter) BlindedBlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (BlindedBeaconBlockProposal, error) {
+	proposals, err := h.BlindedBeaconBlockProposals(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return BlindedBeaconBlockProposal{}, err
+	}
+
+	return proposals[0], nil
+}
+
+// SubmitBlockContents submits the deneb signed block contents including the signed blob sidecars.
+// See https://ethereum.github.io/beacon-APIs/#/Beacon/publishBlock.
+func (h *httpAdapter) SubmitBlockContents(ctx context.Context, contents *eth2deneb.SignedBlockContents) error {
+	reqBody, err := json.Marshal(contents)
+	if err != nil {
+		return errors.Wrap(err, "marshal signed block contents")
+	}
+
+	_, err = httpPost(ctx, h.address, "/eth/v1/beacon/blocks", bytes.NewReader(reqBody), h.timeout)
+	if err != nil {
+		return errors.Wrap(err, "submit signed block contents")
+	}
+
+	return nil
+}
+
+// SubmitBlindedBlockContents submits the deneb signed blinded block contents including the signed blinded blob sidecars.
+// See https://ethereum.github.io/beacon-APIs/#/Beacon/publishBlindedBlock.
+func (h *httpAdapter) SubmitBlindedBlockContents(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error {
+	reqBody, err := json.Marshal(contents)
+	if err != nil {
+		return errors.Wrap(err, "marshal signed blinded block contents")
+	}
+
+	_, err = httpPost(ctx, h.address, "/eth/v1/beacon/blinded_blocks", bytes.NewReader(reqBody), h.timeout)
+	if err != nil {
+		return errors.Wrap(err, "submit signed blinded block contents")
+	}
+
+	return nil
+}
+
+// getBlockProposal requests a block proposal from the endpoint and returns the response body and headers.
+func (h *httpAdapter) getBlockProposal(ctx context.Context, endpoint string, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]byte, http.Header, error) {
+	// Graffiti should be 32 bytes.
+	fixedGraffiti := make([]byte, 32)
+	copy(fixedGraffiti, graffiti)
+
+	query := fmt.Sprintf("randao_reveal=%#x&graffiti=%#x", randaoReveal, fixedGraffiti)
+	respBody, header, statusCode, err := httpGetWithQuery(ctx, h.address, fmt.Sprintf("%s/%d", endpoint, slot), query, h.timeout)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "request block proposal")
+	} else if statusCode != http.StatusOK {
+		return nil, nil, errors.New("request block proposal failed", z.Int("status", statusCode), z.Str("body", string(respBody)))
+	}
+
+	return respBody, header, nil
+}
+
+// blockValues returns the block values reported via the response headers.
+func blockValues(header http.Header) BlockValues {
+	parse := func(key string) *big.Int {
+		val, ok := new(big.Int).SetString(header.Get(key), 10)
+		if !ok {
+			return nil
+		}
+
+		return val
+	}
+
+	return BlockValues{
+		Execution: parse("Eth-Execution-Payload-Value"),
+		Consensus: parse("Eth-Consensus-Block-Value"),
+	}
+}
+
+type blockProposalJSON struct {
+	Version eth2spec.DataVersion `json:"version"`
+	Data    json.RawMessage      `json:"data"`
+}
+
 type submitBeaconCommitteeSelectionsJSON struct {
 	Data []*eth2exp.BeaconCommitteeSelection `json:"data"`
 }
@@ -194,6 +587,14 @@ type attestationsJSON struct {
 	Data []*eth2p0.Attestation `json:"data"`
 }
 
+type livenessJSON struct {
+	Data []*ValidatorLiveness `json:"data"`
+}
+
+type syncStateJSON struct {
+	Data *NodeSyncState `json:"data"`
+}
+
 type peerCountJSON struct {
 	Data struct {
 		Connected int `json:"connected,string"`
@@ -239,34 +640,42 @@ func httpPost(ctx context.Context, base string, endpoint string, body io.Reader,
 
 // httpGet performs a GET request and returns the body and status code or an error.
 func httpGet(ctx context.Context, base string, endpoint string, timeout time.Duration) ([]byte, int, error) {
+	data, _, statusCode, err := httpGetWithQuery(ctx, base, endpoint, "", timeout)
+
+	return data, statusCode, err
+}
+
+// httpGetWithQuery performs a GET request with the raw query and returns the body, headers and status code or an error.
+func httpGetWithQuery(ctx context.Context, base string, endpoint string, query string, timeout time.Duration) ([]byte, http.Header, int, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	addr, err := url.JoinPath(base, endpoint)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "invalid address")
+		return nil, nil, 0, errors.Wrap(err, "invalid address")
 	}
 
 	u, err := url.Parse(addr)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "invalid endpoint")
+		return nil, nil, 0, errors.Wrap(err, "invalid endpoint")
 	}
+	u.RawQuery = query
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "new GET request with ctx")
+		return nil, nil, 0, errors.Wrap(err, "new GET request with ctx")
 	}
 
 	res, err := new(http.Client).Do(req)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "failed to call GET endpoint")
+		return nil, nil, 0, errors.Wrap(err, "failed to call GET endpoint")
 	}
 	defer res.Body.Close()
 
 	data, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "failed to read GET response")
+		return nil, nil, 0, errors.Wrap(err, "failed to read GET response")
 	}
 
-	return data, res.StatusCode, nil
+	return data, res.Header, res.StatusCode, nil
 }
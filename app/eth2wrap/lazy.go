@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
@@ -161,3 +163,102 @@ func (l *lazy) NodePeerCount(ctx context.Context) (int, error) {
 
 	return cl.NodePeerCount(ctx)
 }
+
+func (l *lazy) NodeSyncState(ctx context.Context) (*NodeSyncState, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.NodeSyncState(ctx)
+}
+
+func (l *lazy) NodeHealth() []NodeHealth {
+	cl, ok := l.getClient()
+	if !ok {
+		return nil
+	}
+
+	return cl.NodeHealth()
+}
+
+func (l *lazy) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*ValidatorLiveness, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.ValidatorLiveness(ctx, epoch, indices)
+}
+
+func (l *lazy) AttestationDataVotes(ctx context.Context, slot eth2p0.Slot, committeeIndex eth2p0.CommitteeIndex) ([]AttestationDataVote, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.AttestationDataVotes(ctx, slot, committeeIndex)
+}
+
+func (l *lazy) BeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]BeaconBlockProposal, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.BeaconBlockProposals(ctx, slot, randaoReveal, graffiti)
+}
+
+func (l *lazy) BlindedBeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]BlindedBeaconBlockProposal, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cl.BlindedBeaconBlockProposals(ctx, slot, randaoReveal, graffiti)
+}
+
+func (l *lazy) BlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (BeaconBlockProposal, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return BeaconBlockProposal{}, err
+	}
+
+	return cl.BlockContentsProposal(ctx, slot, randaoReveal, graffiti)
+}
+
+func (l *lazy) BlindedBlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (BlindedBeaconBlockProposal, error) {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return BlindedBeaconBlockProposal{}, err
+	}
+
+	return cl.BlindedBlockContentsProposal(ctx, slot, randaoReveal, graffiti)
+}
+
+func (l *lazy) SubmitBlockContents(ctx context.Context, contents *eth2deneb.SignedBlockContents) error {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cl.SubmitBlockContents(ctx, contents)
+}
+
+func (l *lazy) SubmitBlindedBlockContents(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cl.SubmitBlindedBlockContents(ctx, contents)
+}
+
+func (l *lazy) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	cl, err := l.getOrCreateClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return cl.Events(ctx, topics, handler)
+}
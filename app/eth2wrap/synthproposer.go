@@ -12,16 +12,15 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
-	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
-	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
-	"github.com/attestantio/go-eth2-client/spec/capella"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	shuffle "github.com/protolambda/eth2-shuffle"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/eth2util"
 )
 
 const (
@@ -109,7 +108,86 @@ func (h *synthWrapper) BlindedBeaconBlockProposal(ctx context.Context, slot eth2
 		return nil, err
 	}
 
-	return blindedBlock(block)
+	return eth2util.BlindBlock(block)
+}
+
+// BeaconBlockProposals returns the unsigned beacon block proposals, or a single synthetic proposal without values.
+func (h *synthWrapper) BeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte) ([]BeaconBlockProposal, error) {
+	vIdx, ok, err := h.synthProposerCache.SyntheticVIdx(ctx, h.Client, slot)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return h.Client.BeaconBlockProposals(ctx, slot, randao, graffiti)
+	}
+
+	block, err := h.syntheticBlock(ctx, slot, vIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []BeaconBlockProposal{{Address: h.Address(), Block: block}}, nil
+}
+
+// BlindedBeaconBlockProposals returns the unsigned blinded beacon block proposals, or a single synthetic proposal without values.
+func (h *synthWrapper) BlindedBeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte) ([]BlindedBeaconBlockProposal, error) {
+	vIdx, ok, err := h.synthProposerCache.SyntheticVIdx(ctx, h.Client, slot)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return h.Client.BlindedBeaconBlockProposals(ctx, slot, randao, graffiti)
+	}
+
+	block, err := h.syntheticBlock(ctx, slot, vIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	blinded, err := eth2util.BlindBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return []BlindedBeaconBlockProposal{{Address: h.Address(), Block: blinded}}, nil
+}
+
+// BlockContentsProposal returns the unsigned beacon block proposal including blob sidecars, or a synthetic proposal without values.
+func (h *synthWrapper) BlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte) (BeaconBlockProposal, error) {
+	vIdx, ok, err := h.synthProposerCache.SyntheticVIdx(ctx, h.Client, slot)
+	if err != nil {
+		return BeaconBlockProposal{}, err
+	} else if !ok {
+		return h.Client.BlockContentsProposal(ctx, slot, randao, graffiti)
+	}
+
+	block, err := h.syntheticBlock(ctx, slot, vIdx)
+	if err != nil {
+		return BeaconBlockProposal{}, err
+	}
+
+	return BeaconBlockProposal{Address: h.Address(), Block: block}, nil
+}
+
+// BlindedBlockContentsProposal returns the unsigned blinded beacon block proposal including blinded blob sidecars,
+// or a synthetic proposal without values.
+func (h *synthWrapper) BlindedBlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte) (BlindedBeaconBlockProposal, error) {
+	vIdx, ok, err := h.synthProposerCache.SyntheticVIdx(ctx, h.Client, slot)
+	if err != nil {
+		return BlindedBeaconBlockProposal{}, err
+	} else if !ok {
+		return h.Client.BlindedBlockContentsProposal(ctx, slot, randao, graffiti)
+	}
+
+	block, err := h.syntheticBlock(ctx, slot, vIdx)
+	if err != nil {
+		return BlindedBeaconBlockProposal{}, err
+	}
+
+	blinded, err := eth2util.BlindBlock(block)
+	if err != nil {
+		return BlindedBeaconBlockProposal{}, err
+	}
+
+	return BlindedBeaconBlockProposal{Address: h.Address(), Block: blinded}, nil
 }
 
 // syntheticBlock returns a synthetic beacon block to propose.
@@ -165,6 +243,13 @@ func (h *synthWrapper) syntheticBlock(ctx context.Context, slot eth2p0.Slot, vId
 		block.Capella.ProposerIndex = vIdx
 		block.Capella.Body.ExecutionPayload.FeeRecipient = feeRecipient
 		block.Capella.Body.ExecutionPayload.Transactions = fraction(block.Capella.Body.ExecutionPayload.Transactions)
+	case spec.DataVersionDeneb:
+		block.Deneb = signedBlock.Deneb.Message
+		block.Deneb.Body.Graffiti = GetSyntheticGraffiti()
+		block.Deneb.Slot = slot
+		block.Deneb.ProposerIndex = vIdx
+		block.Deneb.Body.ExecutionPayload.FeeRecipient = feeRecipient
+		block.Deneb.Body.ExecutionPayload.Transactions = fraction(block.Deneb.Body.ExecutionPayload.Transactions)
 	default:
 		return nil, errors.New("unsupported block version")
 	}
@@ -198,6 +283,26 @@ func (h *synthWrapper) SubmitBeaconBlock(ctx context.Context, block *spec.Versio
 	return h.Client.SubmitBeaconBlock(ctx, block)
 }
 
+// SubmitBlockContents submits the signed block contents or swallows it if marked as synthetic.
+func (h *synthWrapper) SubmitBlockContents(ctx context.Context, contents *eth2deneb.SignedBlockContents) error {
+	if contents.SignedBlock.Message.Body.Graffiti == GetSyntheticGraffiti() {
+		log.Debug(ctx, "Synthetic block contents swallowed")
+		return nil
+	}
+
+	return h.Client.SubmitBlockContents(ctx, contents)
+}
+
+// SubmitBlindedBlockContents submits the signed blinded block contents or swallows it if marked as synthetic.
+func (h *synthWrapper) SubmitBlindedBlockContents(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error {
+	if contents.SignedBlindedBlock.Message.Body.Graffiti == GetSyntheticGraffiti() {
+		log.Debug(ctx, "Synthetic blinded block contents swallowed")
+		return nil
+	}
+
+	return h.Client.SubmitBlindedBlockContents(ctx, contents)
+}
+
 // GetSyntheticGraffiti returns the graffiti used to mark synthetic blocks.
 func GetSyntheticGraffiti() [32]byte {
 	var synthGraffiti [32]byte
@@ -214,6 +319,8 @@ func IsSyntheticBlindedBlock(block *api.VersionedSignedBlindedBeaconBlock) bool
 		graffiti = block.Bellatrix.Message.Body.Graffiti
 	case spec.DataVersionCapella:
 		graffiti = block.Capella.Message.Body.Graffiti
+	case spec.DataVersionDeneb:
+		graffiti = block.Deneb.Message.Body.Graffiti
 	default:
 		return false
 	}
@@ -233,6 +340,8 @@ func IsSyntheticBlock(block *spec.VersionedSignedBeaconBlock) bool {
 		graffiti = block.Bellatrix.Message.Body.Graffiti
 	case spec.DataVersionCapella:
 		graffiti = block.Capella.Message.Body.Graffiti
+	case spec.DataVersionDeneb:
+		graffiti = block.Deneb.Message.Body.Graffiti
 	default:
 		return false
 	}
@@ -397,89 +506,3 @@ func getStandardHashFn() shuffle.HashFn {
 
 	return hashFn
 }
-
-// blindedBlock converts a normal block into a blinded block.
-func blindedBlock(block *spec.VersionedBeaconBlock) (*api.VersionedBlindedBeaconBlock, error) {
-	var resp *api.VersionedBlindedBeaconBlock
-	// Blinded blocks are only available from bellatrix.
-	switch block.Version {
-	case spec.DataVersionBellatrix:
-		resp = &api.VersionedBlindedBeaconBlock{
-			Version: block.Version,
-			Bellatrix: &eth2bellatrix.BlindedBeaconBlock{
-				Slot:          block.Bellatrix.Slot,
-				ProposerIndex: block.Bellatrix.ProposerIndex,
-				ParentRoot:    block.Bellatrix.ParentRoot,
-				StateRoot:     block.Bellatrix.StateRoot,
-				Body: &eth2bellatrix.BlindedBeaconBlockBody{
-					RANDAOReveal:      block.Bellatrix.Body.RANDAOReveal,
-					ETH1Data:          block.Bellatrix.Body.ETH1Data,
-					Graffiti:          block.Bellatrix.Body.Graffiti,
-					ProposerSlashings: block.Bellatrix.Body.ProposerSlashings,
-					AttesterSlashings: block.Bellatrix.Body.AttesterSlashings,
-					Attestations:      block.Bellatrix.Body.Attestations,
-					Deposits:          block.Bellatrix.Body.Deposits,
-					VoluntaryExits:    block.Bellatrix.Body.VoluntaryExits,
-					SyncAggregate:     block.Bellatrix.Body.SyncAggregate,
-					ExecutionPayloadHeader: &bellatrix.ExecutionPayloadHeader{
-						ParentHash:       block.Bellatrix.Body.ExecutionPayload.ParentHash,
-						FeeRecipient:     block.Bellatrix.Body.ExecutionPayload.FeeRecipient,
-						StateRoot:        block.Bellatrix.Body.ExecutionPayload.StateRoot,
-						ReceiptsRoot:     block.Bellatrix.Body.ExecutionPayload.ReceiptsRoot,
-						LogsBloom:        block.Bellatrix.Body.ExecutionPayload.LogsBloom,
-						PrevRandao:       block.Bellatrix.Body.ExecutionPayload.PrevRandao,
-						BlockNumber:      block.Bellatrix.Body.ExecutionPayload.BlockNumber,
-						GasLimit:         block.Bellatrix.Body.ExecutionPayload.GasLimit,
-						GasUsed:          block.Bellatrix.Body.ExecutionPayload.GasUsed,
-						Timestamp:        block.Bellatrix.Body.ExecutionPayload.Timestamp,
-						ExtraData:        block.Bellatrix.Body.ExecutionPayload.ExtraData,
-						BaseFeePerGas:    block.Bellatrix.Body.ExecutionPayload.BaseFeePerGas,
-						BlockHash:        block.Bellatrix.Body.ExecutionPayload.BlockHash,
-						TransactionsRoot: eth2p0.Root{}, // Use empty root.
-					},
-				},
-			},
-		}
-	case spec.DataVersionCapella:
-		resp = &api.VersionedBlindedBeaconBlock{
-			Version: block.Version,
-			Capella: &eth2capella.BlindedBeaconBlock{
-				Slot:          block.Capella.Slot,
-				ProposerIndex: block.Capella.ProposerIndex,
-				ParentRoot:    block.Capella.ParentRoot,
-				StateRoot:     block.Capella.StateRoot,
-				Body: &eth2capella.BlindedBeaconBlockBody{
-					RANDAOReveal:      block.Capella.Body.RANDAOReveal,
-					ETH1Data:          block.Capella.Body.ETH1Data,
-					Graffiti:          block.Capella.Body.Graffiti,
-					ProposerSlashings: block.Capella.Body.ProposerSlashings,
-					AttesterSlashings: block.Capella.Body.AttesterSlashings,
-					Attestations:      block.Capella.Body.Attestations,
-					Deposits:          block.Capella.Body.Deposits,
-					VoluntaryExits:    block.Capella.Body.VoluntaryExits,
-					SyncAggregate:     block.Capella.Body.SyncAggregate,
-					ExecutionPayloadHeader: &capella.ExecutionPayloadHeader{
-						ParentHash:       block.Capella.Body.ExecutionPayload.ParentHash,
-						FeeRecipient:     block.Capella.Body.ExecutionPayload.FeeRecipient,
-						StateRoot:        block.Capella.Body.ExecutionPayload.StateRoot,
-						ReceiptsRoot:     block.Capella.Body.ExecutionPayload.ReceiptsRoot,
-						LogsBloom:        block.Capella.Body.ExecutionPayload.LogsBloom,
-						PrevRandao:       block.Capella.Body.ExecutionPayload.PrevRandao,
-						BlockNumber:      block.Capella.Body.ExecutionPayload.BlockNumber,
-						GasLimit:         block.Capella.Body.ExecutionPayload.GasLimit,
-						GasUsed:          block.Capella.Body.ExecutionPayload.GasUsed,
-						Timestamp:        block.Capella.Body.ExecutionPayload.Timestamp,
-						ExtraData:        block.Capella.Body.ExecutionPayload.ExtraData,
-						BaseFeePerGas:    block.Capella.Body.ExecutionPayload.BaseFeePerGas,
-						BlockHash:        block.Capella.Body.ExecutionPayload.BlockHash,
-						TransactionsRoot: eth2p0.Root{}, // Use empty root.
-					},
-				},
-			},
-		}
-	default:
-		return nil, errors.New("unsupported blinded block version")
-	}
-
-	return resp, nil
-}
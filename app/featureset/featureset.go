@@ -46,17 +46,53 @@ const (
 	// PreGenRegistrations enables broadcasting of pre-generated registrations if present in the lock file
 	// and --builder-api=true.
 	PreGenRegistrations Feature = "pre_gen_registrations"
+
+	// LatencyLeader enables latency-aware QBFT leader election. Peers are ordered by recent ping round trip times
+	// and duty participation, with the order agreed via infosync. It is only applied once enabled on all peers.
+	LatencyLeader Feature = "latency_leader"
+
+	// QBFTAdaptiveTimer enables the adaptive round timer that derives round timeouts from recently
+	// decided round durations per duty type, bounded by the increasing round timeout and a hard maximum timeout.
+	QBFTAdaptiveTimer Feature = "qbft_adaptive_timer"
+
+	// QBFTHashOnly enables sending hash-only consensus messages that exclude values via a dedicated protocol.
+	// Peers fetch values on demand from the leader or any other peer via a request/response protocol.
+	QBFTHashOnly Feature = "qbft_hash_only"
+
+	// QBFTBatch enables deciding the aggregation duties proposed in the same proposal window of a slot in a single
+	// consensus instance with a composite value. Duties not included in the decided batch are decided individually.
+	QBFTBatch Feature = "qbft_batch"
+
+	// AttestationDataVoting enables fetching attestation data from all configured beacon nodes and proposing
+	// the majority (or highest corroborated justified checkpoint) attestation data, logging disagreements between nodes.
+	AttestationDataVoting Feature = "attestation_data_voting"
+
+	// BlockValueSelection enables requesting block proposals from all configured beacon nodes and proposing
+	// the most valuable valid block as reported by the beacon nodes.
+	BlockValueSelection Feature = "block_value_selection"
+
+	// ParSigExRelay enables relaying of received partial signatures to peers that haven't acknowledged them,
+	// enabling partial signature exchange in partially connected clusters. Relayed partial signatures are
+	// deduplicated, limited by a hop count and verified against the public shares in the cluster lock.
+	ParSigExRelay Feature = "parsigex_relay"
 )
 
 var (
 	// state defines the current rollout status of each feature.
 	state = map[Feature]status{
-		QBFTConsensus:       statusStable,
-		Priority:            statusStable,
-		MockAlpha:           statusAlpha,
-		RelayDiscovery:      statusStable,
-		QBFTTimersABTest:    statusAlpha,
-		PreGenRegistrations: statusStable,
+		QBFTConsensus:         statusStable,
+		Priority:              statusStable,
+		MockAlpha:             statusAlpha,
+		RelayDiscovery:        statusStable,
+		QBFTTimersABTest:      statusAlpha,
+		PreGenRegistrations:   statusStable,
+		LatencyLeader:         statusAlpha,
+		QBFTAdaptiveTimer:     statusAlpha,
+		QBFTHashOnly:          statusAlpha,
+		QBFTBatch:             statusAlpha,
+		AttestationDataVoting: statusAlpha,
+		BlockValueSelection:   statusAlpha,
+		ParSigExRelay:         statusAlpha,
 		// Add all features and there status here.
 	}
 
@@ -79,6 +79,32 @@ var checks = []check{
 			return max == 1, nil
 		},
 	},
+	{
+		Name:        "beacon_node_divergence",
+		Description: "Beacon nodes persistently returning divergent responses. Check the logs for diffs and ensure all beacon nodes are synced to the same chain.",
+		Severity:    severityWarning,
+		Func: func(q query, m Metadata) (bool, error) {
+			intervals, err := q("app_eth2_divergence_total", sumLabels(), increasingIntervals)
+			if err != nil {
+				return false, err
+			}
+
+			return intervals >= 3, nil // Allow divergence during up to 2 scrape intervals, e.g. due to reorgs.
+		},
+	},
+	{
+		Name:        "partial_signature_equivocation",
+		Description: "Peer equivocated partial signatures, its share was excluded from aggregation. Identify the operator of the share index from the logs and the evidence file in the data directory.",
+		Severity:    severityCritical,
+		Func: func(q query, m Metadata) (bool, error) {
+			max, err := q("core_parsigdb_equivocation_total", sumLabels(), gaugeMax)
+			if err != nil {
+				return false, err
+			}
+
+			return max > 0, nil // Any equivocation since startup requires investigation.
+		},
+	},
 	{
 		Name:        "insufficient_connected_peers",
 		Description: "Not connected to at least quorum peers. Check logs for networking issue or coordinate with peers.",
@@ -330,6 +330,46 @@ func TestWarnLogsCheck(t *testing.T) {
 	})
 }
 
+func TestBNDivergenceCheck(t *testing.T) {
+	m := Metadata{}
+	checkName := "beacon_node_divergence"
+	metricName := "app_eth2_divergence_total"
+
+	duties := genLabels("endpoint", "proposer_duties")
+	validators := genLabels("endpoint", "validators")
+
+	t.Run("no data", func(t *testing.T) {
+		testCheck(t, m, checkName, false, nil)
+	})
+
+	t.Run("no divergence", func(t *testing.T) {
+		testCheck(t, m, checkName, false,
+			genFam(metricName,
+				genCounter(duties, 1, 1, 1, 1, 1),
+				genCounter(validators, 0, 0, 0, 0, 0),
+			),
+		)
+	})
+
+	t.Run("transient divergence", func(t *testing.T) {
+		testCheck(t, m, checkName, false,
+			genFam(metricName,
+				genCounter(duties, 0, 5, 5, 5, 5),
+				genCounter(validators, 0, 0, 0, 1, 1),
+			),
+		)
+	})
+
+	t.Run("persistent divergence", func(t *testing.T) {
+		testCheck(t, m, checkName, true,
+			genFam(metricName,
+				genCounter(duties, 0, 1, 1, 2, 2),
+				genCounter(validators, 0, 0, 1, 1, 1),
+			),
+		)
+	})
+}
+
 func testCheck(t *testing.T, m Metadata, checkName string, expect bool, metrics []*pb.MetricFamily) {
 	t.Helper()
 
@@ -463,3 +503,21 @@ func genGauge(labels []*pb.LabelPair, values ...int) []*pb.Metric {
 
 	return resp
 }
+
+func TestParSigEquivocationCheck(t *testing.T) {
+	m := Metadata{}
+	checkName := "partial_signature_equivocation"
+	metricName := "core_parsigdb_equivocation_total"
+
+	t.Run("no data", func(t *testing.T) {
+		testCheck(t, m, checkName, false, nil)
+	})
+
+	t.Run("equivocation", func(t *testing.T) {
+		testCheck(t, m, checkName, true,
+			genFam(metricName,
+				genCounter(genLabels("share_idx", "2"), 1, 1, 1),
+			),
+		)
+	})
+}
@@ -27,6 +27,25 @@ func increase(samples []*pb.Metric) (float64, error) {
 	return last - first, nil
 }
 
+// increasingIntervals returns the number of intervals between consecutive samples in a time series of counter metrics
+// in which the counter increased.
+func increasingIntervals(samples []*pb.Metric) (float64, error) {
+	var resp float64
+	for i := 1; i < len(samples); i++ {
+		if samples[i].Counter == nil && samples[i].Gauge == nil {
+			return 0, errors.New("bug: unsupported metric passed")
+		}
+
+		prev := samples[i-1].Counter.GetValue() + samples[i-1].Gauge.GetValue()
+		curr := samples[i].Counter.GetValue() + samples[i].Gauge.GetValue()
+		if curr > prev {
+			resp++
+		}
+	}
+
+	return resp, nil
+}
+
 // gaugeMax returns the maximum value in a time series of gauge metrics.
 func gaugeMax(samples []*pb.Metric) (float64, error) {
 	var max float64
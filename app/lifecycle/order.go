@@ -28,6 +28,7 @@ const (
 	StartP2PEventCollector
 	StartPeerInfo
 	StartParSigDB
+	StartWALReplay
 )
 
 // Global ordering of stop hooks; follows dependency tree from root to leaves.
@@ -36,8 +37,10 @@ const (
 	StopPrivkeyLock
 	StopRetryer
 	StopDutyDB
+	StopArchive
 	StopBeaconMock // Close this before validator API, since it can hold long-lived connections.
 	StopValidatorAPI
+	StopWAL     // Close this after the validator API, since submitted partial signatures are persisted.
 	StopTracing // Low level services...
 	StopP2PPeerDB
 	StopP2PTCPNode
@@ -14,18 +14,20 @@ func _() {
 	_ = x[StopPrivkeyLock-1]
 	_ = x[StopRetryer-2]
 	_ = x[StopDutyDB-3]
-	_ = x[StopBeaconMock-4]
-	_ = x[StopValidatorAPI-5]
-	_ = x[StopTracing-6]
-	_ = x[StopP2PPeerDB-7]
-	_ = x[StopP2PTCPNode-8]
-	_ = x[StopP2PUDPNode-9]
-	_ = x[StopMonitoringAPI-10]
+	_ = x[StopArchive-4]
+	_ = x[StopBeaconMock-5]
+	_ = x[StopValidatorAPI-6]
+	_ = x[StopWAL-7]
+	_ = x[StopTracing-8]
+	_ = x[StopP2PPeerDB-9]
+	_ = x[StopP2PTCPNode-10]
+	_ = x[StopP2PUDPNode-11]
+	_ = x[StopMonitoringAPI-12]
 }
 
-const _OrderStop_name = "SchedulerPrivkeyLockRetryerDutyDBBeaconMockValidatorAPITracingP2PPeerDBP2PTCPNodeP2PUDPNodeMonitoringAPI"
+const _OrderStop_name = "SchedulerPrivkeyLockRetryerDutyDBArchiveBeaconMockValidatorAPIWALTracingP2PPeerDBP2PTCPNodeP2PUDPNodeMonitoringAPI"
 
-var _OrderStop_index = [...]uint8{0, 9, 20, 27, 33, 43, 55, 62, 71, 81, 91, 104}
+var _OrderStop_index = [...]uint8{0, 9, 20, 27, 33, 40, 50, 62, 65, 72, 81, 91, 101, 114}
 
 func (i OrderStop) String() string {
 	if i < 0 || i >= OrderStop(len(_OrderStop_index)-1) {
@@ -4,6 +4,7 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/pprof"
 	"sync"
@@ -42,7 +43,7 @@ var (
 )
 
 // wireMonitoringAPI constructs the monitoring API and registers it with the life cycle manager.
-// It serves prometheus metrics, pprof profiling and the runtime enr.
+// It serves prometheus metrics, pprof profiling, beacon node health scores and the runtime enr.
 func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, addr string,
 	tcpNode host.Host, eth2Cl eth2wrap.Client,
 	peerIDs []peer.ID, registry *prometheus.Registry, qbftDebug http.Handler,
@@ -75,6 +76,18 @@ func wireMonitoringAPI(ctx context.Context, life *lifecycle.Manager, addr string
 		writeResponse(w, http.StatusOK, "ok")
 	})
 
+	// Serve beacon node health scores in JSON format.
+	mux.HandleFunc("/beacon_nodes", func(w http.ResponseWriter, _ *http.Request) {
+		b, err := json.Marshal(eth2Cl.NodeHealth())
+		if err != nil {
+			writeResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		writeResponse(w, http.StatusOK, string(b))
+	})
+
 	// Serve sniffed qbft instances messages in gzipped protobuf format.
 	mux.Handle("/debug/qbft", qbftDebug)
 
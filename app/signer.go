@@ -0,0 +1,130 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/signer"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+const (
+	// SignerValidatorClient signs duties by a validator client connected to the validator API.
+	SignerValidatorClient = "validator-client"
+	// SignerEmbedded signs duties by charon itself using the validator key shares.
+	SignerEmbedded = "embedded"
+	// SignerWeb3Signer signs duties by charon itself using the validator key shares held by a remote Web3Signer.
+	SignerWeb3Signer = "web3signer"
+)
+
+// newEmbeddedSigner returns an embedded signer using either the validator key shares of this node loaded from disk
+// or a remote Web3Signer holding them.
+func newEmbeddedSigner(ctx context.Context, conf Config, cluster *manifestpb.Cluster, shareIdx int,
+	eth2Cl eth2wrap.Client, opts ...signer.Option,
+) (*signer.Signer, error) {
+	if conf.DataDir == "" {
+		log.Warn(ctx, "Embedded signer enabled without data dir, slashing protection is lost on restart", nil)
+	}
+
+	if conf.Signer == SignerWeb3Signer {
+		if conf.Web3SignerAddr == "" {
+			return nil, errors.New("web3signer address required")
+		}
+
+		pubshares, err := pubsharesByPubKey(cluster, shareIdx)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Info(ctx, "Web3Signer signer enabled", z.Int("validators", len(pubshares)),
+			z.Str("address", conf.Web3SignerAddr))
+
+		signFunc := signer.NewWeb3SignerSignFunc(eth2Cl, web3signer.New(conf.Web3SignerAddr), pubshares)
+
+		return signer.New(shareIdx, eth2Cl, signFunc, opts...), nil
+	}
+
+	keys := conf.TestConfig.SimnetKeys
+	if len(keys) == 0 {
+		keyFiles, err := keystore.LoadFilesUnordered(conf.ValidatorKeysDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "load validator key shares", z.Str("dir", conf.ValidatorKeysDir))
+		}
+
+		keys = keyFiles.Keys()
+	}
+
+	secrets, err := secretsByPubKey(cluster, shareIdx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "Embedded signer enabled", z.Int("validators", len(secrets)))
+
+	return signer.New(shareIdx, eth2Cl, signer.NewKeyShareSignFunc(eth2Cl, secrets), opts...), nil
+}
+
+// pubsharesByPubKey returns the public shares of this node indexed by distributed validator public key.
+func pubsharesByPubKey(cluster *manifestpb.Cluster, shareIdx int) (map[core.PubKey]tbls.PublicKey, error) {
+	resp := make(map[core.PubKey]tbls.PublicKey)
+	for _, val := range cluster.Validators {
+		pubkey, err := core.PubKeyFromBytes(val.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		// Share index is 1-indexed.
+		if shareIdx < 1 || shareIdx > len(val.PubShares) {
+			return nil, errors.New("invalid share index", z.Int("share_idx", shareIdx))
+		}
+
+		pubshare, err := tblsconv.PubkeyFromBytes(val.PubShares[shareIdx-1])
+		if err != nil {
+			return nil, err
+		}
+
+		resp[pubkey] = pubshare
+	}
+
+	return resp, nil
+}
+
+// secretsByPubKey returns the secret shares of this node indexed by distributed validator public key.
+func secretsByPubKey(cluster *manifestpb.Cluster, shareIdx int, secrets []tbls.PrivateKey) (map[core.PubKey]tbls.PrivateKey, error) {
+	secretsByPubshare := make(map[tbls.PublicKey]tbls.PrivateKey)
+	for _, secret := range secrets {
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			return nil, err
+		}
+
+		secretsByPubshare[pubshare] = secret
+	}
+
+	pubshares, err := pubsharesByPubKey(cluster, shareIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make(map[core.PubKey]tbls.PrivateKey)
+	for pubkey, pubshare := range pubshares {
+		secret, ok := secretsByPubshare[pubshare]
+		if !ok {
+			return nil, errors.New("validator key share not found", z.Str("pubkey", pubkey.String()))
+		}
+
+		resp[pubkey] = secret
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,108 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/wal"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// newWAL returns a new write-ahead store of in-flight duties in the data directory or nil if disabled.
+func newWAL(ctx context.Context, conf Config) (*wal.Store, error) {
+	if !conf.WAL {
+		return nil, nil //nolint:nilnil // Write-ahead store disabled.
+	} else if conf.DataDir == "" {
+		return nil, errors.New("wal requires a data dir")
+	}
+
+	return wal.New(ctx, filepath.Join(conf.DataDir, wal.DirName))
+}
+
+// walReplayKey is the context key marking data replayed from the write-ahead store.
+type walReplayKey struct{}
+
+// isWALReplay returns true if the context is of data replayed from the write-ahead store.
+func isWALReplay(ctx context.Context) bool {
+	replay, _ := ctx.Value(walReplayKey{}).(bool)
+	return replay
+}
+
+// wireWAL persists the node's own partial signatures and the aggregate signatures in the write-ahead store.
+// It must be called before core.Wire, so data is persisted before it is processed further.
+// Data replayed from the write-ahead store isn't persisted again.
+func wireWAL(store *wal.Store, parSigDB core.ParSigDB, sigAgg core.SigAgg) {
+	parSigDB.SubscribeInternal(func(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+		if isWALReplay(ctx) {
+			return nil
+		}
+
+		if err := store.StoreParSigs(ctx, duty, set); err != nil {
+			log.Warn(ctx, "Persist partial signatures in wal", err, z.Any("duty", duty))
+		}
+
+		return nil
+	})
+	sigAgg.Subscribe(func(ctx context.Context, duty core.Duty, set core.SignedDataSet) error {
+		if isWALReplay(ctx) {
+			return nil
+		}
+
+		if err := store.StoreAggSigs(ctx, duty, set); err != nil {
+			log.Warn(ctx, "Persist aggregate signatures in wal", err, z.Any("duty", duty))
+		}
+
+		return nil
+	})
+}
+
+// replayWAL replays the aggregate signatures and the node's own partial signatures of duties that haven't expired
+// from the write-ahead store. Aggregate signatures are stored in the aggsigdb, so they are served to awaiting components.
+// Partial signatures are stored as internal partial signatures, so they are broadcast to peers again.
+// The context is marked as replayed, so wireWAL doesn't persist the replayed data again.
+func replayWAL(ctx context.Context, replay wal.Replay, deadlineFunc func(core.Duty) (time.Time, bool),
+	aggSigDB core.AggSigDB, parSigDB core.ParSigDB,
+) {
+	ctx = log.WithTopic(ctx, "wal")
+	ctx = context.WithValue(ctx, walReplayKey{}, true)
+
+	expired := func(duty core.Duty) bool {
+		deadline, ok := deadlineFunc(duty)
+		return ok && !deadline.After(time.Now())
+	}
+
+	var aggSigs, parSigs int
+	for duty, set := range replay.AggSigs {
+		if expired(duty) {
+			continue
+		}
+
+		if err := aggSigDB.Store(ctx, duty, set); err != nil {
+			log.Warn(ctx, "Replay aggregate signatures from wal", err, z.Any("duty", duty))
+			continue
+		}
+		aggSigs++
+	}
+
+	for duty, set := range replay.ParSigs {
+		if expired(duty) {
+			continue
+		}
+
+		if err := parSigDB.StoreInternal(ctx, duty, set); err != nil {
+			log.Warn(ctx, "Replay partial signatures from wal", err, z.Any("duty", duty))
+			continue
+		}
+		parSigs++
+	}
+
+	if aggSigs > 0 || parSigs > 0 {
+		log.Info(ctx, "Replayed in-flight duties from wal", z.Int("aggsig_duties", aggSigs), z.Int("parsig_duties", parSigs))
+	}
+}
@@ -0,0 +1,578 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package wal provides an on-disk write-ahead store of in-flight duties, i.e., the node's own partial signatures,
+// the aggregate signatures awaited from the aggsigdb and the builder registrations recast every epoch,
+// so that a restarted node can replay them into the core workflow.
+//
+// Partial and aggregate signatures are appended as JSON lines to segment files that are rotated periodically,
+// with segments deleted after the retention period since their duties will have expired by then.
+// Builder registrations don't expire, so the latest registration of each validator is stored in a separate file.
+package wal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+const (
+	// DirName is the name of the write-ahead store directory in the data directory.
+	DirName = "wal"
+
+	// rotateInterval is the interval after which a new segment is started.
+	rotateInterval = time.Minute * 10
+	// retention is the duration after which segments are deleted.
+	retention = time.Hour
+
+	segmentPrefix     = "segment-"
+	segmentExt        = ".jsonl"
+	registrationsFile = "registrations.json"
+)
+
+// Kind is the kind of persisted record.
+type Kind string
+
+const (
+	// KindParSig is a partially signed duty data set signed by this node.
+	KindParSig Kind = "parsig"
+	// KindAggSig is an aggregate signed duty data set.
+	KindAggSig Kind = "aggsig"
+)
+
+// aggSigTypes are the duty types of aggregate signatures awaited from the aggsigdb that are persisted.
+var aggSigTypes = map[core.DutyType]bool{
+	core.DutyRandao:                  true,
+	core.DutyPrepareAggregator:       true,
+	core.DutyPrepareSyncContribution: true,
+}
+
+// record is a persisted duty data set.
+type record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Kind      Kind      `json:"kind"`
+	// Msg is the protojson encoded ParSigExMsg of the data set, aggregate signatures have share index zero.
+	Msg json.RawMessage `json:"msg"`
+}
+
+// Replay is the in-flight duty data loaded from the store on startup.
+type Replay struct {
+	// ParSigs are the partially signed duty data sets signed by this node.
+	ParSigs map[core.Duty]core.ParSignedDataSet
+	// AggSigs are the aggregate signed duty data sets awaited from the aggsigdb.
+	AggSigs map[core.Duty]core.SignedDataSet
+	// Registrations are the latest builder registrations of each validator.
+	Registrations map[core.Duty]core.SignedDataSet
+}
+
+// registration is a persisted builder registration.
+type registration struct {
+	Slot int64           `json:"slot"`
+	Msg  json.RawMessage `json:"msg"`
+}
+
+// segment is a segment file of the store.
+type segment struct {
+	seq     int
+	created time.Time
+}
+
+// New returns a new write-ahead store in the provided directory, loading the data persisted by a previous run.
+// Segments older than the retention period are deleted and new records are appended to a new segment.
+// Unreadable segments and records are logged and skipped, since the store is best-effort.
+func New(ctx context.Context, dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create wal dir", z.Str("dir", dir))
+	}
+
+	s := &Store{
+		dir:           dir,
+		registrations: make(map[core.PubKey]registration),
+		now:           time.Now,
+	}
+
+	segments, err := listSegments(dir)
+	if err != nil {
+		return nil, err
+	}
+	s.segments = segments
+	s.load(log.WithTopic(ctx, "wal"))
+
+	if err := s.rotateUnsafe(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Store is an on-disk write-ahead store of in-flight duties.
+type Store struct {
+	dir    string
+	now    func() time.Time
+	replay Replay
+
+	mu       sync.Mutex
+	segments []segment // Oldest first, the last is the current segment.
+	current  *os.File
+	written  uint64 // Number of records written.
+
+	syncMu sync.Mutex // Held while syncing, so concurrent appends are grouped into a single sync.
+	synced uint64     // Number of records synced.
+
+	regMu         sync.Mutex // Held while updating and rewriting the registrations file, so appends aren't blocked.
+	registrations map[core.PubKey]registration
+}
+
+// Replay returns the in-flight duty data loaded from the store on startup.
+func (s *Store) Replay() Replay {
+	return s.replay
+}
+
+// StoreParSigs persists the partially signed duty data set signed by this node.
+// It is a parsigdb internal subscriber.
+func (s *Store) StoreParSigs(_ context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+	return s.append(KindParSig, duty, set)
+}
+
+// StoreAggSigs persists the aggregate signed duty data set if it is awaited from the aggsigdb
+// or if it contains builder registrations. It is a sigagg subscriber.
+func (s *Store) StoreAggSigs(_ context.Context, duty core.Duty, set core.SignedDataSet) error {
+	if duty.Type == core.DutyBuilderRegistration {
+		return s.storeRegistrations(duty, set)
+	} else if !aggSigTypes[duty.Type] {
+		return nil
+	}
+
+	return s.append(KindAggSig, duty, toParSigs(set))
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.closeCurrentUnsafe()
+}
+
+// append appends a record of the kind containing the data set to the current segment, rotating it if required.
+// It returns once the record is synced to disk. Records appended concurrently are synced together.
+func (s *Store) append(kind Kind, duty core.Duty, set core.ParSignedDataSet) error {
+	msg, err := marshalMsg(duty, set)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(record{
+		Timestamp: s.now(),
+		Kind:      kind,
+		Msg:       msg,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal wal record")
+	}
+
+	seq, err := s.write(append(b, '\n'))
+	if err != nil {
+		return err
+	}
+
+	return s.sync(seq)
+}
+
+// write writes the record to the current segment, rotating it if required, and returns its sequence number.
+func (s *Store) write(b []byte) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.now().Sub(s.segments[len(s.segments)-1].created) >= rotateInterval {
+		if err := s.rotateUnsafe(); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := s.current.Write(b); err != nil {
+		return 0, errors.Wrap(err, "write wal record")
+	}
+	s.written++
+
+	return s.written, nil
+}
+
+// sync syncs the current segment unless the record with the sequence number was already synced
+// by a concurrent append. A single sync covers all records written before it.
+func (s *Store) sync(seq uint64) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	if s.synced >= seq {
+		return nil
+	}
+
+	s.mu.Lock()
+	current, written := s.current, s.written
+	s.mu.Unlock()
+
+	// Segments are synced before they are closed by rotation or Close, so a closed segment is already synced.
+	if err := current.Sync(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return errors.Wrap(err, "sync wal segment")
+	}
+	s.synced = written
+
+	return nil
+}
+
+// storeRegistrations persists the builder registrations that are newer than the stored registrations
+// by rewriting the registrations file.
+func (s *Store) storeRegistrations(duty core.Duty, set core.SignedDataSet) error {
+	s.regMu.Lock()
+	defer s.regMu.Unlock()
+
+	var updated bool
+	for pubkey, data := range set {
+		if existing, ok := s.registrations[pubkey]; ok && existing.Slot >= duty.Slot {
+			continue // Not storing duplicate or older registration.
+		}
+
+		msg, err := marshalMsg(duty, toParSigs(core.SignedDataSet{pubkey: data}))
+		if err != nil {
+			return err
+		}
+
+		s.registrations[pubkey] = registration{Slot: duty.Slot, Msg: msg}
+		updated = true
+	}
+
+	if !updated {
+		return nil
+	}
+
+	b, err := json.Marshal(s.registrations)
+	if err != nil {
+		return errors.Wrap(err, "marshal registrations")
+	}
+
+	// Write to a synced temporary file and rename it, so the registrations file is always complete.
+	tmp := filepath.Join(s.dir, registrationsFile+".tmp")
+	if err := writeSynced(tmp, b); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp, filepath.Join(s.dir, registrationsFile)); err != nil {
+		return errors.Wrap(err, "rename registrations")
+	}
+
+	// Sync the directory, so the rename is durable.
+	return syncDir(s.dir)
+}
+
+// writeSynced writes the data to the file and syncs it to disk.
+func writeSynced(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644) //nolint:gosec // Not sensitive.
+	if err != nil {
+		return errors.Wrap(err, "create registrations")
+	}
+
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "write registrations")
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "sync registrations")
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "close registrations")
+	}
+
+	return nil
+}
+
+// syncDir syncs the directory to disk.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Wrap(err, "open wal dir")
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return errors.Wrap(err, "sync wal dir")
+	}
+
+	return nil
+}
+
+// load loads the data persisted by a previous run, logging and skipping unreadable segments and records.
+func (s *Store) load(ctx context.Context) {
+	s.replay = Replay{
+		ParSigs:       make(map[core.Duty]core.ParSignedDataSet),
+		AggSigs:       make(map[core.Duty]core.SignedDataSet),
+		Registrations: make(map[core.Duty]core.SignedDataSet),
+	}
+
+	for _, seg := range s.segments {
+		if s.now().Sub(seg.created) > retention {
+			continue
+		}
+
+		path := segmentPath(s.dir, seg.seq)
+		records, corrupt, err := readRecords(path)
+		if err != nil {
+			log.Warn(ctx, "Skipping unreadable wal segment", err)
+			continue
+		} else if corrupt > 0 {
+			log.Warn(ctx, "Skipping corrupt wal records", nil, z.Str("path", path), z.Int("count", corrupt))
+		}
+
+		for _, rec := range records {
+			duty, set, err := unmarshalMsg(rec.Msg)
+			if err != nil {
+				log.Warn(ctx, "Skipping invalid wal record", err, z.Str("path", path))
+				continue
+			}
+
+			switch rec.Kind {
+			case KindParSig:
+				merged, ok := s.replay.ParSigs[duty]
+				if !ok {
+					merged = make(core.ParSignedDataSet)
+					s.replay.ParSigs[duty] = merged
+				}
+				for pubkey, data := range set {
+					merged[pubkey] = data
+				}
+			case KindAggSig:
+				merged, ok := s.replay.AggSigs[duty]
+				if !ok {
+					merged = make(core.SignedDataSet)
+					s.replay.AggSigs[duty] = merged
+				}
+				for pubkey, data := range set {
+					merged[pubkey] = data.SignedData
+				}
+			default:
+				log.Warn(ctx, "Skipping unknown wal record kind", nil, z.Str("path", path), z.Str("kind", string(rec.Kind)))
+			}
+		}
+	}
+
+	b, err := os.ReadFile(filepath.Join(s.dir, registrationsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	} else if err != nil {
+		log.Warn(ctx, "Skipping unreadable wal registrations", err)
+		return
+	}
+
+	var registrations map[core.PubKey]registration
+	if err := json.Unmarshal(b, &registrations); err != nil {
+		log.Warn(ctx, "Skipping invalid wal registrations", err)
+		return
+	}
+
+	for pubkey, reg := range registrations {
+		duty, set, err := unmarshalMsg(reg.Msg)
+		if err != nil {
+			log.Warn(ctx, "Skipping invalid wal registration", err, z.Str("pubkey", pubkey.String()))
+			continue
+		}
+
+		s.registrations[pubkey] = reg
+
+		merged, ok := s.replay.Registrations[duty]
+		if !ok {
+			merged = make(core.SignedDataSet)
+			s.replay.Registrations[duty] = merged
+		}
+		merged[pubkey] = set[pubkey].SignedData
+	}
+}
+
+// closeCurrentUnsafe syncs and closes the current segment.
+// It is unsafe since it assumes the lock is held.
+func (s *Store) closeCurrentUnsafe() error {
+	if err := s.current.Sync(); err != nil {
+		return errors.Wrap(err, "sync wal segment")
+	}
+
+	if err := s.current.Close(); err != nil {
+		return errors.Wrap(err, "close wal segment")
+	}
+
+	return nil
+}
+
+// rotateUnsafe closes the current segment, opens a new segment and deletes segments older than the retention period.
+// It is unsafe since it assumes the lock is held.
+func (s *Store) rotateUnsafe() error {
+	if s.current != nil {
+		if err := s.closeCurrentUnsafe(); err != nil {
+			return err
+		}
+	}
+
+	var seq int
+	if len(s.segments) > 0 {
+		seq = s.segments[len(s.segments)-1].seq + 1
+	}
+
+	current, err := os.OpenFile(segmentPath(s.dir, seq), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "create wal segment")
+	}
+
+	s.current = current
+	s.segments = append(s.segments, segment{seq: seq, created: s.now()})
+
+	for len(s.segments) > 1 && s.now().Sub(s.segments[0].created) > retention {
+		if err := os.Remove(segmentPath(s.dir, s.segments[0].seq)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Wrap(err, "delete wal segment")
+		}
+
+		s.segments = s.segments[1:]
+	}
+
+	return nil
+}
+
+// toParSigs returns the aggregate signed data set as a partially signed data set with share index zero.
+func toParSigs(set core.SignedDataSet) core.ParSignedDataSet {
+	resp := make(core.ParSignedDataSet)
+	for pubkey, data := range set {
+		resp[pubkey] = core.ParSignedData{SignedData: data}
+	}
+
+	return resp
+}
+
+// marshalMsg returns the protojson encoded ParSigExMsg of the duty and partially signed data set.
+func marshalMsg(duty core.Duty, set core.ParSignedDataSet) (json.RawMessage, error) {
+	pb, err := core.ParSignedDataSetToProto(set)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := protojson.Marshal(&pbv1.ParSigExMsg{
+		Duty:    core.DutyToProto(duty),
+		DataSet: pb,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal parsigex msg")
+	}
+
+	return b, nil
+}
+
+// unmarshalMsg returns the duty and partially signed data set of the protojson encoded ParSigExMsg.
+func unmarshalMsg(b json.RawMessage) (core.Duty, core.ParSignedDataSet, error) {
+	msg := new(pbv1.ParSigExMsg)
+	if err := protojson.Unmarshal(b, msg); err != nil {
+		return core.Duty{}, nil, errors.Wrap(err, "unmarshal parsigex msg")
+	}
+	if msg.Duty == nil || msg.DataSet == nil {
+		return core.Duty{}, nil, errors.New("invalid parsigex msg fields")
+	}
+
+	duty := core.DutyFromProto(msg.Duty)
+	set, err := core.ParSignedDataSetFromProto(duty.Type, msg.DataSet)
+	if err != nil {
+		return core.Duty{}, nil, err
+	}
+
+	return duty, set, nil
+}
+
+// readRecords returns the valid records of the segment file and the number of corrupt records that were skipped.
+// A trailing partially written record isn't counted as corrupt.
+func readRecords(path string) ([]record, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "open wal segment", z.Str("path", path))
+	}
+	defer f.Close()
+
+	var (
+		resp    []record
+		corrupt int
+		lastBad bool
+		scanner = bufio.NewScanner(f)
+	)
+	scanner.Buffer(nil, 1<<26) // Records may contain large data sets.
+	for scanner.Scan() {
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			corrupt++
+			lastBad = true
+
+			continue
+		}
+		resp = append(resp, rec)
+		lastBad = false
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, 0, errors.Wrap(err, "read wal segment", z.Str("path", path))
+	}
+
+	if lastBad {
+		corrupt-- // Partially written record.
+	}
+
+	return resp, corrupt, nil
+}
+
+// listSegments returns the segments in the directory ordered by sequence number.
+// The creation time of a segment is approximated by its modification time.
+func listSegments(dir string) ([]segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "read wal dir", z.Str("dir", dir))
+	}
+
+	var resp []segment
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, segmentPrefix) || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+
+		var seq int
+		if _, err := fmt.Sscanf(strings.TrimSuffix(strings.TrimPrefix(name, segmentPrefix), segmentExt), "%d", &seq); err != nil {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, errors.Wrap(err, "wal segment info", z.Str("name", name))
+		}
+
+		resp = append(resp, segment{seq: seq, created: info.ModTime()})
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].seq < resp[j].seq
+	})
+
+	return resp, nil
+}
+
+// segmentPath returns the path of the segment file.
+func segmentPath(dir string, seq int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s%08d%s", segmentPrefix, seq, segmentExt))
+}
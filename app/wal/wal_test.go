@@ -0,0 +1,123 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package wal_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/wal"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := wal.New(ctx, dir)
+	require.NoError(t, err)
+	require.Empty(t, s.Replay().ParSigs)
+	require.Empty(t, s.Replay().AggSigs)
+	require.Empty(t, s.Replay().Registrations)
+
+	var (
+		pubkey1 = testutil.RandomCorePubKey(t)
+		pubkey2 = testutil.RandomCorePubKey(t)
+		attDuty = core.NewAttesterDuty(1)
+		att1    = core.NewPartialAttestation(testutil.RandomAttestation(), 1)
+		att2    = core.NewPartialAttestation(testutil.RandomAttestation(), 1)
+		randao  = core.NewSignedRandao(1, testutil.RandomEth2Signature())
+		reg1    = testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+		reg2    = testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+	)
+
+	// Partial signatures of the same duty are merged.
+	require.NoError(t, s.StoreParSigs(ctx, attDuty, core.ParSignedDataSet{pubkey1: att1}))
+	require.NoError(t, s.StoreParSigs(ctx, attDuty, core.ParSignedDataSet{pubkey2: att2}))
+
+	// Only aggregate signatures awaited from the aggsigdb are stored.
+	require.NoError(t, s.StoreAggSigs(ctx, core.NewRandaoDuty(1), core.SignedDataSet{pubkey1: randao}))
+	require.NoError(t, s.StoreAggSigs(ctx, attDuty, core.SignedDataSet{pubkey1: att1.SignedData}))
+
+	// Only the latest registration of each validator is stored.
+	require.NoError(t, s.StoreAggSigs(ctx, core.NewBuilderRegistrationDuty(2), core.SignedDataSet{pubkey1: reg1}))
+	require.NoError(t, s.StoreAggSigs(ctx, core.NewBuilderRegistrationDuty(1), core.SignedDataSet{pubkey1: reg2}))
+	require.NoError(t, s.StoreAggSigs(ctx, core.NewBuilderRegistrationDuty(3), core.SignedDataSet{pubkey2: reg2}))
+	require.NoError(t, s.Close())
+
+	// Corrupt, invalid and unknown records as well as a partially written record are skipped.
+	segments, err := filepath.Glob(filepath.Join(dir, "segment-*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	f, err := os.OpenFile(segments[0], os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString("corrupt\n" +
+		`{"kind":"parsig","msg":{"duty":"invalid"}}` + "\n" +
+		`{"kind":"unknown","msg":{}}` + "\n" +
+		`{"timestamp":`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	s, err = wal.New(ctx, dir)
+	require.NoError(t, err)
+	defer s.Close()
+
+	replay := s.Replay()
+	require.Equal(t, map[core.Duty]core.ParSignedDataSet{
+		attDuty: {pubkey1: att1, pubkey2: att2},
+	}, replay.ParSigs)
+	require.Equal(t, map[core.Duty]core.SignedDataSet{
+		core.NewRandaoDuty(1): {pubkey1: randao},
+	}, replay.AggSigs)
+	require.Equal(t, map[core.Duty]core.SignedDataSet{
+		core.NewBuilderRegistrationDuty(2): {pubkey1: reg1},
+		core.NewBuilderRegistrationDuty(3): {pubkey2: reg2},
+	}, replay.Registrations)
+	require.NoError(t, s.Close())
+
+	// A corrupt registrations file is skipped.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "registrations.json"), []byte("corrupt"), 0o644))
+
+	s, err = wal.New(ctx, dir)
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.Empty(t, s.Replay().Registrations)
+	require.Len(t, s.Replay().ParSigs, 1)
+}
+
+func TestStoreConcurrent(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := wal.New(ctx, dir)
+	require.NoError(t, err)
+
+	// Concurrent appends are synced together.
+	const n = 20
+	var eg errgroup.Group
+	for i := 0; i < n; i++ {
+		slot := int64(i)
+		eg.Go(func() error {
+			set := core.ParSignedDataSet{
+				testutil.RandomCorePubKey(t): core.NewPartialAttestation(testutil.RandomAttestation(), 1),
+			}
+
+			return s.StoreParSigs(ctx, core.NewAttesterDuty(slot), set)
+		})
+	}
+	require.NoError(t, eg.Wait())
+	require.NoError(t, s.Close())
+
+	s, err = wal.New(ctx, dir)
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.Len(t, s.Replay().ParSigs, n)
+}
@@ -0,0 +1,160 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package app
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/wal"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/parsigdb"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestWireWAL(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := wal.New(ctx, dir)
+	require.NoError(t, err)
+
+	parSigDB := parsigdb.NewMemDB(2, core.NewDeadlinerForT(ctx, t, testDeadlineFunc, clockwork.NewFakeClock()))
+	sigAgg := new(testSigAgg)
+	wireWAL(store, parSigDB, sigAgg)
+
+	var (
+		pubkey     = testutil.RandomCorePubKey(t)
+		attDuty    = core.NewAttesterDuty(1)
+		randaoDuty = core.NewRandaoDuty(1)
+		att        = core.NewPartialAttestation(testutil.RandomAttestation(), 1)
+		randao     = core.NewSignedRandao(1, testutil.RandomEth2Signature())
+	)
+
+	require.NoError(t, parSigDB.StoreInternal(ctx, attDuty, core.ParSignedDataSet{pubkey: att}))
+	require.NoError(t, sigAgg.Aggregated(ctx, randaoDuty, core.SignedDataSet{pubkey: randao}))
+	require.NoError(t, store.Close())
+
+	store, err = wal.New(ctx, dir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.Equal(t, map[core.Duty]core.ParSignedDataSet{
+		attDuty: {pubkey: att},
+	}, store.Replay().ParSigs)
+	require.Equal(t, map[core.Duty]core.SignedDataSet{
+		randaoDuty: {pubkey: randao},
+	}, store.Replay().AggSigs)
+}
+
+func TestReplayWAL(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	store, err := wal.New(ctx, dir)
+	require.NoError(t, err)
+
+	parSigDB := parsigdb.NewMemDB(2, core.NewDeadlinerForT(ctx, t, testDeadlineFunc, clockwork.NewFakeClock()))
+	wireWAL(store, parSigDB, new(testSigAgg))
+
+	var internal []core.Duty
+	parSigDB.SubscribeInternal(func(_ context.Context, duty core.Duty, _ core.ParSignedDataSet) error {
+		internal = append(internal, duty)
+		return nil
+	})
+
+	var (
+		pubkey = testutil.RandomCorePubKey(t)
+		att    = core.NewPartialAttestation(testutil.RandomAttestation(), 1)
+		randao = core.NewSignedRandao(1, testutil.RandomEth2Signature())
+	)
+
+	// Duties of slot zero have expired.
+	replay := wal.Replay{
+		ParSigs: map[core.Duty]core.ParSignedDataSet{
+			core.NewAttesterDuty(0): {pubkey: att},
+			core.NewAttesterDuty(1): {pubkey: att},
+		},
+		AggSigs: map[core.Duty]core.SignedDataSet{
+			core.NewRandaoDuty(0): {pubkey: randao},
+			core.NewRandaoDuty(1): {pubkey: randao},
+		},
+	}
+
+	aggSigDB := new(testAggSigDB)
+	replayWAL(ctx, replay, testDeadlineFunc, aggSigDB, parSigDB)
+
+	require.Equal(t, []core.Duty{core.NewAttesterDuty(1)}, internal)
+	require.Equal(t, map[core.Duty]core.SignedDataSet{
+		core.NewRandaoDuty(1): {pubkey: randao},
+	}, aggSigDB.stored)
+
+	// Replayed partial signatures aren't persisted again.
+	require.NoError(t, store.Close())
+
+	store, err = wal.New(ctx, dir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.Empty(t, store.Replay().ParSigs)
+}
+
+// testDeadlineFunc returns a deadline in the past for duties of slot zero and in the future otherwise.
+func testDeadlineFunc(duty core.Duty) (time.Time, bool) {
+	if duty.Slot == 0 {
+		return time.Now().Add(-time.Minute), true
+	}
+
+	return time.Now().Add(time.Hour), true
+}
+
+// testSigAgg is a core.SigAgg that calls its subscribers with the provided aggregated data.
+type testSigAgg struct {
+	subs []func(context.Context, core.Duty, core.SignedDataSet) error
+}
+
+func (*testSigAgg) Aggregate(context.Context, core.Duty, map[core.PubKey][]core.ParSignedData) error {
+	return nil
+}
+
+func (a *testSigAgg) Subscribe(fn func(context.Context, core.Duty, core.SignedDataSet) error) {
+	a.subs = append(a.subs, fn)
+}
+
+// Aggregated calls the subscribers with the aggregated data set.
+func (a *testSigAgg) Aggregated(ctx context.Context, duty core.Duty, set core.SignedDataSet) error {
+	for _, sub := range a.subs {
+		if err := sub(ctx, duty, set); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// testAggSigDB is a core.AggSigDB that records the stored data sets.
+type testAggSigDB struct {
+	mu     sync.Mutex
+	stored map[core.Duty]core.SignedDataSet
+}
+
+func (db *testAggSigDB) Store(_ context.Context, duty core.Duty, set core.SignedDataSet) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.stored == nil {
+		db.stored = make(map[core.Duty]core.SignedDataSet)
+	}
+	db.stored[duty] = set
+
+	return nil
+}
+
+func (*testAggSigDB) Await(context.Context, core.Duty, core.PubKey) (core.SignedData, error) {
+	return nil, nil
+}
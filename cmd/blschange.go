@@ -0,0 +1,556 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/k1util"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/parsigex"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/blschange"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/p2p"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+// blsChangeSigSlot is the slot of the core.DutySignature duty used to exchange partial BLS to execution change
+// signatures via parsigex, similar to the DKG signature types. It should not change as it can break compatibility.
+const blsChangeSigSlot = 201
+
+// blsChangeConfig is the config for the `bls-change` commands.
+type blsChangeConfig struct {
+	LockFile          string
+	ManifestFile      string
+	PrivKeyFile       string
+	ValidatorKeysDir  string
+	ValidatorPubkeys  []string
+	ExecutionAddress  string
+	BeaconNodeAddrs   []string
+	BeaconNodeTimeout time.Duration
+	ExchangeTimeout   time.Duration
+	ChangesFile       string
+	P2P               p2p.Config
+	Log               log.Config
+}
+
+func newBLSChangeCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "bls-change",
+		Short: "Change the withdrawal credentials of distributed validators",
+		Long:  "Change the '0x00' BLS withdrawal credentials of distributed validators to an execution address: sign partial BLS to execution changes with each node's key shares, exchange them with the other nodes of the cluster, aggregate them into a bls_to_execution_changes.json file and broadcast it to the beacon node.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newBLSChangeSignCmd(runFunc func(context.Context, blsChangeConfig) error) *cobra.Command {
+	var config blsChangeConfig
+
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign and aggregate BLS to execution changes",
+		Long:  "Signs BLS to execution changes of the cluster's distributed validators with this node's key shares, exchanges the partial changes with the other nodes of the cluster via libp2p and aggregates them into a bls_to_execution_changes.json file. At least a threshold of nodes must run this command at the same time.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.PrivKeyFile, "private-key-file", ".charon/charon-enr-private-key", "The path to the charon enr private key file.")
+	cmd.Flags().StringSliceVar(&config.BeaconNodeAddrs, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs.")
+	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", 10*time.Second, "Timeout for beacon node HTTP requests.")
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "The directory containing this node's validator key shares.")
+	cmd.Flags().StringSliceVar(&config.ValidatorPubkeys, "validator-public-keys", nil, "Comma separated list of hex encoded public keys of the distributed validators to change. Defaults to all validators of the cluster.")
+	cmd.Flags().StringVar(&config.ExecutionAddress, "execution-address", "", "The checksummed Ethereum address to change the withdrawal credentials to.")
+	cmd.Flags().DurationVar(&config.ExchangeTimeout, "exchange-timeout", 10*time.Minute, "Timeout for exchanging partial changes with the other nodes of the cluster.")
+	cmd.Flags().StringVar(&config.ChangesFile, "changes-file", "bls_to_execution_changes.json", "The path to write the aggregated BLS to execution changes to.")
+	bindP2PFlags(cmd, &config.P2P)
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	mustMarkFlagRequired(cmd, "beacon-node-endpoints")
+	mustMarkFlagRequired(cmd, "execution-address")
+
+	return cmd
+}
+
+func newBLSChangeBroadcastCmd(runFunc func(context.Context, blsChangeConfig) error) *cobra.Command {
+	var config blsChangeConfig
+
+	cmd := &cobra.Command{
+		Use:   "broadcast",
+		Short: "Broadcast signed BLS to execution changes",
+		Long:  "Submits the signed BLS to execution changes of a bls_to_execution_changes.json file to the beacon node.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&config.BeaconNodeAddrs, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs.")
+	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", 10*time.Second, "Timeout for beacon node HTTP requests.")
+	cmd.Flags().StringVar(&config.ChangesFile, "changes-file", "bls_to_execution_changes.json", "The path to the signed BLS to execution changes.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	mustMarkFlagRequired(cmd, "beacon-node-endpoints")
+
+	return cmd
+}
+
+func runBLSChangeSign(ctx context.Context, conf blsChangeConfig) error {
+	cluster, err := loadClusterManifest(conf.ManifestFile, conf.LockFile)
+	if err != nil {
+		return err
+	}
+
+	key, err := k1util.Load(conf.PrivKeyFile)
+	if err != nil {
+		return errors.Wrap(err, "load private key", z.Str("path", conf.PrivKeyFile))
+	}
+
+	eth2Cl, err := eth2wrap.NewMultiHTTP(conf.BeaconNodeTimeout, conf.BeaconNodeAddrs...)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tcpNode, err := setupBLSChangeP2P(ctx, conf.P2P, cluster, key)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tcpNode.Close()
+	}()
+
+	ctx, cancelExchange := context.WithTimeout(ctx, conf.ExchangeTimeout)
+	defer cancelExchange()
+
+	return signBLSChanges(ctx, conf, cluster, eth2Cl, tcpNode)
+}
+
+func runBLSChangeBroadcast(ctx context.Context, conf blsChangeConfig) error {
+	eth2Cl, err := eth2wrap.NewMultiHTTP(conf.BeaconNodeTimeout, conf.BeaconNodeAddrs...)
+	if err != nil {
+		return err
+	}
+
+	return broadcastBLSChanges(ctx, conf, eth2Cl)
+}
+
+// setupBLSChangeP2P returns a started libp2p tcp node connecting to the cluster peers via the configured relays.
+func setupBLSChangeP2P(ctx context.Context, conf p2p.Config, cluster *manifestpb.Cluster, key *k1.PrivateKey) (host.Host, error) {
+	peers, err := manifest.ClusterPeers(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p2p.VerifyP2PKey(peers, key); err != nil {
+		return nil, err
+	}
+
+	peerIDs, err := manifest.ClusterPeerIDs(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	relays, err := p2p.NewRelays(ctx, conf.Relays, hex.EncodeToString(cluster.InitialMutationHash))
+	if err != nil {
+		return nil, err
+	}
+
+	connGater, err := p2p.NewConnGater(peerIDs, relays)
+	if err != nil {
+		return nil, err
+	}
+
+	tcpNode, err := p2p.NewTCPNode(ctx, conf, key, connGater, false)
+	if err != nil {
+		return nil, err
+	}
+
+	p2p.RegisterConnectionLogger(ctx, tcpNode, peerIDs)
+
+	for _, relay := range relays {
+		go p2p.NewRelayReserver(tcpNode, relay)(ctx)
+	}
+
+	go p2p.NewRelayRouter(tcpNode, peerIDs, relays)(ctx)
+
+	return tcpNode, nil
+}
+
+// signBLSChanges signs BLS to execution changes of the configured validators with this node's key shares,
+// exchanges the partial changes with the cluster peers and writes the aggregated changes to the changes file.
+func signBLSChanges(ctx context.Context, conf blsChangeConfig, cluster *manifestpb.Cluster, eth2Cl eth2wrap.Client, tcpNode host.Host) error {
+	network, err := eth2util.ForkVersionToNetwork(cluster.ForkVersion)
+	if err != nil {
+		return err
+	}
+
+	peers, err := manifest.ClusterPeerIDs(cluster)
+	if err != nil {
+		return err
+	}
+
+	vals, err := blsChangeValidators(cluster, conf.ValidatorPubkeys)
+	if err != nil {
+		return err
+	}
+
+	keyFiles, err := keystore.LoadFilesUnordered(conf.ValidatorKeysDir)
+	if err != nil {
+		return err
+	}
+
+	var pubkeys []eth2p0.BLSPubKey
+	for _, val := range vals {
+		pubkeys = append(pubkeys, val.pubkey)
+	}
+
+	eth2Vals, err := eth2Cl.ValidatorsByPubKey(ctx, "head", pubkeys)
+	if err != nil {
+		return err
+	}
+
+	var (
+		set     = make(core.ParSignedDataSet)
+		changes = make(map[core.PubKey]blsChange)
+		order   []core.PubKey
+	)
+	for _, val := range vals {
+		secret, shareIdx, err := validatorKeyShare(val.val, keyFiles.Keys())
+		if err != nil {
+			return errors.Wrap(err, "validator key share", z.Str("pubkey", hexPubkey(val.pubkey)))
+		}
+
+		var found bool
+		for valIdx, eth2Val := range eth2Vals {
+			if eth2Val == nil || eth2Val.Validator == nil || eth2Val.Validator.PublicKey != val.pubkey {
+				continue
+			}
+			found = true
+
+			if err := blschange.VerifyWithdrawalCredentials(eth2Val.Validator.WithdrawalCredentials, val.pubkey); err != nil {
+				return errors.Wrap(err, "cannot change withdrawal credentials", z.Str("pubkey", hexPubkey(val.pubkey)))
+			}
+
+			msg, err := blschange.NewMessage(valIdx, val.pubkey, conf.ExecutionAddress)
+			if err != nil {
+				return err
+			}
+
+			sigRoot, err := blschange.GetMessageSigningRoot(msg, network)
+			if err != nil {
+				return err
+			}
+
+			sig, err := tbls.Sign(secret, sigRoot[:])
+			if err != nil {
+				return err
+			}
+
+			pubShares := make(map[int]tbls.PublicKey)
+			for i, b := range val.val.PubShares {
+				pubshare, err := tblsconv.PubkeyFromBytes(b)
+				if err != nil {
+					return err
+				}
+
+				// Share index is 1-indexed.
+				pubShares[i+1] = pubshare
+			}
+
+			pubkey := core.PubKeyFrom48Bytes(val.pubkey)
+			set[pubkey] = core.NewPartialSignature(core.SigFromETH2(tblsconv.SigToETH2(sig)), shareIdx)
+			changes[pubkey] = blsChange{msg: msg, sigRoot: sigRoot, pubShares: pubShares}
+			order = append(order, pubkey)
+		}
+
+		if !found {
+			return errors.New("validator not found on beacon chain", z.Str("pubkey", hexPubkey(val.pubkey)))
+		}
+	}
+
+	log.Info(ctx, "Signed partial BLS to execution changes, exchanging with peers",
+		z.Int("validators", len(set)),
+		z.Str("execution_address", conf.ExecutionAddress),
+	)
+
+	partials, err := exchangeBLSChanges(ctx, tcpNode, peers, int(cluster.Threshold), set, newBLSChangeVerifier(changes))
+	if err != nil {
+		return errors.Wrap(err, "exchange partial changes")
+	}
+
+	var signed []*capella.SignedBLSToExecutionChange
+	for _, pubkey := range order {
+		sig, err := tbls.ThresholdAggregate(partials[pubkey])
+		if err != nil {
+			return err
+		}
+
+		signed = append(signed, &capella.SignedBLSToExecutionChange{
+			Message:   changes[pubkey].msg,
+			Signature: tblsconv.SigToETH2(sig),
+		})
+	}
+
+	// MarshalChanges verifies the aggregated signatures.
+	b, err := blschange.MarshalChanges(signed, network)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // BLS to execution changes are not secret.
+	if err := os.WriteFile(conf.ChangesFile, b, 0o644); err != nil {
+		return errors.Wrap(err, "write changes file", z.Str("path", conf.ChangesFile))
+	}
+
+	log.Info(ctx, "Aggregated BLS to execution changes",
+		z.Int("validators", len(signed)),
+		z.Str("changes_file", conf.ChangesFile),
+	)
+
+	return nil
+}
+
+// blsChange is the BLS to execution change of a distributed validator with its signing root and public shares.
+type blsChange struct {
+	msg       *capella.BLSToExecutionChange
+	sigRoot   eth2p0.Root
+	pubShares map[int]tbls.PublicKey
+}
+
+// newBLSChangeVerifier returns a parsigex verify function that verifies partial signatures of peers
+// against this node's BLS to execution changes.
+func newBLSChangeVerifier(changes map[core.PubKey]blsChange) func(context.Context, core.Duty, core.ParSignedDataSet) error {
+	return func(_ context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+		for pubkey, data := range set {
+			change, ok := changes[pubkey]
+			if !ok {
+				return errors.New("unexpected partial change", z.Any("pubkey", pubkey))
+			}
+
+			pubshare, ok := change.pubShares[data.ShareIdx]
+			if !ok {
+				return errors.New("invalid partial change share index", z.Any("pubkey", pubkey), z.Int("share_idx", data.ShareIdx))
+			}
+
+			sigRoot := change.sigRoot
+			sig := tbls.Signature(data.Signature().ToETH2())
+			if err := tbls.Verify(pubshare, sigRoot[:], sig); err != nil {
+				return errors.Wrap(err, "invalid partial change signature", z.Any("pubkey", pubkey), z.Int("share_idx", data.ShareIdx))
+			}
+		}
+
+		return nil
+	}
+}
+
+// exchangeBLSChanges exchanges this node's partial signatures with the peers via parsigex and returns the
+// partial signatures by share index of each validator. It rebroadcasts until it received a threshold of partial
+// signatures of every validator and delivered its own partial signatures to every peer it received from.
+func exchangeBLSChanges(ctx context.Context, tcpNode host.Host, peers []peer.ID, threshold int,
+	set core.ParSignedDataSet, verifyFunc func(context.Context, core.Duty, core.ParSignedDataSet) error,
+) (map[core.PubKey]map[int]tbls.Signature, error) {
+	peerIdx := -1
+	for i, pID := range peers {
+		if pID == tcpNode.ID() {
+			peerIdx = i
+		}
+	}
+	if peerIdx < 0 {
+		return nil, errors.New("private key not matching any cluster peer")
+	}
+
+	var (
+		mu        sync.Mutex
+		delivered = make(map[peer.ID]bool)
+		received  = make(map[peer.ID]bool)
+		partials  = make(map[core.PubKey]map[int]tbls.Signature)
+	)
+
+	store := func(set core.ParSignedDataSet) {
+		for pubkey, data := range set {
+			if _, ok := partials[pubkey]; !ok {
+				partials[pubkey] = make(map[int]tbls.Signature)
+			}
+			partials[pubkey][data.ShareIdx] = tbls.Signature(data.Signature().ToETH2())
+		}
+	}
+	store(set)
+
+	// sendFunc sends to peers not delivered to yet. It doesn't return errors so the broadcast continues to
+	// the other peers, undelivered peers are retried on the next broadcast.
+	sendFunc := func(ctx context.Context, tcpNode host.Host, protoID protocol.ID, pID peer.ID, msg proto.Message, opts ...p2p.SendRecvOption) error {
+		mu.Lock()
+		ok := delivered[pID]
+		mu.Unlock()
+
+		if ok {
+			return nil
+		}
+
+		if err := p2p.Send(ctx, tcpNode, protoID, pID, msg, opts...); err != nil {
+			log.Debug(ctx, "Failed sending partial changes to peer, retrying", z.Str("peer", p2p.PeerName(pID)), z.Err(err))
+			return nil
+		}
+
+		mu.Lock()
+		delivered[pID] = true
+		mu.Unlock()
+
+		return nil
+	}
+
+	duty := core.NewSignatureDuty(blsChangeSigSlot)
+	gaterFunc := func(d core.Duty) bool {
+		return d == duty
+	}
+
+	sigex := parsigex.NewParSigEx(tcpNode, sendFunc, peerIdx, peers, verifyFunc, gaterFunc)
+	sigex.Subscribe(func(ctx context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if pID, ok := core.ParSigSender(ctx); ok {
+			received[pID] = true
+		}
+		store(set)
+
+		return nil
+	})
+
+	done := func() (map[core.PubKey]map[int]tbls.Signature, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for pID := range received {
+			if !delivered[pID] {
+				return nil, false
+			}
+		}
+
+		resp := make(map[core.PubKey]map[int]tbls.Signature)
+		for pubkey := range set {
+			if len(partials[pubkey]) < threshold {
+				return nil, false
+			}
+
+			resp[pubkey] = make(map[int]tbls.Signature)
+			for shareIdx, sig := range partials[pubkey] {
+				resp[pubkey][shareIdx] = sig
+			}
+		}
+
+		return resp, true
+	}
+
+	tick := time.NewTicker(time.Second)
+	defer tick.Stop()
+
+	for {
+		if err := sigex.Broadcast(ctx, duty, set); err != nil {
+			return nil, err
+		}
+
+		if resp, ok := done(); ok {
+			return resp, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-tick.C:
+		}
+	}
+}
+
+// broadcastBLSChanges submits the signed BLS to execution changes in the changes file to the beacon node.
+func broadcastBLSChanges(ctx context.Context, conf blsChangeConfig, eth2Cl eth2wrap.Client) error {
+	b, err := os.ReadFile(conf.ChangesFile)
+	if err != nil {
+		return errors.Wrap(err, "read changes file", z.Str("path", conf.ChangesFile))
+	}
+
+	changes, err := blschange.UnmarshalChanges(b)
+	if err != nil {
+		return err
+	}
+
+	if err := eth2Cl.SubmitBLSToExecutionChanges(ctx, changes); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Broadcast signed BLS to execution changes", z.Int("validators", len(changes)))
+
+	return nil
+}
+
+type blsChangeValidator struct {
+	val    *manifestpb.Validator
+	pubkey eth2p0.BLSPubKey
+}
+
+// blsChangeValidators returns the cluster validators matching the provided hex encoded public keys
+// or all cluster validators if none are provided.
+func blsChangeValidators(cluster *manifestpb.Cluster, hexPubkeys []string) ([]blsChangeValidator, error) {
+	var resp []blsChangeValidator
+	if len(hexPubkeys) == 0 {
+		for _, val := range cluster.Validators {
+			pubkey, err := manifest.ValidatorPublicKey(val)
+			if err != nil {
+				return nil, err
+			}
+
+			resp = append(resp, blsChangeValidator{val: val, pubkey: eth2p0.BLSPubKey(pubkey)})
+		}
+
+		return resp, nil
+	}
+
+	for _, hexPubkey := range hexPubkeys {
+		val, pubkey, err := exitValidator(cluster, hexPubkey)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, blsChangeValidator{val: val, pubkey: pubkey})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,136 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestBLSChange(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	const execAddr = "0x321dcb529f3945bc94fecea9d3bc5caf35253b94"
+
+	lock, p2pKeys, shares := cluster.NewForT(t, 2, 3, 4, 0)
+	lockJSON, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, lockJSON, 0o444))
+
+	valSet := make(beaconmock.ValidatorSet)
+	for i, val := range lock.Validators {
+		pubkey := eth2p0.BLSPubKey(val.PubKey)
+		creds := sha256.Sum256(pubkey[:])
+		creds[0] = 0x00
+
+		valSet[eth2p0.ValidatorIndex(i+1)] = &eth2v1.Validator{
+			Index:  eth2p0.ValidatorIndex(i + 1),
+			Status: eth2v1.ValidatorStateActiveOngoing,
+			Validator: &eth2p0.Validator{
+				PublicKey:             pubkey,
+				WithdrawalCredentials: creds[:],
+			},
+		}
+	}
+
+	bmock, err := beaconmock.New(beaconmock.WithValidatorSet(valSet))
+	require.NoError(t, err)
+
+	var submitted []*capella.SignedBLSToExecutionChange
+	bmock.SubmitBLSToExecutionChangesFunc = func(_ context.Context, changes []*capella.SignedBLSToExecutionChange) error {
+		submitted = changes
+		return nil
+	}
+
+	eth2Cl, err := eth2wrap.Instrument(bmock)
+	require.NoError(t, err)
+
+	cluster, err := loadClusterManifest("", lockFile)
+	require.NoError(t, err)
+
+	// Create connected libp2p hosts of a threshold of nodes.
+	var hosts []host.Host
+	for i := 0; i < lock.Threshold; i++ {
+		hosts = append(hosts, testutil.CreateHostWithIdentity(t, testutil.AvailableAddr(t), p2pKeys[i]))
+	}
+	for i, h := range hosts {
+		for j, other := range hosts {
+			if i != j {
+				h.Peerstore().AddAddrs(other.ID(), other.Addrs(), peerstore.PermanentAddrTTL)
+			}
+		}
+	}
+
+	// Sign and exchange partial changes with the key shares of a threshold of nodes.
+	var (
+		eg          errgroup.Group
+		changeFiles []string
+	)
+	for i := 0; i < lock.Threshold; i++ {
+		keysDir := filepath.Join(dir, fmt.Sprintf("node%d", i), "validator_keys")
+		require.NoError(t, os.MkdirAll(keysDir, 0o755))
+		require.NoError(t, keystore.StoreKeysInsecure([]tbls.PrivateKey{shares[0][i], shares[1][i]}, keysDir, keystore.ConfirmInsecureKeys))
+
+		conf := blsChangeConfig{
+			LockFile:         lockFile,
+			ValidatorKeysDir: keysDir,
+			ExecutionAddress: execAddr,
+			ChangesFile:      filepath.Join(dir, fmt.Sprintf("bls_to_execution_changes_%d.json", i)),
+		}
+		changeFiles = append(changeFiles, conf.ChangesFile)
+
+		tcpNode := hosts[i]
+		eg.Go(func() error {
+			return signBLSChanges(ctx, conf, cluster, eth2Cl, tcpNode)
+		})
+	}
+	require.NoError(t, eg.Wait())
+
+	// All nodes aggregated the same changes.
+	expect, err := os.ReadFile(changeFiles[0])
+	require.NoError(t, err)
+	for _, file := range changeFiles[1:] {
+		actual, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, expect, actual)
+	}
+
+	conf := blsChangeConfig{ChangesFile: changeFiles[0]}
+	require.NoError(t, broadcastBLSChanges(ctx, conf, eth2Cl))
+
+	require.Len(t, submitted, len(lock.Validators))
+	for i, change := range submitted {
+		require.EqualValues(t, i+1, change.Message.ValidatorIndex)
+		require.EqualValues(t, lock.Validators[i].PubKey, change.Message.FromBLSPubkey[:])
+		require.Equal(t, execAddr, fmt.Sprintf("%#x", change.Message.ToExecutionAddress))
+	}
+
+	// Validators without BLS withdrawal credentials are refused.
+	valSet[1].Validator.WithdrawalCredentials[0] = 0x01
+	conf.ValidatorKeysDir = filepath.Join(dir, "node0", "validator_keys")
+	conf.ExecutionAddress = execAddr
+	require.ErrorContains(t, signBLSChanges(ctx, conf, cluster, eth2Cl, hosts[0]), "not BLS withdrawal credentials")
+}
@@ -44,10 +44,27 @@ func New() *cobra.Command {
 			newCreateClusterCmd(runCreateCluster),
 		),
 		newCombineCmd(newCombineFunc),
+		newSlashingCmd(
+			newSlashingExportCmd(runSlashingExport),
+			newSlashingImportCmd(runSlashingImport),
+		),
+		newExitCmd(
+			newExitSignCmd(runExitSign),
+			newExitAggregateCmd(runExitAggregate),
+			newExitBroadcastCmd(runExitBroadcast),
+		),
+		newBLSChangeCmd(
+			newBLSChangeSignCmd(runBLSChangeSign),
+			newBLSChangeBroadcastCmd(runBLSChangeBroadcast),
+		),
 		newAlphaCmd(
 			newAddValidatorsCmd(runAddValidatorsSolo),
 			newViewClusterManifestCmd(runViewClusterManifest),
 		),
+		newDebugCmd(
+			newQBFTReplayCmd(runQBFTReplay),
+			newDebugDumpCmd(runDebugDump),
+		),
 		newUnsafeCmd(newRunCmd(app.Run, true)),
 	)
 }
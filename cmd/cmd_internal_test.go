@@ -79,6 +79,8 @@ func TestCmdFlags(t *testing.T) {
 				SimnetSlotDuration:     time.Second,
 				MonitoringAddr:         "127.0.0.1:3620",
 				ValidatorAPIAddr:       "127.0.0.1:3600",
+				Signer:                 app.SignerValidatorClient,
+				ValidatorKeysDir:       ".charon/validator_keys",
 				BeaconNodeAddrs:        []string{"http://beacon.node"},
 				JaegerAddr:             "",
 				JaegerService:          "charon",
@@ -132,6 +134,8 @@ func TestCmdFlags(t *testing.T) {
 				SimnetSlotDuration:     time.Second,
 				MonitoringAddr:         "127.0.0.1:3620",
 				ValidatorAPIAddr:       "127.0.0.1:3600",
+				Signer:                 app.SignerValidatorClient,
+				ValidatorKeysDir:       ".charon/validator_keys",
 				BeaconNodeAddrs:        []string{"http://beacon.node"},
 				JaegerAddr:             "",
 				JaegerService:          "charon",
@@ -0,0 +1,19 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newDebugCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "debug",
+		Short: "Debugging tools for analysing charon nodes",
+		Long:  `Debug subcommands provide tools for analysing data captured from charon nodes, for example via the monitoring API debug endpoints.`,
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
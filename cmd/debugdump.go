@@ -0,0 +1,123 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/archive"
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// debugDumpConfig is the config for the `debug dump` command.
+type debugDumpConfig struct {
+	DataDir    string
+	Slot       int64
+	Duty       string
+	QBFTOutput string
+}
+
+func newDebugDumpCmd(runFunc func(io.Writer, debugDumpConfig) error) *cobra.Command {
+	var config debugDumpConfig
+
+	cmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Dump the archived history of a slot's duties",
+		Long: `Extracts the sniffed consensus instances and tracker events of a slot's duties from the rolling archive ` +
+			`in the data directory (enabled via 'charon run --archive-size-mb') and prints them as JSON.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.DataDir, "data-dir", "", "The directory where charon persists its internal state. This must match the --data-dir flag of charon run.")
+	cmd.Flags().Int64Var(&config.Slot, "slot", 0, "The slot of the duties to dump.")
+	cmd.Flags().StringVar(&config.Duty, "duty", "", "Only dump records of the duty type, e.g. 'attester'.")
+	cmd.Flags().StringVar(&config.QBFTOutput, "qbft-output", "", "Optional path to write the dumped consensus instances as a gzipped file that can be replayed with 'charon debug qbft-replay'.")
+
+	mustMarkFlagRequired(cmd, "data-dir")
+	mustMarkFlagRequired(cmd, "slot")
+
+	return cmd
+}
+
+func runDebugDump(out io.Writer, conf debugDumpConfig) error {
+	dir := filepath.Join(conf.DataDir, archive.DirName)
+	if _, err := os.Stat(dir); err != nil {
+		return errors.Wrap(err, "archive not found, ensure charon run --archive-size-mb is enabled", z.Str("dir", dir))
+	}
+
+	records, err := archive.Query(dir, conf.Slot, conf.Duty)
+	if err != nil {
+		return err
+	} else if len(records) == 0 {
+		return errors.New("no archived records found", z.I64("slot", conf.Slot), z.Str("duty", conf.Duty))
+	}
+
+	b, err := json.MarshalIndent(records, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal records")
+	}
+
+	if _, err := fmt.Fprintln(out, string(b)); err != nil {
+		return errors.Wrap(err, "write output")
+	}
+
+	if conf.QBFTOutput == "" {
+		return nil
+	}
+
+	return writeQBFTOutput(conf.QBFTOutput, records)
+}
+
+// writeQBFTOutput writes the sniffed consensus instances of the records to the file in
+// the same gzipped format as served by the monitoring API /debug/qbft endpoint.
+func writeQBFTOutput(file string, records []archive.Record) error {
+	instances := new(pbv1.SniffedConsensusInstances)
+	for _, record := range records {
+		if record.Kind != archive.KindQBFTInstance {
+			continue
+		}
+
+		instance := new(pbv1.SniffedConsensusInstance)
+		if err := protojson.Unmarshal(record.Data, instance); err != nil {
+			return errors.Wrap(err, "unmarshal sniffed instance", z.Str("duty", record.Duty()))
+		}
+
+		instances.Instances = append(instances.Instances, instance)
+	}
+
+	b, err := proto.Marshal(instances)
+	if err != nil {
+		return errors.Wrap(err, "marshal sniffed instances")
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return errors.Wrap(err, "zip sniffed instances")
+	}
+
+	if err := zw.Close(); err != nil {
+		return errors.Wrap(err, "close gzip writer")
+	}
+
+	if err := os.WriteFile(file, buf.Bytes(), 0o644); err != nil {
+		return errors.Wrap(err, "write qbft output", z.Str("file", file))
+	}
+
+	return nil
+}
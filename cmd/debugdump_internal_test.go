@@ -0,0 +1,96 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/obolnetwork/charon/app/archive"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+	"github.com/obolnetwork/charon/core/tracker"
+)
+
+func TestDebugDump(t *testing.T) {
+	dataDir := t.TempDir()
+	duty := core.NewAttesterDuty(123)
+
+	arch, err := archive.New(filepath.Join(dataDir, archive.DirName), 1<<20)
+	require.NoError(t, err)
+
+	start := time.Unix(1700000000, 0)
+	instance := &pbv1.SniffedConsensusInstance{
+		StartedAt: timestamppb.New(start),
+		Nodes:     4,
+	}
+	for i := int64(0); i < 4; i++ {
+		instance.Msgs = append(instance.Msgs, &pbv1.SniffedConsensusMsg{
+			Timestamp: timestamppb.New(start.Add(time.Duration(i) * time.Millisecond)),
+			Msg: &pbv1.ConsensusMsg{
+				Msg: &pbv1.QBFTMsg{
+					Type:    int64(qbft.MsgPrepare),
+					Duty:    core.DutyToProto(duty),
+					PeerIdx: i,
+					Round:   1,
+				},
+			},
+		})
+	}
+
+	b, err := protojson.Marshal(instance)
+	require.NoError(t, err)
+	require.NoError(t, arch.AddRaw(duty, archive.KindQBFTInstance, b))
+	require.NoError(t, arch.Add(duty, archive.KindTrackerEvents, tracker.ArchivedDuty{
+		Events: []tracker.ArchivedEvent{{Step: "fetcher", PubKey: "0x1234"}},
+	}))
+	require.NoError(t, arch.Add(core.NewProposerDuty(123), archive.KindTrackerEvents, tracker.ArchivedDuty{}))
+	require.NoError(t, arch.Add(core.NewAttesterDuty(124), archive.KindTrackerEvents, tracker.ArchivedDuty{}))
+
+	t.Run("slot and duty", func(t *testing.T) {
+		qbftOutput := filepath.Join(t.TempDir(), "qbft_messages.pb.gz")
+
+		var out bytes.Buffer
+		err := runDebugDump(&out, debugDumpConfig{DataDir: dataDir, Slot: 123, Duty: "attester", QBFTOutput: qbftOutput})
+		require.NoError(t, err)
+
+		var records []archive.Record
+		require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+		require.Len(t, records, 2)
+		require.Equal(t, archive.KindQBFTInstance, records[0].Kind)
+		require.Equal(t, archive.KindTrackerEvents, records[1].Kind)
+
+		// The qbft output can be replayed.
+		out.Reset()
+		err = runQBFTReplay(context.Background(), &out, qbftReplayConfig{File: qbftOutput, RoundTimer: "inc"})
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "duty=123/attester")
+		require.Contains(t, out.String(), "Replayed 1 of 1 instances")
+	})
+
+	t.Run("slot", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, runDebugDump(&out, debugDumpConfig{DataDir: dataDir, Slot: 123}))
+
+		var records []archive.Record
+		require.NoError(t, json.Unmarshal(out.Bytes(), &records))
+		require.Len(t, records, 3)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := runDebugDump(new(bytes.Buffer), debugDumpConfig{DataDir: dataDir, Slot: 1})
+		require.ErrorContains(t, err, "no archived records found")
+
+		err = runDebugDump(new(bytes.Buffer), debugDumpConfig{DataDir: t.TempDir(), Slot: 123})
+		require.ErrorContains(t, err, "archive not found")
+	})
+}
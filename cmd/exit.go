@@ -0,0 +1,446 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+// exitConfig is the config for the `exit` commands.
+type exitConfig struct {
+	LockFile          string
+	ManifestFile      string
+	ValidatorKeysDir  string
+	ValidatorPubkey   string
+	ExitEpoch         uint64
+	BeaconNodeAddrs   []string
+	BeaconNodeTimeout time.Duration
+	PartialExitFile   string
+	PartialExitFiles  []string
+	ExitFile          string
+	Log               log.Config
+}
+
+// partialExit is a voluntary exit of a distributed validator signed by a single key share.
+type partialExit struct {
+	PublicKey  string                      `json:"public_key"`
+	ShareIdx   int                         `json:"share_idx"`
+	SignedExit *eth2p0.SignedVoluntaryExit `json:"signed_exit"`
+}
+
+func newExitCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "exit",
+		Short: "Exit a distributed validator",
+		Long:  "Exit a distributed validator without a validator client: sign a partial voluntary exit with each node's key share, aggregate a threshold of partial exits and broadcast the resulting voluntary exit to the beacon node.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newExitSignCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
+	var config exitConfig
+
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign a partial voluntary exit",
+		Long:  "Signs a partial voluntary exit of a distributed validator with this node's key share and writes it to a partial exit file. Share the file with the operator aggregating the partial exits.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindExitClusterFlags(cmd, &config)
+	bindExitBeaconFlags(cmd, &config)
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "The directory containing this node's validator key shares.")
+	cmd.Flags().StringVar(&config.ValidatorPubkey, "validator-public-key", "", "The hex encoded public key of the distributed validator to exit.")
+	cmd.Flags().Uint64Var(&config.ExitEpoch, "exit-epoch", 0, "The epoch from which the voluntary exit is valid. All operators must sign the same exit epoch.")
+	cmd.Flags().StringVar(&config.PartialExitFile, "partial-exit-file", "partial-exit.json", "The path to write the partial exit file to.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	mustMarkFlagRequired(cmd, "validator-public-key")
+	mustMarkFlagRequired(cmd, "exit-epoch")
+
+	return cmd
+}
+
+func newExitAggregateCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
+	var config exitConfig
+
+	cmd := &cobra.Command{
+		Use:   "aggregate",
+		Short: "Aggregate partial voluntary exits",
+		Long:  "Verifies and aggregates the partial exit files of at least a threshold of nodes into a signed voluntary exit of the distributed validator.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindExitClusterFlags(cmd, &config)
+	bindExitBeaconFlags(cmd, &config)
+	cmd.Flags().StringSliceVar(&config.PartialExitFiles, "partial-exit-files", nil, "Comma separated list of partial exit files to aggregate.")
+	cmd.Flags().StringVar(&config.ExitFile, "exit-file", "signed-exit.json", "The path to write the aggregated signed voluntary exit to.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	mustMarkFlagRequired(cmd, "partial-exit-files")
+
+	return cmd
+}
+
+func newExitBroadcastCmd(runFunc func(context.Context, exitConfig) error) *cobra.Command {
+	var config exitConfig
+
+	cmd := &cobra.Command{
+		Use:   "broadcast",
+		Short: "Broadcast a signed voluntary exit",
+		Long:  "Submits an aggregated signed voluntary exit of a distributed validator to the beacon node.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindExitBeaconFlags(cmd, &config)
+	cmd.Flags().StringVar(&config.ExitFile, "exit-file", "signed-exit.json", "The path to the aggregated signed voluntary exit.")
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func bindExitClusterFlags(cmd *cobra.Command, config *exitConfig) {
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+}
+
+func bindExitBeaconFlags(cmd *cobra.Command, config *exitConfig) {
+	cmd.Flags().StringSliceVar(&config.BeaconNodeAddrs, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs.")
+	cmd.Flags().DurationVar(&config.BeaconNodeTimeout, "beacon-node-timeout", 10*time.Second, "Timeout for beacon node HTTP requests.")
+
+	mustMarkFlagRequired(cmd, "beacon-node-endpoints")
+}
+
+func runExitSign(ctx context.Context, conf exitConfig) error {
+	eth2Cl, err := eth2wrap.NewMultiHTTP(conf.BeaconNodeTimeout, conf.BeaconNodeAddrs...)
+	if err != nil {
+		return err
+	}
+
+	return signPartialExit(ctx, conf, eth2Cl)
+}
+
+func runExitAggregate(ctx context.Context, conf exitConfig) error {
+	eth2Cl, err := eth2wrap.NewMultiHTTP(conf.BeaconNodeTimeout, conf.BeaconNodeAddrs...)
+	if err != nil {
+		return err
+	}
+
+	return aggregatePartialExits(ctx, conf, eth2Cl)
+}
+
+func runExitBroadcast(ctx context.Context, conf exitConfig) error {
+	eth2Cl, err := eth2wrap.NewMultiHTTP(conf.BeaconNodeTimeout, conf.BeaconNodeAddrs...)
+	if err != nil {
+		return err
+	}
+
+	return broadcastExit(ctx, conf, eth2Cl)
+}
+
+// signPartialExit signs a voluntary exit of the configured validator with this node's key share and writes it to the partial exit file.
+func signPartialExit(ctx context.Context, conf exitConfig, eth2Cl eth2wrap.Client) error {
+	cluster, err := loadClusterManifest(conf.ManifestFile, conf.LockFile)
+	if err != nil {
+		return err
+	}
+
+	val, pubkey, err := exitValidator(cluster, conf.ValidatorPubkey)
+	if err != nil {
+		return err
+	}
+
+	keyFiles, err := keystore.LoadFilesUnordered(conf.ValidatorKeysDir)
+	if err != nil {
+		return err
+	}
+
+	secret, shareIdx, err := validatorKeyShare(val, keyFiles.Keys())
+	if err != nil {
+		return err
+	}
+
+	epoch := eth2p0.Epoch(conf.ExitEpoch)
+
+	valIdx, err := validatorIndex(ctx, eth2Cl, pubkey)
+	if err != nil {
+		return err
+	}
+
+	exit := &eth2p0.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: valIdx,
+	}
+
+	msgRoot, err := exit.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "hash voluntary exit")
+	}
+
+	sigData, err := signing.GetDataRoot(ctx, eth2Cl, signing.DomainExit, epoch, msgRoot)
+	if err != nil {
+		return err
+	}
+
+	sig, err := tbls.Sign(secret, sigData[:])
+	if err != nil {
+		return err
+	}
+
+	err = writeExitFile(conf.PartialExitFile, partialExit{
+		PublicKey: conf.ValidatorPubkey,
+		ShareIdx:  shareIdx,
+		SignedExit: &eth2p0.SignedVoluntaryExit{
+			Message:   exit,
+			Signature: tblsconv.SigToETH2(sig),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Signed partial voluntary exit",
+		z.Str("pubkey", conf.ValidatorPubkey),
+		z.U64("validator_index", uint64(valIdx)),
+		z.U64("epoch", uint64(epoch)),
+		z.Int("share_idx", shareIdx),
+		z.Str("partial_exit_file", conf.PartialExitFile),
+	)
+
+	return nil
+}
+
+// aggregatePartialExits verifies and aggregates the partial exit files and writes the resulting signed voluntary exit to the exit file.
+func aggregatePartialExits(ctx context.Context, conf exitConfig, eth2Cl eth2wrap.Client) error {
+	cluster, err := loadClusterManifest(conf.ManifestFile, conf.LockFile)
+	if err != nil {
+		return err
+	}
+
+	var (
+		val      *manifestpb.Validator
+		pubkey   eth2p0.BLSPubKey
+		exit     *eth2p0.VoluntaryExit
+		partials = make(map[int]tbls.Signature)
+	)
+	for _, file := range conf.PartialExitFiles {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return errors.Wrap(err, "read partial exit file", z.Str("path", file))
+		}
+
+		var partial partialExit
+		if err := json.Unmarshal(b, &partial); err != nil {
+			return errors.Wrap(err, "unmarshal partial exit file", z.Str("path", file))
+		} else if partial.SignedExit == nil || partial.SignedExit.Message == nil {
+			return errors.New("missing signed exit", z.Str("path", file))
+		}
+
+		partialVal, partialPubkey, err := exitValidator(cluster, partial.PublicKey)
+		if err != nil {
+			return err
+		}
+
+		if val == nil {
+			val, pubkey, exit = partialVal, partialPubkey, partial.SignedExit.Message
+		} else if partialPubkey != pubkey {
+			return errors.New("mismatching partial exit validator public key", z.Str("path", file))
+		} else if *partial.SignedExit.Message != *exit {
+			return errors.New("mismatching partial exit message", z.Str("path", file))
+		}
+
+		if partial.ShareIdx <= 0 || partial.ShareIdx > len(val.PubShares) {
+			return errors.New("invalid partial exit share index", z.Str("path", file), z.Int("share_idx", partial.ShareIdx))
+		} else if _, ok := partials[partial.ShareIdx]; ok {
+			return errors.New("duplicate partial exit share index", z.Str("path", file), z.Int("share_idx", partial.ShareIdx))
+		}
+
+		pubshare, err := tblsconv.PubkeyFromBytes(val.PubShares[partial.ShareIdx-1])
+		if err != nil {
+			return err
+		}
+
+		err = core.VerifyEth2SignedData(ctx, eth2Cl, core.NewSignedVoluntaryExit(partial.SignedExit), pubshare)
+		if err != nil {
+			return errors.Wrap(err, "invalid partial exit signature", z.Str("path", file), z.Int("share_idx", partial.ShareIdx))
+		}
+
+		partials[partial.ShareIdx] = tbls.Signature(partial.SignedExit.Signature)
+	}
+
+	if len(partials) < int(cluster.Threshold) {
+		return errors.New("insufficient partial exits", z.Int("partial_exits", len(partials)), z.Int("threshold", int(cluster.Threshold)))
+	}
+
+	sig, err := tbls.ThresholdAggregate(partials)
+	if err != nil {
+		return err
+	}
+
+	signedExit := &eth2p0.SignedVoluntaryExit{
+		Message:   exit,
+		Signature: tblsconv.SigToETH2(sig),
+	}
+
+	err = core.VerifyEth2SignedData(ctx, eth2Cl, core.NewSignedVoluntaryExit(signedExit), tbls.PublicKey(pubkey))
+	if err != nil {
+		return errors.Wrap(err, "invalid aggregated exit signature")
+	}
+
+	if err := writeExitFile(conf.ExitFile, signedExit); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Aggregated partial voluntary exits",
+		z.Str("pubkey", hexPubkey(pubkey)),
+		z.U64("validator_index", uint64(exit.ValidatorIndex)),
+		z.U64("epoch", uint64(exit.Epoch)),
+		z.Int("partial_exits", len(partials)),
+		z.Str("exit_file", conf.ExitFile),
+	)
+
+	return nil
+}
+
+// broadcastExit submits the signed voluntary exit in the exit file to the beacon node.
+func broadcastExit(ctx context.Context, conf exitConfig, eth2Cl eth2wrap.Client) error {
+	b, err := os.ReadFile(conf.ExitFile)
+	if err != nil {
+		return errors.Wrap(err, "read exit file", z.Str("path", conf.ExitFile))
+	}
+
+	signedExit := new(eth2p0.SignedVoluntaryExit)
+	if err := json.Unmarshal(b, signedExit); err != nil {
+		return errors.Wrap(err, "unmarshal exit file", z.Str("path", conf.ExitFile))
+	}
+
+	if err := eth2Cl.SubmitVoluntaryExit(ctx, signedExit); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Broadcast signed voluntary exit",
+		z.U64("validator_index", uint64(signedExit.Message.ValidatorIndex)),
+		z.U64("epoch", uint64(signedExit.Message.Epoch)),
+	)
+
+	return nil
+}
+
+// exitValidator returns the cluster validator and its public key matching the provided hex encoded public key.
+func exitValidator(cluster *manifestpb.Cluster, hexPubkey string) (*manifestpb.Validator, eth2p0.BLSPubKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(hexPubkey, "0x"))
+	if err != nil {
+		return nil, eth2p0.BLSPubKey{}, errors.Wrap(err, "decode validator public key")
+	}
+
+	for _, val := range cluster.Validators {
+		pubkey, err := manifest.ValidatorPublicKey(val)
+		if err != nil {
+			return nil, eth2p0.BLSPubKey{}, err
+		}
+
+		if bytes.Equal(pubkey[:], b) {
+			return val, eth2p0.BLSPubKey(pubkey), nil
+		}
+	}
+
+	return nil, eth2p0.BLSPubKey{}, errors.New("validator not in cluster", z.Str("pubkey", hexPubkey))
+}
+
+// validatorKeyShare returns the key share of the validator and its share index from the provided secrets.
+func validatorKeyShare(val *manifestpb.Validator, secrets []tbls.PrivateKey) (tbls.PrivateKey, int, error) {
+	for _, secret := range secrets {
+		pubshare, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			return tbls.PrivateKey{}, 0, err
+		}
+
+		for i, b := range val.PubShares {
+			if bytes.Equal(pubshare[:], b) {
+				return secret, i + 1, nil // Share index is 1-indexed
+			}
+		}
+	}
+
+	return tbls.PrivateKey{}, 0, errors.New("validator key share not found")
+}
+
+// validatorIndex returns the beacon chain validator index of the provided public key.
+func validatorIndex(ctx context.Context, eth2Cl eth2wrap.Client, pubkey eth2p0.BLSPubKey) (eth2p0.ValidatorIndex, error) {
+	vals, err := eth2Cl.ValidatorsByPubKey(ctx, "head", []eth2p0.BLSPubKey{pubkey})
+	if err != nil {
+		return 0, err
+	}
+
+	for idx, val := range vals {
+		if val != nil && val.Validator != nil && val.Validator.PublicKey == pubkey {
+			return idx, nil
+		}
+	}
+
+	return 0, errors.New("validator not found on beacon chain", z.Str("pubkey", hexPubkey(pubkey)))
+}
+
+// writeExitFile writes the json encoded exit to the provided file.
+func writeExitFile(path string, exit any) error {
+	b, err := json.MarshalIndent(exit, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal exit")
+	}
+
+	//nolint:gosec // Exit files are not secret.
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return errors.Wrap(err, "write exit file", z.Str("path", path))
+	}
+
+	return nil
+}
+
+func hexPubkey(pubkey eth2p0.BLSPubKey) string {
+	return "0x" + hex.EncodeToString(pubkey[:])
+}
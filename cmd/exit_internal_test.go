@@ -0,0 +1,99 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util/keystore"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestExit(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	const (
+		valIdx    = 42
+		exitEpoch = 10
+	)
+
+	lock, _, shares := cluster.NewForT(t, 2, 3, 4, 0)
+	lockJSON, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, lockJSON, 0o444))
+
+	pubkey := eth2p0.BLSPubKey(lock.Validators[1].PubKey)
+
+	bmock, err := beaconmock.New(beaconmock.WithValidatorSet(beaconmock.ValidatorSet{
+		valIdx: {
+			Index:     valIdx,
+			Status:    eth2v1.ValidatorStateActiveOngoing,
+			Validator: &eth2p0.Validator{PublicKey: pubkey},
+		},
+	}))
+	require.NoError(t, err)
+
+	var submitted *eth2p0.SignedVoluntaryExit
+	bmock.SubmitVoluntaryExitFunc = func(_ context.Context, exit *eth2p0.SignedVoluntaryExit) error {
+		submitted = exit
+		return nil
+	}
+
+	eth2Cl, err := eth2wrap.Instrument(bmock)
+	require.NoError(t, err)
+
+	// Sign partial exits with the key shares of a threshold of nodes.
+	var partialFiles []string
+	for i := 0; i < lock.Threshold; i++ {
+		keysDir := filepath.Join(dir, fmt.Sprintf("node%d", i), "validator_keys")
+		require.NoError(t, os.MkdirAll(keysDir, 0o755))
+		require.NoError(t, keystore.StoreKeysInsecure([]tbls.PrivateKey{shares[0][i], shares[1][i]}, keysDir, keystore.ConfirmInsecureKeys))
+
+		conf := exitConfig{
+			LockFile:         lockFile,
+			ValidatorKeysDir: keysDir,
+			ValidatorPubkey:  hexPubkey(pubkey),
+			ExitEpoch:        exitEpoch,
+			PartialExitFile:  filepath.Join(dir, fmt.Sprintf("partial-exit-%d.json", i)),
+		}
+		require.NoError(t, signPartialExit(ctx, conf, eth2Cl))
+
+		partialFiles = append(partialFiles, conf.PartialExitFile)
+	}
+
+	conf := exitConfig{
+		LockFile: lockFile,
+		ExitFile: filepath.Join(dir, "signed-exit.json"),
+	}
+
+	// Insufficient partial exits are refused.
+	conf.PartialExitFiles = partialFiles[:lock.Threshold-1]
+	require.ErrorContains(t, aggregatePartialExits(ctx, conf, eth2Cl), "insufficient partial exits")
+
+	// Duplicate partial exits are refused.
+	conf.PartialExitFiles = append([]string{partialFiles[0]}, partialFiles...)
+	require.ErrorContains(t, aggregatePartialExits(ctx, conf, eth2Cl), "duplicate partial exit share index")
+
+	conf.PartialExitFiles = partialFiles
+	require.NoError(t, aggregatePartialExits(ctx, conf, eth2Cl))
+	require.NoError(t, broadcastExit(ctx, conf, eth2Cl))
+
+	require.NotNil(t, submitted)
+	require.EqualValues(t, valIdx, submitted.Message.ValidatorIndex)
+	require.EqualValues(t, exitEpoch, submitted.Message.Epoch)
+}
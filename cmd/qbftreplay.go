@@ -0,0 +1,192 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/consensus"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+// qbftReplayConfig is the config for the `debug qbft-replay` command.
+type qbftReplayConfig struct {
+	File       string
+	RoundTimer string
+	Duty       string
+}
+
+func newQBFTReplayCmd(runFunc func(context.Context, io.Writer, qbftReplayConfig) error) *cobra.Command {
+	var config qbftReplayConfig
+
+	cmd := &cobra.Command{
+		Use:   "qbft-replay",
+		Short: "Replay sniffed QBFT consensus instances",
+		Long: `Replays the sniffed QBFT consensus instances downloaded from the monitoring API /debug/qbft endpoint. ` +
+			`Each instance is deterministically re-run from the perspective of the sniffing peer, printing the round-by-round upon rule traces, ` +
+			`the decided value and any mismatch with the decision contained in the recorded messages.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFunc(cmd.Context(), cmd.OutOrStdout(), config)
+		},
+	}
+
+	cmd.Flags().StringVar(&config.File, "file", "qbft_messages.pb.gz", "The path to the gzipped sniffed consensus instances file downloaded from the monitoring API /debug/qbft endpoint.")
+	cmd.Flags().StringVar(&config.RoundTimer, "round-timer", consensus.ReplayTimerTypes()[0], fmt.Sprintf("The round timer used when replaying instances. One of: %s.", strings.Join(consensus.ReplayTimerTypes(), ", ")))
+	cmd.Flags().StringVar(&config.Duty, "duty", "", "Only replay instances of the duty, e.g. 'attester' or '123/attester' to also filter by slot.")
+
+	return cmd
+}
+
+func runQBFTReplay(ctx context.Context, out io.Writer, conf qbftReplayConfig) error {
+	var validTimer bool
+	for _, timer := range consensus.ReplayTimerTypes() {
+		validTimer = validTimer || timer == conf.RoundTimer
+	}
+	if !validTimer {
+		return errors.New("unsupported round timer", z.Str("round_timer", conf.RoundTimer))
+	}
+
+	instances, err := loadSniffedInstances(conf.File)
+	if err != nil {
+		return err
+	}
+
+	var (
+		replayed   int
+		mismatches int
+	)
+	for i, instance := range instances.Instances {
+		if len(instance.Msgs) == 0 || instance.Msgs[0].GetMsg().GetMsg() == nil {
+			continue
+		}
+
+		duty := core.DutyFromProto(instance.Msgs[0].Msg.Msg.Duty)
+		if !matchDuty(conf.Duty, duty) {
+			continue
+		}
+
+		res, err := consensus.Replay(ctx, instance, conf.RoundTimer)
+		if err != nil {
+			return errors.Wrap(err, "replay instance", z.Int("instance", i), z.Any("duty", duty))
+		}
+
+		if err := writeReplayResult(out, i, res); err != nil {
+			return err
+		}
+
+		replayed++
+		if res.Mismatch() {
+			mismatches++
+		}
+	}
+
+	_, err = fmt.Fprintf(out, "Replayed %d of %d instances (git_hash=%s, round_timer=%s): %d mismatches\n",
+		replayed, len(instances.Instances), instances.GitHash, conf.RoundTimer, mismatches)
+	if err != nil {
+		return errors.Wrap(err, "write output")
+	}
+
+	return nil
+}
+
+// loadSniffedInstances returns the sniffed consensus instances of the gzipped proto file.
+func loadSniffedInstances(file string) (*pbv1.SniffedConsensusInstances, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "read file", z.Str("file", file))
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, errors.Wrap(err, "gzip reader")
+	}
+
+	b, err = io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "gunzip file")
+	}
+
+	resp := new(pbv1.SniffedConsensusInstances)
+	if err := proto.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal sniffed instances")
+	}
+
+	return resp, nil
+}
+
+// matchDuty returns true if the filter is empty or matches the duty type or slot/type.
+func matchDuty(filter string, duty core.Duty) bool {
+	if filter == "" {
+		return true
+	}
+
+	if strings.Contains(filter, "/") {
+		return filter == duty.String()
+	}
+
+	return filter == duty.Type.String()
+}
+
+// writeReplayResult writes a human-readable replay result to out.
+func writeReplayResult(out io.Writer, i int, res consensus.ReplayResult) error {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "=== Instance %d: duty=%s peer=%d nodes=%d msgs=%d\n", i, res.Duty, res.PeerIdx, res.Nodes, res.Msgs)
+
+	for _, step := range res.Steps {
+		fmt.Fprintf(&buf, "  %8s round=%d rule=%s", step.Elapsed, step.Round, step.Rule)
+		if step.MsgType != qbft.MsgUnknown {
+			fmt.Fprintf(&buf, " msg=%s source=%d msg_round=%d", step.MsgType, step.MsgSource, step.MsgRound)
+		}
+		if step.NewRound != 0 {
+			fmt.Fprintf(&buf, " new_round=%d", step.NewRound)
+		}
+		if step.Detail != "" {
+			fmt.Fprintf(&buf, " %s", step.Detail)
+		}
+		fmt.Fprintln(&buf)
+	}
+
+	if res.Decided {
+		fmt.Fprintf(&buf, "  Replayed: decided round=%d hash=%#x\n", res.DecidedRound, res.DecidedHash)
+		if res.DecidedValue != nil {
+			value, err := protoToJSON(res.DecidedValue)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&buf, "  Value: %s\n", value)
+		}
+	} else {
+		fmt.Fprintln(&buf, "  Replayed: undecided")
+	}
+
+	if res.RecordedDecided {
+		fmt.Fprintf(&buf, "  Recorded: decided round=%d hash=%#x\n", res.RecordedRound, res.RecordedHash)
+	} else {
+		fmt.Fprintln(&buf, "  Recorded: undecided")
+	}
+
+	if res.Mismatch() {
+		fmt.Fprintln(&buf, "  MISMATCH: replayed decision differs from recorded decision")
+	}
+
+	if _, err := out.Write(buf.Bytes()); err != nil {
+		return errors.Wrap(err, "write output")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -run=TestQBFTReplay -update
+
+func TestQBFTReplay(t *testing.T) {
+	const nodes = 4
+
+	start := time.Unix(1700000000, 0)
+	valueHash := bytes.Repeat([]byte{0x12}, 32) // Hash-only messages of a non-zero value.
+	newInstance := func(duty core.Duty, msgTypes ...qbft.MsgType) *pbv1.SniffedConsensusInstance {
+		instance := &pbv1.SniffedConsensusInstance{
+			StartedAt: timestamppb.New(start),
+			Nodes:     nodes,
+		}
+
+		var n int
+		for _, typ := range msgTypes {
+			for i := int64(0); i < nodes; i++ {
+				n++
+				instance.Msgs = append(instance.Msgs, &pbv1.SniffedConsensusMsg{
+					Timestamp: timestamppb.New(start.Add(time.Duration(n) * time.Millisecond * 10)),
+					Msg: &pbv1.ConsensusMsg{
+						Msg: &pbv1.QBFTMsg{
+							Type:      int64(typ),
+							Duty:      core.DutyToProto(duty),
+							PeerIdx:   i,
+							Round:     1,
+							ValueHash: valueHash,
+						},
+					},
+				})
+			}
+		}
+
+		return instance
+	}
+
+	b, err := proto.Marshal(&pbv1.SniffedConsensusInstances{
+		Instances: []*pbv1.SniffedConsensusInstance{
+			newInstance(core.NewAttesterDuty(1), qbft.MsgPrepare, qbft.MsgCommit),
+			newInstance(core.NewProposerDuty(2), qbft.MsgPrepare),
+		},
+		GitHash: "abcdef",
+	})
+	require.NoError(t, err)
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, err = zw.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	file := filepath.Join(t.TempDir(), "qbft_messages.pb.gz")
+	require.NoError(t, os.WriteFile(file, gz.Bytes(), 0o644))
+
+	t.Run("all", func(t *testing.T) {
+		var out bytes.Buffer
+		err := runQBFTReplay(context.Background(), &out, qbftReplayConfig{File: file, RoundTimer: "inc"})
+		require.NoError(t, err)
+		testutil.RequireGoldenBytes(t, out.Bytes())
+	})
+
+	t.Run("duty filter", func(t *testing.T) {
+		var out bytes.Buffer
+		err := runQBFTReplay(context.Background(), &out, qbftReplayConfig{File: file, RoundTimer: "inc", Duty: "proposer"})
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "Replayed 1 of 2 instances")
+		require.NotContains(t, out.String(), "attester")
+	})
+
+	t.Run("unsupported timer", func(t *testing.T) {
+		err := runQBFTReplay(context.Background(), new(bytes.Buffer), qbftReplayConfig{File: file, RoundTimer: "foo"})
+		require.ErrorContains(t, err, "unsupported round timer")
+	})
+}
@@ -5,6 +5,7 @@ package cmd
 import (
 	"context"
 	"net/url"
+	"strings"
 	"time"
 
 	libp2plog "github.com/ipfs/go-log/v2"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/obolnetwork/charon/app"
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
 	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
@@ -69,7 +71,15 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
 	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
 	cmd.Flags().StringSliceVar(&config.BeaconNodeAddrs, "beacon-node-endpoints", nil, "Comma separated list of one or more beacon node endpoint URLs.")
+	cmd.Flags().StringSliceVar(&config.BeaconNodeCrossCheck, "beacon-node-cross-check", nil, "Comma separated list of endpoint groups for which the responses of all beacon nodes are compared, recording divergence metrics and logging diffs. Supported groups: "+strings.Join(eth2wrap.CrossCheckGroups(), ", ")+".")
 	cmd.Flags().StringVar(&config.ValidatorAPIAddr, "validator-api-address", "127.0.0.1:3600", "Listening address (ip and port) for validator-facing traffic proxying the beacon-node API.")
+	cmd.Flags().StringVar(&config.ValidatorAPITLS.CertFile, "validator-api-tls-cert-file", "", "The path to the TLS certificate of the validator API. Enables TLS if provided.")
+	cmd.Flags().StringVar(&config.ValidatorAPITLS.KeyFile, "validator-api-tls-key-file", "", "The path to the TLS certificate private key of the validator API.")
+	cmd.Flags().StringVar(&config.ValidatorAPITLS.ClientCAFile, "validator-api-tls-client-ca-file", "", "The path to the CA certificates used to verify validator client TLS certificates. Enables mutual TLS if provided.")
+	cmd.Flags().StringVar(&config.ValidatorAPITokensFile, "validator-api-tokens-file", "", "The path to a JSON file listing validator API bearer tokens and the distributed validator public keys each token is authorised for: [{\"token\":\"...\",\"pubkeys\":[\"0x...\"]}]. Enables bearer token authentication if provided.")
+	cmd.Flags().StringVar(&config.Signer, "signer", app.SignerValidatorClient, "Configures how duties are signed: 'validator-client' expects a validator client connected to the validator API, 'embedded' signs duties with the validator key shares in validator-keys-dir without a validator client, 'web3signer' signs duties with the validator key shares held by the Web3Signer at web3signer-address without a validator client. Do not connect a validator client when using 'embedded' or 'web3signer'.")
+	cmd.Flags().StringVar(&config.ValidatorKeysDir, "validator-keys-dir", ".charon/validator_keys", "The directory containing the validator key shares used by the embedded signer.")
+	cmd.Flags().StringVar(&config.Web3SignerAddr, "web3signer-address", "", "The base URL of the Web3Signer holding the validator key shares used by the web3signer signer.")
 	cmd.Flags().StringVar(&config.MonitoringAddr, "monitoring-address", "127.0.0.1:3620", "Listening address (ip and port) for the monitoring API (prometheus, pprof).")
 	cmd.Flags().StringVar(&config.JaegerAddr, "jaeger-address", "", "Listening address for jaeger tracing.")
 	cmd.Flags().StringVar(&config.JaegerService, "jaeger-service", "charon", "Service name used for jaeger tracing.")
@@ -77,15 +87,48 @@ func bindRunFlags(cmd *cobra.Command, config *app.Config) {
 	cmd.Flags().BoolVar(&config.SimnetVMock, "simnet-validator-mock", false, "Enables an internal mock validator client when running a simnet. Requires simnet-beacon-mock.")
 	cmd.Flags().StringVar(&config.SimnetValidatorKeysDir, "simnet-validator-keys-dir", ".charon/validator_keys", "The directory containing the simnet validator key shares.")
 	cmd.Flags().BoolVar(&config.BuilderAPI, "builder-api", false, "Enables the builder api. Will only produce builder blocks. Builder API must also be enabled on the validator client. Beacon node must be connected to a builder-relay to access the builder network.")
+	cmd.Flags().Uint64Var(&config.BuilderMinBidGwei, "builder-min-bid-gwei", 0, "Minimum builder bid value in gwei. Local blocks are proposed if the most valuable builder bid reported by the beacon nodes is lower. Zero disables the minimum.")
 	cmd.Flags().BoolVar(&config.SyntheticBlockProposals, "synthetic-block-proposals", false, "Enables additional synthetic block proposal duties. Used for testing of rare duties.")
 	cmd.Flags().DurationVar(&config.SimnetSlotDuration, "simnet-slot-duration", time.Second, "Configures slot duration in simnet beacon mock.")
 	cmd.Flags().BoolVar(&config.SimnetBMockFuzz, "simnet-beacon-mock-fuzz", false, "Configures simnet beaconmock to return fuzzed responses.")
+	cmd.Flags().IntVar(&config.DoppelgangerEpochs, "doppelganger-epochs", 0, "Enables doppelganger detection by delaying duties for this number of epochs after startup while checking that none of the cluster's validators are live, refusing to start if any are. All peers should be (re)started together. Zero disables doppelganger detection.")
+	cmd.Flags().StringVar(&config.DataDir, "data-dir", "", "The directory where charon persists its internal state, e.g., the duty database slashing records allowing safe restarts and partial signature equivocation evidence. Empty disables persistence.")
+	cmd.Flags().IntVar(&config.ArchiveSizeMB, "archive-size-mb", 0, "Enables a rolling archive of sniffed consensus instances and tracker events in the data directory, capped at this size in megabytes. Extract a duty's history with 'charon debug dump'. Requires data-dir. Zero disables the archive.")
+	cmd.Flags().BoolVar(&config.WAL, "wal", false, "Enables a write-ahead store of in-flight duties in the data directory, so a restarted node rebroadcasts its own partial signatures, serves aggregate randao reveals and selection proofs and resumes recasting builder registrations. Requires data-dir.")
 
 	wrapPreRunE(cmd, func(cmd *cobra.Command, args []string) error {
 		if len(config.BeaconNodeAddrs) == 0 && !config.SimnetBMock {
 			return errors.New("either flag 'beacon-node-endpoints' or flag 'simnet-beacon-mock=true' must be specified")
 		}
 
+		switch config.Signer {
+		case app.SignerValidatorClient:
+		case app.SignerEmbedded, app.SignerWeb3Signer:
+			if config.SimnetVMock {
+				return errors.New("flag 'simnet-validator-mock' cannot be used with flag 'signer'", z.Str("signer", config.Signer))
+			}
+		default:
+			return errors.New("invalid signer", z.Str("signer", config.Signer))
+		}
+
+		if config.Signer == app.SignerWeb3Signer && config.Web3SignerAddr == "" {
+			return errors.New("flag 'web3signer-address' must be specified with flag 'signer=web3signer'")
+		}
+
+		if config.DoppelgangerEpochs < 0 {
+			return errors.New("flag 'doppelganger-epochs' must not be negative")
+		}
+
+		if config.ArchiveSizeMB < 0 {
+			return errors.New("flag 'archive-size-mb' must not be negative")
+		} else if config.ArchiveSizeMB > 0 && config.DataDir == "" {
+			return errors.New("flag 'archive-size-mb' requires flag 'data-dir'")
+		}
+
+		if config.WAL && config.DataDir == "" {
+			return errors.New("flag 'wal' requires flag 'data-dir'")
+		}
+
 		return nil
 	})
 }
@@ -0,0 +1,231 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/spf13/cobra"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/cluster/manifest"
+	manifestpb "github.com/obolnetwork/charon/cluster/manifestpb/v1"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/eip3076"
+)
+
+// slashingConfig is the config for the `slashing` commands.
+type slashingConfig struct {
+	DataDir               string
+	LockFile              string
+	ManifestFile          string
+	InterchangeFile       string
+	GenesisValidatorsRoot string
+	Log                   log.Config
+}
+
+func newSlashingCmd(cmds ...*cobra.Command) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "slashing",
+		Short: "Import and export slashing protection history of distributed validators",
+		Long:  "Import and export the slashing protection history of the cluster's distributed validators in the EIP-3076 slashing protection interchange format. Charon must not be running while using these commands.",
+	}
+
+	root.AddCommand(cmds...)
+
+	return root
+}
+
+func newSlashingExportCmd(runFunc func(context.Context, slashingConfig) error) *cobra.Command {
+	var config slashingConfig
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export slashing protection history to an EIP-3076 interchange file",
+		Long:  "Exports the slashing protection history of the cluster's distributed validators persisted in the data directory to an EIP-3076 slashing protection interchange file.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindSlashingFlags(cmd, &config)
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func newSlashingImportCmd(runFunc func(context.Context, slashingConfig) error) *cobra.Command {
+	var config slashingConfig
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import slashing protection history from an EIP-3076 interchange file",
+		Long:  "Imports the slashing protection history of the cluster's distributed validators from an EIP-3076 slashing protection interchange file into the data directory. Charon refuses to agree on attestations or blocks that violate the imported history. History of validators not in the cluster is ignored. Note that the imported history is only consulted if charon run is started with the same --data-dir flag, since charon run doesn't persist slashing records by default.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := log.InitLogger(config.Log); err != nil {
+				return err
+			}
+
+			return runFunc(cmd.Context(), config)
+		},
+	}
+
+	bindSlashingFlags(cmd, &config)
+	bindLogFlags(cmd.Flags(), &config.Log)
+
+	return cmd
+}
+
+func bindSlashingFlags(cmd *cobra.Command, config *slashingConfig) {
+	cmd.Flags().StringVar(&config.DataDir, "data-dir", "", "The directory where charon persists its internal state. This must match the --data-dir flag of charon run.")
+	cmd.Flags().StringVar(&config.LockFile, "lock-file", ".charon/cluster-lock.json", "The path to the cluster lock file defining distributed validator cluster. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.ManifestFile, "manifest-file", ".charon/cluster-manifest.pb", "The path to the cluster manifest file. If both cluster manifest and cluster lock files are provided, the cluster manifest file takes precedence.")
+	cmd.Flags().StringVar(&config.InterchangeFile, "interchange-file", "slashing-protection.json", "The path to the EIP-3076 slashing protection interchange file.")
+	cmd.Flags().StringVar(&config.GenesisValidatorsRoot, "genesis-validators-root", "", "The hex encoded genesis validators root of the network. Defaults to the value of the cluster's network if known.")
+
+	mustMarkFlagRequired(cmd, "data-dir")
+}
+
+func runSlashingExport(ctx context.Context, conf slashingConfig) error {
+	cluster, err := loadClusterManifest(conf.ManifestFile, conf.LockFile)
+	if err != nil {
+		return err
+	}
+
+	gvr, err := slashingGenesisValidatorsRoot(conf, cluster)
+	if err != nil {
+		return err
+	}
+
+	pubkeys, err := clusterPubkeys(cluster)
+	if err != nil {
+		return err
+	}
+
+	interchange, err := dutydb.ExportInterchange(conf.DataDir, gvr, pubkeys)
+	if err != nil {
+		return err
+	}
+
+	if err := eip3076.Write(conf.InterchangeFile, interchange); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Exported slashing protection history",
+		z.Str("interchange_file", conf.InterchangeFile),
+		z.Int("validators", len(interchange.Data)),
+	)
+
+	return nil
+}
+
+func runSlashingImport(ctx context.Context, conf slashingConfig) error {
+	cluster, err := loadClusterManifest(conf.ManifestFile, conf.LockFile)
+	if err != nil {
+		return err
+	}
+
+	gvr, err := slashingGenesisValidatorsRoot(conf, cluster)
+	if err != nil {
+		return err
+	}
+
+	pubkeys, err := clusterPubkeys(cluster)
+	if err != nil {
+		return err
+	}
+
+	interchange, err := eip3076.Load(conf.InterchangeFile)
+	if err != nil {
+		return err
+	}
+
+	if interchange.Metadata.GenesisValidatorsRoot != gvr {
+		return errors.New("interchange genesis validators root doesn't match cluster network",
+			z.Str("interchange", interchange.Metadata.GenesisValidatorsRoot.String()),
+			z.Str("expected", gvr.String()),
+		)
+	}
+
+	known := make(map[core.PubKey]bool)
+	for _, pubkey := range pubkeys {
+		known[pubkey] = true
+	}
+
+	var filtered []eip3076.Validator
+	for _, val := range interchange.Data {
+		if !known[core.PubKeyFrom48Bytes(val.Pubkey)] {
+			log.Warn(ctx, "Ignoring slashing protection history of validator not in cluster", nil,
+				z.Str("pubkey", val.Pubkey.String()))
+
+			continue
+		}
+
+		filtered = append(filtered, val)
+	}
+	interchange.Data = filtered
+
+	if err := dutydb.ImportInterchange(conf.DataDir, interchange); err != nil {
+		return err
+	}
+
+	log.Info(ctx, "Imported slashing protection history",
+		z.Str("interchange_file", conf.InterchangeFile),
+		z.Int("validators", len(filtered)),
+	)
+
+	return nil
+}
+
+// slashingGenesisValidatorsRoot returns the genesis validators root from the config or from the cluster's network.
+func slashingGenesisValidatorsRoot(conf slashingConfig, cluster *manifestpb.Cluster) (eth2p0.Root, error) {
+	var (
+		b   []byte
+		err error
+	)
+	if conf.GenesisValidatorsRoot != "" {
+		b, err = hex.DecodeString(strings.TrimPrefix(conf.GenesisValidatorsRoot, "0x"))
+		if err != nil {
+			return eth2p0.Root{}, errors.Wrap(err, "decode genesis validators root")
+		}
+	} else {
+		b, err = eth2util.ForkVersionToGenesisValidatorsRoot(cluster.ForkVersion)
+		if err != nil {
+			return eth2p0.Root{}, errors.Wrap(err, "unknown cluster network, please provide --genesis-validators-root")
+		}
+	}
+
+	if len(b) != len(eth2p0.Root{}) {
+		return eth2p0.Root{}, errors.New("invalid genesis validators root length")
+	}
+
+	return eth2p0.Root(b), nil
+}
+
+// clusterPubkeys returns the distributed validator public keys of the cluster.
+func clusterPubkeys(cluster *manifestpb.Cluster) ([]core.PubKey, error) {
+	var pubkeys []core.PubKey
+	for _, val := range cluster.Validators {
+		pk, err := manifest.ValidatorPublicKey(val)
+		if err != nil {
+			return nil, err
+		}
+
+		pubkeys = append(pubkeys, core.PubKeyFrom48Bytes(pk))
+	}
+
+	return pubkeys, nil
+}
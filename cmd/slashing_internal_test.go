@@ -0,0 +1,83 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package cmd
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/cluster"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/eip3076"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestSlashingImportExport(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	lock, _, _ := cluster.NewForT(t, 2, 3, 4, 0)
+	lockJSON, err := json.Marshal(lock)
+	require.NoError(t, err)
+
+	lockFile := filepath.Join(dir, "cluster-lock.json")
+	require.NoError(t, os.WriteFile(lockFile, lockJSON, 0o444))
+
+	gvrBytes, err := hex.DecodeString(strings.TrimPrefix(eth2util.Goerli.GenesisValidatorsRootHex, "0x"))
+	require.NoError(t, err)
+	gvr := eth2p0.Root(gvrBytes)
+
+	pubkey := eth2p0.BLSPubKey(lock.Validators[0].PubKey)
+	imported := eip3076.Interchange{
+		Metadata: eip3076.Metadata{
+			InterchangeFormatVersion: eip3076.FormatVersion,
+			GenesisValidatorsRoot:    gvr,
+		},
+		Data: []eip3076.Validator{
+			{
+				Pubkey:             pubkey,
+				SignedBlocks:       []eip3076.SignedBlock{{Slot: 10}},
+				SignedAttestations: []eip3076.SignedAttestation{{SourceEpoch: 1, TargetEpoch: 2}},
+			},
+			{
+				Pubkey:       testutil.RandomEth2PubKey(t), // Not in cluster, ignored.
+				SignedBlocks: []eip3076.SignedBlock{{Slot: 11}},
+			},
+		},
+	}
+
+	conf := slashingConfig{
+		DataDir:         filepath.Join(dir, "data"),
+		LockFile:        lockFile,
+		InterchangeFile: filepath.Join(dir, "import.json"),
+	}
+	require.NoError(t, eip3076.Write(conf.InterchangeFile, imported))
+	require.NoError(t, runSlashingImport(ctx, conf))
+
+	conf.InterchangeFile = filepath.Join(dir, "export.json")
+	require.NoError(t, runSlashingExport(ctx, conf))
+
+	exported, err := eip3076.Load(conf.InterchangeFile)
+	require.NoError(t, err)
+	require.Equal(t, gvr, exported.Metadata.GenesisValidatorsRoot)
+	require.Len(t, exported.Data, 2)
+	require.Equal(t, imported.Data[0], exported.Data[0])
+	require.Equal(t, eth2p0.BLSPubKey(lock.Validators[1].PubKey), exported.Data[1].Pubkey)
+	require.Empty(t, exported.Data[1].SignedBlocks)
+	require.Empty(t, exported.Data[1].SignedAttestations)
+
+	// Mismatching genesis validators root is refused.
+	imported.Metadata.GenesisValidatorsRoot = testutil.RandomRoot()
+	conf.InterchangeFile = filepath.Join(dir, "invalid.json")
+	require.NoError(t, eip3076.Write(conf.InterchangeFile, imported))
+	err = runSlashingImport(ctx, conf)
+	require.ErrorContains(t, err, "interchange genesis validators root doesn't match cluster network")
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
@@ -18,24 +19,31 @@ import (
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
 )
 
-// New returns a new broadcaster instance.
-func New(ctx context.Context, eth2Cl eth2wrap.Client) (Broadcaster, error) {
+// New returns a new broadcaster instance. The optional await blinded block function returns the decided blinded
+// block of a slot. If it includes the local block proposed as the blinded block, the local block is unblinded
+// and submitted as a block.
+func New(ctx context.Context, eth2Cl eth2wrap.Client,
+	awaitBlindedBlockFunc func(ctx context.Context, slot int64) (*core.VersionedBlindedBeaconBlock, error),
+) (Broadcaster, error) {
 	delayFunc, err := newDelayFunc(ctx, eth2Cl)
 	if err != nil {
 		return Broadcaster{}, err
 	}
 
 	return Broadcaster{
-		eth2Cl:    eth2Cl,
-		delayFunc: delayFunc,
+		eth2Cl:                eth2Cl,
+		delayFunc:             delayFunc,
+		awaitBlindedBlockFunc: awaitBlindedBlockFunc,
 	}, nil
 }
 
 type Broadcaster struct {
-	eth2Cl    eth2wrap.Client
-	delayFunc func(slot int64) time.Duration
+	eth2Cl                eth2wrap.Client
+	delayFunc             func(slot int64) time.Duration
+	awaitBlindedBlockFunc func(ctx context.Context, slot int64) (*core.VersionedBlindedBeaconBlock, error)
 }
 
 // Broadcast broadcasts the aggregated signed duty data object to the beacon-node.
@@ -78,7 +86,7 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.Sig
 			return errors.New("invalid block")
 		}
 
-		err = b.eth2Cl.SubmitBeaconBlock(ctx, &block.VersionedSignedBeaconBlock)
+		err = b.submitBlock(ctx, block)
 		if err == nil {
 			log.Info(ctx, "Successfully submitted block proposal to beacon node",
 				z.Any("delay", b.delayFunc(duty.Slot)),
@@ -99,7 +107,22 @@ func (b Broadcaster) Broadcast(ctx context.Context, duty core.Duty, set core.Sig
 			return errors.New("invalid block")
 		}
 
-		err = b.eth2Cl.SubmitBlindedBeaconBlock(ctx, &block.VersionedSignedBlindedBeaconBlock)
+		// Local blocks proposed as blinded blocks are unknown to builders, so submit them unblinded.
+		if signed, ok, err := b.unblindLocal(ctx, duty.Slot, block); err != nil {
+			return err
+		} else if ok {
+			err = b.submitBlock(ctx, signed)
+			if err == nil {
+				log.Info(ctx, "Successfully submitted unblinded local block proposal to beacon node",
+					z.Any("delay", b.delayFunc(duty.Slot)),
+					z.Any("pubkey", pubkey),
+				)
+			}
+
+			return err
+		}
+
+		err = b.submitBlindedBlock(ctx, block)
 		if err == nil {
 			log.Info(ctx, "Successfully submitted blinded block proposal to beacon node",
 				z.Any("delay", b.delayFunc(duty.Slot)),
@@ -240,6 +263,80 @@ func setToAggAndProof(set core.SignedDataSet) ([]*eth2p0.SignedAggregateAndProof
 	return resp, nil
 }
 
+// submitBlock submits the signed block, including its signed blob sidecars from deneb.
+func (b Broadcaster) submitBlock(ctx context.Context, block core.VersionedSignedBeaconBlock) error {
+	if block.Version != eth2spec.DataVersionDeneb {
+		return b.eth2Cl.SubmitBeaconBlock(ctx, &block.VersionedSignedBeaconBlock)
+	}
+
+	contents, err := block.Contents()
+	if err != nil {
+		return err
+	}
+
+	return b.eth2Cl.SubmitBlockContents(ctx, contents)
+}
+
+// submitBlindedBlock submits the signed blinded block, including its signed blinded blob sidecars from deneb.
+func (b Broadcaster) submitBlindedBlock(ctx context.Context, block core.VersionedSignedBlindedBeaconBlock) error {
+	if block.Version != eth2spec.DataVersionDeneb {
+		return b.eth2Cl.SubmitBlindedBeaconBlock(ctx, &block.VersionedSignedBlindedBeaconBlock)
+	}
+
+	contents, err := block.Contents()
+	if err != nil {
+		return err
+	}
+
+	return b.eth2Cl.SubmitBlindedBlockContents(ctx, contents)
+}
+
+// unblindLocal returns the signed block and true if the signed blinded block is a local block proposed as a blinded block.
+// The signed blinded blob sidecars are unblinded along with the block.
+func (b Broadcaster) unblindLocal(ctx context.Context, slot int64, signed core.VersionedSignedBlindedBeaconBlock) (core.VersionedSignedBeaconBlock, bool, error) {
+	if b.awaitBlindedBlockFunc == nil {
+		return core.VersionedSignedBeaconBlock{}, false, nil
+	}
+
+	decided, err := b.awaitBlindedBlockFunc(ctx, slot)
+	if err != nil {
+		return core.VersionedSignedBeaconBlock{}, false, errors.Wrap(err, "await decided blinded block")
+	} else if decided.LocalBlock == nil {
+		return core.VersionedSignedBeaconBlock{}, false, nil
+	}
+	local := decided.LocalBlock
+
+	root, err := signed.Root()
+	if err != nil {
+		return core.VersionedSignedBeaconBlock{}, false, errors.Wrap(err, "blinded block root")
+	}
+
+	localRoot, err := local.Root()
+	if err != nil {
+		return core.VersionedSignedBeaconBlock{}, false, errors.Wrap(err, "local block root")
+	} else if localRoot != root {
+		return core.VersionedSignedBeaconBlock{}, false, errors.New("signed blinded block mismatches decided local block")
+	}
+
+	block, err := eth2util.UnblindSignedBlock(&signed.VersionedSignedBlindedBeaconBlock, &local.VersionedBeaconBlock)
+	if err != nil {
+		return core.VersionedSignedBeaconBlock{}, false, err
+	}
+
+	sidecars, err := eth2util.UnblindSignedBlobSidecars(signed.SignedBlindedBlobSidecars, local.BlobSidecars)
+	if err != nil {
+		return core.VersionedSignedBeaconBlock{}, false, err
+	}
+
+	resp, err := core.NewVersionedSignedBeaconBlock(block)
+	if err != nil {
+		return core.VersionedSignedBeaconBlock{}, false, err
+	}
+	resp.SignedBlobSidecars = sidecars
+
+	return resp, true, nil
+}
+
 // setToRegistrations converts a set of signed data into a list of registrations.
 func setToRegistrations(set core.SignedDataSet) ([]*eth2api.VersionedSignedValidatorRegistration, error) {
 	var resp []*eth2api.VersionedSignedValidatorRegistration
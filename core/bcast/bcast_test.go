@@ -8,37 +8,45 @@ import (
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/core/bcast"
+	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/testutil"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
 )
 
 type test struct {
-	name     string          // Name of the test
-	aggData  core.SignedData // Aggregated signed duty data object that needs to be broadcasted
-	duty     core.DutyType   // Duty type
-	bcastCnt int             // The no of times Broadcast() needs to be called
-	asserted chan struct{}   // Closed when test output asserted
+	name     string                            // Name of the test
+	aggData  core.SignedData                   // Aggregated signed duty data object that needs to be broadcasted
+	duty     core.DutyType                     // Duty type
+	bcastCnt int                               // The no of times Broadcast() needs to be called
+	asserted chan struct{}                     // Closed when test output asserted
+	decided  *core.VersionedBlindedBeaconBlock // Optional decided blinded block
 }
 
 func TestBroadcast(t *testing.T) {
 	testFuncs := []func(*testing.T, *beaconmock.Mock) test{
-		attData,                   // Attestation
-		beaconBlockData,           // BeaconBlock
-		blindedBeaconBlockData,    // BlindedBeaconBlock
-		validatorRegistrationData, // ValidatorRegistration
-		validatorExitData,         // ValidatorExit
-		aggregateAttestationData,  // AggregateAttestation
-		beaconCommitteeSelections, // BeaconCommitteeSelections
-		syncCommitteeMessage,      // SyncCommitteeMessage
-		syncCommitteeContribution, // SyncCommitteeContribution
+		attData,                    // Attestation
+		beaconBlockData,            // BeaconBlock
+		denebBeaconBlockData,       // DenebBeaconBlock
+		blindedBeaconBlockData,     // BlindedBeaconBlock
+		denebBlindedBlockData,      // DenebBlindedBeaconBlock
+		localBlindedBlockData,      // LocalBlindedBeaconBlock
+		localDenebBlindedBlockData, // LocalDenebBlindedBeaconBlock
+		validatorRegistrationData,  // ValidatorRegistration
+		validatorExitData,          // ValidatorExit
+		aggregateAttestationData,   // AggregateAttestation
+		beaconCommitteeSelections,  // BeaconCommitteeSelections
+		syncCommitteeMessage,       // SyncCommitteeMessage
+		syncCommitteeContribution,  // SyncCommitteeContribution
 	}
 
 	for _, testFunc := range testFuncs {
@@ -50,7 +58,14 @@ func TestBroadcast(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
-			bcaster, err := bcast.New(ctx, mock)
+			var awaitBlindedBlock func(context.Context, int64) (*core.VersionedBlindedBeaconBlock, error)
+			if test.decided != nil {
+				awaitBlindedBlock = func(context.Context, int64) (*core.VersionedBlindedBeaconBlock, error) {
+					return test.decided, nil
+				}
+			}
+
+			bcaster, err := bcast.New(ctx, mock, awaitBlindedBlock)
 			require.NoError(t, err)
 
 			for i := 0; i < test.bcastCnt; i++ {
@@ -133,6 +148,37 @@ func beaconBlockData(t *testing.T, mock *beaconmock.Mock) test {
 	}
 }
 
+func denebBeaconBlockData(t *testing.T, mock *beaconmock.Mock) test {
+	t.Helper()
+
+	asserted := make(chan struct{})
+
+	aggData := testutil.RandomDenebCoreVersionedSignedBeaconBlock()
+	aggData.SignedBlobSidecars = []*deneb.SignedBlobSidecar{
+		testutil.RandomDenebSignedBlobSidecar(0),
+		testutil.RandomDenebSignedBlobSidecar(1),
+	}
+
+	mock.SubmitBeaconBlockFunc = func(context.Context, *eth2spec.VersionedSignedBeaconBlock) error {
+		return errors.New("unexpected block without blob sidecars")
+	}
+	mock.SubmitBlockContentsFunc = func(ctx context.Context, contents *eth2deneb.SignedBlockContents) error {
+		require.Equal(t, aggData.Deneb, contents.SignedBlock)
+		require.Equal(t, aggData.SignedBlobSidecars, contents.SignedBlobSidecars)
+		close(asserted)
+
+		return nil
+	}
+
+	return test{
+		name:     "Broadcast Deneb Beacon Block",
+		aggData:  aggData,
+		duty:     core.DutyProposer,
+		bcastCnt: 1,
+		asserted: asserted,
+	}
+}
+
 func blindedBeaconBlockData(t *testing.T, mock *beaconmock.Mock) test {
 	t.Helper()
 
@@ -164,6 +210,150 @@ func blindedBeaconBlockData(t *testing.T, mock *beaconmock.Mock) test {
 	}
 }
 
+func denebBlindedBlockData(t *testing.T, mock *beaconmock.Mock) test {
+	t.Helper()
+
+	asserted := make(chan struct{})
+
+	aggData := testutil.RandomDenebVersionedSignedBlindedBeaconBlock()
+	aggData.SignedBlindedBlobSidecars = []*eth2deneb.SignedBlindedBlobSidecar{
+		testutil.RandomDenebSignedBlindedBlobSidecar(0),
+		testutil.RandomDenebSignedBlindedBlobSidecar(1),
+	}
+
+	mock.SubmitBlindedBeaconBlockFunc = func(context.Context, *eth2api.VersionedSignedBlindedBeaconBlock) error {
+		return errors.New("unexpected blinded block without blob sidecars")
+	}
+	mock.SubmitBlindedBlockContentsFunc = func(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error {
+		require.Equal(t, aggData.Deneb, contents.SignedBlindedBlock)
+		require.Equal(t, aggData.SignedBlindedBlobSidecars, contents.SignedBlindedBlobSidecars)
+		close(asserted)
+
+		return nil
+	}
+
+	return test{
+		name:     "Broadcast Deneb Blinded Beacon Block",
+		aggData:  aggData,
+		duty:     core.DutyBuilderProposer,
+		bcastCnt: 1,
+		asserted: asserted,
+	}
+}
+
+func localBlindedBlockData(t *testing.T, mock *beaconmock.Mock) test {
+	t.Helper()
+
+	asserted := make(chan struct{})
+
+	block := &eth2spec.VersionedBeaconBlock{
+		Version: eth2spec.DataVersionCapella,
+		Capella: testutil.RandomCapellaBeaconBlock(),
+	}
+	blinded, err := eth2util.BlindBlock(block)
+	require.NoError(t, err)
+
+	sig := testutil.RandomEth2Signature()
+	aggData := core.VersionedSignedBlindedBeaconBlock{
+		VersionedSignedBlindedBeaconBlock: eth2api.VersionedSignedBlindedBeaconBlock{
+			Version: eth2spec.DataVersionCapella,
+			Capella: &eth2capella.SignedBlindedBeaconBlock{
+				Message:   blinded.Capella,
+				Signature: sig,
+			},
+		},
+	}
+
+	mock.SubmitBlindedBeaconBlockFunc = func(context.Context, *eth2api.VersionedSignedBlindedBeaconBlock) error {
+		return errors.New("unexpected blinded block")
+	}
+	mock.SubmitBeaconBlockFunc = func(ctx context.Context, signed *eth2spec.VersionedSignedBeaconBlock) error {
+		require.Equal(t, block.Capella, signed.Capella.Message)
+		require.Equal(t, sig, signed.Capella.Signature)
+		close(asserted)
+
+		return nil
+	}
+
+	return test{
+		name:     "Broadcast Local Blinded Beacon Block",
+		aggData:  aggData,
+		duty:     core.DutyBuilderProposer,
+		bcastCnt: 1,
+		asserted: asserted,
+		decided: &core.VersionedBlindedBeaconBlock{
+			VersionedBlindedBeaconBlock: *blinded,
+			LocalBlock:                  &core.VersionedBeaconBlock{VersionedBeaconBlock: *block},
+		},
+	}
+}
+
+func localDenebBlindedBlockData(t *testing.T, mock *beaconmock.Mock) test {
+	t.Helper()
+
+	asserted := make(chan struct{})
+
+	block := &eth2spec.VersionedBeaconBlock{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   testutil.RandomDenebBeaconBlock(),
+	}
+	sidecars := []*deneb.BlobSidecar{
+		testutil.RandomDenebBlobSidecar(0),
+		testutil.RandomDenebBlobSidecar(1),
+	}
+	local, err := core.NewVersionedBeaconBlockContents(block, sidecars)
+	require.NoError(t, err)
+
+	blinded, err := eth2util.BlindBlock(block)
+	require.NoError(t, err)
+	blindedSidecars, err := eth2util.BlindBlobSidecars(sidecars)
+	require.NoError(t, err)
+
+	var signedSidecars []*eth2deneb.SignedBlindedBlobSidecar
+	for _, sidecar := range blindedSidecars {
+		signedSidecars = append(signedSidecars, &eth2deneb.SignedBlindedBlobSidecar{
+			Message:   sidecar,
+			Signature: testutil.RandomEth2Signature(),
+		})
+	}
+
+	sig := testutil.RandomEth2Signature()
+	aggData, err := core.NewVersionedSignedBlindedBeaconBlockContents(&eth2deneb.SignedBlindedBlockContents{
+		SignedBlindedBlock:        &eth2deneb.SignedBlindedBeaconBlock{Message: blinded.Deneb, Signature: sig},
+		SignedBlindedBlobSidecars: signedSidecars,
+	})
+	require.NoError(t, err)
+
+	mock.SubmitBlindedBlockContentsFunc = func(context.Context, *eth2deneb.SignedBlindedBlockContents) error {
+		return errors.New("unexpected blinded block contents")
+	}
+	mock.SubmitBlockContentsFunc = func(ctx context.Context, contents *eth2deneb.SignedBlockContents) error {
+		require.Equal(t, block.Deneb, contents.SignedBlock.Message)
+		require.Equal(t, sig, contents.SignedBlock.Signature)
+		require.Len(t, contents.SignedBlobSidecars, len(sidecars))
+		for i, sidecar := range contents.SignedBlobSidecars {
+			require.Equal(t, sidecars[i], sidecar.Message)
+			require.Equal(t, signedSidecars[i].Signature, sidecar.Signature)
+		}
+		close(asserted)
+
+		return nil
+	}
+
+	return test{
+		name:     "Broadcast Local Deneb Blinded Beacon Block",
+		aggData:  aggData,
+		duty:     core.DutyBuilderProposer,
+		bcastCnt: 1,
+		asserted: asserted,
+		decided: &core.VersionedBlindedBeaconBlock{
+			VersionedBlindedBeaconBlock: *blinded,
+			BlindedBlobSidecars:         blindedSidecars,
+			LocalBlock:                  &local,
+		},
+	}
+}
+
 func validatorRegistrationData(t *testing.T, mock *beaconmock.Mock) test {
 	t.Helper()
 
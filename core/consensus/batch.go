@@ -0,0 +1,207 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// batchWindow is the duration after the first proposal of a slot's batched duties during which
+// other proposals of the slot's batched duties are included in the slot's consensus batch.
+const batchWindow = time.Millisecond * 100
+
+// batchDutyTypes are the duty types decided in consensus batches if featureset.QBFTBatch is enabled.
+// These are the duties proposed in the same proposal window; two thirds into the slot. Other duties
+// are proposed alone in their proposal window, so batching them would only delay them.
+var batchDutyTypes = map[core.DutyType]bool{
+	core.DutyAggregator:       true,
+	core.DutySyncContribution: true,
+}
+
+// newBatch returns a new empty consensus batch.
+func newBatch() *batch {
+	return &batch{
+		sets: make(map[core.DutyType]*pbv1.UnsignedDataSet),
+		done: make(chan struct{}),
+	}
+}
+
+// batch is a consensus batch of a slot's duties. A single consensus instance decides the composite value
+// of all duties proposed within batchWindow of the first proposal. Duties not included in the decided
+// batch are decided individually.
+type batch struct {
+	mu       sync.Mutex
+	sets     map[core.DutyType]*pbv1.UnsignedDataSet // Locally proposed values.
+	started  bool
+	decided  map[core.DutyType]bool // Duty types included in the decided batch, nil if not decided.
+	done     chan struct{}          // Closed when decided or when the batch instance completed.
+	doneOnce sync.Once
+}
+
+// Add adds a locally proposed value to the batch and returns true if it is the first value and the batch should be started.
+// It returns an error if the duty type was already proposed.
+func (b *batch) Add(typ core.DutyType, set *pbv1.UnsignedDataSet) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.sets[typ]; ok {
+		return false, errors.New("already proposed")
+	}
+	b.sets[typ] = set
+
+	first := !b.started
+	b.started = true
+
+	return first, nil
+}
+
+// Value returns the composite value of all locally proposed values.
+func (b *batch) Value() *pbv1.UnsignedDataSetBatch {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	resp := &pbv1.UnsignedDataSetBatch{Sets: make(map[int32]*pbv1.UnsignedDataSet)}
+	for typ, set := range b.sets {
+		resp.Sets[int32(typ)] = set
+	}
+
+	return resp
+}
+
+// SetDecided marks the duty types as decided.
+func (b *batch) SetDecided(types []core.DutyType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.decided != nil {
+		return
+	}
+
+	b.decided = make(map[core.DutyType]bool)
+	for _, typ := range types {
+		b.decided[typ] = true
+	}
+
+	b.complete()
+}
+
+// Complete marks the batch as completed, without a decision if not already decided.
+func (b *batch) Complete() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.complete()
+}
+
+// complete closes the done channel once. It is unsafe since it assumes the lock is held.
+func (b *batch) complete() {
+	b.doneOnce.Do(func() {
+		close(b.done)
+	})
+}
+
+// Included returns true if the duty type is included in the decided batch.
+func (b *batch) Included(typ core.DutyType) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.decided[typ]
+}
+
+// batchTypes returns the valid batched duty types of the composite value ordered by duty type.
+func batchTypes(value *pbv1.UnsignedDataSetBatch) []core.DutyType {
+	var resp []core.DutyType
+	for typ, set := range value.Sets {
+		if !batchDutyTypes[core.DutyType(typ)] || set == nil {
+			continue
+		}
+		resp = append(resp, core.DutyType(typ))
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i] < resp[j]
+	})
+
+	return resp
+}
+
+// getBatch returns the consensus batch of the slot.
+func (c *Component) getBatch(slot int64) *batch {
+	c.mutable.Lock()
+	defer c.mutable.Unlock()
+
+	b, ok := c.mutable.batches[slot]
+	if !ok {
+		b = newBatch()
+		c.mutable.batches[slot] = b
+	}
+
+	return b
+}
+
+// proposeBatched adds the proposed value to the slot's consensus batch, starting it if not already started.
+// It waits for the batch to be decided and then either returns nil if the duty was included,
+// or decides the duty individually if it wasn't.
+func (c *Component) proposeBatched(ctx context.Context, duty core.Duty, value *pbv1.UnsignedDataSet) error {
+	b := c.getBatch(duty.Slot)
+
+	first, err := b.Add(duty.Type, value)
+	if err != nil {
+		return errors.Wrap(err, "propose consensus", z.Any("duty", duty))
+	} else if first {
+		go c.runBatch(ctx, duty.Slot, b)
+	}
+
+	select {
+	case <-ctx.Done():
+		return errors.New("consensus timeout", z.Str("duty", duty.String()))
+	case <-b.done:
+	}
+
+	if b.Included(duty.Type) {
+		return nil
+	}
+
+	batchFallbackCounter.WithLabelValues(duty.Type.String()).Inc()
+
+	return c.propose(ctx, duty, value)
+}
+
+// runBatch runs the slot's consensus batch instance after the batch window.
+func (c *Component) runBatch(ctx context.Context, slot int64, b *batch) {
+	defer b.Complete()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(batchWindow):
+	}
+
+	duty := core.NewConsensusBatchDuty(slot)
+	if err := c.propose(ctx, duty, b.Value()); err != nil {
+		log.Warn(ctx, "Consensus batch failed", err, z.Any("duty", duty))
+	}
+}
+
+// decideBatch is a subscriber that marks the duties of decided consensus batches.
+func (c *Component) decideBatch(_ context.Context, duty core.Duty, value proto.Message) error {
+	batchPB, ok := value.(*pbv1.UnsignedDataSetBatch)
+	if !ok {
+		return nil
+	}
+
+	c.getBatch(duty.Slot).SetDecided(batchTypes(batchPB))
+
+	return nil
+}
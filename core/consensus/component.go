@@ -18,6 +18,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
@@ -29,55 +30,74 @@ import (
 const (
 	recvBuffer  = 100 // Allow buffering some initial messages when this node is late to start an instance.
 	protocolID2 = "/charon/consensus/qbft/2.0.0"
+
+	// protocolIDHashOnly is the protocol of consensus messages that may not include the values of their hashes.
+	protocolIDHashOnly = "/charon/consensus/qbft/hash/1.0.0"
+
+	// protocolIDValue is the protocol used to fetch values not included in hash-only consensus messages.
+	protocolIDValue = "/charon/consensus/qbft/value/1.0.0"
 )
 
 // Protocols returns the supported protocols of this package in order of precedence.
 func Protocols() []protocol.ID {
-	return []protocol.ID{protocolID2}
+	return []protocol.ID{protocolID2, protocolIDHashOnly, protocolIDValue}
 }
 
 type subscriber func(ctx context.Context, duty core.Duty, value proto.Message) error
 
+// fetchFunc returns the value of the hash, fetching it from peers (starting at the source peer) if not known locally.
+type fetchFunc func(ctx context.Context, duty core.Duty, hash [32]byte, source int64) (*anypb.Any, error)
+
 // newDefinition returns a qbft definition (this is constant across all consensus instances).
-func newDefinition(nodes int, subs func() []subscriber, roundTimer roundTimer,
-	decideCallback func(qcommit []qbft.Msg[core.Duty, [32]byte]),
+func newDefinition(nodes int, leaderFunc leaderFunc, subs func() []subscriber, fetchValue fetchFunc,
+	roundTimer roundTimer, decideCallback func(qcommit []qbft.Msg[core.Duty, [32]byte]),
 ) qbft.Definition[core.Duty, [32]byte] {
 	quorum := qbft.Definition[int, int]{Nodes: nodes}.Quorum()
 
 	return qbft.Definition[core.Duty, [32]byte]{
 		// IsLeader is a deterministic leader election function.
 		IsLeader: func(duty core.Duty, round, process int64) bool {
-			return leader(duty, round, nodes) == process
+			return leaderFunc(duty, round) == process
 		},
 
 		// Decide sends consensus output to subscribers.
-		Decide: func(ctx context.Context, duty core.Duty, _ [32]byte, qcommit []qbft.Msg[core.Duty, [32]byte]) {
-			defer endCtxSpan(ctx) // End the parent tracing span when decided
-			msg, ok := qcommit[0].(msg)
-			if !ok {
-				log.Error(ctx, "Invalid message type", nil)
-				return
-			}
+		Decide: func(ctx context.Context, duty core.Duty, hash [32]byte, qcommit []qbft.Msg[core.Duty, [32]byte]) {
+			decideCallback(qcommit)
 
-			anyValue, ok := msg.values[msg.valueHash]
-			if !ok {
-				log.Error(ctx, "Invalid value hash", nil)
-				return
+			decide := func(anyValue *anypb.Any) {
+				defer endCtxSpan(ctx) // End the parent tracing span when decided
+
+				value, err := anyValue.UnmarshalNew()
+				if err != nil {
+					log.Error(ctx, "Invalid any value", err)
+					return
+				}
+
+				for _, sub := range subs() {
+					if err := sub(ctx, duty, value); err != nil {
+						log.Warn(ctx, "Subscriber error", err)
+					}
+				}
 			}
 
-			value, err := anyValue.UnmarshalNew()
-			if err != nil {
-				log.Error(ctx, "Invalid any value", err)
+			if anyValue, ok := qcommitValue(qcommit, hash); ok {
+				decide(anyValue)
 				return
 			}
 
-			decideCallback(qcommit)
+			// Value not included in hash-only messages, so fetch it asynchronously (not blocking the instance),
+			// preferably from the decided round leader.
+			go func() {
+				anyValue, err := fetchDecidedValue(ctx, duty, hash, leaderFunc(duty, qcommit[0].Round()), fetchValue)
+				if err != nil {
+					endCtxSpan(ctx)
+					log.Error(ctx, "Fetch decided value", err)
 
-			for _, sub := range subs() {
-				if err := sub(ctx, duty, value); err != nil {
-					log.Warn(ctx, "Subscriber error", err)
+					return
 				}
-			}
+
+				decide(anyValue)
+			}()
 		},
 
 		NewTimer: roundTimer.Timer,
@@ -99,7 +119,7 @@ func newDefinition(nodes int, subs func() []subscriber, roundTimer roundTimer,
 				z.I64("new_round", newRound),
 			}
 
-			steps := groupRoundMessages(msgs, nodes, round, int(leader(duty, round, nodes)))
+			steps := groupRoundMessages(msgs, nodes, round, int(leaderFunc(duty, round)))
 			for _, step := range steps {
 				fields = append(fields, z.Str(step.Type.String(), fmtStepPeers(step)))
 			}
@@ -127,7 +147,7 @@ func newDefinition(nodes int, subs func() []subscriber, roundTimer roundTimer,
 
 // newInstanceIO returns a new instanceIO.
 func newInstanceIO() instanceIO {
-	return instanceIO{
+	io := instanceIO{
 		participated: make(chan struct{}),
 		proposed:     make(chan struct{}),
 		running:      make(chan struct{}),
@@ -137,6 +157,9 @@ func newInstanceIO() instanceIO {
 		errCh:        make(chan error, 1),
 		decidedAtCh:  make(chan time.Time, 1),
 	}
+	io.values = newValueCache(io.valueCh)
+
+	return io
 }
 
 // instanceIO defines the async input and output channels of a
@@ -150,6 +173,7 @@ type instanceIO struct {
 	valueCh      chan proto.Message // Async input value channel.
 	errCh        chan error         // Async output error channel.
 	decidedAtCh  chan time.Time     // Async output decided timestamp channel.
+	values       *valueCache        // Proposed and received values by their hashes.
 }
 
 // MarkParticipated marks the instance as participated.
@@ -221,8 +245,15 @@ func New(tcpNode host.Host, sender *p2p.Sender, peers []p2p.Peer, p2pKey *k1.Pri
 		gaterFunc:   gaterFunc,
 		dropFilter:  log.Filter(),
 		timerFunc:   getTimerFunc(),
+		hashOnly:    featureset.Enabled(featureset.QBFTHashOnly),
+		batching:    featureset.Enabled(featureset.QBFTBatch),
 	}
 	c.mutable.instances = make(map[core.Duty]instanceIO)
+	c.mutable.batches = make(map[int64]*batch)
+
+	if c.batching {
+		c.subs = append(c.subs, c.decideBatch)
+	}
 
 	return c, nil
 }
@@ -242,11 +273,15 @@ type Component struct {
 	gaterFunc   core.DutyGaterFunc
 	dropFilter  z.Field // Filter buffer overflow errors (possible DDoS)
 	timerFunc   timerFunc
+	leaderOrder func(slot int64) []int64
+	hashOnly    bool // Only include value hashes in messages, peers fetch values on demand.
+	batching    bool // Decide duties of a slot in consensus batches.
 
 	// Mutable state
 	mutable struct {
 		sync.Mutex
 		instances map[core.Duty]instanceIO
+		batches   map[int64]*batch
 	}
 }
 
@@ -254,6 +289,10 @@ type Component struct {
 // Note this function is not thread safe, it should be called *before* Start and Propose.
 func (c *Component) Subscribe(fn func(ctx context.Context, duty core.Duty, set core.UnsignedDataSet) error) {
 	c.subs = append(c.subs, func(ctx context.Context, duty core.Duty, value proto.Message) error {
+		if batchPB, ok := value.(*pbv1.UnsignedDataSetBatch); ok {
+			return fanOutBatch(ctx, duty.Slot, batchPB, fn)
+		}
+
 		unsignedPB, ok := value.(*pbv1.UnsignedDataSet)
 		if !ok {
 			return nil
@@ -273,6 +312,13 @@ func (c *Component) subscribers() []subscriber {
 	return c.subs
 }
 
+// SetLeaderOrder sets the function returning the cluster agreed upon peer order (best first) used for leader election
+// of duties at the slot. Round-robin leader election is used if it returns an invalid order.
+// Note this function is not thread safe, it should be called *before* Start and Propose.
+func (c *Component) SetLeaderOrder(fn func(slot int64) []int64) {
+	c.leaderOrder = fn
+}
+
 // SubscribePriority registers a callback for priority protocol message proposals from leaders.
 // Note this function is not thread safe, it should be called *before* Start and Propose.
 func (c *Component) SubscribePriority(fn func(ctx context.Context, duty core.Duty, msg *pbv1.PriorityResult) error) {
@@ -292,6 +338,14 @@ func (c *Component) Start(ctx context.Context) {
 		func() proto.Message { return new(pbv1.ConsensusMsg) },
 		c.handle)
 
+	p2p.RegisterHandler("qbft", c.tcpNode, protocolIDHashOnly,
+		func() proto.Message { return new(pbv1.ConsensusMsg) },
+		c.handleHashOnly)
+
+	p2p.RegisterHandler("qbft", c.tcpNode, protocolIDValue,
+		func() proto.Message { return new(pbv1.ConsensusValueRequest) },
+		c.handleValue)
+
 	go func() {
 		for {
 			select {
@@ -315,6 +369,10 @@ func (c *Component) Propose(ctx context.Context, duty core.Duty, data core.Unsig
 		return err
 	}
 
+	if c.batching && batchDutyTypes[duty.Type] {
+		return c.proposeBatched(ctx, duty, value)
+	}
+
 	return c.propose(ctx, duty, value)
 }
 
@@ -382,6 +440,10 @@ func (c *Component) Participate(ctx context.Context, duty core.Duty) error {
 		return nil // No eager consensus for potential no-op aggregation duties.
 	}
 
+	if c.batching && batchDutyTypes[duty.Type] {
+		return nil // Batched duties are started by Propose.
+	}
+
 	if !c.timerFunc(duty).Type().Eager() {
 		return nil // Not an eager start timer, wait for Propose to start.
 	}
@@ -434,21 +496,32 @@ func (c *Component) runInstance(ctx context.Context, duty core.Duty) (err error)
 	decideCallback := func(qcommit []qbft.Msg[core.Duty, [32]byte]) {
 		decided = true
 		decidedRoundsGauge.WithLabelValues(duty.Type.String(), string(roundTimer.Type())).Set(float64(qcommit[0].Round()))
+		if adaptive, ok := roundTimer.(*adaptiveRoundTimer); ok {
+			adaptive.Decided(qcommit[0].Round())
+		}
 		inst.decidedAtCh <- time.Now()
 	}
 
-	// Create a new qbft definition for this instance.
-	def := newDefinition(len(c.peers), c.subscribers, roundTimer, decideCallback)
+	// Only valid leader orders are used and recorded, otherwise round-robin leader election is used.
+	var order []int64
+	if c.leaderOrder != nil {
+		if o := c.leaderOrder(duty.Slot); validOrder(o, len(c.peers)) {
+			order = o
+		}
+	}
 
 	// Create a new transport that handles sending and receiving for this instance.
-	t := transport{
+	t := &transport{
 		component:  c,
-		values:     make(map[[32]byte]*anypb.Any),
-		valueCh:    inst.valueCh,
+		values:     inst.values,
 		recvBuffer: make(chan qbft.Msg[core.Duty, [32]byte]),
-		sniffer:    newSniffer(int64(def.Nodes), peerIdx),
+		sniffer:    newSniffer(int64(len(c.peers)), peerIdx, order),
+		fetch:      c.fetchValue,
 	}
 
+	// Create a new qbft definition for this instance.
+	def := newDefinition(len(c.peers), newLeaderFunc(len(c.peers), order), c.subscribers, t.fetchValue, roundTimer, decideCallback)
+
 	// Provide sniffed buffer to snifferFunc at the end.
 	defer func() {
 		c.snifferFunc(t.sniffer.Instance())
@@ -481,6 +554,16 @@ func (c *Component) runInstance(ctx context.Context, duty core.Duty) (err error)
 
 // handle processes an incoming consensus wire message.
 func (c *Component) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+	return c.handleMsg(ctx, req, false)
+}
+
+// handleHashOnly processes an incoming hash-only consensus wire message that may not include the values of its hashes.
+func (c *Component) handleHashOnly(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+	return c.handleMsg(ctx, req, true)
+}
+
+// handleMsg processes an incoming consensus wire message.
+func (c *Component) handleMsg(ctx context.Context, req proto.Message, hashOnly bool) (proto.Message, bool, error) {
 	t0 := time.Now()
 
 	pbMsg, ok := req.(*pbv1.ConsensusMsg)
@@ -519,7 +602,7 @@ func (c *Component) handle(ctx context.Context, _ peer.ID, req proto.Message) (p
 		return nil, false, err
 	}
 
-	msg, err := newMsg(pbMsg.Msg, pbMsg.Justification, values)
+	msg, err := newMsg(pbMsg.Msg, pbMsg.Justification, values, hashOnly)
 	if err != nil {
 		return nil, false, err
 	}
@@ -580,6 +663,10 @@ func (c *Component) deleteInstanceIO(duty core.Duty) {
 	defer c.mutable.Unlock()
 
 	delete(c.mutable.instances, duty)
+
+	if duty.Type == core.DutyConsensusBatch {
+		delete(c.mutable.batches, duty.Slot)
+	}
 }
 
 // getPeerIdx returns the local peer index.
@@ -746,6 +833,46 @@ func leader(duty core.Duty, round int64, nodes int) int64 {
 	return ((duty.Slot) + int64(duty.Type) + round) % int64(nodes)
 }
 
+// fanOutBatch calls the subscriber with the unsigned data set of each duty in the decided consensus batch.
+// It returns the first subscriber error after calling it with all duties.
+func fanOutBatch(ctx context.Context, slot int64, batchPB *pbv1.UnsignedDataSetBatch,
+	fn func(context.Context, core.Duty, core.UnsignedDataSet) error,
+) error {
+	var resp error
+	for _, typ := range batchTypes(batchPB) {
+		duty := core.Duty{Slot: slot, Type: typ}
+
+		unsigned, err := core.UnsignedDataSetFromProto(typ, batchPB.Sets[int32(typ)])
+		if err != nil {
+			err = errors.Wrap(err, "batched unsigned data set", z.Any("duty", duty))
+		} else {
+			err = fn(ctx, duty, unsigned)
+		}
+
+		if err != nil && resp == nil {
+			resp = err
+		}
+	}
+
+	return resp
+}
+
+// qcommitValue returns the value of the hash included in any of the quorum commit messages and true, or false if not included.
+func qcommitValue(qcommit []qbft.Msg[core.Duty, [32]byte], hash [32]byte) (*anypb.Any, bool) {
+	for _, q := range qcommit {
+		m, ok := q.(msg)
+		if !ok {
+			continue
+		}
+
+		if value, ok := m.values[hash]; ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
 func valuesByHash(values []*anypb.Any) (map[[32]byte]*anypb.Any, error) {
 	resp := make(map[[32]byte]*anypb.Any)
 	for _, v := range values {
@@ -9,8 +9,10 @@ import (
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/k1util"
 	"github.com/obolnetwork/charon/core"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
@@ -584,3 +586,151 @@ func signConsensusMsg(t *testing.T, msg *pbv1.ConsensusMsg, privKey *k1.PrivateK
 
 	return msg
 }
+
+func TestHandleValue(t *testing.T) {
+	var c Component
+	c.gaterFunc = func(duty core.Duty) bool { return duty.Slot != 99 }
+	c.mutable.instances = make(map[core.Duty]instanceIO)
+
+	duty := core.Duty{Slot: 42, Type: core.DutyProposer}
+	value := &pbv1.Duty{Slot: 42}
+	hash, err := hashProto(value)
+	require.NoError(t, err)
+
+	request := func(duty core.Duty, hash []byte) (*pbv1.ConsensusValueResponse, error) {
+		resp, ok, err := c.handleValue(context.Background(), "", &pbv1.ConsensusValueRequest{
+			Duty:      core.DutyToProto(duty),
+			ValueHash: hash,
+		})
+		if err != nil {
+			return nil, err
+		}
+		require.True(t, ok)
+
+		return resp.(*pbv1.ConsensusValueResponse), nil
+	}
+
+	// Unknown instance.
+	resp, err := request(duty, hash[:])
+	require.NoError(t, err)
+	require.Nil(t, resp.Value)
+
+	// Lazily proposed value.
+	inst := c.getInstanceIO(duty)
+	inst.valueCh <- value
+
+	resp, err = request(duty, hash[:])
+	require.NoError(t, err)
+	require.NotNil(t, resp.Value)
+
+	inner, err := resp.Value.UnmarshalNew()
+	require.NoError(t, err)
+	require.True(t, proto.Equal(value, inner))
+
+	// Unknown value.
+	resp, err = request(duty, make([]byte, 32))
+	require.ErrorContains(t, err, "invalid consensus value hash")
+	require.Nil(t, resp)
+
+	otherHash := [32]byte{1}
+	resp, err = request(duty, otherHash[:])
+	require.NoError(t, err)
+	require.Nil(t, resp.Value)
+
+	// Invalid duty.
+	_, err = request(core.Duty{Slot: 99, Type: core.DutyProposer}, hash[:])
+	require.ErrorContains(t, err, "invalid duty")
+}
+
+func TestHashOnlyPrePrepare(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	duty := core.Duty{Slot: 42, Type: core.DutyProposer}
+	value := &pbv1.Duty{Slot: 42}
+	hash, err := hashProto(value)
+	require.NoError(t, err)
+	anyValue, err := anypb.New(value)
+	require.NoError(t, err)
+
+	fetched := make(chan error)
+	tr := &transport{
+		recvBuffer: make(chan qbft.Msg[core.Duty, [32]byte]),
+		sniffer:    newSniffer(4, 0, nil),
+		values:     newValueCache(make(chan proto.Message)),
+		fetch: func(_ context.Context, _ core.Duty, fetchHash [32]byte, source int64) (*anypb.Any, error) {
+			require.Equal(t, hash, fetchHash)
+			require.EqualValues(t, 1, source)
+
+			if err := <-fetched; err != nil {
+				return nil, err
+			}
+
+			return anyValue, nil
+		},
+	}
+
+	outer := make(chan msg)
+	go tr.ProcessReceives(ctx, outer)
+
+	prePrepare, err := newMsg(&pbv1.QBFTMsg{
+		Type:      int64(qbft.MsgPrePrepare),
+		Duty:      core.DutyToProto(duty),
+		PeerIdx:   1,
+		Round:     1,
+		ValueHash: hash[:],
+	}, nil, make(map[[32]byte]*anypb.Any), true)
+	require.NoError(t, err)
+
+	// Pre-prepares are dropped if the value cannot be fetched.
+	outer <- prePrepare
+	fetched <- errors.New("not found")
+
+	// Pre-prepares are only received once the value is known locally.
+	outer <- prePrepare
+	select {
+	case <-tr.recvBuffer:
+		require.Fail(t, "pre-prepare received before value fetched")
+	case fetched <- nil:
+	}
+
+	require.Equal(t, prePrepare, <-tr.recvBuffer)
+
+	_, ok, err := tr.values.Get(hash)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Subsequent pre-prepares of the known value are received directly.
+	outer <- prePrepare
+	require.Equal(t, prePrepare, <-tr.recvBuffer)
+}
+
+func TestFetchDecidedValue(t *testing.T) {
+	duty := core.Duty{Slot: 42, Type: core.DutyProposer}
+	anyValue, err := anypb.New(&pbv1.Duty{Slot: 42})
+	require.NoError(t, err)
+
+	var attempts int
+	fetch := func(context.Context, core.Duty, [32]byte, int64) (*anypb.Any, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, errors.New("not found")
+		}
+
+		return anyValue, nil
+	}
+
+	// Fetching is retried until successful.
+	value, err := fetchDecidedValue(context.Background(), duty, [32]byte{1}, 0, fetch)
+	require.NoError(t, err)
+	require.Equal(t, anyValue, value)
+	require.Equal(t, 3, attempts)
+
+	// Fetching is retried until the context is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = fetchDecidedValue(ctx, duty, [32]byte{1}, 0, func(context.Context, core.Duty, [32]byte, int64) (*anypb.Any, error) {
+		cancel()
+		return nil, errors.New("not found")
+	})
+	require.ErrorContains(t, err, "duty expired")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/require"
 
+	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/cluster"
@@ -31,6 +32,7 @@ func TestComponent(t *testing.T) {
 		name      string
 		threshold int
 		nodes     int
+		hashOnly  bool
 	}{
 		{
 			name:      "2-of-3",
@@ -52,10 +54,19 @@ func TestComponent(t *testing.T) {
 			threshold: 4,
 			nodes:     6,
 		},
+		{
+			name:      "3-of-4 hash-only",
+			threshold: 3,
+			nodes:     4,
+			hashOnly:  true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			if tt.hashOnly {
+				featureset.EnableForT(t, featureset.QBFTHashOnly)
+			}
 			testComponent(t, tt.threshold, tt.nodes)
 		})
 	}
@@ -182,3 +193,116 @@ func (testDeadliner) Add(core.Duty) bool {
 func (t testDeadliner) C() <-chan core.Duty {
 	return t.deadlineChan
 }
+
+func TestComponentBatch(t *testing.T) {
+	featureset.EnableForT(t, featureset.QBFTBatch)
+
+	const n = 3
+
+	lock, p2pkeys, _ := cluster.NewForT(t, 1, n, n, 0)
+
+	type result struct {
+		Duty core.Duty
+		Set  core.UnsignedDataSet
+	}
+
+	var (
+		peers       []p2p.Peer
+		hosts       []host.Host
+		hostsInfo   []peer.AddrInfo
+		components  []*consensus.Component
+		results     = make(chan result, n*2)
+		runErrs     = make(chan error, n*2)
+		sniffed     = make(chan core.DutyType, n*2)
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+
+	for i := 0; i < n; i++ {
+		addr := testutil.AvailableAddr(t)
+		mAddr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", addr.IP, addr.Port))
+		require.NoError(t, err)
+
+		priv := (*libp2pcrypto.Secp256k1PrivateKey)(p2pkeys[i])
+		h, err := libp2p.New(libp2p.Identity(priv), libp2p.ListenAddrs(mAddr))
+		testutil.SkipIfBindErr(t, err)
+		require.NoError(t, err)
+
+		record, err := enr.Parse(lock.Operators[i].ENR)
+		require.NoError(t, err)
+
+		p, err := p2p.NewPeerFromENR(record, i)
+		require.NoError(t, err)
+
+		hostsInfo = append(hostsInfo, peer.AddrInfo{ID: h.ID(), Addrs: h.Addrs()})
+		peers = append(peers, p)
+		hosts = append(hosts, h)
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			hosts[i].Peerstore().AddAddrs(hostsInfo[j].ID, hostsInfo[j].Addrs, peerstore.PermanentAddrTTL)
+		}
+
+		sniffer := func(instance *pbv1.SniffedConsensusInstance) {
+			sniffed <- core.DutyType(instance.Msgs[0].Msg.Msg.Duty.Type)
+		}
+
+		gaterFunc := func(core.Duty) bool { return true }
+
+		c, err := consensus.New(hosts[i], new(p2p.Sender), peers, p2pkeys[i], testDeadliner{}, gaterFunc, sniffer)
+		require.NoError(t, err)
+		c.Subscribe(func(_ context.Context, duty core.Duty, set core.UnsignedDataSet) error {
+			results <- result{Duty: duty, Set: set}
+			return nil
+		})
+		c.Start(log.WithCtx(ctx, z.Int("node", i)))
+
+		components = append(components, c)
+	}
+
+	pubkey := testutil.RandomCorePubKey(t)
+	aggregator := core.NewAggregatorDuty(1)
+	contribution := core.NewSyncContributionDuty(1)
+
+	// Propose the aggregation duties of the slot at the same time.
+	for i, c := range components {
+		for duty, data := range map[core.Duty]core.UnsignedData{
+			aggregator:   core.NewAggregatedAttestation(testutil.RandomAggregateAttestation()),
+			contribution: testutil.RandomCoreSyncContribution(),
+		} {
+			go func(i int, c *consensus.Component, duty core.Duty, data core.UnsignedData) {
+				runErrs <- c.Propose(
+					log.WithCtx(ctx, z.Int("node", i), z.Str("peer", p2p.PeerName(hosts[i].ID()))),
+					duty, core.UnsignedDataSet{pubkey: data},
+				)
+			}(i, c, duty, data)
+		}
+	}
+
+	// Assert all nodes decided the same values of both duties.
+	decided := make(map[core.Duty]core.UnsignedDataSet)
+	for count := 0; count < n*2; {
+		select {
+		case err := <-runErrs:
+			testutil.RequireNoError(t, err)
+		case res := <-results:
+			if prev, ok := decided[res.Duty]; ok {
+				require.EqualValues(t, prev, res.Set)
+			}
+			decided[res.Duty] = res.Set
+			count++
+		}
+	}
+	require.Len(t, decided, 2)
+
+	// Assert both duties were decided in a single batch instance.
+	cancel()
+	for i := 0; i < n; i++ {
+		require.Equal(t, core.DutyConsensusBatch, <-sniffed)
+	}
+	require.Empty(t, sniffed)
+}
@@ -0,0 +1,115 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+// participationBucket is the granularity of participation rates when ranking peers,
+// so that peers with similar participation are ranked by round trip time.
+const participationBucket = 0.05
+
+// leaderFunc returns the leader process of a duty and round.
+type leaderFunc func(duty core.Duty, round int64) int64
+
+// newLeaderFunc returns a leader function using the agreed upon peer order if valid, otherwise round-robin leader election.
+func newLeaderFunc(nodes int, order []int64) leaderFunc {
+	if !validOrder(order, nodes) {
+		return func(duty core.Duty, round int64) int64 {
+			return leader(duty, round, nodes)
+		}
+	}
+
+	quorum := qbft.Definition[int, int]{Nodes: nodes}.Quorum()
+
+	return func(duty core.Duty, round int64) int64 {
+		return orderedLeader(duty, round, order, quorum)
+	}
+}
+
+// orderedLeader is a deterministic leader election function that elects leaders by peer order, best first.
+// First round leaders rotate between the quorum best peers, subsequent rounds iterate over all peers in order.
+func orderedLeader(duty core.Duty, round int64, order []int64, quorum int) int64 {
+	first := (duty.Slot + int64(duty.Type)) % int64(quorum)
+
+	return order[(first+round-1)%int64(len(order))]
+}
+
+// validOrder returns true if the order is a permutation of all peer indexes.
+func validOrder(order []int64, nodes int) bool {
+	if len(order) != nodes {
+		return false
+	}
+
+	seen := make(map[int64]bool)
+	for _, idx := range order {
+		if idx < 0 || idx >= int64(nodes) || seen[idx] {
+			return false
+		}
+		seen[idx] = true
+	}
+
+	return true
+}
+
+// RankPeers returns all peer indexes ordered by suitability as leader, best first.
+// Peers are ranked by recent participation rate (bucketed) and then by round trip time, with unknown
+// round trip times ranked last. The local peer (without a round trip time) is assigned the median of the other peers'
+// round trip times to avoid ranking itself first.
+func RankPeers(nodes int, self int64, rtts map[int64]time.Duration, participation map[int64]float64) []int64 {
+	var known []time.Duration
+	for idx, rtt := range rtts {
+		if idx != self && rtt > 0 {
+			known = append(known, rtt)
+		}
+	}
+	sort.Slice(known, func(i, j int) bool {
+		return known[i] < known[j]
+	})
+
+	rtt := func(idx int64) time.Duration {
+		if idx == self {
+			if len(known) == 0 {
+				return 0
+			}
+
+			return known[len(known)/2]
+		}
+
+		if rtt, ok := rtts[idx]; ok && rtt > 0 {
+			return rtt
+		}
+
+		return math.MaxInt64
+	}
+
+	bucket := func(idx int64) int {
+		rate, ok := participation[idx]
+		if !ok {
+			rate = 1
+		}
+
+		return int(math.Round(rate / participationBucket))
+	}
+
+	var resp []int64
+	for i := 0; i < nodes; i++ {
+		resp = append(resp, int64(i))
+	}
+
+	sort.SliceStable(resp, func(i, j int) bool {
+		if bi, bj := bucket(resp[i]), bucket(resp[j]); bi != bj {
+			return bi > bj
+		}
+
+		return rtt(resp[i]) < rtt(resp[j])
+	})
+
+	return resp
+}
@@ -0,0 +1,93 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+func TestRankPeers(t *testing.T) {
+	const ms = time.Millisecond
+
+	tests := []struct {
+		name          string
+		self          int64
+		rtts          map[int64]time.Duration
+		participation map[int64]float64
+		expect        []int64
+	}{
+		{
+			name:   "by rtt",
+			self:   0,
+			rtts:   map[int64]time.Duration{1: 90 * ms, 2: 10 * ms, 3: 50 * ms},
+			expect: []int64{2, 0, 3, 1}, // Self is ranked with median rtt.
+		},
+		{
+			name:   "unknown rtt last",
+			self:   1,
+			rtts:   map[int64]time.Duration{0: 0, 2: 10 * ms, 3: 50 * ms},
+			expect: []int64{2, 1, 3, 0},
+		},
+		{
+			name:          "by participation then rtt",
+			self:          0,
+			rtts:          map[int64]time.Duration{1: 90 * ms, 2: 10 * ms, 3: 50 * ms},
+			participation: map[int64]float64{0: 1, 1: 0.99, 2: 0.5, 3: 1},
+			expect:        []int64{0, 3, 1, 2},
+		},
+		{
+			name:   "no rtts",
+			self:   3,
+			expect: []int64{3, 0, 1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, RankPeers(4, test.self, test.rtts, test.participation))
+		})
+	}
+}
+
+func TestLeaderFunc(t *testing.T) {
+	const nodes = 4
+	order := []int64{2, 0, 3, 1}
+	quorum := 3
+
+	t.Run("ordered", func(t *testing.T) {
+		leaderFunc := newLeaderFunc(nodes, order)
+
+		firsts := make(map[int64]bool)
+		for slot := int64(0); slot < 100; slot++ {
+			duty := core.NewAttesterDuty(slot)
+
+			// First round leaders are the quorum best peers.
+			first := leaderFunc(duty, 1)
+			require.NotEqual(t, order[nodes-1], first)
+			firsts[first] = true
+
+			// All peers lead one of the first rounds.
+			leaders := make(map[int64]bool)
+			for round := int64(1); round <= nodes; round++ {
+				leaders[leaderFunc(duty, round)] = true
+			}
+			require.Len(t, leaders, nodes)
+		}
+		require.Len(t, firsts, quorum)
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		for _, invalid := range [][]int64{nil, {0, 1, 2}, {0, 1, 2, 2}, {0, 1, 2, 4}} {
+			leaderFunc := newLeaderFunc(nodes, invalid)
+			for round := int64(1); round <= nodes; round++ {
+				duty := core.NewProposerDuty(round * 7)
+				require.Equal(t, leader(duty, round, nodes), leaderFunc(duty, round))
+			}
+		}
+	})
+}
@@ -31,6 +31,20 @@ var (
 		Help:      "Total count of consensus timeouts by duty and timer type.",
 	}, []string{"duty", "timer"})
 
+	adaptiveTimeoutGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "adaptive_timeout_seconds",
+		Help:      "First round timeout of the adaptive round timer in seconds by duty.",
+	}, []string{"duty"})
+
+	batchFallbackCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "consensus",
+		Name:      "batch_fallback_total",
+		Help:      "Total count of duties not included in a decided consensus batch and decided individually by duty.",
+	}, []string{"duty"})
+
 	consensusError = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: "core",
 		Subsystem: "consensus",
@@ -15,8 +15,9 @@ import (
 	"github.com/obolnetwork/charon/core/qbft"
 )
 
-// newMsg returns a new msg.
-func newMsg(pbMsg *pbv1.QBFTMsg, justification []*pbv1.QBFTMsg, values map[[32]byte]*anypb.Any) (msg, error) {
+// newMsg returns a new msg. Values of the message hashes are required unless hashOnly,
+// since hash-only messages don't include them, see protocolIDHashOnly.
+func newMsg(pbMsg *pbv1.QBFTMsg, justification []*pbv1.QBFTMsg, values map[[32]byte]*anypb.Any, hashOnly bool) (msg, error) {
 	if pbMsg == nil {
 		return msg{}, errors.New("nil qbft message")
 	}
@@ -29,21 +30,21 @@ func newMsg(pbMsg *pbv1.QBFTMsg, justification []*pbv1.QBFTMsg, values map[[32]b
 
 	if hash, ok := toHash32(pbMsg.ValueHash); ok {
 		valueHash = hash
-		if _, ok := values[valueHash]; !ok {
+		if _, ok := values[valueHash]; !ok && !hashOnly {
 			return msg{}, errors.New("value hash not found in values")
 		}
 	}
 
 	if hash, ok := toHash32(pbMsg.PreparedValueHash); ok {
 		preparedValueHash = hash
-		if _, ok := values[preparedValueHash]; !ok {
+		if _, ok := values[preparedValueHash]; !ok && !hashOnly {
 			return msg{}, errors.New("prepared value hash not found in values")
 		}
 	}
 
 	var justImpls []qbft.Msg[core.Duty, [32]byte]
 	for _, j := range justification {
-		impl, err := newMsg(j, nil, values)
+		impl, err := newMsg(j, nil, values, hashOnly)
 		if err != nil {
 			return msg{}, err
 		}
@@ -81,7 +81,7 @@ func TestNewMsg(t *testing.T) {
 		Type:              int64(qbft.MsgPrePrepare),
 		ValueHash:         hash1[:],
 		PreparedValueHash: hash2[:],
-	}, nil, values)
+	}, nil, values, false)
 	require.NoError(t, err)
 
 	require.Equal(t, msg.Value(), hash1)
@@ -101,10 +101,41 @@ func TestPartialLegacyNewMsg(t *testing.T) {
 			Type:      int64(qbft.MsgPrePrepare),
 			ValueHash: hash1[:],
 		},
-	}, make(map[[32]byte]*anypb.Any))
+	}, make(map[[32]byte]*anypb.Any), false)
 	require.ErrorContains(t, err, "value hash not found in values")
 }
 
+func TestHashOnlyNewMsg(t *testing.T) {
+	val1 := timestamppb.New(time.Time{})
+	hash1, err := hashProto(val1)
+	require.NoError(t, err)
+	hash2 := [32]byte{2}
+
+	pbMsg := &pbv1.QBFTMsg{
+		Type:              int64(qbft.MsgRoundChange),
+		ValueHash:         hash1[:],
+		PreparedValueHash: hash2[:],
+	}
+
+	// Values are required by default.
+	_, err = newMsg(pbMsg, nil, make(map[[32]byte]*anypb.Any), false)
+	require.ErrorContains(t, err, "value hash not found in values")
+
+	// Values are not required for hash-only messages.
+	msg, err := newMsg(pbMsg, []*pbv1.QBFTMsg{
+		{
+			Type:      int64(qbft.MsgPrePrepare),
+			ValueHash: hash1[:],
+		},
+	}, make(map[[32]byte]*anypb.Any), true)
+	require.NoError(t, err)
+	require.Equal(t, hash1, msg.Value())
+	require.Equal(t, hash2, msg.PreparedValue())
+	require.Len(t, msg.Justification(), 1)
+	require.Equal(t, hash1, msg.Justification()[0].Value())
+	require.Empty(t, msg.values)
+}
+
 // randomMsg returns a random qbft message.
 func randomMsg(t *testing.T) *pbv1.QBFTMsg {
 	t.Helper()
@@ -0,0 +1,491 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+// replayStallTimeout is the max real time to wait for the replayed instance to accept an event.
+const replayStallTimeout = time.Second * 10
+
+// ReplayTimerTypes returns the round timer types supported by Replay.
+// The adaptive timer is replayed without the decided round durations of previous instances,
+// so it uses its fallback timeouts.
+func ReplayTimerTypes() []string {
+	return []string{string(timerIncreasing), string(timerEagerDoubleLinear), string(timerAdaptive)}
+}
+
+// ReplayStep is a step of a replayed consensus instance; either a triggered upon rule, a round change or an unjust message.
+type ReplayStep struct {
+	// Elapsed is the virtual time since the instance started.
+	Elapsed time.Duration
+	// Round is the round of the process when the step occurred.
+	Round int64
+	// Rule is the triggered upon rule, UponRoundTimeout for round timeouts and UponNothing for unjust messages.
+	Rule qbft.UponRule
+	// MsgType, MsgSource and MsgRound identify the message that triggered the step, MsgType is unknown for round timeouts.
+	MsgType   qbft.MsgType
+	MsgSource int64
+	MsgRound  int64
+	// NewRound is the new round for round changes, otherwise zero.
+	NewRound int64
+	// Detail describes the round messages by peer for round changes (*=present, ?=missing) and the reason for timeouts.
+	Detail string
+}
+
+// ReplayResult is the result of replaying a sniffed consensus instance.
+type ReplayResult struct {
+	Duty    core.Duty
+	Nodes   int64
+	PeerIdx int64
+	Msgs    int
+	Steps   []ReplayStep
+
+	// Decided is true if the replayed instance decided, see DecidedRound, DecidedHash and DecidedValue.
+	Decided      bool
+	DecidedRound int64
+	DecidedHash  [32]byte
+	DecidedValue proto.Message
+
+	// RecordedDecided is true if the recorded messages contain a decision; either quorum commits or a justified decided message.
+	RecordedDecided bool
+	RecordedRound   int64
+	RecordedHash    [32]byte
+}
+
+// Mismatch returns true if the replayed decision differs from the recorded decision.
+func (r ReplayResult) Mismatch() bool {
+	return r.Decided != r.RecordedDecided ||
+		r.DecidedRound != r.RecordedRound ||
+		r.DecidedHash != r.RecordedHash
+}
+
+// Replay deterministically re-runs the sniffed consensus instance through qbft.Run from the perspective of the sniffing
+// peer using the provided round timer type and the recorded leader order (round-robin if not recorded). Recorded messages
+// (including the peer's own) are received in order with round timers driven by a virtual clock following the recorded
+// message timestamps. Messages broadcast by the replayed process are dropped. Round timers are not fired after the last
+// recorded message.
+func Replay(ctx context.Context, instance *pbv1.SniffedConsensusInstance, timer string) (ReplayResult, error) {
+	if len(instance.Msgs) == 0 {
+		return ReplayResult{}, errors.New("no messages in instance")
+	}
+
+	var (
+		msgs  []msg
+		times []time.Time
+	)
+	for _, sniffed := range instance.Msgs {
+		if sniffed.Msg == nil || sniffed.Msg.Msg == nil {
+			return ReplayResult{}, errors.New("invalid sniffed message")
+		}
+
+		values, err := valuesByHash(sniffed.Msg.Values)
+		if err != nil {
+			return ReplayResult{}, err
+		}
+
+		m, err := newMsg(sniffed.Msg.Msg, sniffed.Msg.Justification, values, true) // Sniffed messages may be hash-only.
+		if err != nil {
+			return ReplayResult{}, err
+		}
+
+		msgs = append(msgs, m)
+		times = append(times, sniffed.Timestamp.AsTime())
+	}
+
+	start := instance.StartedAt.AsTime()
+	clock := newReplayClock(start)
+
+	var roundTimer roundTimer
+	switch timerType(timer) {
+	case timerIncreasing:
+		roundTimer = &increasingRoundTimer{clock: clock}
+	case timerEagerDoubleLinear:
+		roundTimer = &doubleEagerLinearRoundTimer{clock: clock, firstDeadlines: make(map[int64]time.Time)}
+	case timerAdaptive:
+		roundTimer = &adaptiveRoundTimer{
+			clock:     clock,
+			duty:      msgs[0].Instance(),
+			latencies: newAdaptiveLatencies(),
+			starts:    make(map[int64]time.Time),
+		}
+	default:
+		return ReplayResult{}, errors.New("unsupported round timer type", z.Str("timer", timer))
+	}
+
+	nodes := int(instance.Nodes)
+	leaderFunc := newLeaderFunc(nodes, instance.LeaderOrder)
+	duty := msgs[0].Instance()
+	quorum := qbft.Definition[int, int]{Nodes: nodes}.Quorum()
+
+	res := ReplayResult{
+		Duty:    duty,
+		Nodes:   instance.Nodes,
+		PeerIdx: instance.PeerIdx,
+		Msgs:    len(msgs),
+	}
+	res.RecordedDecided, res.RecordedRound, res.RecordedHash = recordedDecision(msgs, quorum)
+
+	// barrier is an unjust message that is dropped by the replayed process. Sending it after each event
+	// ensures the previous event was completely processed, which makes the replay deterministic.
+	barrier := msg{
+		msg: &pbv1.QBFTMsg{
+			Type:          int64(qbft.MsgRoundChange),
+			Duty:          core.DutyToProto(duty),
+			PeerIdx:       instance.PeerIdx,
+			PreparedRound: 0,
+		},
+		preparedValueHash: [32]byte{1}, // Non-zero prepared value without prepared round is unjust.
+	}
+
+	var (
+		mu    sync.Mutex
+		steps []ReplayStep
+	)
+	addStep := func(step ReplayStep) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		step.Elapsed = clock.Now().Sub(start)
+		steps = append(steps, step)
+	}
+
+	def := qbft.Definition[core.Duty, [32]byte]{
+		IsLeader: func(duty core.Duty, round, process int64) bool {
+			return leaderFunc(duty, round) == process
+		},
+		NewTimer: roundTimer.Timer,
+		Decide: func(_ context.Context, _ core.Duty, value [32]byte, qcommit []qbft.Msg[core.Duty, [32]byte]) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			res.Decided = true
+			res.DecidedRound = qcommit[0].Round()
+			res.DecidedHash = value
+
+			if m, ok := qcommit[0].(msg); ok {
+				if anyValue, ok := m.values[value]; ok {
+					res.DecidedValue, _ = anyValue.UnmarshalNew()
+				}
+			}
+		},
+		LogUponRule: func(_ context.Context, _ core.Duty, _, round int64, m qbft.Msg[core.Duty, [32]byte], rule qbft.UponRule) {
+			addStep(ReplayStep{
+				Round:     round,
+				Rule:      rule,
+				MsgType:   m.Type(),
+				MsgSource: m.Source(),
+				MsgRound:  m.Round(),
+			})
+		},
+		LogRoundChange: func(_ context.Context, duty core.Duty, _, round, newRound int64, rule qbft.UponRule, roundMsgs []qbft.Msg[core.Duty, [32]byte]) {
+			roundSteps := groupRoundMessages(roundMsgs, nodes, round, int(leaderFunc(duty, round)))
+
+			var details []string
+			for _, step := range roundSteps {
+				details = append(details, fmt.Sprintf("%s=%s", step.Type, fmtStepPeers(step)))
+			}
+			if rule == qbft.UponRoundTimeout {
+				details = append(details, "reason="+timeoutReason(roundSteps, round, quorum))
+			}
+
+			addStep(ReplayStep{
+				Round:    round,
+				Rule:     rule,
+				NewRound: newRound,
+				Detail:   strings.Join(details, " "),
+			})
+		},
+		LogUnjust: func(_ context.Context, _ core.Duty, _ int64, m qbft.Msg[core.Duty, [32]byte]) {
+			if bm, ok := m.(msg); ok && bm.msg == barrier.msg {
+				return
+			}
+
+			addStep(ReplayStep{
+				Rule:      qbft.UponNothing,
+				MsgType:   m.Type(),
+				MsgSource: m.Source(),
+				MsgRound:  m.Round(),
+				Detail:    "unjust message dropped",
+			})
+		},
+		Nodes:     nodes,
+		FIFOLimit: recvBuffer,
+	}
+
+	// The own input value is the value of the first own pre-prepare, if any.
+	var (
+		inputValue   [32]byte
+		inputValueCh = make(chan [32]byte)
+	)
+	for _, m := range msgs {
+		if m.Type() == qbft.MsgPrePrepare && m.Source() == instance.PeerIdx {
+			inputValue = m.Value()
+			break
+		}
+	}
+
+	recv := make(chan qbft.Msg[core.Duty, [32]byte])
+	transport := qbft.Transport[core.Duty, [32]byte]{
+		Broadcast: func(context.Context, qbft.MsgType, core.Duty, int64, int64, [32]byte, int64, [32]byte, []qbft.Msg[core.Duty, [32]byte]) error {
+			return nil // Own messages are replayed from the recording.
+		},
+		Receive: recv,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- qbft.Run[core.Duty, [32]byte](ctx, def, transport, duty, instance.PeerIdx, inputValueCh)
+	}()
+
+	// send sends the event on the channel, returning an error if the replayed instance exits or stalls.
+	send := func(ch chan<- qbft.Msg[core.Duty, [32]byte], m qbft.Msg[core.Duty, [32]byte]) error {
+		select {
+		case ch <- m:
+			return nil
+		case err := <-errCh:
+			return errors.Wrap(err, "replayed instance exited")
+		case <-time.After(replayStallTimeout):
+			return errors.New("replayed instance stalled")
+		}
+	}
+
+	// Provide the input value (if any) before any message.
+	if inputValue != ([32]byte{}) {
+		select {
+		case inputValueCh <- inputValue:
+		case err := <-errCh:
+			return ReplayResult{}, errors.Wrap(err, "replayed instance exited")
+		case <-time.After(replayStallTimeout):
+			return ReplayResult{}, errors.New("replayed instance stalled")
+		}
+	}
+
+	if err := send(recv, barrier); err != nil {
+		return ReplayResult{}, err
+	}
+
+	for i, m := range msgs {
+		for {
+			t, ok := clock.NextTimer(times[i])
+			if !ok {
+				break
+			}
+
+			select {
+			case t.ch <- t.deadline:
+			case err := <-errCh:
+				return ReplayResult{}, errors.Wrap(err, "replayed instance exited")
+			case <-time.After(replayStallTimeout):
+				return ReplayResult{}, errors.New("replayed instance stalled")
+			}
+
+			if err := send(recv, barrier); err != nil {
+				return ReplayResult{}, err
+			}
+		}
+
+		clock.Set(times[i])
+
+		if err := send(recv, m); err != nil {
+			return ReplayResult{}, err
+		}
+
+		if err := send(recv, barrier); err != nil {
+			return ReplayResult{}, err
+		}
+	}
+
+	cancel()
+	<-errCh
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	res.Steps = steps
+
+	return res, nil
+}
+
+// recordedDecision returns the decided round and value hash derived from the recorded messages.
+func recordedDecision(msgs []msg, quorum int) (bool, int64, [32]byte) {
+	type key struct {
+		Round int64
+		Value [32]byte
+	}
+
+	commits := make(map[key]map[int64]bool)
+	for _, m := range msgs {
+		switch m.Type() {
+		case qbft.MsgDecided:
+			var justified int
+			for _, j := range m.Justification() {
+				if j.Type() == qbft.MsgCommit && j.Round() == m.Round() && j.Value() == m.Value() {
+					justified++
+				}
+			}
+			if justified >= quorum {
+				return true, m.Round(), m.Value()
+			}
+		case qbft.MsgCommit:
+			k := key{Round: m.Round(), Value: m.Value()}
+			if commits[k] == nil {
+				commits[k] = make(map[int64]bool)
+			}
+			commits[k][m.Source()] = true
+
+			if len(commits[k]) >= quorum {
+				return true, k.Round, k.Value
+			}
+		default:
+		}
+	}
+
+	return false, 0, [32]byte{}
+}
+
+// newReplayClock returns a new replay clock starting at the provided time.
+func newReplayClock(start time.Time) *replayClock {
+	return &replayClock{now: start}
+}
+
+// replayClock is a virtual clock driven by the replayed events. It only supports timers as used by round timers,
+// the embedded nil clockwork.Clock panics if other functions are called.
+type replayClock struct {
+	clockwork.Clock
+
+	mu     sync.Mutex
+	now    time.Time
+	timers []*replayTimer
+}
+
+func (c *replayClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *replayClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *replayClock) NewTimer(d time.Duration) clockwork.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &replayTimer{
+		clock:    c,
+		deadline: c.now.Add(d),
+		ch:       make(chan time.Time),
+	}
+	c.timers = append(c.timers, t)
+
+	return t
+}
+
+func (c *replayClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).Chan()
+}
+
+// Set sets the clock to the provided time if it is later than the current time.
+func (c *replayClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t.After(c.now) {
+		c.now = t
+	}
+}
+
+// NextTimer removes and returns the earliest active timer expiring before or at the provided time
+// after setting the clock to its deadline. It returns false if no such timer exists.
+func (c *replayClock) NextTimer(until time.Time) (*replayTimer, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var active []*replayTimer
+	for _, t := range c.timers {
+		if !t.stopped {
+			active = append(active, t)
+		}
+	}
+	c.timers = active
+
+	if len(active) == 0 {
+		return nil, false
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].deadline.Before(active[j].deadline)
+	})
+
+	next := active[0]
+	if next.deadline.After(until) {
+		return nil, false
+	}
+
+	c.timers = active[1:]
+	next.fired = true
+	if next.deadline.After(c.now) {
+		c.now = next.deadline
+	}
+
+	return next, true
+}
+
+// replayTimer is a timer of the replay clock.
+type replayTimer struct {
+	clock    *replayClock
+	deadline time.Time
+	ch       chan time.Time
+	stopped  bool
+	fired    bool
+}
+
+func (t *replayTimer) Chan() <-chan time.Time {
+	return t.ch
+}
+
+func (t *replayTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasActive := !t.stopped && !t.fired
+	if !wasActive {
+		t.clock.timers = append(t.clock.timers, t)
+	}
+	t.deadline = t.clock.now.Add(d)
+	t.stopped = false
+	t.fired = false
+
+	return wasActive
+}
+
+func (t *replayTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	wasActive := !t.stopped && !t.fired
+	t.stopped = true
+
+	return wasActive
+}
@@ -0,0 +1,232 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/core/qbft"
+)
+
+func TestReplay(t *testing.T) {
+	const nodes = 4
+
+	duty := core.Duty{Type: core.DutyAttester, Slot: 1}
+	value := &pbv1.Duty{Slot: 99}
+	valueHash, err := hashProto(value)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		build    func(b *sniffBuilder)
+		decided  bool
+		round    int64
+		contains []qbft.UponRule
+	}{
+		{
+			name: "decided in first round",
+			build: func(b *sniffBuilder) {
+				b.Add(qbft.MsgPrePrepare, leader(duty, 1, nodes), 1, 0, nil)
+				b.AddAll(qbft.MsgPrepare, 1)
+				b.AddAll(qbft.MsgCommit, 1)
+			},
+			decided:  true,
+			round:    1,
+			contains: []qbft.UponRule{qbft.UponJustifiedPrePrepare, qbft.UponQuorumPrepares, qbft.UponQuorumCommits},
+		},
+		{
+			name: "decided in second round after timeout",
+			build: func(b *sniffBuilder) {
+				b.Sleep(time.Second) // First round leader is offline, so the round times out.
+				var justification []*pbv1.QBFTMsg
+				for i := int64(0); i < nodes; i++ {
+					justification = append(justification, b.Add(qbft.MsgRoundChange, i, 2, 0, nil))
+				}
+				b.Add(qbft.MsgPrePrepare, leader(duty, 2, nodes), 2, 0, justification)
+				b.AddAll(qbft.MsgPrepare, 2)
+				b.AddAll(qbft.MsgCommit, 2)
+			},
+			decided:  true,
+			round:    2,
+			contains: []qbft.UponRule{qbft.UponRoundTimeout, qbft.UponJustifiedPrePrepare, qbft.UponQuorumCommits},
+		},
+		{
+			name: "undecided without quorum commits",
+			build: func(b *sniffBuilder) {
+				b.Add(qbft.MsgPrePrepare, leader(duty, 1, nodes), 1, 0, nil)
+				b.AddAll(qbft.MsgPrepare, 1)
+				b.Add(qbft.MsgCommit, 0, 1, 0, nil)
+				b.Add(qbft.MsgCommit, 1, 1, 0, nil)
+			},
+			contains: []qbft.UponRule{qbft.UponJustifiedPrePrepare, qbft.UponQuorumPrepares},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, timer := range ReplayTimerTypes() {
+				b := newSniffBuilder(t, duty, nodes, value)
+				test.build(b)
+
+				res, err := Replay(context.Background(), b.Instance(), timer)
+				require.NoError(t, err)
+
+				require.Equal(t, duty, res.Duty)
+				require.False(t, res.Mismatch())
+				require.Equal(t, test.decided, res.Decided)
+				require.Equal(t, test.decided, res.RecordedDecided)
+				require.Equal(t, test.round, res.DecidedRound)
+
+				if test.decided {
+					require.Equal(t, valueHash, res.DecidedHash)
+					require.True(t, proto.Equal(value, res.DecidedValue))
+				}
+
+				rules := make(map[qbft.UponRule]bool)
+				for _, step := range res.Steps {
+					rules[step.Rule] = true
+				}
+				for _, rule := range test.contains {
+					require.True(t, rules[rule], "missing rule %s", rule)
+				}
+			}
+		})
+	}
+
+	t.Run("mismatch", func(t *testing.T) {
+		b := newSniffBuilder(t, duty, nodes, value)
+		b.Add(qbft.MsgPrePrepare, leader(duty, 1, nodes), 1, 0, nil)
+		b.AddAll(qbft.MsgPrepare, 1)
+		b.AddAll(qbft.MsgCommit, 1)
+
+		res, err := Replay(context.Background(), b.Instance(), string(timerIncreasing))
+		require.NoError(t, err)
+
+		res.RecordedRound = 2
+		require.True(t, res.Mismatch())
+	})
+
+	t.Run("recorded leader order", func(t *testing.T) {
+		order := []int64{3, 2, 1, 0}
+		orderedLeader := newLeaderFunc(nodes, order)(duty, 1)
+		require.NotEqual(t, leader(duty, 1, nodes), orderedLeader)
+
+		justifiedPrePrepare := func(instance *pbv1.SniffedConsensusInstance) bool {
+			res, err := Replay(context.Background(), instance, string(timerIncreasing))
+			require.NoError(t, err)
+
+			for _, step := range res.Steps {
+				if step.Rule == qbft.UponJustifiedPrePrepare {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		b := newSniffBuilder(t, duty, nodes, value)
+		b.Add(qbft.MsgPrePrepare, orderedLeader, 1, 0, nil)
+		b.AddAll(qbft.MsgPrepare, 1)
+
+		// The pre-prepare is only justified using the recorded leader order.
+		require.False(t, justifiedPrePrepare(b.Instance()))
+		b.Instance().LeaderOrder = order
+		require.True(t, justifiedPrePrepare(b.Instance()))
+	})
+
+	t.Run("unsupported timer", func(t *testing.T) {
+		b := newSniffBuilder(t, duty, nodes, value)
+		b.AddAll(qbft.MsgPrepare, 1)
+
+		_, err := Replay(context.Background(), b.Instance(), "unknown")
+		require.ErrorContains(t, err, "unsupported round timer type")
+	})
+}
+
+// newSniffBuilder returns a new sniffBuilder of a consensus instance sniffed by peer 0.
+func newSniffBuilder(t *testing.T, duty core.Duty, nodes int64, value proto.Message) *sniffBuilder {
+	t.Helper()
+
+	anyValue, err := anypb.New(value)
+	require.NoError(t, err)
+
+	hash, err := hashProto(value)
+	require.NoError(t, err)
+
+	start := time.Unix(1700000000, 0)
+
+	return &sniffBuilder{
+		duty:      duty,
+		nodes:     nodes,
+		value:     anyValue,
+		valueHash: hash,
+		now:       start,
+		instance: &pbv1.SniffedConsensusInstance{
+			StartedAt: timestamppb.New(start),
+			Nodes:     nodes,
+		},
+	}
+}
+
+// sniffBuilder builds sniffed consensus instances where all messages have the same value.
+type sniffBuilder struct {
+	duty      core.Duty
+	nodes     int64
+	value     *anypb.Any
+	valueHash [32]byte
+	now       time.Time
+	instance  *pbv1.SniffedConsensusInstance
+}
+
+// Sleep advances the timestamp of subsequent messages.
+func (b *sniffBuilder) Sleep(d time.Duration) {
+	b.now = b.now.Add(d)
+}
+
+// Add adds and returns a message 10ms after the previous message.
+func (b *sniffBuilder) Add(typ qbft.MsgType, source, round, preparedRound int64, justification []*pbv1.QBFTMsg) *pbv1.QBFTMsg {
+	b.Sleep(time.Millisecond * 10)
+
+	m := &pbv1.QBFTMsg{
+		Type:          int64(typ),
+		Duty:          core.DutyToProto(b.duty),
+		PeerIdx:       source,
+		Round:         round,
+		PreparedRound: preparedRound,
+	}
+	if typ != qbft.MsgRoundChange {
+		m.ValueHash = b.valueHash[:]
+	}
+
+	b.instance.Msgs = append(b.instance.Msgs, &pbv1.SniffedConsensusMsg{
+		Timestamp: timestamppb.New(b.now),
+		Msg: &pbv1.ConsensusMsg{
+			Msg:           m,
+			Justification: justification,
+			Values:        []*anypb.Any{b.value},
+		},
+	})
+
+	return m
+}
+
+// AddAll adds a message of the type from all nodes.
+func (b *sniffBuilder) AddAll(typ qbft.MsgType, round int64) {
+	for i := int64(0); i < b.nodes; i++ {
+		b.Add(typ, i, round, 0, nil)
+	}
+}
+
+// Instance returns the sniffed consensus instance.
+func (b *sniffBuilder) Instance() *pbv1.SniffedConsensusInstance {
+	return b.instance
+}
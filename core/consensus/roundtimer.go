@@ -3,7 +3,9 @@
 package consensus
 
 import (
+	"math"
 	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +20,17 @@ const (
 	incRoundStart    = time.Millisecond * 750
 	incRoundIncrease = time.Millisecond * 250
 	linearRoundInc   = time.Second
+
+	// adaptiveSamples is the number of recent decided round durations per duty type used by the adaptive timer.
+	adaptiveSamples = 64
+	// adaptiveMinSamples is the minimum number of samples before the adaptive timer stops falling back to increasing timeouts.
+	adaptiveMinSamples = 8
+	// adaptivePercentile is the percentile of recent decided round durations used as the adaptive timer base.
+	adaptivePercentile = 0.9
+	// adaptiveFactor is multiplied with the percentile to provide margin for slower than usual rounds.
+	adaptiveFactor = 1.5
+	// adaptiveMaxTimeout is the hard upper bound of adaptive round timeouts, the lower bound is the increasing round timeout.
+	adaptiveMaxTimeout = time.Second * 8
 )
 
 // timerFunc is a function that returns a round timer.
@@ -25,6 +38,14 @@ type timerFunc func(core.Duty) roundTimer
 
 // getTimerFunc returns a timer function based on the enabled features.
 func getTimerFunc() timerFunc {
+	if featureset.Enabled(featureset.QBFTAdaptiveTimer) {
+		latencies := newAdaptiveLatencies()
+
+		return func(duty core.Duty) roundTimer {
+			return newAdaptiveRoundTimer(duty, latencies)
+		}
+	}
+
 	if featureset.Enabled(featureset.QBFTTimersABTest) {
 		abTimers := []func() roundTimer{
 			newIncreasingRoundTimer,
@@ -54,6 +75,7 @@ func (t timerType) Eager() bool {
 const (
 	timerIncreasing        timerType = "inc"
 	timerEagerDoubleLinear timerType = "eager_dlinear"
+	timerAdaptive          timerType = "adaptive"
 )
 
 // increasingRoundTimeout returns the duration for a round that starts at incRoundStart in round 1
@@ -147,3 +169,129 @@ func (t *doubleEagerLinearRoundTimer) Timer(round int64) (<-chan time.Time, func
 
 	return timer.Chan(), func() { timer.Stop() }
 }
+
+// newAdaptiveLatencies returns a new empty adaptiveLatencies.
+func newAdaptiveLatencies() *adaptiveLatencies {
+	return &adaptiveLatencies{
+		samples: make(map[core.DutyType][]time.Duration),
+		next:    make(map[core.DutyType]int),
+	}
+}
+
+// adaptiveLatencies stores the most recent decided round durations per duty type.
+type adaptiveLatencies struct {
+	mu      sync.Mutex
+	samples map[core.DutyType][]time.Duration
+	next    map[core.DutyType]int
+}
+
+// Add adds a decided round duration of the duty type, replacing the oldest sample if full.
+func (l *adaptiveLatencies) Add(typ core.DutyType, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.samples[typ]) < adaptiveSamples {
+		l.samples[typ] = append(l.samples[typ], d)
+		return
+	}
+
+	l.samples[typ][l.next[typ]] = d
+	l.next[typ] = (l.next[typ] + 1) % adaptiveSamples
+}
+
+// Timeout returns the round timeout of the duty type. It is the percentile of recent decided round
+// durations multiplied by adaptiveFactor, increasing linearly per round and clamped to the increasing
+// round timeout and adaptiveMaxTimeout. It falls back to increasing round timeouts if insufficient samples are available.
+func (l *adaptiveLatencies) Timeout(typ core.DutyType, round int64) time.Duration {
+	l.mu.Lock()
+	samples := append([]time.Duration(nil), l.samples[typ]...)
+	l.mu.Unlock()
+
+	if len(samples) < adaptiveMinSamples {
+		return increasingRoundTimeout(round)
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i] < samples[j]
+	})
+
+	idx := int(math.Ceil(adaptivePercentile*float64(len(samples)))) - 1
+	base := time.Duration(float64(samples[idx]) * adaptiveFactor)
+
+	return clampTimeout(base*time.Duration(round), round)
+}
+
+// clampTimeout returns the timeout of the round clamped to the fixed increasing round timeout and adaptiveMaxTimeout.
+func clampTimeout(d time.Duration, round int64) time.Duration {
+	if d > adaptiveMaxTimeout {
+		d = adaptiveMaxTimeout
+	}
+
+	if floor := increasingRoundTimeout(round); d < floor {
+		return floor
+	}
+
+	return d
+}
+
+// newAdaptiveRoundTimer returns a new adaptive round timer of the duty using the shared latencies.
+func newAdaptiveRoundTimer(duty core.Duty, latencies *adaptiveLatencies) roundTimer {
+	return &adaptiveRoundTimer{
+		clock:     clockwork.NewRealClock(),
+		duty:      duty,
+		latencies: latencies,
+		starts:    make(map[int64]time.Time),
+	}
+}
+
+// adaptiveRoundTimer implements a round timer with timeouts derived from the durations of recently
+// decided rounds of the same duty type, see adaptiveLatencies.Timeout.
+//
+// The duration of the decided round (from its start to the decision) is added to the shared latencies,
+// so timeouts of slow clusters (e.g. geographically spread) increase. Note that only durations of decided
+// rounds are used, which excludes the time lost to timed out rounds.
+//
+// The samples are local to each peer, so peers' timeouts of the same round differ, which misaligns their
+// round changes. This is traded off for not requiring agreement on the timeouts by using the fixed increasing
+// round timeout as floor: no peer times out before the default timer would, so the adaptive timer only extends
+// rounds of slow clusters, bounding the misalignment to the extension, which is capped by adaptiveMaxTimeout.
+type adaptiveRoundTimer struct {
+	clock     clockwork.Clock
+	duty      core.Duty
+	latencies *adaptiveLatencies
+
+	mu     sync.Mutex
+	starts map[int64]time.Time
+}
+
+func (*adaptiveRoundTimer) Type() timerType {
+	return timerAdaptive
+}
+
+func (t *adaptiveRoundTimer) Timer(round int64) (<-chan time.Time, func()) {
+	t.mu.Lock()
+	t.starts[round] = t.clock.Now()
+	t.mu.Unlock()
+
+	timeout := t.latencies.Timeout(t.duty.Type, round)
+	if round == 1 {
+		adaptiveTimeoutGauge.WithLabelValues(t.duty.Type.String()).Set(timeout.Seconds())
+	}
+
+	timer := t.clock.NewTimer(timeout)
+
+	return timer.Chan(), func() { timer.Stop() }
+}
+
+// Decided records the duration of the decided round.
+func (t *adaptiveRoundTimer) Decided(round int64) {
+	t.mu.Lock()
+	start, ok := t.starts[round]
+	t.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	t.latencies.Add(t.duty.Type, t.clock.Since(start))
+}
@@ -113,6 +113,11 @@ func TestDoubleEagerLinearRoundTimer(t *testing.T) {
 }
 
 func TestGetTimerFunc(t *testing.T) {
+	t.Run("adaptive", func(t *testing.T) {
+		featureset.EnableForT(t, featureset.QBFTAdaptiveTimer)
+		require.Equal(t, timerAdaptive, getTimerFunc()(core.NewAttesterDuty(0)).Type())
+	})
+
 	featureset.EnableForT(t, featureset.QBFTTimersABTest)
 
 	timerFunc := getTimerFunc()
@@ -120,3 +125,75 @@ func TestGetTimerFunc(t *testing.T) {
 	require.Equal(t, timerIncreasing, timerFunc(core.NewAttesterDuty(1)).Type())
 	require.Equal(t, timerEagerDoubleLinear, timerFunc(core.NewAttesterDuty(2)).Type())
 }
+
+func TestAdaptiveRoundTimer(t *testing.T) {
+	fakeClock := clockwork.NewFakeClock()
+	latencies := newAdaptiveLatencies()
+	duty := core.NewProposerDuty(1)
+
+	newTimer := func() *adaptiveRoundTimer {
+		timer := newAdaptiveRoundTimer(duty, latencies).(*adaptiveRoundTimer)
+		timer.clock = fakeClock
+
+		return timer
+	}
+
+	require.Equal(t, timerAdaptive, newTimer().Type())
+	require.False(t, newTimer().Type().Eager())
+
+	// Fallback to increasing timeouts without sufficient samples.
+	require.Equal(t, increasingRoundTimeout(1), latencies.Timeout(duty.Type, 1))
+	require.Equal(t, increasingRoundTimeout(2), latencies.Timeout(duty.Type, 2))
+
+	// Decide round 1 after 1s for all samples.
+	for i := 0; i < adaptiveMinSamples; i++ {
+		timer := newTimer()
+		_, stop := timer.Timer(1)
+		fakeClock.Advance(time.Second)
+		timer.Decided(1)
+		stop()
+	}
+
+	require.Equal(t, 1500*time.Millisecond, latencies.Timeout(duty.Type, 1))
+	require.Equal(t, 3000*time.Millisecond, latencies.Timeout(duty.Type, 2))
+	require.Equal(t, adaptiveMaxTimeout, latencies.Timeout(duty.Type, 10))
+
+	// Other duty types are not affected.
+	require.Equal(t, increasingRoundTimeout(1), latencies.Timeout(core.DutyAttester, 1))
+
+	// Assert the timer fires after the adaptive timeout.
+	timerC, stop := newTimer().Timer(1)
+	fakeClock.Advance(1499 * time.Millisecond)
+	select {
+	case <-timerC:
+		require.Fail(t, "timer fired early")
+	default:
+	}
+	fakeClock.Advance(time.Millisecond)
+	select {
+	case <-timerC:
+	default:
+		require.Fail(t, "timer did not fire")
+	}
+	stop()
+
+	// Fast decisions replace old samples and are bounded by the increasing round timeout.
+	for i := 0; i < adaptiveSamples; i++ {
+		latencies.Add(duty.Type, time.Millisecond*10)
+	}
+	require.Equal(t, increasingRoundTimeout(1), latencies.Timeout(duty.Type, 1))
+	require.Equal(t, increasingRoundTimeout(2), latencies.Timeout(duty.Type, 2))
+
+	// Decisions of rounds that were not started are ignored.
+	newTimer().Decided(2)
+}
+
+func TestAdaptiveLatenciesPercentile(t *testing.T) {
+	latencies := newAdaptiveLatencies()
+	for i := 1; i <= 10; i++ {
+		latencies.Add(core.DutyAttester, time.Duration(i)*time.Second/2)
+	}
+
+	// p90 of 0.5s..5s is 4.5s, times 1.5 is 6.75s.
+	require.Equal(t, 6750*time.Millisecond, latencies.Timeout(core.DutyAttester, 1))
+}
@@ -15,7 +15,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
@@ -68,7 +70,7 @@ func testSniffedInstance(ctx context.Context, t *testing.T, instance *pbv1.Sniff
 
 	var expectDecided bool
 
-	def := newDefinition(int(instance.Nodes), func() []subscriber {
+	def := newDefinition(int(instance.Nodes), newLeaderFunc(int(instance.Nodes), instance.LeaderOrder), func() []subscriber {
 		return []subscriber{func(ctx context.Context, duty core.Duty, value proto.Message) error {
 			log.Info(ctx, "Consensus decided", z.Any("value", value))
 			expectDecided = true
@@ -76,6 +78,8 @@ func testSniffedInstance(ctx context.Context, t *testing.T, instance *pbv1.Sniff
 
 			return nil
 		}}
+	}, func(context.Context, core.Duty, [32]byte, int64) (*anypb.Any, error) {
+		return nil, errors.New("value not sniffed")
 	}, newIncreasingRoundTimer(), func(qcommit []qbft.Msg[core.Duty, [32]byte]) {})
 
 	recvBuffer := make(chan qbft.Msg[core.Duty, [32]byte], len(instance.Msgs))
@@ -91,7 +95,7 @@ func testSniffedInstance(ctx context.Context, t *testing.T, instance *pbv1.Sniff
 		values, err := valuesByHash(msg.Msg.Values)
 		require.NoError(t, err)
 
-		m, err := newMsg(msg.Msg.Msg, msg.Msg.Justification, values)
+		m, err := newMsg(msg.Msg.Msg, msg.Msg.Justification, values, true)
 		require.NoError(t, err)
 		recvBuffer <- m
 	}
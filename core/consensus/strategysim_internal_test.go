@@ -640,7 +640,7 @@ func (i *transportInstance) Broadcast(_ context.Context, typ qbft.MsgType,
 		values[impl.PreparedValue()] = dummy
 	}
 
-	msg, err := newMsg(pbMsg, justMsgs, values)
+	msg, err := newMsg(pbMsg, justMsgs, values, false)
 	if err != nil {
 		return err
 	}
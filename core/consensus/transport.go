@@ -8,12 +8,13 @@ import (
 	"time"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
-	"google.golang.org/protobuf/proto"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
 	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
 	"github.com/obolnetwork/charon/core/qbft"
@@ -25,52 +26,41 @@ type transport struct {
 	component  *Component
 	recvBuffer chan qbft.Msg[core.Duty, [32]byte] // Instance inner receive buffer.
 	sniffer    *sniffer
-
-	// Mutable state
-	valueMu sync.Mutex
-	valueCh <-chan proto.Message    // Channel providing lazy proposed values.
-	values  map[[32]byte]*anypb.Any // maps any-wrapped proposed values to their hashes
+	values     *valueCache // Proposed and received values by their hashes.
+	fetch      fetchFunc   // Fetches values from peers.
 }
 
 // setValues caches the values and their hashes.
 func (t *transport) setValues(msg msg) {
-	t.valueMu.Lock()
-	defer t.valueMu.Unlock()
-
-	for k, v := range msg.values {
-		t.values[k] = v
-	}
+	t.values.Set(msg.values)
 }
 
 // getValue returns the value by its hash.
 func (t *transport) getValue(hash [32]byte) (*anypb.Any, error) {
-	t.valueMu.Lock()
-	defer t.valueMu.Unlock()
-
-	// First check if we have a new value.
-	select {
-	case value := <-t.valueCh:
-		valueHash, err := hashProto(value)
-		if err != nil {
-			return nil, err
-		}
+	value, ok, err := t.values.Get(hash)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, errors.New("unknown value")
+	}
 
-		anyValue, err := anypb.New(value)
-		if err != nil {
-			return nil, errors.Wrap(err, "wrap any value")
-		}
+	return value, nil
+}
 
-		t.values[valueHash] = anyValue
-	default:
-		// No new values
+// fetchValue returns the value by its hash, fetching it from peers (starting at the source peer) if not cached.
+func (t *transport) fetchValue(ctx context.Context, duty core.Duty, hash [32]byte, source int64) (*anypb.Any, error) {
+	if value, err := t.getValue(hash); err == nil {
+		return value, nil
 	}
 
-	pb, ok := t.values[hash]
-	if !ok {
-		return nil, errors.New("unknown value")
+	value, err := t.fetch(ctx, duty, hash, source)
+	if err != nil {
+		return nil, err
 	}
 
-	return pb, nil
+	t.values.Set(map[[32]byte]*anypb.Any{hash: value})
+
+	return value, nil
 }
 
 // Broadcast creates a msg and sends it to all peers (including self).
@@ -91,16 +81,22 @@ func (t *transport) Broadcast(ctx context.Context, typ qbft.MsgType, duty core.D
 		hashes = append(hashes, msg.preparedValueHash)
 	}
 
-	// Get values by their hashes if not zero.
+	// Get values by their hashes if not zero, unless only hashes are sent in which case peers fetch values on demand.
+	// Messages are also sent hash-only (including known values) if values of hash-only messages received from peers
+	// are not known locally.
+	hashOnly := t.component.hashOnly
 	values := make(map[[32]byte]*anypb.Any)
 	for _, hash := range hashes {
-		if hash == [32]byte{} || values[hash] != nil {
+		if hashOnly || hash == [32]byte{} || values[hash] != nil {
 			continue
 		}
 
-		value, err := t.getValue(hash)
+		value, ok, err := t.values.Get(hash)
 		if err != nil {
 			return err
+		} else if !ok {
+			hashOnly = true
+			continue
 		}
 
 		values[hash] = value
@@ -108,11 +104,16 @@ func (t *transport) Broadcast(ctx context.Context, typ qbft.MsgType, duty core.D
 
 	// Make the message
 	msg, err := createMsg(typ, duty, peerIdx, round, valueHash, pr,
-		pvHash, values, justification, t.component.privkey)
+		pvHash, values, justification, t.component.privkey, hashOnly)
 	if err != nil {
 		return err
 	}
 
+	var protocolID protocol.ID = protocolID2
+	if hashOnly {
+		protocolID = protocolIDHashOnly
+	}
+
 	// Send to self (async since buffer is blocking).
 	go func() {
 		select {
@@ -128,7 +129,7 @@ func (t *transport) Broadcast(ctx context.Context, typ qbft.MsgType, duty core.D
 			continue
 		}
 
-		err = t.component.sender.SendAsync(ctx, t.component.tcpNode, protocolID2, p.ID, msg.ToConsensusMsg())
+		err = t.component.sender.SendAsync(ctx, t.component.tcpNode, protocolID, p.ID, msg.ToConsensusMsg())
 		if err != nil {
 			return err
 		}
@@ -151,14 +152,49 @@ func (t *transport) ProcessReceives(ctx context.Context, outerBuffer chan msg) {
 
 			t.setValues(msg)
 
-			select {
-			case <-ctx.Done():
-				return
-			case t.recvBuffer <- msg:
-				t.sniffer.Add(msg.ToConsensusMsg())
+			if t.missingPrePrepareValue(msg) {
+				// Refuse to process (and PREPARE) a pre-prepare until its value is known locally.
+				go t.fetchAndReceive(ctx, msg)
+				continue
 			}
+
+			t.receive(ctx, msg)
+		}
+	}
+}
+
+// receive sends the message to the instance inner receive buffer.
+func (t *transport) receive(ctx context.Context, msg msg) {
+	select {
+	case <-ctx.Done():
+	case t.recvBuffer <- msg:
+		t.sniffer.Add(msg.ToConsensusMsg())
+	}
+}
+
+// missingPrePrepareValue returns true if the message is a pre-prepare of a hash-only message with an unknown value.
+func (t *transport) missingPrePrepareValue(msg msg) bool {
+	if msg.Type() != qbft.MsgPrePrepare || msg.Value() == [32]byte{} {
+		return false
+	}
+
+	_, ok, err := t.values.Get(msg.Value())
+
+	return !ok && err == nil
+}
+
+// fetchAndReceive fetches the value of the pre-prepare message from its source (the leader) or any other
+// peer and then sends the message to the instance. The message is dropped if the value cannot be fetched.
+func (t *transport) fetchAndReceive(ctx context.Context, msg msg) {
+	if _, err := t.fetchValue(ctx, msg.Instance(), msg.Value(), msg.Source()); err != nil {
+		if ctx.Err() == nil {
+			log.Warn(ctx, "Dropping pre-prepare with unknown value", err, z.I64("peer", msg.Source()))
 		}
+
+		return
 	}
+
+	t.receive(ctx, msg)
 }
 
 // createMsg returns a new message by converting the inputs into a protobuf
@@ -166,7 +202,7 @@ func (t *transport) ProcessReceives(ctx context.Context, outerBuffer chan msg) {
 func createMsg(typ qbft.MsgType, duty core.Duty,
 	peerIdx int64, round int64, vHash [32]byte, pr int64, pvHash [32]byte,
 	values map[[32]byte]*anypb.Any, justification []qbft.Msg[core.Duty, [32]byte],
-	privkey *k1.PrivateKey,
+	privkey *k1.PrivateKey, hashOnly bool,
 ) (msg, error) {
 	pbMsg := &pbv1.QBFTMsg{
 		Type:              int64(typ),
@@ -193,7 +229,7 @@ func createMsg(typ qbft.MsgType, duty core.Duty,
 		justMsgs = append(justMsgs, impl.msg) // Note nested justifications are ignored.
 	}
 
-	return newMsg(pbMsg, justMsgs, values)
+	return newMsg(pbMsg, justMsgs, values, hashOnly)
 }
 
 // validateMsg returns an error if the message is invalid.
@@ -203,19 +239,21 @@ func validateMsg(_ msg) error {
 }
 
 // newSniffer returns a new sniffer.
-func newSniffer(nodes, peerIdx int64) *sniffer {
+func newSniffer(nodes, peerIdx int64, leaderOrder []int64) *sniffer {
 	return &sniffer{
-		nodes:     nodes,
-		peerIdx:   peerIdx,
-		startedAt: time.Now(),
+		nodes:       nodes,
+		peerIdx:     peerIdx,
+		leaderOrder: leaderOrder,
+		startedAt:   time.Now(),
 	}
 }
 
 // sniffer buffers consensus messages.
 type sniffer struct {
-	nodes     int64
-	peerIdx   int64
-	startedAt time.Time
+	nodes       int64
+	peerIdx     int64
+	leaderOrder []int64
+	startedAt   time.Time
 
 	mu   sync.Mutex
 	msgs []*pbv1.SniffedConsensusMsg
@@ -238,9 +276,10 @@ func (c *sniffer) Instance() *pbv1.SniffedConsensusInstance {
 	defer c.mu.Unlock()
 
 	return &pbv1.SniffedConsensusInstance{
-		Nodes:     c.nodes,
-		PeerIdx:   c.peerIdx,
-		StartedAt: timestamppb.New(c.startedAt),
-		Msgs:      c.msgs,
+		Nodes:       c.nodes,
+		PeerIdx:     c.peerIdx,
+		StartedAt:   timestamppb.New(c.startedAt),
+		Msgs:        c.msgs,
+		LeaderOrder: c.leaderOrder,
 	}
 }
@@ -0,0 +1,190 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package consensus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/expbackoff"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+)
+
+// valueFetchTimeout is the maximum duration of fetching a value from a single peer.
+const valueFetchTimeout = time.Second * 2
+
+// newValueCache returns a new value cache that lazily includes values proposed via the channel.
+func newValueCache(valueCh <-chan proto.Message) *valueCache {
+	return &valueCache{
+		valueCh: valueCh,
+		values:  make(map[[32]byte]*anypb.Any),
+	}
+}
+
+// valueCache caches the any-wrapped proposed and received values of a consensus instance by their hashes.
+type valueCache struct {
+	mu      sync.Mutex
+	valueCh <-chan proto.Message // Channel providing lazy proposed values.
+	values  map[[32]byte]*anypb.Any
+}
+
+// Set caches the values by their hashes.
+func (c *valueCache) Set(values map[[32]byte]*anypb.Any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, v := range values {
+		c.values[k] = v
+	}
+}
+
+// Get returns the value by its hash and true, or false if the value is unknown.
+func (c *valueCache) Get(hash [32]byte) (*anypb.Any, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// First check if we have a new value.
+	select {
+	case value := <-c.valueCh:
+		valueHash, err := hashProto(value)
+		if err != nil {
+			return nil, false, err
+		}
+
+		anyValue, err := anypb.New(value)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "wrap any value")
+		}
+
+		c.values[valueHash] = anyValue
+	default:
+		// No new values
+	}
+
+	value, ok := c.values[hash]
+
+	return value, ok, nil
+}
+
+// fetchDecidedValue returns the decided value of the hash, retrying fetching it from the cluster peers (starting with
+// the source peer) until the context is cancelled, which is bound to the duty deadline.
+func fetchDecidedValue(ctx context.Context, duty core.Duty, hash [32]byte, source int64, fetchValue fetchFunc) (*anypb.Any, error) {
+	backoff := expbackoff.New(ctx, expbackoff.WithFastConfig())
+	for {
+		value, err := fetchValue(ctx, duty, hash, source)
+		if err == nil {
+			return value, nil
+		} else if ctx.Err() != nil {
+			return nil, errors.Wrap(err, "duty expired")
+		}
+
+		log.Debug(ctx, "Retrying fetching decided consensus value", z.Err(err))
+		backoff()
+	}
+}
+
+// fetchValue returns the value of the hash requested from the cluster peers, starting with the source peer.
+func (c *Component) fetchValue(ctx context.Context, duty core.Duty, hash [32]byte, source int64) (*anypb.Any, error) {
+	peerIdx, err := c.getPeerIdx()
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pbv1.ConsensusValueRequest{
+		Duty:      core.DutyToProto(duty),
+		ValueHash: hash[:],
+	}
+
+	nodes := int64(len(c.peers))
+	for i := int64(0); i < nodes; i++ {
+		idx := (source + i) % nodes
+		if idx == peerIdx {
+			continue
+		}
+
+		value, err := c.fetchValueFrom(ctx, c.peers[idx].ID, req, hash)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		} else if err != nil {
+			log.Debug(ctx, "Failed fetching consensus value from peer", z.Err(err), z.Str("peer", c.peers[idx].Name))
+			continue
+		} else if value == nil {
+			continue // Peer doesn't have the value.
+		}
+
+		return value, nil
+	}
+
+	return nil, errors.New("consensus value not found on any peer", z.Hex("hash", hash[:]))
+}
+
+// fetchValueFrom returns the value of the hash requested from the peer or nil if the peer doesn't have it.
+func (c *Component) fetchValueFrom(ctx context.Context, peerID peer.ID, req *pbv1.ConsensusValueRequest, hash [32]byte) (*anypb.Any, error) {
+	ctx, cancel := context.WithTimeout(ctx, valueFetchTimeout)
+	defer cancel()
+
+	resp := new(pbv1.ConsensusValueResponse)
+	if err := c.sender.SendReceive(ctx, c.tcpNode, peerID, req, resp, protocolIDValue); err != nil {
+		return nil, err
+	} else if resp.Value == nil {
+		return nil, nil
+	}
+
+	inner, err := resp.Value.UnmarshalNew()
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal any value")
+	}
+
+	valueHash, err := hashProto(inner)
+	if err != nil {
+		return nil, err
+	} else if valueHash != hash {
+		return nil, errors.New("consensus value hash mismatch")
+	}
+
+	return resp.Value, nil
+}
+
+// handleValue serves requests for values of running consensus instances.
+func (c *Component) handleValue(_ context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+	pbReq, ok := req.(*pbv1.ConsensusValueRequest)
+	if !ok || pbReq == nil || pbReq.Duty == nil {
+		return nil, false, errors.New("invalid consensus value request")
+	}
+
+	hash, ok := toHash32(pbReq.ValueHash)
+	if !ok {
+		return nil, false, errors.New("invalid consensus value hash")
+	}
+
+	duty := core.DutyFromProto(pbReq.Duty)
+	if !c.gaterFunc(duty) {
+		return nil, false, errors.New("invalid duty", z.Any("duty", duty))
+	}
+
+	resp := new(pbv1.ConsensusValueResponse)
+
+	c.mutable.Lock()
+	inst, ok := c.mutable.instances[duty]
+	c.mutable.Unlock()
+	if !ok {
+		return resp, true, nil
+	}
+
+	value, _, err := inst.values.Get(hash)
+	if err != nil {
+		return nil, false, err
+	}
+	resp.Value = value
+
+	return resp, true, nil
+}
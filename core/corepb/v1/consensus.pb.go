@@ -248,10 +248,11 @@ type SniffedConsensusInstance struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StartedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	Nodes     int64                  `protobuf:"varint,2,opt,name=nodes,proto3" json:"nodes,omitempty"`
-	PeerIdx   int64                  `protobuf:"varint,3,opt,name=peer_idx,json=peerIdx,proto3" json:"peer_idx,omitempty"`
-	Msgs      []*SniffedConsensusMsg `protobuf:"bytes,4,rep,name=msgs,proto3" json:"msgs,omitempty"`
+	StartedAt   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Nodes       int64                  `protobuf:"varint,2,opt,name=nodes,proto3" json:"nodes,omitempty"`
+	PeerIdx     int64                  `protobuf:"varint,3,opt,name=peer_idx,json=peerIdx,proto3" json:"peer_idx,omitempty"`
+	Msgs        []*SniffedConsensusMsg `protobuf:"bytes,4,rep,name=msgs,proto3" json:"msgs,omitempty"`
+	LeaderOrder []int64                `protobuf:"varint,5,rep,packed,name=leader_order,json=leaderOrder,proto3" json:"leader_order,omitempty"` // leader_order is the peer order used for leader election, empty if round-robin
 }
 
 func (x *SniffedConsensusInstance) Reset() {
@@ -314,6 +315,13 @@ func (x *SniffedConsensusInstance) GetMsgs() []*SniffedConsensusMsg {
 	return nil
 }
 
+func (x *SniffedConsensusInstance) GetLeaderOrder() []int64 {
+	if x != nil {
+		return x.LeaderOrder
+	}
+	return nil
+}
+
 type SniffedConsensusInstances struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -369,6 +377,155 @@ func (x *SniffedConsensusInstances) GetGitHash() string {
 	return ""
 }
 
+type ConsensusValueRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Duty      *Duty  `protobuf:"bytes,1,opt,name=duty,proto3" json:"duty,omitempty"`
+	ValueHash []byte `protobuf:"bytes,2,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+}
+
+func (x *ConsensusValueRequest) Reset() {
+	*x = ConsensusValueRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_corepb_v1_consensus_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsensusValueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusValueRequest) ProtoMessage() {}
+
+func (x *ConsensusValueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusValueRequest.ProtoReflect.Descriptor instead.
+func (*ConsensusValueRequest) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConsensusValueRequest) GetDuty() *Duty {
+	if x != nil {
+		return x.Duty
+	}
+	return nil
+}
+
+func (x *ConsensusValueRequest) GetValueHash() []byte {
+	if x != nil {
+		return x.ValueHash
+	}
+	return nil
+}
+
+type ConsensusValueResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *anypb.Any `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"` // value is empty if the peer doesn't have the requested value
+}
+
+func (x *ConsensusValueResponse) Reset() {
+	*x = ConsensusValueResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_corepb_v1_consensus_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsensusValueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsensusValueResponse) ProtoMessage() {}
+
+func (x *ConsensusValueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsensusValueResponse.ProtoReflect.Descriptor instead.
+func (*ConsensusValueResponse) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConsensusValueResponse) GetValue() *anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type UnsignedDataSetBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sets map[int32]*UnsignedDataSet `protobuf:"bytes,1,rep,name=sets,proto3" json:"sets,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // map[core.DutyType]core.UnsignedDataSet
+}
+
+func (x *UnsignedDataSetBatch) Reset() {
+	*x = UnsignedDataSetBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_corepb_v1_consensus_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnsignedDataSetBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsignedDataSetBatch) ProtoMessage() {}
+
+func (x *UnsignedDataSetBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_core_corepb_v1_consensus_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsignedDataSetBatch.ProtoReflect.Descriptor instead.
+func (*UnsignedDataSetBatch) Descriptor() ([]byte, []int) {
+	return file_core_corepb_v1_consensus_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UnsignedDataSetBatch) GetSets() map[int32]*UnsignedDataSet {
+	if x != nil {
+		return x.Sets
+	}
+	return nil
+}
+
 var File_core_corepb_v1_consensus_proto protoreflect.FileDescriptor
 
 var file_core_corepb_v1_consensus_proto_rawDesc = []byte{
@@ -417,7 +574,7 @@ var file_core_corepb_v1_consensus_proto_rawDesc = []byte{
 	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2e, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72,
 	0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73,
-	0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0xbf, 0x01, 0x0a, 0x18, 0x53, 0x6e, 0x69,
+	0x4d, 0x73, 0x67, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x22, 0xe2, 0x01, 0x0a, 0x18, 0x53, 0x6e, 0x69,
 	0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x73,
 	0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
 	0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
@@ -429,19 +586,43 @@ var file_core_corepb_v1_consensus_proto_rawDesc = []byte{
 	0x78, 0x12, 0x37, 0x0a, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x23, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31,
 	0x2e, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75,
-	0x73, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x22, 0x7e, 0x0a, 0x19, 0x53, 0x6e,
-	0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x72,
-	0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x69, 0x66,
-	0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12,
-	0x19, 0x0a, 0x08, 0x67, 0x69, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x67, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e, 0x65, 0x74,
-	0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65,
-	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x73, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x03, 0x28, 0x03,
+	0x52, 0x0b, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x22, 0x7e, 0x0a,
+	0x19, 0x53, 0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75,
+	0x73, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x09, 0x69, 0x6e,
+	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6e, 0x69, 0x66, 0x66, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x69, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68, 0x22, 0x60, 0x0a,
+	0x15, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x64, 0x75, 0x74, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x75, 0x74, 0x79, 0x52, 0x04, 0x64, 0x75, 0x74, 0x79,
+	0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x61, 0x73, 0x68, 0x22,
+	0x44, 0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xb4, 0x01, 0x0a, 0x14, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x42,
+	0x0a, 0x04, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e,
+	0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x74, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x2e, 0x53, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x73, 0x65,
+	0x74, 0x73, 0x1a, 0x58, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x35, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x6e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65,
+	0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x2e, 0x5a, 0x2c,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x62, 0x6f, 0x6c, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x63, 0x68, 0x61, 0x72, 0x6f, 0x6e, 0x2f, 0x63, 0x6f,
+	0x72, 0x65, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -456,32 +637,41 @@ func file_core_corepb_v1_consensus_proto_rawDescGZIP() []byte {
 	return file_core_corepb_v1_consensus_proto_rawDescData
 }
 
-var file_core_corepb_v1_consensus_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_core_corepb_v1_consensus_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_core_corepb_v1_consensus_proto_goTypes = []interface{}{
 	(*QBFTMsg)(nil),                   // 0: core.corepb.v1.QBFTMsg
 	(*ConsensusMsg)(nil),              // 1: core.corepb.v1.ConsensusMsg
 	(*SniffedConsensusMsg)(nil),       // 2: core.corepb.v1.SniffedConsensusMsg
 	(*SniffedConsensusInstance)(nil),  // 3: core.corepb.v1.SniffedConsensusInstance
 	(*SniffedConsensusInstances)(nil), // 4: core.corepb.v1.SniffedConsensusInstances
-	(*Duty)(nil),                      // 5: core.corepb.v1.Duty
-	(*anypb.Any)(nil),                 // 6: google.protobuf.Any
-	(*timestamppb.Timestamp)(nil),     // 7: google.protobuf.Timestamp
+	(*ConsensusValueRequest)(nil),     // 5: core.corepb.v1.ConsensusValueRequest
+	(*ConsensusValueResponse)(nil),    // 6: core.corepb.v1.ConsensusValueResponse
+	(*UnsignedDataSetBatch)(nil),      // 7: core.corepb.v1.UnsignedDataSetBatch
+	nil,                               // 8: core.corepb.v1.UnsignedDataSetBatch.SetsEntry
+	(*Duty)(nil),                      // 9: core.corepb.v1.Duty
+	(*anypb.Any)(nil),                 // 10: google.protobuf.Any
+	(*timestamppb.Timestamp)(nil),     // 11: google.protobuf.Timestamp
+	(*UnsignedDataSet)(nil),           // 12: core.corepb.v1.UnsignedDataSet
 }
 var file_core_corepb_v1_consensus_proto_depIdxs = []int32{
-	5, // 0: core.corepb.v1.QBFTMsg.duty:type_name -> core.corepb.v1.Duty
-	0, // 1: core.corepb.v1.ConsensusMsg.msg:type_name -> core.corepb.v1.QBFTMsg
-	0, // 2: core.corepb.v1.ConsensusMsg.justification:type_name -> core.corepb.v1.QBFTMsg
-	6, // 3: core.corepb.v1.ConsensusMsg.values:type_name -> google.protobuf.Any
-	7, // 4: core.corepb.v1.SniffedConsensusMsg.timestamp:type_name -> google.protobuf.Timestamp
-	1, // 5: core.corepb.v1.SniffedConsensusMsg.msg:type_name -> core.corepb.v1.ConsensusMsg
-	7, // 6: core.corepb.v1.SniffedConsensusInstance.started_at:type_name -> google.protobuf.Timestamp
-	2, // 7: core.corepb.v1.SniffedConsensusInstance.msgs:type_name -> core.corepb.v1.SniffedConsensusMsg
-	3, // 8: core.corepb.v1.SniffedConsensusInstances.instances:type_name -> core.corepb.v1.SniffedConsensusInstance
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	9,  // 0: core.corepb.v1.QBFTMsg.duty:type_name -> core.corepb.v1.Duty
+	0,  // 1: core.corepb.v1.ConsensusMsg.msg:type_name -> core.corepb.v1.QBFTMsg
+	0,  // 2: core.corepb.v1.ConsensusMsg.justification:type_name -> core.corepb.v1.QBFTMsg
+	10, // 3: core.corepb.v1.ConsensusMsg.values:type_name -> google.protobuf.Any
+	11, // 4: core.corepb.v1.SniffedConsensusMsg.timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 5: core.corepb.v1.SniffedConsensusMsg.msg:type_name -> core.corepb.v1.ConsensusMsg
+	11, // 6: core.corepb.v1.SniffedConsensusInstance.started_at:type_name -> google.protobuf.Timestamp
+	2,  // 7: core.corepb.v1.SniffedConsensusInstance.msgs:type_name -> core.corepb.v1.SniffedConsensusMsg
+	3,  // 8: core.corepb.v1.SniffedConsensusInstances.instances:type_name -> core.corepb.v1.SniffedConsensusInstance
+	9,  // 9: core.corepb.v1.ConsensusValueRequest.duty:type_name -> core.corepb.v1.Duty
+	10, // 10: core.corepb.v1.ConsensusValueResponse.value:type_name -> google.protobuf.Any
+	8,  // 11: core.corepb.v1.UnsignedDataSetBatch.sets:type_name -> core.corepb.v1.UnsignedDataSetBatch.SetsEntry
+	12, // 12: core.corepb.v1.UnsignedDataSetBatch.SetsEntry.value:type_name -> core.corepb.v1.UnsignedDataSet
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_core_corepb_v1_consensus_proto_init() }
@@ -551,6 +741,42 @@ func file_core_corepb_v1_consensus_proto_init() {
 				return nil
 			}
 		}
+		file_core_corepb_v1_consensus_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsensusValueRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_corepb_v1_consensus_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsensusValueResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_corepb_v1_consensus_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnsignedDataSetBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -558,7 +784,7 @@ func file_core_corepb_v1_consensus_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_core_corepb_v1_consensus_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,235 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package doppelganger provides doppelganger detection that checks that none of the cluster's validators
+// are live on the beacon chain before duties are scheduled. This protects against running
+// the same cluster twice, e.g. when restoring a backup on another host.
+package doppelganger
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jonboulle/clockwork"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/priority"
+)
+
+// topic is the priority protocol topic used to exchange detected validators.
+const topic = "doppelganger"
+
+// Prioritiser is the priority protocol interface used to exchange detected validators across the cluster.
+type Prioritiser interface {
+	Prioritise(ctx context.Context, duty core.Duty, proposals ...priority.TopicProposal) error
+	Subscribe(fn func(context.Context, core.Duty, []priority.TopicResult) error)
+}
+
+// New returns a new doppelganger detection component that checks validator liveness for the number of epochs.
+// The prioritiser is optional, detected validators are not exchanged across the cluster if it is nil.
+func New(eth2Cl eth2wrap.Client, prioritiser Prioritiser, epochs int) *Component {
+	c := &Component{
+		eth2Cl:      eth2Cl,
+		prioritiser: prioritiser,
+		epochs:      epochs,
+		clock:       clockwork.NewRealClock(),
+		results:     make(chan result, 1),
+	}
+
+	if prioritiser != nil {
+		prioritiser.Subscribe(func(ctx context.Context, duty core.Duty, results []priority.TopicResult) error {
+			if duty.Type != core.DutyInfoSync {
+				return nil
+			}
+
+			for _, res := range results {
+				if res.Topic != topic {
+					continue
+				}
+
+				// Validators detected by any peer are included, not only those detected by a threshold of peers,
+				// since a doppelganger detected by a single peer must stop all peers.
+				select {
+				case c.results <- result{slot: duty.Slot, pubkeys: res.Proposed}:
+				default:
+					log.Warn(ctx, "Dropping doppelganger agreement result", nil, z.Any("duty", duty))
+				}
+			}
+
+			return nil
+		})
+	}
+
+	return c
+}
+
+// Component checks that none of the cluster's validators are live before duties are scheduled.
+type Component struct {
+	eth2Cl      eth2wrap.Client
+	prioritiser Prioritiser
+	epochs      int
+	clock       clockwork.Clock
+	results     chan result
+}
+
+// result is a cluster-wide doppelganger detection result, containing the validators detected by any peer.
+type result struct {
+	slot    int64
+	pubkeys []string
+}
+
+// Run blocks while checking the liveness of the cluster's validators in each of the configured number of epochs
+// following the current epoch. It returns nil if no validators were live, or an error if any validator was
+// detected as live, either by this node's beacon node or by any other peer in the cluster.
+//
+// The current epoch is not checked, since the cluster itself may have been active in it before a restart.
+// Note that all peers should therefore be (re)started together, since remaining active peers are detected as doppelgangers.
+func (c *Component) Run(ctx context.Context) error {
+	if c.epochs <= 0 {
+		return nil
+	}
+
+	ctx = log.WithTopic(ctx, "doppel")
+
+	genesis, err := c.eth2Cl.GenesisTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	slotDuration, err := c.eth2Cl.SlotDuration(ctx)
+	if err != nil {
+		return err
+	}
+
+	slotsPerEpoch, err := c.eth2Cl.SlotsPerEpoch(ctx)
+	if err != nil {
+		return err
+	}
+
+	epochDuration := slotDuration * time.Duration(slotsPerEpoch)
+	epochStart := func(epoch int64) time.Time {
+		return genesis.Add(epochDuration * time.Duration(epoch))
+	}
+
+	var startEpoch int64
+	if elapsed := c.clock.Since(genesis); elapsed > 0 {
+		startEpoch = int64(elapsed / epochDuration)
+	}
+
+	log.Info(ctx, "Doppelganger detection started, delaying duties until validators are confirmed inactive",
+		z.Int("epochs", c.epochs), z.I64("start_epoch", startEpoch))
+
+	for epoch := startEpoch + 1; epoch <= startEpoch+int64(c.epochs); epoch++ {
+		// Wait for the epoch to complete.
+		if err := c.waitUntil(ctx, epochStart(epoch+1)); err != nil {
+			return err
+		}
+
+		live, err := c.detect(ctx, epoch)
+		if err != nil {
+			return errors.Wrap(err, "doppelganger detection", z.I64("epoch", epoch))
+		}
+
+		cluster := c.exchange(ctx, (epoch+1)*int64(slotsPerEpoch), live, epochStart(epoch+2))
+
+		if len(live) > 0 || len(cluster) > 0 {
+			return errors.New("doppelganger detected, refusing to start duties; ensure the cluster is not already running elsewhere",
+				z.I64("epoch", epoch), z.Any("live", live), z.Any("cluster_live", cluster))
+		}
+
+		log.Info(ctx, "No doppelganger detected in epoch", z.I64("epoch", epoch),
+			z.I64("remaining_epochs", startEpoch+int64(c.epochs)-epoch))
+	}
+
+	log.Info(ctx, "Doppelganger detection completed, starting duties")
+
+	return nil
+}
+
+// detect returns the sorted public keys of the active validators that were live in the epoch.
+func (c *Component) detect(ctx context.Context, epoch int64) ([]string, error) {
+	vals, err := c.eth2Cl.ActiveValidators(ctx)
+	if err != nil {
+		return nil, err
+	} else if len(vals) == 0 {
+		return nil, nil
+	}
+
+	liveness, err := c.eth2Cl.ValidatorLiveness(ctx, eth2p0.Epoch(epoch), vals.Indices())
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []string
+	for _, l := range liveness {
+		if !l.IsLive {
+			continue
+		}
+
+		pubkey, ok := vals[l.Index]
+		if !ok {
+			return nil, errors.New("unexpected validator liveness index", z.U64("index", uint64(l.Index)))
+		}
+
+		resp = append(resp, string(core.PubKeyFrom48Bytes(pubkey)))
+	}
+
+	sort.Strings(resp)
+
+	return resp, nil
+}
+
+// exchange returns the live validators detected by any peer in the cluster using the priority protocol.
+// It returns nil if the exchange fails or doesn't complete before the deadline, in which case only the local result applies.
+func (c *Component) exchange(ctx context.Context, slot int64, live []string, deadline time.Time) []string {
+	if c.prioritiser == nil {
+		return nil
+	}
+
+	// Prioritise only returns at the duty deadline, since it keeps participating in the protocol
+	// for slower peers, while the result is provided to subscribers as soon as it is decided.
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- c.prioritiser.Prioritise(ctx, core.NewInfoSyncDuty(slot), priority.TopicProposal{
+			Topic:      topic,
+			Priorities: live,
+		})
+	}()
+
+	timer := c.clock.After(deadline.Sub(c.clock.Now()))
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			if err != nil {
+				log.Warn(ctx, "Doppelganger cluster exchange failed, using local result", err)
+				return nil
+			}
+		case <-timer:
+			log.Warn(ctx, "Doppelganger cluster exchange timeout, using local result", nil, z.I64("slot", slot))
+			return nil
+		case res := <-c.results:
+			if res.slot != slot {
+				continue // Ignore stale results.
+			}
+
+			return res.pubkeys
+		}
+	}
+}
+
+// waitUntil blocks until the provided time or until the context is closed.
+func (c *Component) waitUntil(ctx context.Context, t time.Time) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.clock.After(t.Sub(c.clock.Now())):
+		return nil
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package doppelganger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/priority"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestRun(t *testing.T) {
+	const (
+		epochs     = 3
+		startEpoch = 10
+		liveIdx    = 2
+	)
+
+	set := beaconmock.ValidatorSetA
+	livePubkey := string(core.PubKeyFrom48Bytes(set[liveIdx].Validator.PublicKey))
+
+	tests := []struct {
+		name      string
+		liveEpoch int64                         // Epoch in which the validator is live, zero if never.
+		peerLive  func(duty core.Duty) []string // Validators reported live by another single peer.
+		noAgree   bool
+		errMsg    string
+		checked   []int64
+	}{
+		{
+			name:    "none live",
+			checked: []int64{11, 12, 13},
+		},
+		{
+			name:      "live in current epoch ignored",
+			liveEpoch: startEpoch,
+			checked:   []int64{11, 12, 13},
+		},
+		{
+			name:      "live detected locally",
+			liveEpoch: 12,
+			errMsg:    "doppelganger detected",
+			checked:   []int64{11, 12},
+		},
+		{
+			name: "live reported by single peer",
+			peerLive: func(duty core.Duty) []string {
+				if duty.Slot == 12*16 {
+					return []string{livePubkey}
+				}
+
+				return nil
+			},
+			errMsg:  "doppelganger detected",
+			checked: []int64{11},
+		},
+		{
+			name:    "no cluster agreement",
+			noAgree: true,
+			checked: []int64{11, 12, 13},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			clock := clockwork.NewFakeClock()
+
+			bmock, err := beaconmock.New(
+				beaconmock.WithValidatorSet(set),
+				beaconmock.WithSlotsPerEpoch(16),
+				beaconmock.WithSlotDuration(time.Second),
+				beaconmock.WithGenesisTime(clock.Now().Add(-startEpoch*16*time.Second-time.Second)),
+			)
+			require.NoError(t, err)
+
+			var checked []int64
+			bmock.ValidatorLivenessFunc = func(_ context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*eth2wrap.ValidatorLiveness, error) {
+				checked = append(checked, int64(epoch))
+
+				var resp []*eth2wrap.ValidatorLiveness
+				for _, index := range indices {
+					resp = append(resp, &eth2wrap.ValidatorLiveness{
+						Index:  index,
+						IsLive: index == liveIdx && int64(epoch) == test.liveEpoch,
+					})
+				}
+
+				return resp, nil
+			}
+
+			prio := &testPrioritiser{peerLive: test.peerLive, noAgree: test.noAgree}
+			c := New(bmock, prio, epochs)
+			c.clock = clock
+
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- c.Run(ctx)
+			}()
+
+		loop:
+			for {
+				select {
+				case err = <-errCh:
+					break loop
+				case <-time.After(time.Millisecond):
+					clock.Advance(time.Second)
+				}
+			}
+
+			if test.errMsg != "" {
+				require.ErrorContains(t, err, test.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.checked, checked)
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		require.NoError(t, New(nil, nil, 0).Run(context.Background()))
+	})
+}
+
+// testPrioritiser is a prioritiser returning the locally proposed priorities as the cluster result
+// along with the priorities proposed by another single peer, which are below the threshold.
+// Like the priority component, it only returns once the context is closed.
+type testPrioritiser struct {
+	peerLive func(duty core.Duty) []string
+	noAgree  bool
+	subs     []func(context.Context, core.Duty, []priority.TopicResult) error
+}
+
+func (p *testPrioritiser) Prioritise(ctx context.Context, duty core.Duty, proposals ...priority.TopicProposal) error {
+	if p.noAgree {
+		return nil
+	}
+
+	var results []priority.TopicResult
+	for _, proposal := range proposals {
+		var scored []priority.ScoredPriority
+		for _, prio := range proposal.Priorities {
+			scored = append(scored, priority.ScoredPriority{Priority: prio})
+		}
+
+		proposed := append([]string(nil), proposal.Priorities...)
+		if p.peerLive != nil {
+			proposed = append(proposed, p.peerLive(duty)...)
+		}
+
+		results = append(results, priority.TopicResult{Topic: proposal.Topic, Priorities: scored, Proposed: proposed})
+	}
+
+	for _, sub := range p.subs {
+		if err := sub(ctx, duty, results); err != nil {
+			return err
+		}
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+func (p *testPrioritiser) Subscribe(fn func(context.Context, core.Duty, []priority.TopicResult) error) {
+	p.subs = append(p.subs, fn)
+}
@@ -0,0 +1,486 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+// diskFilename is the name of the file in the data directory containing the persisted slashing records.
+const diskFilename = "dutydb.json"
+
+// NewDiskDB returns a new disk-backed dutyDB instance. It wraps the provided in-memory dutyDB
+// which serves all queries, while the attester and proposer records required for slashing protection
+// are persisted to the data directory. Existing records are loaded, so conflicting stores are
+// refused even after a restart. Records are pruned when the provided deadliner expires their duty,
+// except for each validator's slashing protection history which is never pruned.
+func NewDiskDB(dataDir string, memDB *MemDB, deadliner core.Deadliner) (*DiskDB, error) {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "create data dir", z.Str("dir", dataDir))
+	}
+
+	db := &DiskDB{
+		MemDB:     memDB,
+		path:      filepath.Join(dataDir, diskFilename),
+		deadliner: deadliner,
+		atts:      make(map[pkKey]attRecord),
+		attRoots:  make(map[attKey]eth2p0.Root),
+		proposals: make(map[int64]proRecord),
+		history:   make(map[core.PubKey]historyRecord),
+	}
+
+	if err := db.load(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// DiskDB is a disk-backed dutyDB implementation.
+// It persists slashing records to disk and delegates everything else to MemDB.
+type DiskDB struct {
+	*MemDB
+
+	mu        sync.Mutex
+	path      string
+	deadliner core.Deadliner
+
+	atts      map[pkKey]attRecord
+	attRoots  map[attKey]eth2p0.Root
+	proposals map[int64]proRecord
+	history   map[core.PubKey]historyRecord
+}
+
+// attRecord is a persisted record of an agreed attestation data for a validator.
+type attRecord struct {
+	DutySlot   int64                   `json:"duty_slot"`
+	PubKey     core.PubKey             `json:"pubkey"`
+	Slot       int64                   `json:"slot"`
+	CommIdx    int64                   `json:"committee_index"`
+	ValCommIdx int64                   `json:"validator_committee_index"`
+	Data       *eth2p0.AttestationData `json:"attestation_data"`
+}
+
+// proRecord is a persisted record of an agreed (full or blinded) block proposal for a slot.
+type proRecord struct {
+	PubKey core.PubKey `json:"pubkey"`
+	Slot   int64       `json:"slot"`
+	Root   eth2p0.Root `json:"block_root"`
+}
+
+// historyRecord is the persisted slashing protection history of a validator. It contains the highest
+// block slot and attestation source and target epochs either agreed on by the cluster or imported
+// via EIP-3076 slashing protection interchange.
+type historyRecord struct {
+	PubKey      core.PubKey   `json:"pubkey"`
+	BlockSlot   *eth2p0.Slot  `json:"block_slot,omitempty"`
+	SourceEpoch *eth2p0.Epoch `json:"source_epoch,omitempty"`
+	TargetEpoch *eth2p0.Epoch `json:"target_epoch,omitempty"`
+}
+
+// diskRecords is the json file format of the persisted slashing records.
+type diskRecords struct {
+	Attestations []attRecord     `json:"attestations"`
+	Proposals    []proRecord     `json:"proposals"`
+	History      []historyRecord `json:"history"`
+}
+
+// Store implements core.DutyDB, see its godoc.
+// Slashing records are checked against and persisted to disk before the unsigned data set is
+// stored in memory, ensuring that nothing is returned to the validator client that isn't persisted.
+func (db *DiskDB) Store(ctx context.Context, duty core.Duty, unsignedSet core.UnsignedDataSet) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if !db.deadliner.Add(duty) {
+		return errors.New("not storing unsigned data for expired duty", z.Any("duty", duty))
+	}
+
+	// Records are added as they are checked, so clashes within the set are also detected.
+	var changed bool
+	switch duty.Type {
+	case core.DutyAttester:
+		for pubkey, unsignedData := range unsignedSet {
+			record, err := db.checkAttestationUnsafe(duty, pubkey, unsignedData)
+			if err != nil {
+				return err
+			}
+			if err := db.addAttestationUnsafe(record); err != nil {
+				return err
+			}
+			changed = true
+		}
+	case core.DutyProposer, core.DutyBuilderProposer:
+		for pubkey, unsignedData := range unsignedSet {
+			record, err := db.checkProposalUnsafe(pubkey, unsignedData)
+			if err != nil {
+				return err
+			}
+			db.addProposalUnsafe(record)
+			changed = true
+		}
+	default:
+		// Other duties are not slashable, so not persisted.
+	}
+
+	// Delete all expired duties.
+	for {
+		var deleted bool
+		select {
+		case duty := <-db.deadliner.C():
+			db.deleteDutyUnsafe(duty)
+			deleted = true
+			changed = true
+		default:
+		}
+
+		if !deleted {
+			break
+		}
+	}
+
+	if changed {
+		if err := db.flushUnsafe(); err != nil {
+			return err
+		}
+	}
+
+	return db.MemDB.Store(ctx, duty, unsignedSet)
+}
+
+// checkAttestationUnsafe returns a new attestation record or an error if it clashes with a persisted record.
+// It is unsafe since it assumes the lock is held.
+func (db *DiskDB) checkAttestationUnsafe(duty core.Duty, pubkey core.PubKey, unsignedData core.UnsignedData) (attRecord, error) {
+	attData, ok := unsignedData.(core.AttestationData)
+	if !ok {
+		return attRecord{}, errors.New("invalid unsigned attestation data")
+	}
+
+	record := attRecord{
+		DutySlot:   duty.Slot,
+		PubKey:     pubkey,
+		Slot:       int64(attData.Data.Slot),
+		CommIdx:    int64(attData.Data.Index),
+		ValCommIdx: int64(attData.Duty.ValidatorCommitteeIndex),
+		Data:       &attData.Data,
+	}
+
+	if existing, ok := db.atts[record.key()]; ok && existing.PubKey != pubkey {
+		return attRecord{}, errors.New("clashing public key", z.Any("key", record.key()))
+	}
+
+	root, err := attData.Data.HashTreeRoot()
+	if err != nil {
+		return attRecord{}, errors.Wrap(err, "hash attestation data")
+	}
+
+	if existing, ok := db.attRoots[record.attKey()]; ok && existing != root {
+		return attRecord{}, errors.New("clashing persisted attestation data",
+			z.I64("slot", record.Slot), z.I64("commidx", record.CommIdx))
+	}
+
+	if _, ok := db.atts[record.key()]; ok {
+		return record, nil // Identical to existing record, so already checked against history.
+	}
+
+	if attData.Data.Source == nil || attData.Data.Target == nil {
+		return attRecord{}, errors.New("invalid attestation data checkpoints")
+	}
+
+	err = db.history[pubkey].checkAttestation(attData.Data.Source.Epoch, attData.Data.Target.Epoch)
+	if err != nil {
+		return attRecord{}, err
+	}
+
+	return record, nil
+}
+
+// addAttestationUnsafe adds the attestation record. It is unsafe since it assumes the lock is held.
+func (db *DiskDB) addAttestationUnsafe(record attRecord) error {
+	root, err := record.Data.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "hash attestation data")
+	}
+
+	db.atts[record.key()] = record
+	db.attRoots[record.attKey()] = root
+
+	if record.Data.Source != nil && record.Data.Target != nil {
+		history := db.history[record.PubKey]
+		history.PubKey = record.PubKey
+		history.addAttestation(record.Data.Source.Epoch, record.Data.Target.Epoch)
+		db.history[record.PubKey] = history
+	}
+
+	return nil
+}
+
+// addProposalUnsafe adds the proposal record. It is unsafe since it assumes the lock is held.
+func (db *DiskDB) addProposalUnsafe(record proRecord) {
+	db.proposals[record.Slot] = record
+
+	history := db.history[record.PubKey]
+	history.PubKey = record.PubKey
+	history.addBlock(eth2p0.Slot(record.Slot))
+	db.history[record.PubKey] = history
+}
+
+// checkProposalUnsafe returns a new proposal record or an error if it clashes with a persisted record.
+// Note that full and blinded blocks are checked against each other since the blinded block root equals
+// the full block root. It is unsafe since it assumes the lock is held.
+func (db *DiskDB) checkProposalUnsafe(pubkey core.PubKey, unsignedData core.UnsignedData) (proRecord, error) {
+	var (
+		slot eth2p0.Slot
+		root eth2p0.Root
+		err  error
+	)
+	switch block := unsignedData.(type) {
+	case core.VersionedBeaconBlock:
+		if slot, err = block.Slot(); err != nil {
+			return proRecord{}, err
+		}
+		if root, err = block.Root(); err != nil {
+			return proRecord{}, errors.Wrap(err, "block root")
+		}
+	case core.VersionedBlindedBeaconBlock:
+		if slot, err = block.Slot(); err != nil {
+			return proRecord{}, err
+		}
+		if root, err = block.Root(); err != nil {
+			return proRecord{}, errors.Wrap(err, "blinded block root")
+		}
+	default:
+		return proRecord{}, errors.New("invalid unsigned block")
+	}
+
+	if existing, ok := db.proposals[int64(slot)]; ok && existing.Root != root {
+		return proRecord{}, errors.New("clashing persisted blocks", z.U64("slot", uint64(slot)))
+	} else if !ok {
+		if err := db.history[pubkey].checkBlock(slot); err != nil {
+			return proRecord{}, err
+		}
+	}
+
+	return proRecord{
+		PubKey: pubkey,
+		Slot:   int64(slot),
+		Root:   root,
+	}, nil
+}
+
+// deleteDutyUnsafe deletes the duty's records. It is unsafe since it assumes the lock is held.
+func (db *DiskDB) deleteDutyUnsafe(duty core.Duty) {
+	switch duty.Type {
+	case core.DutyProposer, core.DutyBuilderProposer:
+		delete(db.proposals, duty.Slot)
+	case core.DutyAttester:
+		for key, record := range db.atts {
+			if record.DutySlot == duty.Slot {
+				delete(db.atts, key)
+				delete(db.attRoots, record.attKey())
+			}
+		}
+	default:
+	}
+}
+
+// load loads the persisted records from disk, dropping records of already expired duties.
+func (db *DiskDB) load() error {
+	records, err := readRecords(db.path)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records.History {
+		db.history[record.PubKey] = record
+	}
+
+	for _, record := range records.Attestations {
+		if record.Data == nil {
+			return errors.New("invalid dutydb file, missing attestation data", z.Str("path", db.path))
+		}
+		if !db.deadliner.Add(core.NewAttesterDuty(record.DutySlot)) {
+			continue
+		}
+		if err := db.addAttestationUnsafe(record); err != nil {
+			return err
+		}
+	}
+
+	for _, record := range records.Proposals {
+		if !db.deadliner.Add(core.NewProposerDuty(record.Slot)) {
+			continue
+		}
+		db.addProposalUnsafe(record)
+	}
+
+	return nil
+}
+
+// flushUnsafe atomically writes all records to disk. It is unsafe since it assumes the lock is held.
+func (db *DiskDB) flushUnsafe() error {
+	var records diskRecords
+	for _, record := range db.atts {
+		records.Attestations = append(records.Attestations, record)
+	}
+	for _, record := range db.proposals {
+		records.Proposals = append(records.Proposals, record)
+	}
+	for _, record := range db.history {
+		records.History = append(records.History, record)
+	}
+
+	return writeRecords(db.path, records)
+}
+
+// readRecords returns the records read from the provided file or empty records if it doesn't exist.
+func readRecords(path string) (diskRecords, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return diskRecords{}, nil
+	} else if err != nil {
+		return diskRecords{}, errors.Wrap(err, "read dutydb file", z.Str("path", path))
+	}
+
+	var records diskRecords
+	if err := json.Unmarshal(b, &records); err != nil {
+		return diskRecords{}, errors.Wrap(err, "unmarshal dutydb file", z.Str("path", path))
+	}
+
+	return records, nil
+}
+
+// writeRecords atomically writes the records to the provided file.
+func writeRecords(path string, records diskRecords) error {
+	// Sort for deterministic output.
+	sort.Slice(records.Attestations, func(i, j int) bool {
+		return records.Attestations[i].key().less(records.Attestations[j].key())
+	})
+	sort.Slice(records.Proposals, func(i, j int) bool {
+		return records.Proposals[i].Slot < records.Proposals[j].Slot
+	})
+	sort.Slice(records.History, func(i, j int) bool {
+		return records.History[i].PubKey < records.History[j].PubKey
+	})
+
+	b, err := json.Marshal(records)
+	if err != nil {
+		return errors.Wrap(err, "marshal dutydb records")
+	}
+
+	return writeFileAtomic(path, b)
+}
+
+// checkBlock returns an error if a block at the provided slot violates the history.
+func (h historyRecord) checkBlock(slot eth2p0.Slot) error {
+	if h.BlockSlot != nil && slot <= *h.BlockSlot {
+		return errors.New("block slot not higher than slashing protection history",
+			z.U64("slot", uint64(slot)), z.U64("history_slot", uint64(*h.BlockSlot)))
+	}
+
+	return nil
+}
+
+// checkAttestation returns an error if an attestation with the provided
+// source and target epochs violates the history.
+func (h historyRecord) checkAttestation(source, target eth2p0.Epoch) error {
+	if h.SourceEpoch != nil && source < *h.SourceEpoch {
+		return errors.New("attestation source epoch lower than slashing protection history",
+			z.U64("source", uint64(source)), z.U64("history_source", uint64(*h.SourceEpoch)))
+	}
+
+	if h.TargetEpoch != nil && target <= *h.TargetEpoch {
+		return errors.New("attestation target epoch not higher than slashing protection history",
+			z.U64("target", uint64(target)), z.U64("history_target", uint64(*h.TargetEpoch)))
+	}
+
+	return nil
+}
+
+// addBlock updates the history with the block slot if higher.
+func (h *historyRecord) addBlock(slot eth2p0.Slot) {
+	if h.BlockSlot == nil || slot > *h.BlockSlot {
+		h.BlockSlot = &slot
+	}
+}
+
+// addAttestation updates the history with the attestation source and target epochs if higher.
+func (h *historyRecord) addAttestation(source, target eth2p0.Epoch) {
+	if h.SourceEpoch == nil || source > *h.SourceEpoch {
+		h.SourceEpoch = &source
+	}
+	if h.TargetEpoch == nil || target > *h.TargetEpoch {
+		h.TargetEpoch = &target
+	}
+}
+
+// key returns the records's pubkey by attestation key.
+func (r attRecord) key() pkKey {
+	return pkKey{
+		Slot:       r.Slot,
+		CommIdx:    r.CommIdx,
+		ValCommIdx: r.ValCommIdx,
+	}
+}
+
+// attKey returns the records's attestation data key.
+func (r attRecord) attKey() attKey {
+	return attKey{
+		Slot:    r.Slot,
+		CommIdx: r.CommIdx,
+	}
+}
+
+// less returns true if the key sorts before the other key.
+func (k pkKey) less(other pkKey) bool {
+	if k.Slot != other.Slot {
+		return k.Slot < other.Slot
+	}
+	if k.CommIdx != other.CommIdx {
+		return k.CommIdx < other.CommIdx
+	}
+
+	return k.ValCommIdx < other.ValCommIdx
+}
+
+// writeFileAtomic writes the data to a temporary file which is synced
+// and then renamed to the provided path, so the file is never partially written.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "create temp file", z.Str("path", tmp))
+	}
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "write temp file", z.Str("path", tmp))
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "sync temp file", z.Str("path", tmp))
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "close temp file", z.Str("path", tmp))
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrap(err, "rename temp file", z.Str("path", path))
+	}
+
+	return nil
+}
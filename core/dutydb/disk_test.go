@@ -0,0 +1,184 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb_test
+
+import (
+	"context"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/dutydb"
+	"github.com/obolnetwork/charon/eth2util/eip3076"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestDiskDBRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	const slot = int64(123)
+
+	att := testutil.RandomCoreAttestationData(t)
+	att.Duty.Slot = eth2p0.Slot(slot)
+	attPubkey := testutil.RandomCorePubKey(t)
+
+	block := testutil.RandomBellatrixCoreVersionedBeaconBlock()
+	block.Bellatrix.Slot = eth2p0.Slot(slot)
+	blockPubkey := testutil.RandomCorePubKey(t)
+
+	newDB := func(t *testing.T) *dutydb.DiskDB {
+		t.Helper()
+
+		db, err := dutydb.NewDiskDB(dir, dutydb.NewMemDB(new(testDeadliner)), new(testDeadliner))
+		require.NoError(t, err)
+
+		return db
+	}
+
+	db := newDB(t)
+	err := db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{attPubkey: att})
+	require.NoError(t, err)
+	err = db.Store(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{blockPubkey: block})
+	require.NoError(t, err)
+
+	// Restart, identical data may be stored again and is served from memory.
+	db = newDB(t)
+	err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{attPubkey: att})
+	require.NoError(t, err)
+	err = db.Store(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{blockPubkey: block})
+	require.NoError(t, err)
+
+	resp, err := db.AwaitAttestation(ctx, int64(att.Data.Slot), int64(att.Data.Index))
+	require.NoError(t, err)
+	require.Equal(t, att.Data.String(), resp.String())
+
+	// Restart, clashing data is refused.
+	db = newDB(t)
+
+	clashAtt := att
+	clashAtt.Data.BeaconBlockRoot = testutil.RandomRoot()
+	err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{attPubkey: clashAtt})
+	require.ErrorContains(t, err, "clashing persisted attestation data")
+
+	otherAtt := att
+	otherAtt.Duty.ValidatorCommitteeIndex++
+	otherAtt.Data.BeaconBlockRoot = testutil.RandomRoot()
+	err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{testutil.RandomCorePubKey(t): otherAtt})
+	require.ErrorContains(t, err, "clashing persisted attestation data")
+
+	clashBlock := testutil.RandomBellatrixCoreVersionedBeaconBlock()
+	clashBlock.Bellatrix.Slot = eth2p0.Slot(slot)
+	err = db.Store(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{blockPubkey: clashBlock})
+	require.ErrorContains(t, err, "clashing persisted blocks")
+
+	blinded := testutil.RandomBellatrixVersionedBlindedBeaconBlock()
+	blinded.Bellatrix.Slot = eth2p0.Slot(slot)
+	err = db.Store(ctx, core.NewBuilderProposerDuty(slot), core.UnsignedDataSet{blockPubkey: blinded})
+	require.ErrorContains(t, err, "clashing persisted blocks")
+}
+
+func TestDiskDBExpiry(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	const slot = int64(123)
+
+	att := testutil.RandomCoreAttestationData(t)
+	att.Duty.Slot = eth2p0.Slot(slot)
+
+	deadliner := &testDeadliner{ch: make(chan core.Duty, 10)}
+	db, err := dutydb.NewDiskDB(dir, dutydb.NewMemDB(new(testDeadliner)), deadliner)
+	require.NoError(t, err)
+
+	err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{testutil.RandomCorePubKey(t): att})
+	require.NoError(t, err)
+
+	// Expire attestation
+	deadliner.expire()
+
+	// Store another duty which deletes expired duties
+	err = db.Store(ctx, core.NewProposerDuty(slot+1), core.UnsignedDataSet{
+		testutil.RandomCorePubKey(t): testutil.RandomBellatrixCoreVersionedBeaconBlock(),
+	})
+	require.NoError(t, err)
+
+	// Restart, different attestation data can be stored since previous was pruned.
+	db, err = dutydb.NewDiskDB(dir, dutydb.NewMemDB(new(testDeadliner)), new(testDeadliner))
+	require.NoError(t, err)
+
+	att.Data.BeaconBlockRoot = testutil.RandomRoot()
+	err = db.Store(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{testutil.RandomCorePubKey(t): att})
+	require.NoError(t, err)
+}
+
+func TestDiskDBInterchange(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	pubkey := testutil.RandomCorePubKey(t)
+	eth2Pubkey, err := pubkey.ToETH2()
+	require.NoError(t, err)
+
+	// Import history
+	err = dutydb.ImportInterchange(dir, eip3076.Interchange{
+		Metadata: eip3076.Metadata{InterchangeFormatVersion: eip3076.FormatVersion},
+		Data: []eip3076.Validator{{
+			Pubkey:             eth2Pubkey,
+			SignedBlocks:       []eip3076.SignedBlock{{Slot: 100}, {Slot: 99}},
+			SignedAttestations: []eip3076.SignedAttestation{{SourceEpoch: 9, TargetEpoch: 10}},
+		}},
+	})
+	require.NoError(t, err)
+
+	db, err := dutydb.NewDiskDB(dir, dutydb.NewMemDB(new(testDeadliner)), new(testDeadliner))
+	require.NoError(t, err)
+
+	newAtt := func(source, target eth2p0.Epoch) core.AttestationData {
+		att := testutil.RandomCoreAttestationData(t)
+		att.Data.Source.Epoch = source
+		att.Data.Target.Epoch = target
+
+		return att
+	}
+	newBlock := func(slot eth2p0.Slot) core.VersionedBeaconBlock {
+		block := testutil.RandomBellatrixCoreVersionedBeaconBlock()
+		block.Bellatrix.Slot = slot
+
+		return block
+	}
+
+	// Violating history is refused
+	err = db.Store(ctx, core.NewAttesterDuty(1), core.UnsignedDataSet{pubkey: newAtt(9, 10)})
+	require.ErrorContains(t, err, "attestation target epoch not higher than slashing protection history")
+	err = db.Store(ctx, core.NewAttesterDuty(1), core.UnsignedDataSet{pubkey: newAtt(8, 11)})
+	require.ErrorContains(t, err, "attestation source epoch lower than slashing protection history")
+	err = db.Store(ctx, core.NewProposerDuty(100), core.UnsignedDataSet{pubkey: newBlock(100)})
+	require.ErrorContains(t, err, "block slot not higher than slashing protection history")
+
+	// Other validators are not affected
+	err = db.Store(ctx, core.NewAttesterDuty(1), core.UnsignedDataSet{testutil.RandomCorePubKey(t): newAtt(9, 10)})
+	require.NoError(t, err)
+
+	// Valid data is stored and added to history
+	err = db.Store(ctx, core.NewAttesterDuty(2), core.UnsignedDataSet{pubkey: newAtt(10, 12)})
+	require.NoError(t, err)
+	err = db.Store(ctx, core.NewProposerDuty(101), core.UnsignedDataSet{pubkey: newBlock(101)})
+	require.NoError(t, err)
+
+	interchange, err := dutydb.ExportInterchange(dir, eth2p0.Root{1}, []core.PubKey{pubkey})
+	require.NoError(t, err)
+	require.Equal(t, eip3076.Interchange{
+		Metadata: eip3076.Metadata{
+			InterchangeFormatVersion: eip3076.FormatVersion,
+			GenesisValidatorsRoot:    eth2p0.Root{1},
+		},
+		Data: []eip3076.Validator{{
+			Pubkey:             eth2Pubkey,
+			SignedBlocks:       []eip3076.SignedBlock{{Slot: 101}},
+			SignedAttestations: []eip3076.SignedAttestation{{SourceEpoch: 10, TargetEpoch: 12}},
+		}},
+	}, interchange)
+}
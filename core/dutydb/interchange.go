@@ -0,0 +1,106 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package dutydb
+
+import (
+	"os"
+	"path/filepath"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/eip3076"
+)
+
+// ImportInterchange merges the EIP-3076 slashing protection interchange into the validator slashing
+// protection history persisted in the data directory. The DiskDB refuses to store attestation data or
+// blocks that violate this history. It may not be called while a DiskDB is using the data directory.
+func ImportInterchange(dataDir string, interchange eip3076.Interchange) error {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return errors.Wrap(err, "create data dir", z.Str("dir", dataDir))
+	}
+
+	path := filepath.Join(dataDir, diskFilename)
+
+	records, err := readRecords(path)
+	if err != nil {
+		return err
+	}
+
+	history := make(map[core.PubKey]historyRecord)
+	for _, record := range records.History {
+		history[record.PubKey] = record
+	}
+
+	for _, val := range interchange.Data {
+		pubkey := core.PubKeyFrom48Bytes(val.Pubkey)
+
+		record := history[pubkey]
+		record.PubKey = pubkey
+
+		if slot, ok := val.MaxBlockSlot(); ok {
+			record.addBlock(slot)
+		}
+
+		if source, target, ok := val.MaxAttestationEpochs(); ok {
+			record.addAttestation(source, target)
+		}
+
+		history[pubkey] = record
+	}
+
+	records.History = nil
+	for _, record := range history {
+		records.History = append(records.History, record)
+	}
+
+	return writeRecords(path, records)
+}
+
+// ExportInterchange returns the validator slashing protection history persisted in the data directory
+// as minimal EIP-3076 slashing protection interchange for the provided validators. It contains at most a single
+// block and attestation per validator: the highest block slot and attestation source and target epochs.
+func ExportInterchange(dataDir string, genesisValidatorsRoot eth2p0.Root, pubkeys []core.PubKey) (eip3076.Interchange, error) {
+	records, err := readRecords(filepath.Join(dataDir, diskFilename))
+	if err != nil {
+		return eip3076.Interchange{}, err
+	}
+
+	history := make(map[core.PubKey]historyRecord)
+	for _, record := range records.History {
+		history[record.PubKey] = record
+	}
+
+	resp := eip3076.Interchange{
+		Metadata: eip3076.Metadata{
+			InterchangeFormatVersion: eip3076.FormatVersion,
+			GenesisValidatorsRoot:    genesisValidatorsRoot,
+		},
+	}
+
+	for _, pubkey := range pubkeys {
+		eth2Pubkey, err := pubkey.ToETH2()
+		if err != nil {
+			return eip3076.Interchange{}, err
+		}
+
+		val := eip3076.Validator{Pubkey: eth2Pubkey}
+
+		record := history[pubkey]
+		if record.BlockSlot != nil {
+			val.SignedBlocks = append(val.SignedBlocks, eip3076.SignedBlock{Slot: *record.BlockSlot})
+		}
+		if record.SourceEpoch != nil && record.TargetEpoch != nil {
+			val.SignedAttestations = append(val.SignedAttestations, eip3076.SignedAttestation{
+				SourceEpoch: *record.SourceEpoch,
+				TargetEpoch: *record.TargetEpoch,
+			})
+		}
+
+		resp.Data = append(resp.Data, val)
+	}
+
+	return resp, nil
+}
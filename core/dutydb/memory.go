@@ -6,8 +6,6 @@ import (
 	"context"
 	"sync"
 
-	eth2api "github.com/attestantio/go-eth2-client/api"
-	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
@@ -22,8 +20,8 @@ func NewMemDB(deadliner core.Deadliner) *MemDB {
 		attDuties:         make(map[attKey]*eth2p0.AttestationData),
 		attPubKeys:        make(map[pkKey]core.PubKey),
 		attKeysBySlot:     make(map[int64][]pkKey),
-		builderProDuties:  make(map[int64]*eth2api.VersionedBlindedBeaconBlock),
-		proDuties:         make(map[int64]*eth2spec.VersionedBeaconBlock),
+		builderProDuties:  make(map[int64]*core.VersionedBlindedBeaconBlock),
+		proDuties:         make(map[int64]*core.VersionedBeaconBlock),
 		aggDuties:         make(map[aggKey]core.AggregatedAttestation),
 		aggKeysBySlot:     make(map[int64][]aggKey),
 		contribDuties:     make(map[contribKey]*altair.SyncCommitteeContribution),
@@ -45,11 +43,11 @@ type MemDB struct {
 	attQueries    []attQuery
 
 	// DutyBuilderProposer
-	builderProDuties  map[int64]*eth2api.VersionedBlindedBeaconBlock
+	builderProDuties  map[int64]*core.VersionedBlindedBeaconBlock
 	builderProQueries []builderProQuery
 
 	// DutyProposer
-	proDuties  map[int64]*eth2spec.VersionedBeaconBlock
+	proDuties  map[int64]*core.VersionedBeaconBlock
 	proQueries []proQuery
 
 	// DutyAggregator
@@ -156,10 +154,10 @@ func (db *MemDB) Store(_ context.Context, duty core.Duty, unsignedSet core.Unsig
 }
 
 // AwaitBeaconBlock implements core.DutyDB, see its godoc.
-func (db *MemDB) AwaitBeaconBlock(ctx context.Context, slot int64) (*eth2spec.VersionedBeaconBlock, error) {
+func (db *MemDB) AwaitBeaconBlock(ctx context.Context, slot int64) (*core.VersionedBeaconBlock, error) {
 	cancel := make(chan struct{})
 	defer close(cancel)
-	response := make(chan *eth2spec.VersionedBeaconBlock, 1)
+	response := make(chan *core.VersionedBeaconBlock, 1)
 
 	db.mu.Lock()
 	db.proQueries = append(db.proQueries, proQuery{
@@ -181,10 +179,10 @@ func (db *MemDB) AwaitBeaconBlock(ctx context.Context, slot int64) (*eth2spec.Ve
 }
 
 // AwaitBlindedBeaconBlock implements core.DutyDB, see its godoc.
-func (db *MemDB) AwaitBlindedBeaconBlock(ctx context.Context, slot int64) (*eth2api.VersionedBlindedBeaconBlock, error) {
+func (db *MemDB) AwaitBlindedBeaconBlock(ctx context.Context, slot int64) (*core.VersionedBlindedBeaconBlock, error) {
 	cancel := make(chan struct{})
 	defer close(cancel)
-	response := make(chan *eth2api.VersionedBlindedBeaconBlock, 1)
+	response := make(chan *core.VersionedBlindedBeaconBlock, 1)
 
 	db.mu.Lock()
 	db.builderProQueries = append(db.builderProQueries, builderProQuery{
@@ -484,7 +482,7 @@ func (db *MemDB) storeBeaconBlockUnsafe(unsignedData core.UnsignedData) error {
 			return errors.New("clashing blocks")
 		}
 	} else {
-		db.proDuties[int64(slot)] = &block.VersionedBeaconBlock
+		db.proDuties[int64(slot)] = &block
 	}
 
 	return nil
@@ -522,7 +520,7 @@ func (db *MemDB) storeBlindedBeaconBlockUnsafe(unsignedData core.UnsignedData) e
 			return errors.New("clashing blinded blocks")
 		}
 	} else {
-		db.builderProDuties[int64(slot)] = &block.VersionedBlindedBeaconBlock
+		db.builderProDuties[int64(slot)] = &block
 	}
 
 	return nil
@@ -699,7 +697,7 @@ type attQuery struct {
 // proQuery is a waiting proQuery with a response channel.
 type proQuery struct {
 	Key      int64
-	Response chan<- *eth2spec.VersionedBeaconBlock
+	Response chan<- *core.VersionedBeaconBlock
 	Cancel   <-chan struct{}
 }
 
@@ -713,7 +711,7 @@ type aggQuery struct {
 // builderProQuery is a waiting builderProQuery with a response channel.
 type builderProQuery struct {
 	Key      int64
-	Response chan<- *eth2api.VersionedBlindedBeaconBlock
+	Response chan<- *core.VersionedBlindedBeaconBlock
 	Cancel   <-chan struct{}
 }
 
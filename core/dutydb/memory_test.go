@@ -10,7 +10,9 @@ import (
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
@@ -133,7 +135,7 @@ func TestMemDBProposer(t *testing.T) {
 	slots := [queries]int64{123, 456, 789}
 
 	type response struct {
-		block *eth2spec.VersionedBeaconBlock
+		block *core.VersionedBeaconBlock
 	}
 	var awaitResponse [queries]chan response
 	for i := 0; i < queries; i++ {
@@ -172,10 +174,52 @@ func TestMemDBProposer(t *testing.T) {
 	// Get and assert the proQuery responses
 	for i := 0; i < queries; i++ {
 		actualData := <-awaitResponse[i]
-		require.Equal(t, blocks[i], actualData.block)
+		require.Equal(t, blocks[i], &actualData.block.VersionedBeaconBlock)
 	}
 }
 
+func TestMemDBBlockContents(t *testing.T) {
+	ctx := context.Background()
+	db := dutydb.NewMemDB(new(testDeadliner))
+
+	const slot = 123
+
+	block := &eth2spec.VersionedBeaconBlock{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   testutil.RandomDenebBeaconBlock(),
+	}
+	block.Deneb.Slot = slot
+	unsigned, err := core.NewVersionedBeaconBlockContents(block, []*deneb.BlobSidecar{
+		testutil.RandomDenebBlobSidecar(0),
+		testutil.RandomDenebBlobSidecar(1),
+	})
+	require.NoError(t, err)
+
+	blinded := &eth2api.VersionedBlindedBeaconBlock{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   testutil.RandomDenebBlindedBeaconBlock(),
+	}
+	blinded.Deneb.Slot = slot
+	unsignedBlinded, err := core.NewVersionedBlindedBeaconBlockContents(blinded, []*eth2deneb.BlindedBlobSidecar{
+		testutil.RandomDenebBlindedBlobSidecar(0),
+	})
+	require.NoError(t, err)
+
+	pubkey := testutil.RandomCorePubKey(t)
+	err = db.Store(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{pubkey: unsigned})
+	require.NoError(t, err)
+	err = db.Store(ctx, core.NewBuilderProposerDuty(slot), core.UnsignedDataSet{pubkey: unsignedBlinded})
+	require.NoError(t, err)
+
+	resp, err := db.AwaitBeaconBlock(ctx, slot)
+	require.NoError(t, err)
+	require.Equal(t, unsigned, *resp)
+
+	respBlinded, err := db.AwaitBlindedBeaconBlock(ctx, slot)
+	require.NoError(t, err)
+	require.Equal(t, unsignedBlinded, *respBlinded)
+}
+
 func TestMemDBAggregator(t *testing.T) {
 	ctx := context.Background()
 	db := dutydb.NewMemDB(new(testDeadliner))
@@ -381,7 +425,7 @@ func TestMemDBBuilderProposer(t *testing.T) {
 	slots := [queries]int64{123, 456, 789}
 
 	type response struct {
-		block *eth2api.VersionedBlindedBeaconBlock
+		block *core.VersionedBlindedBeaconBlock
 	}
 	var awaitResponse [queries]chan response
 	for i := 0; i < queries; i++ {
@@ -420,7 +464,7 @@ func TestMemDBBuilderProposer(t *testing.T) {
 	// Get and assert the proQuery responses
 	for i := 0; i < queries; i++ {
 		actualData := <-awaitResponse[i]
-		require.Equal(t, blocks[i], actualData.block)
+		require.Equal(t, blocks[i], &actualData.block.VersionedBlindedBeaconBlock)
 	}
 }
 
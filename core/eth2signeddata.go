@@ -7,7 +7,9 @@ import (
 
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/tracer"
 	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/signing"
 	"github.com/obolnetwork/charon/tbls"
@@ -25,21 +27,103 @@ var (
 	_ Eth2SignedData = SignedSyncMessage{}
 	_ Eth2SignedData = SignedSyncContributionAndProof{}
 	_ Eth2SignedData = SyncCommitteeSelection{}
+	_ Eth2SignedData = SignedBlobSidecar{}
+	_ Eth2SignedData = SignedBlindedBlobSidecar{}
 )
 
-// VerifyEth2SignedData verifies signature associated with given Eth2SignedData.
+// VerifyEth2SignedData verifies signature associated with given Eth2SignedData,
+// including the signatures of its sidecars if it is SidecarSignedData.
 func VerifyEth2SignedData(ctx context.Context, eth2Cl eth2wrap.Client, data Eth2SignedData, pubkey tbls.PublicKey) error {
-	epoch, err := data.Epoch(ctx, eth2Cl)
-	if err != nil {
-		return err
+	for _, signed := range withSidecars(data) {
+		epoch, err := signed.Epoch(ctx, eth2Cl)
+		if err != nil {
+			return err
+		}
+
+		sigRoot, err := signed.MessageRoot()
+		if err != nil {
+			return err
+		}
+
+		err = signing.Verify(ctx, eth2Cl, signed.DomainName(), epoch, sigRoot, signed.Signature().ToETH2(), pubkey)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withSidecars returns the Eth2SignedData followed by its sidecars if it is SidecarSignedData.
+func withSidecars(data Eth2SignedData) []Eth2SignedData {
+	resp := []Eth2SignedData{data}
+	if sidecarData, ok := data.(SidecarSignedData); ok {
+		resp = append(resp, sidecarData.Sidecars()...)
+	}
+
+	return resp
+}
+
+// VerifyEth2SignedDataBatch verifies the signatures of the Eth2SignedData by the public keys at the same index
+// using batch verification. It returns the verification error of each Eth2SignedData by index, which is nil if valid.
+// The signatures of the sidecars of SidecarSignedData are verified along with it.
+func VerifyEth2SignedDataBatch(ctx context.Context, eth2Cl eth2wrap.Client, datas []Eth2SignedData, pubkeys []tbls.PublicKey) ([]error, error) {
+	if len(datas) != len(pubkeys) {
+		return nil, errors.New("mismatching signed data and public key lengths")
 	}
 
-	sigRoot, err := data.MessageRoot()
+	resp := make([]error, len(datas))
+
+	var (
+		indexes  []int
+		batchPKs []tbls.PublicKey
+		sigDatas [][]byte
+		sigs     []tbls.Signature
+	)
+	for i, data := range datas {
+		for _, signed := range withSidecars(data) {
+			epoch, err := signed.Epoch(ctx, eth2Cl)
+			if err != nil {
+				return nil, err
+			}
+
+			sigRoot, err := signed.MessageRoot()
+			if err != nil {
+				return nil, err
+			}
+
+			sigData, err := signing.GetDataRoot(ctx, eth2Cl, signed.DomainName(), epoch, sigRoot)
+			if err != nil {
+				return nil, err
+			}
+
+			var zeroSig eth2p0.BLSSignature
+			if signed.Signature().ToETH2() == zeroSig {
+				resp[i] = errors.New("no signature found")
+				continue
+			}
+
+			indexes = append(indexes, i)
+			batchPKs = append(batchPKs, pubkeys[i])
+			sigDatas = append(sigDatas, sigData[:])
+			sigs = append(sigs, tbls.Signature(signed.Signature().ToETH2()))
+		}
+	}
+
+	_, span := tracer.Start(ctx, "tbls.BatchVerifyEach")
+	errs, err := tbls.BatchVerifyEach(batchPKs, sigDatas, sigs)
+	span.End()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return signing.Verify(ctx, eth2Cl, data.DomainName(), epoch, sigRoot, data.Signature().ToETH2(), pubkey)
+	for i, err := range errs {
+		if err != nil && resp[indexes[i]] == nil {
+			resp[indexes[i]] = err
+		}
+	}
+
+	return resp, nil
 }
 
 // Implement Eth2SignedData for VersionedSignedBeaconBlock.
@@ -162,3 +246,23 @@ func (SyncCommitteeSelection) DomainName() signing.DomainName {
 func (s SyncCommitteeSelection) Epoch(ctx context.Context, eth2Cl eth2wrap.Client) (eth2p0.Epoch, error) {
 	return eth2util.EpochFromSlot(ctx, eth2Cl, s.Slot)
 }
+
+// Implement Eth2SignedData for SignedBlobSidecar.
+
+func (SignedBlobSidecar) DomainName() signing.DomainName {
+	return signing.DomainBlobSidecar
+}
+
+func (s SignedBlobSidecar) Epoch(ctx context.Context, eth2Cl eth2wrap.Client) (eth2p0.Epoch, error) {
+	return eth2util.EpochFromSlot(ctx, eth2Cl, s.Message.Slot)
+}
+
+// Implement Eth2SignedData for SignedBlindedBlobSidecar.
+
+func (SignedBlindedBlobSidecar) DomainName() signing.DomainName {
+	return signing.DomainBlobSidecar
+}
+
+func (s SignedBlindedBlobSidecar) Epoch(ctx context.Context, eth2Cl eth2wrap.Client) (eth2p0.Epoch, error) {
+	return eth2util.EpochFromSlot(ctx, eth2Cl, s.Message.Slot)
+}
@@ -4,8 +4,11 @@ package core_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
@@ -111,6 +114,75 @@ func TestVerifyEth2SignedData(t *testing.T) {
 	}
 }
 
+func TestVerifyEth2SignedDataSidecars(t *testing.T) {
+	block := testutil.RandomDenebCoreVersionedSignedBeaconBlock()
+	block.SignedBlobSidecars = []*deneb.SignedBlobSidecar{
+		testutil.RandomDenebSignedBlobSidecar(0),
+		testutil.RandomDenebSignedBlobSidecar(1),
+	}
+
+	blinded := testutil.RandomDenebVersionedSignedBlindedBeaconBlock()
+	blinded.SignedBlindedBlobSidecars = []*eth2deneb.SignedBlindedBlobSidecar{
+		testutil.RandomDenebSignedBlindedBlobSidecar(0),
+	}
+
+	for _, data := range []core.SidecarSignedData{block, blinded} {
+		t.Run(fmt.Sprintf("%T", data), func(t *testing.T) {
+			ctx := context.Background()
+			bmock, err := beaconmock.New()
+			require.NoError(t, err)
+
+			secret, err := tbls.GenerateSecretKey()
+			require.NoError(t, err)
+			pubkey, err := tbls.SecretToPublicKey(secret)
+			require.NoError(t, err)
+
+			signWith := func(data core.Eth2SignedData) core.Signature {
+				epoch, err := data.Epoch(ctx, bmock)
+				require.NoError(t, err)
+
+				root, err := data.MessageRoot()
+				require.NoError(t, err)
+
+				sigData, err := signing.GetDataRoot(ctx, bmock, data.DomainName(), epoch, root)
+				require.NoError(t, err)
+
+				sig, err := tbls.Sign(secret, sigData[:])
+				require.NoError(t, err)
+
+				return tblsconv.SigToCore(sig)
+			}
+
+			var sidecarSigs []core.Signature
+			for _, sidecar := range data.Sidecars() {
+				require.Equal(t, signing.DomainBlobSidecar, sidecar.DomainName())
+				sidecarSigs = append(sidecarSigs, signWith(sidecar))
+			}
+
+			signed, err := data.SetSidecarSignatures(sidecarSigs)
+			require.NoError(t, err)
+			signed, err = signed.SetSignature(signWith(signed.(core.Eth2SignedData)))
+			require.NoError(t, err)
+
+			require.NoError(t, core.VerifyEth2SignedData(ctx, bmock, signed.(core.Eth2SignedData), pubkey))
+
+			// Replace the last sidecar signature with the block signature.
+			sidecarSigs[len(sidecarSigs)-1] = signed.Signature()
+			invalid, err := signed.(core.SidecarSignedData).SetSidecarSignatures(sidecarSigs)
+			require.NoError(t, err)
+
+			require.Error(t, core.VerifyEth2SignedData(ctx, bmock, invalid.(core.Eth2SignedData), pubkey))
+
+			errs, err := core.VerifyEth2SignedDataBatch(ctx, bmock,
+				[]core.Eth2SignedData{signed.(core.Eth2SignedData), invalid.(core.Eth2SignedData)},
+				[]tbls.PublicKey{pubkey, pubkey})
+			require.NoError(t, err)
+			require.NoError(t, errs[0])
+			require.Error(t, errs[1])
+		})
+	}
+}
+
 func sign(t *testing.T, data []byte) (core.Signature, tbls.PublicKey) {
 	t.Helper()
 
@@ -13,6 +13,7 @@ import (
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/app/log"
 	"github.com/obolnetwork/charon/app/version"
 	"github.com/obolnetwork/charon/app/z"
@@ -20,11 +21,13 @@ import (
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 )
 
-// New returns a new fetcher instance.
-func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string) (*Fetcher, error) {
+// New returns a new fetcher instance. A non-zero builder minimum bid enables proposing local blocks
+// for builder proposer duties if the most valuable builder bid is below the minimum.
+func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string, builderMinBid eth2p0.Gwei) (*Fetcher, error) {
 	return &Fetcher{
 		eth2Cl:           eth2Cl,
 		feeRecipientFunc: feeRecipientFunc,
+		builderMinBid:    builderMinBid,
 	}, nil
 }
 
@@ -32,6 +35,7 @@ func New(eth2Cl eth2wrap.Client, feeRecipientFunc func(core.PubKey) string) (*Fe
 type Fetcher struct {
 	eth2Cl           eth2wrap.Client
 	feeRecipientFunc func(core.PubKey) string
+	builderMinBid    eth2p0.Gwei
 	subs             []func(context.Context, core.Duty, core.UnsignedDataSet) error
 	aggSigDBFunc     func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
 	awaitAttDataFunc func(ctx context.Context, slot int64, commIdx int64) (*eth2p0.AttestationData, error)
@@ -128,7 +132,7 @@ func (f *Fetcher) fetchAttesterData(ctx context.Context, slot int64, defSet core
 		eth2AttData, ok := dataByCommIdx[commIdx]
 		if !ok {
 			var err error
-			eth2AttData, err = f.eth2Cl.AttestationData(ctx, eth2p0.Slot(uint64(slot)), commIdx)
+			eth2AttData, err = f.fetchAttestationData(ctx, eth2p0.Slot(uint64(slot)), commIdx)
 			if err != nil {
 				return nil, err
 			} else if eth2AttData == nil {
@@ -147,6 +151,21 @@ func (f *Fetcher) fetchAttesterData(ctx context.Context, slot int64, defSet core
 	return resp, nil
 }
 
+// fetchAttestationData returns the attestation data of the slot and committee index. If featureset.AttestationDataVoting
+// is enabled, it is selected from the attestation data of all beacon nodes, otherwise it is from the first beacon node to respond.
+func (f *Fetcher) fetchAttestationData(ctx context.Context, slot eth2p0.Slot, commIdx eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
+	if !featureset.Enabled(featureset.AttestationDataVoting) {
+		return f.eth2Cl.AttestationData(ctx, slot, commIdx)
+	}
+
+	votes, err := f.eth2Cl.AttestationDataVotes(ctx, slot, commIdx)
+	if err != nil {
+		return nil, err
+	}
+
+	return selectAttestationData(ctx, votes)
+}
+
 // fetchAggregatorData fetches the attestation aggregation data.
 func (f *Fetcher) fetchAggregatorData(ctx context.Context, slot int64, defSet core.DutyDefinitionSet) (core.UnsignedDataSet, error) {
 	// We may have multiple aggregators in the same committee, use the same aggregated attestation in that case.
@@ -236,15 +255,15 @@ func (f *Fetcher) fetchProposerData(ctx context.Context, slot int64, defSet core
 		var graffiti [32]byte
 		commitSHA, _ := version.GitCommit()
 		copy(graffiti[:], fmt.Sprintf("charon/%v-%s", version.Version, commitSHA))
-		block, err := f.eth2Cl.BeaconBlockProposal(ctx, eth2p0.Slot(uint64(slot)), randao, graffiti[:])
+		proposal, err := f.proposeBlock(ctx, eth2p0.Slot(uint64(slot)), randao, graffiti[:], f.feeRecipientFunc(pubkey))
 		if err != nil {
 			return nil, err
 		}
 
 		// Ensure fee recipient is correctly populated in block.
-		verifyFeeRecipient(ctx, block, f.feeRecipientFunc(pubkey))
+		verifyFeeRecipient(ctx, proposal.Block, f.feeRecipientFunc(pubkey))
 
-		coreBlock, err := core.NewVersionedBeaconBlock(block)
+		coreBlock, err := core.NewVersionedBeaconBlockContents(proposal.Block, proposal.BlobSidecars)
 		if err != nil {
 			return nil, errors.Wrap(err, "new block")
 		}
@@ -274,17 +293,18 @@ func (f *Fetcher) fetchBuilderProposerData(ctx context.Context, slot int64, defS
 		var graffiti [32]byte
 		commitSHA, _ := version.GitCommit()
 		copy(graffiti[:], fmt.Sprintf("charon/%v-%s", version.Version, commitSHA))
-		block, err := f.eth2Cl.BlindedBeaconBlockProposal(ctx, eth2p0.Slot(uint64(slot)), randao, graffiti[:])
+		proposal, local, err := f.proposeBlindedBlock(ctx, eth2p0.Slot(uint64(slot)), randao, graffiti[:], f.feeRecipientFunc(pubkey))
 		if err != nil {
 			return nil, err
 		}
 
-		verifyFeeRecipientBlindedBlock(ctx, block, f.feeRecipientFunc(pubkey))
+		verifyFeeRecipientBlindedBlock(ctx, proposal.Block, f.feeRecipientFunc(pubkey))
 
-		coreBlock, err := core.NewVersionedBlindedBeaconBlock(block)
+		coreBlock, err := core.NewVersionedBlindedBeaconBlockContents(proposal.Block, proposal.BlindedBlobSidecars)
 		if err != nil {
 			return nil, errors.Wrap(err, "new block")
 		}
+		coreBlock.LocalBlock = local
 
 		resp[pubkey] = coreBlock
 	}
@@ -352,18 +372,7 @@ func (f *Fetcher) fetchContributionData(ctx context.Context, slot int64, defSet
 
 // verifyFeeRecipient logs a warning when fee recipient is not correctly populated in the block.
 func verifyFeeRecipient(ctx context.Context, block *eth2spec.VersionedBeaconBlock, feeRecipientAddress string) {
-	// Note that fee-recipient is not available in forks earlier than bellatrix.
-	var actualAddr string
-
-	switch block.Version {
-	case eth2spec.DataVersionBellatrix:
-		actualAddr = fmt.Sprintf("%#x", block.Bellatrix.Body.ExecutionPayload.FeeRecipient)
-	case eth2spec.DataVersionCapella:
-		actualAddr = fmt.Sprintf("%#x", block.Capella.Body.ExecutionPayload.FeeRecipient)
-	default:
-		return
-	}
-
+	actualAddr := blockFeeRecipient(block)
 	if actualAddr != "" && !strings.EqualFold(actualAddr, feeRecipientAddress) {
 		log.Warn(ctx, "Proposing block with unexpected fee recipient address", nil,
 			z.Str("expected", feeRecipientAddress), z.Str("actual", actualAddr))
@@ -372,20 +381,38 @@ func verifyFeeRecipient(ctx context.Context, block *eth2spec.VersionedBeaconBloc
 
 // verifyFeeRecipientBlindedBlock logs a warning when fee recipient is not correctly populated in the provided blinded beacon block.
 func verifyFeeRecipientBlindedBlock(ctx context.Context, block *eth2api.VersionedBlindedBeaconBlock, feeRecipientAddress string) {
-	// Note that fee-recipient is not available in forks earlier than bellatrix.
-	var actualAddr string
+	actualAddr := blindedBlockFeeRecipient(block)
+	if actualAddr != "" && !strings.EqualFold(actualAddr, feeRecipientAddress) {
+		log.Warn(ctx, "Proposing block with unexpected fee recipient address", nil,
+			z.Str("expected", feeRecipientAddress), z.Str("actual", actualAddr))
+	}
+}
 
+// blockFeeRecipient returns the hex fee recipient address of the block or an empty string if not available.
+// Note that fee-recipient is not available in forks earlier than bellatrix.
+func blockFeeRecipient(block *eth2spec.VersionedBeaconBlock) string {
 	switch block.Version {
 	case eth2spec.DataVersionBellatrix:
-		actualAddr = fmt.Sprintf("%#x", block.Bellatrix.Body.ExecutionPayloadHeader.FeeRecipient)
+		return fmt.Sprintf("%#x", block.Bellatrix.Body.ExecutionPayload.FeeRecipient)
 	case eth2spec.DataVersionCapella:
-		actualAddr = fmt.Sprintf("%#x", block.Capella.Body.ExecutionPayloadHeader.FeeRecipient)
+		return fmt.Sprintf("%#x", block.Capella.Body.ExecutionPayload.FeeRecipient)
+	case eth2spec.DataVersionDeneb:
+		return fmt.Sprintf("%#x", block.Deneb.Body.ExecutionPayload.FeeRecipient)
 	default:
-		return
+		return ""
 	}
+}
 
-	if actualAddr != "" && !strings.EqualFold(actualAddr, feeRecipientAddress) {
-		log.Warn(ctx, "Proposing block with unexpected fee recipient address", nil,
-			z.Str("expected", feeRecipientAddress), z.Str("actual", actualAddr))
+// blindedBlockFeeRecipient returns the hex fee recipient address of the blinded block or an empty string if not available.
+func blindedBlockFeeRecipient(block *eth2api.VersionedBlindedBeaconBlock) string {
+	switch block.Version {
+	case eth2spec.DataVersionBellatrix:
+		return fmt.Sprintf("%#x", block.Bellatrix.Body.ExecutionPayloadHeader.FeeRecipient)
+	case eth2spec.DataVersionCapella:
+		return fmt.Sprintf("%#x", block.Capella.Body.ExecutionPayloadHeader.FeeRecipient)
+	case eth2spec.DataVersionDeneb:
+		return fmt.Sprintf("%#x", block.Deneb.Body.ExecutionPayloadHeader.FeeRecipient)
+	default:
+		return ""
 	}
 }
@@ -7,18 +7,23 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math"
+	"math/big"
 	"testing"
 
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stretchr/testify/require"
 
 	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/featureset"
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/core/fetcher"
+	"github.com/obolnetwork/charon/eth2util"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 	"github.com/obolnetwork/charon/testutil"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
@@ -62,7 +67,7 @@ func TestFetchAttester(t *testing.T) {
 	duty := core.NewAttesterDuty(slot)
 	bmock, err := beaconmock.New()
 	require.NoError(t, err)
-	fetch, err := fetcher.New(bmock, nil)
+	fetch, err := fetcher.New(bmock, nil, 0)
 	require.NoError(t, err)
 
 	fetch.Subscribe(func(ctx context.Context, resDuty core.Duty, resDataSet core.UnsignedDataSet) error {
@@ -86,6 +91,112 @@ func TestFetchAttester(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestFetchAttesterVoting(t *testing.T) {
+	featureset.EnableForT(t, featureset.AttestationDataVoting)
+
+	const slot = 1
+
+	newData := func(sourceEpoch, targetEpoch eth2p0.Epoch) *eth2p0.AttestationData {
+		data := testutil.RandomAttestationData()
+		data.Slot = slot
+		data.Source.Epoch = sourceEpoch
+		data.Source.Root = eth2p0.Root{byte(sourceEpoch)} // Same source checkpoint for the same epoch.
+		data.Target.Epoch = targetEpoch
+
+		return data
+	}
+
+	dataA := newData(10, 11)
+	dataB := newData(10, 11)
+	dataC := newData(11, 12)
+	dataD := newData(9, 10)
+	dataE := newData(11, 13)
+
+	tests := []struct {
+		name     string
+		votes    []*eth2p0.AttestationData
+		expected *eth2p0.AttestationData
+	}{
+		{
+			name:     "single",
+			votes:    []*eth2p0.AttestationData{dataA},
+			expected: dataA,
+		},
+		{
+			name:     "unanimous",
+			votes:    []*eth2p0.AttestationData{dataA, dataA, dataA},
+			expected: dataA,
+		},
+		{
+			name:     "majority",
+			votes:    []*eth2p0.AttestationData{dataC, dataA, dataA},
+			expected: dataA,
+		},
+		{
+			name:     "two beacon nodes, inflated source not corroborated",
+			votes:    []*eth2p0.AttestationData{dataA, dataC},
+			expected: dataA,
+		},
+		{
+			name:     "no sources corroborated, primary",
+			votes:    []*eth2p0.AttestationData{dataD, dataA, dataC},
+			expected: dataD,
+		},
+		{
+			name:     "no majority, highest corroborated justified over most votes",
+			votes:    []*eth2p0.AttestationData{dataA, dataA, dataC, dataE, dataD},
+			expected: dataE,
+		},
+		{
+			name:     "no majority, uncorroborated highest justified ignored",
+			votes:    []*eth2p0.AttestationData{dataD, dataA, dataA, dataC},
+			expected: dataA,
+		},
+		{
+			name:     "no majority, most votes",
+			votes:    []*eth2p0.AttestationData{dataA, dataB, dataB, dataD},
+			expected: dataB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			duty := testutil.RandomAttestationDuty(t)
+			duty.Slot = slot
+			pubkey := testutil.RandomCorePubKey(t)
+
+			bmock, err := beaconmock.New()
+			require.NoError(t, err)
+			bmock.AttestationDataVotesFunc = func(context.Context, eth2p0.Slot, eth2p0.CommitteeIndex) ([]eth2wrap.AttestationDataVote, error) {
+				var resp []eth2wrap.AttestationDataVote
+				for i, data := range test.votes {
+					resp = append(resp, eth2wrap.AttestationDataVote{Address: fmt.Sprint(i), Data: data})
+				}
+
+				return resp, nil
+			}
+
+			fetch, err := fetcher.New(bmock, nil, 0)
+			require.NoError(t, err)
+
+			var called bool
+			fetch.Subscribe(func(ctx context.Context, _ core.Duty, resDataSet core.UnsignedDataSet) error {
+				called = true
+				require.Len(t, resDataSet, 1)
+				require.Equal(t, *test.expected, resDataSet[pubkey].(core.AttestationData).Data)
+
+				return nil
+			})
+
+			err = fetch.Fetch(context.Background(), core.NewAttesterDuty(slot), core.DutyDefinitionSet{
+				pubkey: core.NewAttesterDefinition(duty),
+			})
+			require.NoError(t, err)
+			require.True(t, called)
+		})
+	}
+}
+
 func TestFetchAggregator(t *testing.T) {
 	ctx := context.Background()
 
@@ -157,7 +268,7 @@ func TestFetchAggregator(t *testing.T) {
 		return nil, errors.New("expected unknown root")
 	}
 
-	fetch, err := fetcher.New(bmock, nil)
+	fetch, err := fetcher.New(bmock, nil, 0)
 	require.NoError(t, err)
 
 	fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -278,7 +389,7 @@ func TestFetchBlocks(t *testing.T) {
 		duty := core.NewProposerDuty(slot)
 		fetch, err := fetcher.New(bmock, func(core.PubKey) string {
 			return feeRecipientAddr
-		})
+		}, 0)
 		require.NoError(t, err)
 
 		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -314,7 +425,7 @@ func TestFetchBlocks(t *testing.T) {
 		duty := core.NewBuilderProposerDuty(slot)
 		fetch, err := fetcher.New(bmock, func(core.PubKey) string {
 			return feeRecipientAddr
-		})
+		}, 0)
 		require.NoError(t, err)
 
 		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -345,6 +456,187 @@ func TestFetchBlocks(t *testing.T) {
 		err = fetch.Fetch(ctx, duty, defSet)
 		require.NoError(t, err)
 	})
+
+	denebMock, err := beaconmock.New(beaconmock.WithDenebBlocks())
+	require.NoError(t, err)
+
+	t.Run("fetch deneb DutyProposer", func(t *testing.T) {
+		duty := core.NewProposerDuty(slot)
+		fetch, err := fetcher.New(denebMock, func(core.PubKey) string {
+			return feeRecipientAddr
+		}, 0)
+		require.NoError(t, err)
+
+		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
+			return randaoByPubKey[key], nil
+		})
+
+		fetch.Subscribe(func(ctx context.Context, resDuty core.Duty, resDataSet core.UnsignedDataSet) error {
+			require.Equal(t, duty, resDuty)
+			require.Len(t, resDataSet, 2)
+
+			for pubkey, data := range resDataSet {
+				block := data.(core.VersionedBeaconBlock)
+				require.Equal(t, eth2spec.DataVersionDeneb, block.Version)
+				require.EqualValues(t, slot, block.Deneb.Slot)
+				require.NotEmpty(t, block.Deneb.Body.BlobKzgCommitments)
+				require.Len(t, block.BlobSidecars, len(block.Deneb.Body.BlobKzgCommitments))
+				require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", block.Deneb.Body.ExecutionPayload.FeeRecipient))
+				assertRandao(t, randaoByPubKey[pubkey].Signature().ToETH2(), block)
+			}
+
+			return nil
+		})
+
+		err = fetch.Fetch(ctx, duty, defSet)
+		require.NoError(t, err)
+	})
+
+	t.Run("fetch deneb DutyBuilderProposer", func(t *testing.T) {
+		duty := core.NewBuilderProposerDuty(slot)
+		fetch, err := fetcher.New(denebMock, func(core.PubKey) string {
+			return feeRecipientAddr
+		}, 0)
+		require.NoError(t, err)
+
+		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
+			return randaoByPubKey[key], nil
+		})
+
+		fetch.Subscribe(func(ctx context.Context, resDuty core.Duty, resDataSet core.UnsignedDataSet) error {
+			require.Equal(t, duty, resDuty)
+			require.Len(t, resDataSet, 2)
+
+			for pubkey, data := range resDataSet {
+				block := data.(core.VersionedBlindedBeaconBlock)
+				require.Equal(t, eth2spec.DataVersionDeneb, block.Version)
+				require.EqualValues(t, slot, block.Deneb.Slot)
+				require.Len(t, block.BlindedBlobSidecars, len(block.Deneb.Body.BlobKzgCommitments))
+				require.Equal(t, feeRecipientAddr, fmt.Sprintf("%#x", block.Deneb.Body.ExecutionPayloadHeader.FeeRecipient))
+				assertRandaoBlindedBlock(t, randaoByPubKey[pubkey].Signature().ToETH2(), block)
+			}
+
+			return nil
+		})
+
+		err = fetch.Fetch(ctx, duty, defSet)
+		require.NoError(t, err)
+	})
+}
+
+func TestFetchBlocksValueSelection(t *testing.T) {
+	featureset.EnableForT(t, featureset.BlockValueSelection)
+
+	ctx := context.Background()
+
+	const slot = 1
+
+	var feeRecipient bellatrix.ExecutionAddress
+	feeRecipient[0] = 1
+	pubkey := testutil.RandomCorePubKey(t)
+	randao := testutil.RandomCoreSignature()
+	defSet := core.DutyDefinitionSet{
+		pubkey: core.NewProposerDefinition(&eth2v1.ProposerDuty{Slot: slot}),
+	}
+
+	newBlock := func(feeRecipient bellatrix.ExecutionAddress) *eth2spec.VersionedBeaconBlock {
+		block := testutil.RandomCapellaBeaconBlock()
+		block.Slot = slot
+		block.Body.RANDAOReveal = randao.Signature().ToETH2()
+		block.Body.ExecutionPayload.FeeRecipient = feeRecipient
+
+		return &eth2spec.VersionedBeaconBlock{Version: eth2spec.DataVersionCapella, Capella: block}
+	}
+
+	gwei := func(val int64) eth2wrap.BlockValues {
+		return eth2wrap.BlockValues{Execution: big.NewInt(val * 1e9)}
+	}
+
+	invalid := newBlock(bellatrix.ExecutionAddress{})
+	unknown := newBlock(feeRecipient)
+	valuable := newBlock(feeRecipient)
+	cheap := newBlock(feeRecipient)
+
+	newFetcher := func(t *testing.T, bmock beaconmock.Mock, minBid eth2p0.Gwei) *fetcher.Fetcher {
+		t.Helper()
+
+		bmock.BeaconBlockProposalsFunc = func(context.Context, eth2p0.Slot, eth2p0.BLSSignature, []byte) ([]eth2wrap.BeaconBlockProposal, error) {
+			return []eth2wrap.BeaconBlockProposal{
+				{Address: "invalid", Block: invalid, Values: gwei(100)},
+				{Address: "unknown", Block: unknown},
+				{Address: "valuable", Block: valuable, Values: gwei(10)},
+				{Address: "cheap", Block: cheap, Values: gwei(5)},
+			}, nil
+		}
+
+		fetch, err := fetcher.New(bmock, func(core.PubKey) string {
+			return fmt.Sprintf("%#x", feeRecipient)
+		}, minBid)
+		require.NoError(t, err)
+
+		fetch.RegisterAggSigDB(func(context.Context, core.Duty, core.PubKey) (core.SignedData, error) {
+			return randao, nil
+		})
+
+		return fetch
+	}
+
+	t.Run("proposer", func(t *testing.T) {
+		bmock, err := beaconmock.New()
+		require.NoError(t, err)
+
+		fetch := newFetcher(t, bmock, 0)
+		fetch.Subscribe(func(ctx context.Context, _ core.Duty, resDataSet core.UnsignedDataSet) error {
+			require.Equal(t, *valuable, resDataSet[pubkey].(core.VersionedBeaconBlock).VersionedBeaconBlock)
+			return nil
+		})
+
+		err = fetch.Fetch(ctx, core.NewProposerDuty(slot), defSet)
+		require.NoError(t, err)
+	})
+
+	builderBid, err := eth2util.BlindBlock(newBlock(feeRecipient))
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		name          string
+		minBid        eth2p0.Gwei
+		localFallback bool
+	}{
+		{name: "builder above min bid", minBid: 20},
+		{name: "builder below min bid", minBid: 21, localFallback: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			bmock, err := beaconmock.New()
+			require.NoError(t, err)
+			bmock.BlindedBeaconBlockProposalsFunc = func(context.Context, eth2p0.Slot, eth2p0.BLSSignature, []byte) ([]eth2wrap.BlindedBeaconBlockProposal, error) {
+				return []eth2wrap.BlindedBeaconBlockProposal{{Address: "builder", Block: builderBid, Values: gwei(20)}}, nil
+			}
+
+			fetch := newFetcher(t, bmock, test.minBid)
+			fetch.Subscribe(func(ctx context.Context, _ core.Duty, resDataSet core.UnsignedDataSet) error {
+				blinded := resDataSet[pubkey].(core.VersionedBlindedBeaconBlock)
+				if test.localFallback {
+					require.NotNil(t, blinded.LocalBlock)
+					require.Equal(t, *valuable, blinded.LocalBlock.VersionedBeaconBlock)
+
+					root, err := blinded.Root()
+					require.NoError(t, err)
+					localRoot, err := blinded.LocalBlock.Root()
+					require.NoError(t, err)
+					require.Equal(t, localRoot, root)
+				} else {
+					require.Nil(t, blinded.LocalBlock)
+					require.Equal(t, *builderBid, blinded.VersionedBlindedBeaconBlock)
+				}
+
+				return nil
+			})
+
+			err = fetch.Fetch(ctx, core.NewBuilderProposerDuty(slot), defSet)
+			require.NoError(t, err)
+		})
+	}
 }
 
 func TestFetchSyncContribution(t *testing.T) {
@@ -449,7 +741,7 @@ func TestFetchSyncContribution(t *testing.T) {
 		}
 
 		// Construct fetcher component.
-		fetch, err := fetcher.New(bmock, nil)
+		fetch, err := fetcher.New(bmock, nil, 0)
 		require.NoError(t, err)
 
 		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -510,7 +802,7 @@ func TestFetchSyncContribution(t *testing.T) {
 		require.NoError(t, err)
 
 		// Construct fetcher component.
-		fetch, err := fetcher.New(bmock, nil)
+		fetch, err := fetcher.New(bmock, nil, 0)
 		require.NoError(t, err)
 
 		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -540,7 +832,7 @@ func TestFetchSyncContribution(t *testing.T) {
 		require.NoError(t, err)
 
 		// Construct fetcher component.
-		fetch, err := fetcher.New(bmock, nil)
+		fetch, err := fetcher.New(bmock, nil, 0)
 		require.NoError(t, err)
 
 		fetch.RegisterAggSigDB(func(ctx context.Context, duty core.Duty, key core.PubKey) (core.SignedData, error) {
@@ -566,6 +858,8 @@ func assertRandao(t *testing.T, randao eth2p0.BLSSignature, block core.Versioned
 		require.EqualValues(t, randao, block.Bellatrix.Body.RANDAOReveal)
 	case eth2spec.DataVersionCapella:
 		require.EqualValues(t, randao, block.Capella.Body.RANDAOReveal)
+	case eth2spec.DataVersionDeneb:
+		require.EqualValues(t, randao, block.Deneb.Body.RANDAOReveal)
 	default:
 		require.Fail(t, "invalid block")
 	}
@@ -579,6 +873,8 @@ func assertRandaoBlindedBlock(t *testing.T, randao eth2p0.BLSSignature, block co
 		require.EqualValues(t, randao, block.Bellatrix.Body.RANDAOReveal)
 	case eth2spec.DataVersionCapella:
 		require.EqualValues(t, randao, block.Capella.Body.RANDAOReveal)
+	case eth2spec.DataVersionDeneb:
+		require.EqualValues(t, randao, block.Deneb.Body.RANDAOReveal)
 	default:
 		require.Fail(t, "invalid block")
 	}
@@ -0,0 +1,32 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fetcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/obolnetwork/charon/app/promauto"
+)
+
+var (
+	disagreementCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "fetcher",
+		Name:      "attestation_data_disagreements_total",
+		Help:      "Total number of times beacon nodes disagreed on attestation data by field (head, source or target).",
+	}, []string{"field"})
+
+	proposalSelectionCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "fetcher",
+		Name:      "proposal_selection_total",
+		Help:      "Total number of selected block proposals by duty and selection (highest_value, unknown_value or local_fallback).",
+	}, []string{"duty", "selection"})
+
+	proposalValueGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "core",
+		Subsystem: "fetcher",
+		Name:      "proposal_value_gwei",
+		Help:      "Value in gwei of the last selected block proposal by duty as reported by the beacon node.",
+	}, []string{"duty"})
+)
@@ -0,0 +1,286 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fetcher
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/featureset"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+)
+
+const (
+	// Block proposal selections.
+	selectionHighestValue  = "highest_value"  // Most valuable valid proposal.
+	selectionUnknownValue  = "unknown_value"  // No valid proposal reported its value, so the first is selected.
+	selectionLocalFallback = "local_fallback" // Most valuable builder bid below the minimum, so a local block is selected.
+
+	weiPerGwei = 1e9
+)
+
+// localProposal is a local block proposal as a blinded block proposal.
+type localProposal struct {
+	Blinded eth2wrap.BlindedBeaconBlockProposal
+	Block   core.VersionedBeaconBlock
+	Value   *big.Int
+	Err     error
+}
+
+// candidate is a block proposal candidate for selection.
+type candidate struct {
+	Address string
+	Value   *big.Int // Total value in wei, nil if not reported.
+	Valid   bool
+}
+
+// proposeBlock returns the beacon block proposal to propose, including its blob sidecars from deneb.
+// If featureset.BlockValueSelection is enabled, it is the most valuable valid block proposal of all beacon nodes,
+// otherwise it is from the first beacon node to respond.
+func (f *Fetcher) proposeBlock(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte, feeRecipient string,
+) (eth2wrap.BeaconBlockProposal, error) {
+	if !featureset.Enabled(featureset.BlockValueSelection) {
+		return f.eth2Cl.BlockContentsProposal(ctx, slot, randao, graffiti)
+	}
+
+	proposal, value, selection, err := f.selectBlock(ctx, slot, randao, graffiti, feeRecipient)
+	if err != nil {
+		return eth2wrap.BeaconBlockProposal{}, err
+	}
+
+	instrumentProposal(core.DutyProposer, selection, value)
+
+	return proposal, nil
+}
+
+// proposeBlindedBlock returns the blinded beacon block to propose. If featureset.BlockValueSelection is enabled or a
+// builder minimum bid is configured, it is the most valuable valid blinded block proposal of all beacon nodes, otherwise
+// it is from the first beacon node to respond. If the most valuable blinded block is below the builder minimum bid,
+// the most valuable local block is proposed as a blinded block instead and also returned, otherwise the returned local block is nil.
+// The proposal includes its blinded blob sidecars from deneb.
+func (f *Fetcher) proposeBlindedBlock(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte, feeRecipient string,
+) (eth2wrap.BlindedBeaconBlockProposal, *core.VersionedBeaconBlock, error) {
+	if !featureset.Enabled(featureset.BlockValueSelection) && f.builderMinBid == 0 {
+		proposal, err := f.eth2Cl.BlindedBlockContentsProposal(ctx, slot, randao, graffiti)
+		return proposal, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Fetch the local block concurrently with the builder bids, so it is available without delay if the bids are below the minimum.
+	var localCh chan localProposal
+	if f.builderMinBid > 0 {
+		localCh = make(chan localProposal, 1)
+		go func() {
+			localCh <- f.localBlindedBlock(ctx, slot, randao, graffiti, feeRecipient)
+		}()
+	}
+
+	proposals, err := f.eth2Cl.BlindedBeaconBlockProposals(ctx, slot, randao, graffiti)
+	if err != nil {
+		return eth2wrap.BlindedBeaconBlockProposal{}, nil, err
+	} else if len(proposals) == 0 {
+		return eth2wrap.BlindedBeaconBlockProposal{}, nil, errors.New("no blinded block proposals")
+	}
+
+	var candidates []candidate
+	for _, proposal := range proposals {
+		candidates = append(candidates, candidate{
+			Address: proposal.Address,
+			Value:   proposal.Values.Total(),
+			Valid:   validBlindedBlock(proposal.Block, slot, randao, feeRecipient),
+		})
+	}
+
+	idx, selection := selectProposal(candidates)
+	value := candidates[idx].Value
+
+	minBid := new(big.Int).Mul(new(big.Int).SetUint64(uint64(f.builderMinBid)), big.NewInt(weiPerGwei))
+	if f.builderMinBid > 0 && value != nil && value.Cmp(minBid) < 0 {
+		var local localProposal
+		select {
+		case <-ctx.Done():
+			return eth2wrap.BlindedBeaconBlockProposal{}, nil, ctx.Err()
+		case local = <-localCh:
+		}
+
+		if local.Err == nil {
+			log.Info(ctx, "Proposing local block since builder bid is below minimum",
+				z.Str("bid_wei", value.String()), z.Str("min_bid_wei", minBid.String()))
+			instrumentProposal(core.DutyBuilderProposer, selectionLocalFallback, local.Value)
+
+			return local.Blinded, &local.Block, nil
+		}
+
+		log.Warn(ctx, "Failed proposing local block for builder bid below minimum, proposing builder block", local.Err)
+	}
+
+	instrumentProposal(core.DutyBuilderProposer, selection, value)
+
+	return proposals[idx], nil, nil
+}
+
+// localBlindedBlock returns the most valuable valid local block proposal as a blinded block proposal
+// along with the local block including its blob sidecars, so the signed blinded block can be unblinded.
+func (f *Fetcher) localBlindedBlock(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte, feeRecipient string,
+) localProposal {
+	proposal, value, _, err := f.selectBlock(ctx, slot, randao, graffiti, feeRecipient)
+	if err != nil {
+		return localProposal{Err: err}
+	}
+
+	blinded, err := eth2util.BlindBlock(proposal.Block)
+	if err != nil {
+		return localProposal{Err: err}
+	}
+
+	blindedSidecars, err := eth2util.BlindBlobSidecars(proposal.BlobSidecars)
+	if err != nil {
+		return localProposal{Err: err}
+	}
+
+	local, err := core.NewVersionedBeaconBlockContents(proposal.Block, proposal.BlobSidecars)
+	if err != nil {
+		return localProposal{Err: err}
+	}
+
+	return localProposal{
+		Blinded: eth2wrap.BlindedBeaconBlockProposal{
+			Address:             proposal.Address,
+			Block:               blinded,
+			BlindedBlobSidecars: blindedSidecars,
+		},
+		Block: local,
+		Value: value,
+	}
+}
+
+// selectBlock returns the most valuable valid beacon block proposal of all beacon nodes, its value and the selection.
+func (f *Fetcher) selectBlock(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte, feeRecipient string,
+) (eth2wrap.BeaconBlockProposal, *big.Int, string, error) {
+	proposals, err := f.eth2Cl.BeaconBlockProposals(ctx, slot, randao, graffiti)
+	if err != nil {
+		return eth2wrap.BeaconBlockProposal{}, nil, "", err
+	} else if len(proposals) == 0 {
+		return eth2wrap.BeaconBlockProposal{}, nil, "", errors.New("no block proposals")
+	}
+
+	var candidates []candidate
+	for _, proposal := range proposals {
+		candidates = append(candidates, candidate{
+			Address: proposal.Address,
+			Value:   proposal.Values.Total(),
+			Valid:   validBlock(proposal.Block, slot, randao, feeRecipient),
+		})
+	}
+
+	idx, selection := selectProposal(candidates)
+
+	return proposals[idx], candidates[idx].Value, selection, nil
+}
+
+// selectProposal returns the index of the most valuable candidate and the selection. Invalid candidates are only
+// selected if no candidate is valid. Candidates without values are ranked lowest and ties are broken by order.
+func selectProposal(candidates []candidate) (int, string) {
+	var anyValid bool
+	for _, c := range candidates {
+		anyValid = anyValid || c.Valid
+	}
+
+	best := -1
+	for i, c := range candidates {
+		if anyValid && !c.Valid {
+			continue
+		}
+
+		if best < 0 || compareValues(c.Value, candidates[best].Value) > 0 {
+			best = i
+		}
+	}
+
+	if candidates[best].Value == nil {
+		return best, selectionUnknownValue
+	}
+
+	return best, selectionHighestValue
+}
+
+// compareValues compares the values, with nil values being the lowest.
+func compareValues(a, b *big.Int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	default:
+		return a.Cmp(b)
+	}
+}
+
+// validBlock returns true if the block has the expected slot, randao reveal and fee recipient.
+func validBlock(block *eth2spec.VersionedBeaconBlock, slot eth2p0.Slot, randao eth2p0.BLSSignature, feeRecipient string) bool {
+	if blockSlot, err := block.Slot(); err != nil || blockSlot != slot {
+		return false
+	}
+
+	var blockRandao eth2p0.BLSSignature
+	switch block.Version {
+	case eth2spec.DataVersionPhase0:
+		blockRandao = block.Phase0.Body.RANDAOReveal
+	case eth2spec.DataVersionAltair:
+		blockRandao = block.Altair.Body.RANDAOReveal
+	case eth2spec.DataVersionBellatrix:
+		blockRandao = block.Bellatrix.Body.RANDAOReveal
+	case eth2spec.DataVersionCapella:
+		blockRandao = block.Capella.Body.RANDAOReveal
+	case eth2spec.DataVersionDeneb:
+		blockRandao = block.Deneb.Body.RANDAOReveal
+	default:
+		return false
+	}
+
+	return blockRandao == randao && validFeeRecipient(blockFeeRecipient(block), feeRecipient)
+}
+
+// validBlindedBlock returns true if the blinded block has the expected slot, randao reveal and fee recipient.
+func validBlindedBlock(block *eth2api.VersionedBlindedBeaconBlock, slot eth2p0.Slot, randao eth2p0.BLSSignature, feeRecipient string) bool {
+	if blockSlot, err := block.Slot(); err != nil || blockSlot != slot {
+		return false
+	}
+
+	if blockRandao, err := block.RandaoReveal(); err != nil || blockRandao != randao {
+		return false
+	}
+
+	return validFeeRecipient(blindedBlockFeeRecipient(block), feeRecipient)
+}
+
+// validFeeRecipient returns true if the block's fee recipient is the expected fee recipient or not available.
+func validFeeRecipient(actual, expected string) bool {
+	return actual == "" || strings.EqualFold(actual, expected)
+}
+
+// instrumentProposal records the block proposal selection and its value.
+func instrumentProposal(duty core.DutyType, selection string, value *big.Int) {
+	proposalSelectionCounter.WithLabelValues(duty.String(), selection).Inc()
+
+	if value == nil {
+		return
+	}
+
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(value), big.NewFloat(weiPerGwei)).Float64()
+	proposalValueGauge.WithLabelValues(duty.String()).Set(gwei)
+}
@@ -0,0 +1,148 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package fetcher
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// attDataCandidate is a distinct attestation data and the beacon nodes that voted for it.
+type attDataCandidate struct {
+	Root      eth2p0.Root
+	Data      *eth2p0.AttestationData
+	Addresses []string
+}
+
+// selectAttestationData returns the attestation data voted for by the majority of beacon nodes or,
+// if there is no majority, the attestation data with the highest justified (source) checkpoint reported
+// by at least two beacon nodes, followed by the highest target checkpoint and the most votes.
+// Remaining ties are broken by data root. If no source checkpoint is reported by at least two beacon nodes,
+// the attestation data of the primary beacon node, i.e., the first vote, is selected.
+// Disagreements between beacon nodes on head, source or target are logged and counted.
+func selectAttestationData(ctx context.Context, votes []eth2wrap.AttestationDataVote) (*eth2p0.AttestationData, error) {
+	if len(votes) == 0 {
+		return nil, errors.New("no attestation data votes")
+	}
+
+	var (
+		byRoot      = make(map[eth2p0.Root]*attDataCandidate)
+		sourceVotes = make(map[eth2p0.Checkpoint]int)
+		primaryRoot eth2p0.Root
+	)
+	for i, vote := range votes {
+		if vote.Data == nil {
+			return nil, errors.New("attestation data cannot be nil", z.Str("address", vote.Address))
+		}
+
+		root, err := vote.Data.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "hash attestation data")
+		}
+
+		candidate, ok := byRoot[root]
+		if !ok {
+			candidate = &attDataCandidate{Root: root, Data: vote.Data}
+			byRoot[root] = candidate
+		}
+		candidate.Addresses = append(candidate.Addresses, vote.Address)
+
+		if vote.Data.Source != nil {
+			sourceVotes[*vote.Data.Source]++
+		}
+		if i == 0 {
+			primaryRoot = root
+		}
+	}
+
+	var candidates []*attDataCandidate
+	for _, candidate := range byRoot {
+		candidates = append(candidates, candidate)
+	}
+
+	// Prefer the highest source epoch over the most votes since the source must match the justified
+	// checkpoint of the including block's state. A beacon node with a lower justified checkpoint
+	// hasn't processed the latest justification yet, so its attestation data is stale and
+	// will not be included once the chain justified the higher checkpoint.
+	// Only sources corroborated by another beacon node are preferred, so a single faulty beacon node
+	// reporting an inflated source cannot decide the vote.
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		if ci.Data.Source.Epoch != cj.Data.Source.Epoch {
+			return ci.Data.Source.Epoch > cj.Data.Source.Epoch
+		}
+		if ci.Data.Target.Epoch != cj.Data.Target.Epoch {
+			return ci.Data.Target.Epoch > cj.Data.Target.Epoch
+		}
+		if len(ci.Addresses) != len(cj.Addresses) {
+			return len(ci.Addresses) > len(cj.Addresses)
+		}
+
+		return bytes.Compare(ci.Root[:], cj.Root[:]) < 0
+	})
+
+	selected := byRoot[primaryRoot]
+	for _, candidate := range candidates {
+		if sourceVotes[*candidate.Data.Source] > 1 {
+			selected = candidate
+			break
+		}
+	}
+	for _, candidate := range candidates {
+		if len(candidate.Addresses)*2 > len(votes) {
+			selected = candidate
+			break
+		}
+	}
+
+	if len(candidates) > 1 {
+		logDisagreement(ctx, selected, candidates)
+	}
+
+	return selected.Data, nil
+}
+
+// logDisagreement logs and counts the fields of the attestation data that the beacon nodes disagree on.
+func logDisagreement(ctx context.Context, selected *attDataCandidate, candidates []*attDataCandidate) {
+	var (
+		heads   = make(map[eth2p0.Root]bool)
+		sources = make(map[eth2p0.Checkpoint]bool)
+		targets = make(map[eth2p0.Checkpoint]bool)
+		others  []string
+	)
+	for _, candidate := range candidates {
+		heads[candidate.Data.BeaconBlockRoot] = true
+		sources[*candidate.Data.Source] = true
+		targets[*candidate.Data.Target] = true
+
+		if candidate != selected {
+			others = append(others, candidate.Addresses...)
+		}
+	}
+
+	var fields []string
+	for field, distinct := range map[string]int{"head": len(heads), "source": len(sources), "target": len(targets)} {
+		if distinct > 1 {
+			fields = append(fields, field)
+			disagreementCounter.WithLabelValues(field).Inc()
+		}
+	}
+	sort.Strings(fields)
+
+	log.Warn(ctx, "Beacon nodes disagree on attestation data", nil,
+		z.Any("fields", fields),
+		z.Any("selected_nodes", selected.Addresses),
+		z.Any("other_nodes", others),
+		z.U64("slot", uint64(selected.Data.Slot)),
+		z.Hex("selected_head", selected.Data.BeaconBlockRoot[:]),
+		z.U64("selected_target_epoch", uint64(selected.Data.Target.Epoch)),
+	)
+}
@@ -6,6 +6,7 @@ package infosync
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 
 	"github.com/libp2p/go-libp2p/core/protocol"
@@ -22,14 +23,20 @@ const (
 	topicVersion  = "version"
 	topicProtocol = "protocol"
 	topicProposal = "proposal"
+	topicLeader   = "leader"
+
+	// leaderDelay is the number of slots after an infosync that its leader order is applied,
+	// ensuring all peers have obtained the result.
+	leaderDelay = 2
 
 	// maxResults limits the number of results to keep.
 	maxResults = 100
 )
 
-// New returns a new infosync component.
+// New returns a new infosync component. The optional peerRanking function returns the local ranking of
+// peer indexes (best first) to agree upon a cluster wide leader order of the peers, see LeaderOrder.
 func New(prioritiser *priority.Component, versions []version.SemVer, protocols []protocol.ID,
-	proposals []core.ProposalType,
+	proposals []core.ProposalType, peers int, peerRanking func() []int64,
 ) *Component {
 	// Add a mock alpha protocol if alpha features enabled in order to test infosync in prod.
 	// TODO(corver): Remove this once we have an actual use case.
@@ -42,6 +49,7 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 		versions:    versions,
 		protocols:   protocols,
 		proposals:   proposals,
+		peerRanking: peerRanking,
 	}
 
 	prioritiser.Subscribe(func(ctx context.Context, duty core.Duty, results []priority.TopicResult) error {
@@ -60,6 +68,10 @@ func New(prioritiser *priority.Component, versions []version.SemVer, protocols [
 					res.proposals = append(res.proposals, core.ProposalType(prio))
 				}
 			}
+
+			if result.Topic == topicLeader {
+				res.leaders = leadersFromResult(ctx, result, peers)
+			}
 		}
 
 		log.Debug(ctx, "Infosync completed", fields...)
@@ -79,6 +91,7 @@ type Component struct {
 	versions    []version.SemVer
 	protocols   []protocol.ID
 	proposals   []core.ProposalType
+	peerRanking func() []int64
 
 	mu      sync.Mutex
 	results []result
@@ -122,6 +135,24 @@ func (c *Component) Proposals(slot int64) []core.ProposalType {
 	return resp
 }
 
+// LeaderOrder returns the latest cluster wide agreed upon peer order (best first) for leader election of duties at the slot.
+// Results are only applied leaderDelay slots after the infosync slot. It returns nil if no results are available.
+func (c *Component) LeaderOrder(slot int64) []int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resp []int64
+	for _, result := range c.results {
+		if result.slot+leaderDelay > slot {
+			break
+		}
+
+		resp = result.leaders
+	}
+
+	return resp
+}
+
 // addResult adds the result to the results if it is different from the last result.
 func (c *Component) addResult(result result) {
 	c.mu.Lock()
@@ -141,19 +172,29 @@ func (c *Component) addResult(result result) {
 }
 
 func (c *Component) Trigger(ctx context.Context, slot int64) error {
-	return c.prioritiser.Prioritise(ctx, core.NewInfoSyncDuty(slot),
-		priority.TopicProposal{
+	proposals := []priority.TopicProposal{
+		{
 			Topic:      topicVersion,
 			Priorities: versionsToStrings(c.versions),
 		},
-		priority.TopicProposal{
+		{
 			Topic:      topicProtocol,
 			Priorities: protocolsToStrings(c.protocols),
 		},
-		priority.TopicProposal{
+		{
 			Topic:      topicProposal,
 			Priorities: proposalsToStrings(c.proposals),
+		},
+	}
+
+	if c.peerRanking != nil {
+		proposals = append(proposals, priority.TopicProposal{
+			Topic:      topicLeader,
+			Priorities: indexesToStrings(c.peerRanking()),
 		})
+	}
+
+	return c.prioritiser.Prioritise(ctx, core.NewInfoSyncDuty(slot), proposals...)
 }
 
 // versionsToStrings returns the versions as strings.
@@ -186,12 +227,48 @@ func proposalsToStrings(proposals []core.ProposalType) []string {
 	return resp
 }
 
+// leadersFromResult returns the leader order of the topic result or nil if not all peers proposed it.
+// This ensures the leader order is only used if all peers support it, so leader election is consistent cluster wide.
+func leadersFromResult(ctx context.Context, result priority.TopicResult, peers int) []int64 {
+	if len(result.Priorities) != peers {
+		return nil
+	}
+
+	var resp []int64
+	for _, prio := range result.Priorities {
+		if !prio.ProposedByAll(peers) {
+			log.Debug(ctx, "Ignoring infosync leader order not proposed by all peers")
+			return nil
+		}
+
+		idx, err := strconv.ParseInt(prio.Priority, 10, 64)
+		if err != nil {
+			log.Warn(ctx, "Invalid infosync leader priority", err)
+			return nil
+		}
+		resp = append(resp, idx)
+	}
+
+	return resp
+}
+
+// indexesToStrings returns the peer indexes as strings.
+func indexesToStrings(indexes []int64) []string {
+	var resp []string
+	for _, idx := range indexes {
+		resp = append(resp, strconv.FormatInt(idx, 10))
+	}
+
+	return resp
+}
+
 // result is a cluster-wide agreed-upon infosync result.
 type result struct {
 	slot      int64
 	versions  []string
 	protocols []protocol.ID
 	proposals []core.ProposalType
+	leaders   []int64
 }
 
 // Equal returns true if the results are equal.
@@ -199,5 +276,6 @@ func (x result) Equal(y result) bool {
 	return x.slot == y.slot &&
 		fmt.Sprint(x.versions) == fmt.Sprint(y.versions) &&
 		fmt.Sprint(x.protocols) == fmt.Sprint(y.protocols) &&
-		fmt.Sprint(x.proposals) == fmt.Sprint(y.proposals)
+		fmt.Sprint(x.proposals) == fmt.Sprint(y.proposals) &&
+		fmt.Sprint(x.leaders) == fmt.Sprint(y.leaders)
 }
@@ -5,8 +5,6 @@ package core
 import (
 	"context"
 
-	eth2api "github.com/attestantio/go-eth2-client/api"
-	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 )
@@ -44,13 +42,13 @@ type DutyDB interface {
 	// Store stores the unsigned duty data set.
 	Store(context.Context, Duty, UnsignedDataSet) error
 
-	// AwaitBeaconBlock blocks and returns the proposed beacon block
+	// AwaitBeaconBlock blocks and returns the proposed beacon block, including its blob sidecars from deneb,
 	// for the slot when available.
-	AwaitBeaconBlock(ctx context.Context, slot int64) (*eth2spec.VersionedBeaconBlock, error)
+	AwaitBeaconBlock(ctx context.Context, slot int64) (*VersionedBeaconBlock, error)
 
-	// AwaitBlindedBeaconBlock blocks and returns the proposed blinded beacon block
+	// AwaitBlindedBeaconBlock blocks and returns the proposed blinded beacon block, including its blob sidecars from deneb,
 	// for the slot when available.
-	AwaitBlindedBeaconBlock(ctx context.Context, slot int64) (*eth2api.VersionedBlindedBeaconBlock, error)
+	AwaitBlindedBeaconBlock(ctx context.Context, slot int64) (*VersionedBlindedBeaconBlock, error)
 
 	// AwaitAttestation blocks and returns the attestation data
 	// for the slot and committee index when available.
@@ -85,10 +83,10 @@ type Consensus interface {
 // ValidatorAPI provides a beacon node API to validator clients. It serves duty data from the DutyDB and stores partial signed data in the ParSigDB.
 type ValidatorAPI interface {
 	// RegisterAwaitBeaconBlock registers a function to query unsigned beacon block by slot.
-	RegisterAwaitBeaconBlock(func(ctx context.Context, slot int64) (*eth2spec.VersionedBeaconBlock, error))
+	RegisterAwaitBeaconBlock(func(ctx context.Context, slot int64) (*VersionedBeaconBlock, error))
 
 	// RegisterAwaitBlindedBeaconBlock registers a function to query unsigned blinded beacon block by slot.
-	RegisterAwaitBlindedBeaconBlock(func(ctx context.Context, slot int64) (*eth2api.VersionedBlindedBeaconBlock, error))
+	RegisterAwaitBlindedBeaconBlock(func(ctx context.Context, slot int64) (*VersionedBlindedBeaconBlock, error))
 
 	// RegisterAwaitAttestation registers a function to query attestation data.
 	RegisterAwaitAttestation(func(ctx context.Context, slot, commIdx int64) (*eth2p0.AttestationData, error))
@@ -158,6 +156,27 @@ type AggSigDB interface {
 	Await(context.Context, Duty, PubKey) (SignedData, error)
 }
 
+// EmbeddedSigner signs duties with this node's validator key shares, replacing the validator client.
+type EmbeddedSigner interface {
+	// SignDuty signs the data a validator client provides when a duty is scheduled, e.g. randao reveals.
+	SignDuty(context.Context, Duty, DutyDefinitionSet) error
+
+	// SignSlot signs the data a validator client provides every slot, e.g. sync committee messages.
+	SignSlot(context.Context, Slot) error
+
+	// Sign signs the unsigned data set stored in the DutyDB.
+	Sign(context.Context, Duty, UnsignedDataSet) error
+
+	// RegisterGetDutyDefinition registers a function to query duty definitions.
+	RegisterGetDutyDefinition(func(context.Context, Duty) (DutyDefinitionSet, error))
+
+	// RegisterAwaitAggSigDB registers a function to query aggregated signed data from aggSigDB.
+	RegisterAwaitAggSigDB(func(context.Context, Duty, PubKey) (SignedData, error))
+
+	// Subscribe registers a function to store partially signed data sets.
+	Subscribe(func(context.Context, Duty, ParSignedDataSet) error)
+}
+
 // Broadcaster broadcasts aggregated signed duty data set to the beacon node.
 type Broadcaster interface {
 	Broadcast(context.Context, Duty, SignedDataSet) error
@@ -217,16 +236,16 @@ type wireFuncs struct {
 	ConsensusPropose                    func(context.Context, Duty, UnsignedDataSet) error
 	ConsensusSubscribe                  func(func(context.Context, Duty, UnsignedDataSet) error)
 	DutyDBStore                         func(context.Context, Duty, UnsignedDataSet) error
-	DutyDBAwaitBeaconBlock              func(ctx context.Context, slot int64) (*eth2spec.VersionedBeaconBlock, error)
-	DutyDBAwaitBlindedBeaconBlock       func(ctx context.Context, slot int64) (*eth2api.VersionedBlindedBeaconBlock, error)
+	DutyDBAwaitBeaconBlock              func(ctx context.Context, slot int64) (*VersionedBeaconBlock, error)
+	DutyDBAwaitBlindedBeaconBlock       func(ctx context.Context, slot int64) (*VersionedBlindedBeaconBlock, error)
 	DutyDBAwaitAttestation              func(ctx context.Context, slot, commIdx int64) (*eth2p0.AttestationData, error)
 	DutyDBPubKeyByAttestation           func(ctx context.Context, slot, commIdx, valCommIdx int64) (PubKey, error)
 	DutyDBAwaitAggAttestation           func(ctx context.Context, slot int64, attestationRoot eth2p0.Root) (*eth2p0.Attestation, error)
 	DutyDBAwaitSyncContribution         func(ctx context.Context, slot, subcommIdx int64, beaconBlockRoot eth2p0.Root) (*altair.SyncCommitteeContribution, error)
 	VAPIRegisterAwaitAttestation        func(func(ctx context.Context, slot, commIdx int64) (*eth2p0.AttestationData, error))
 	VAPIRegisterAwaitSyncContribution   func(func(ctx context.Context, slot, subcommIdx int64, beaconBlockRoot eth2p0.Root) (*altair.SyncCommitteeContribution, error))
-	VAPIRegisterAwaitBeaconBlock        func(func(ctx context.Context, slot int64) (*eth2spec.VersionedBeaconBlock, error))
-	VAPIRegisterAwaitBlindedBeaconBlock func(func(ctx context.Context, slot int64) (*eth2api.VersionedBlindedBeaconBlock, error))
+	VAPIRegisterAwaitBeaconBlock        func(func(ctx context.Context, slot int64) (*VersionedBeaconBlock, error))
+	VAPIRegisterAwaitBlindedBeaconBlock func(func(ctx context.Context, slot int64) (*VersionedBlindedBeaconBlock, error))
 	VAPIRegisterGetDutyDefinition       func(func(context.Context, Duty) (DutyDefinitionSet, error))
 	VAPIRegisterPubKeyByAttestation     func(func(ctx context.Context, slot, commIdx, valCommIdx int64) (PubKey, error))
 	VAPIRegisterAwaitAggAttestation     func(func(ctx context.Context, slot int64, attestationRoot eth2p0.Root) (*eth2p0.Attestation, error))
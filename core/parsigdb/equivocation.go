@@ -0,0 +1,121 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package parsigdb
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// EvidenceFilename is the name of the file in the data directory containing partial signature equivocation evidence.
+const EvidenceFilename = "parsig_equivocations.jsonl"
+
+// Option configures a MemDB.
+type Option func(*MemDB)
+
+// WithEvidenceFile returns an option that appends the evidence of each detected equivocation
+// as a JSON line to the file at the provided path.
+func WithEvidenceFile(path string) Option {
+	return func(db *MemDB) {
+		db.evidencePath = path
+	}
+}
+
+// Equivocation is the evidence of conflicting partially signed data for the same duty, validator and share index.
+// Both messages contain a partial signature by the share's private key, so they prove that the operator
+// of the share signed conflicting data, independently of which peer sent them.
+type Equivocation struct {
+	Timestamp time.Time   `json:"timestamp"`
+	Duty      string      `json:"duty"`
+	PubKey    core.PubKey `json:"pubkey"`
+	ShareIdx  int         `json:"share_idx"`
+	// Sender is the peer ID of the peer that sent the conflicting message, or of the share's peer if it was relayed.
+	Sender string `json:"sender"`
+	// Messages are the protojson encoded ParSigExMsgs of the previously stored and the conflicting partially signed data.
+	Messages [2]json.RawMessage `json:"messages"`
+}
+
+// newEquivocation returns the equivocation evidence of the conflicting partially signed data.
+func newEquivocation(k key, existing, conflicting core.ParSignedData, sender peer.ID) (Equivocation, error) {
+	resp := Equivocation{
+		Timestamp: time.Now(),
+		Duty:      k.Duty.String(),
+		PubKey:    k.PubKey,
+		ShareIdx:  existing.ShareIdx,
+		Sender:    sender.String(),
+	}
+
+	for i, data := range []core.ParSignedData{existing, conflicting} {
+		set, err := core.ParSignedDataSetToProto(core.ParSignedDataSet{k.PubKey: data})
+		if err != nil {
+			return Equivocation{}, err
+		}
+
+		b, err := protojson.Marshal(&pbv1.ParSigExMsg{
+			Duty:    core.DutyToProto(k.Duty),
+			DataSet: set,
+		})
+		if err != nil {
+			return Equivocation{}, errors.Wrap(err, "marshal parsigex msg")
+		}
+
+		resp.Messages[i] = b
+	}
+
+	return resp, nil
+}
+
+// reportEquivocation records the equivocation in metrics and logs, and appends it to the evidence file if configured.
+func (db *MemDB) reportEquivocation(ctx context.Context, sender peer.ID, equivocation Equivocation) {
+	equivocationCounter.WithLabelValues(strconv.Itoa(equivocation.ShareIdx)).Inc()
+
+	log.Error(ctx, "Equivocating partial signed data received, excluding share from duty", nil,
+		z.Any("pubkey", equivocation.PubKey),
+		z.Int("share_idx", equivocation.ShareIdx),
+		z.Str("sender", p2p.PeerName(sender)))
+
+	if db.evidencePath == "" {
+		return
+	}
+
+	if err := appendEvidence(db.evidencePath, equivocation); err != nil {
+		log.Error(ctx, "Failed to write equivocation evidence", err, z.Str("path", db.evidencePath))
+	}
+}
+
+// appendEvidence appends the equivocation as a JSON line to the file.
+func appendEvidence(path string, equivocation Equivocation) error {
+	b, err := json.Marshal(equivocation)
+	if err != nil {
+		return errors.Wrap(err, "marshal equivocation")
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return errors.Wrap(err, "open evidence file")
+	}
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, "write evidence file")
+	}
+
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "close evidence file")
+	}
+
+	return nil
+}
@@ -15,13 +15,21 @@ import (
 )
 
 // NewMemDB returns a new in-memory partial signature database instance.
-func NewMemDB(threshold int, deadliner core.Deadliner) *MemDB {
-	return &MemDB{
+func NewMemDB(threshold int, deadliner core.Deadliner, opts ...Option) *MemDB {
+	db := &MemDB{
 		entries:    make(map[key][]core.ParSignedData),
 		keysByDuty: make(map[core.Duty][]key),
+		excluded:   make(map[core.Duty]map[int]bool),
+		retries:    make(map[key]bool),
 		threshold:  threshold,
 		deadliner:  deadliner,
 	}
+
+	for _, opt := range opts {
+		opt(db)
+	}
+
+	return db
 }
 
 // MemDB is a placeholder in-memory partial signature database.
@@ -31,10 +39,13 @@ type MemDB struct {
 	internalSubs []func(context.Context, core.Duty, core.ParSignedDataSet) error
 	threshSubs   []func(context.Context, core.Duty, map[core.PubKey][]core.ParSignedData) error
 
-	entries    map[key][]core.ParSignedData
-	keysByDuty map[core.Duty][]key
-	threshold  int
-	deadliner  core.Deadliner
+	entries      map[key][]core.ParSignedData
+	keysByDuty   map[core.Duty][]key
+	excluded     map[core.Duty]map[int]bool // Share indexes excluded from threshold matching due to equivocation.
+	retries      map[key]bool               // Keys with failed threshold subscribers retried on subsequent partial signed data.
+	threshold    int
+	deadliner    core.Deadliner
+	evidencePath string
 }
 
 // SubscribeInternal registers a callback when an internal
@@ -82,10 +93,21 @@ func (db *MemDB) StoreExternal(ctx context.Context, duty core.Duty, signedSet co
 
 	output := make(map[core.PubKey][]core.ParSignedData)
 
+	sender, external := core.ParSigSender(ctx)
+
 	for pubkey, sig := range signedSet {
-		sigs, ok, err := db.store(key{Duty: duty, PubKey: pubkey}, sig)
+		sigs, ok, equivocation, err := db.store(key{Duty: duty, PubKey: pubkey}, sig, external)
 		if err != nil {
 			return err
+		} else if equivocation != nil {
+			evidence, err := newEquivocation(key{Duty: duty, PubKey: pubkey}, *equivocation, sig, sender)
+			if err != nil {
+				return err
+			}
+
+			db.reportEquivocation(ctx, sender, evidence)
+
+			continue
 		} else if !ok {
 			log.Debug(ctx, "Partial signed data ignored since duplicate")
 
@@ -97,7 +119,7 @@ func (db *MemDB) StoreExternal(ctx context.Context, duty core.Duty, signedSet co
 			z.Any("pubkey", pubkey))
 
 		// Check if sufficient matching partial signed data has been received.
-		psigs, ok, err := getThresholdMatching(duty.Type, sigs, db.threshold)
+		psigs, ok, err := getThresholdMatching(duty.Type, sigs, db.threshold, db.isRetry(key{Duty: duty, PubKey: pubkey}))
 		if err != nil {
 			return err
 		} else if !ok {
@@ -115,13 +137,40 @@ func (db *MemDB) StoreExternal(ctx context.Context, duty core.Duty, signedSet co
 	for _, sub := range db.threshSubs {
 		// Clone before calling each subscriber.
 		if err := sub(ctx, duty, clone(output)); err != nil {
+			// Retry with all matching partial signed data when subsequent partial signed data is received,
+			// since aggregation may succeed with other partial signatures.
+			db.setRetries(duty, output, true)
 			return err
 		}
 	}
 
+	db.setRetries(duty, output, false)
+
 	return nil
 }
 
+// isRetry returns true if the threshold subscribers failed for the key.
+func (db *MemDB) isRetry(k key) bool {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.retries[k]
+}
+
+// setRetries marks or unmarks the keys of the DVs in the output for retry.
+func (db *MemDB) setRetries(duty core.Duty, output map[core.PubKey][]core.ParSignedData, retry bool) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for pubkey := range output {
+		if retry {
+			db.retries[key{Duty: duty, PubKey: pubkey}] = true
+		} else {
+			delete(db.retries, key{Duty: duty, PubKey: pubkey})
+		}
+	}
+}
+
 // Trim blocks until the context is closed, it deletes state for expired duties.
 // It should only be called once.
 func (db *MemDB) Trim(ctx context.Context) {
@@ -133,16 +182,20 @@ func (db *MemDB) Trim(ctx context.Context) {
 			db.mu.Lock()
 			for _, key := range db.keysByDuty[duty] {
 				delete(db.entries, key)
+				delete(db.retries, key)
 			}
 			delete(db.keysByDuty, duty)
+			delete(db.excluded, duty)
 			db.mu.Unlock()
 		}
 	}
 }
 
 // store returns true if the value was added to the list of signatures at the provided key
-// and returns a copy of the resulting list.
-func (db *MemDB) store(k key, value core.ParSignedData) ([]core.ParSignedData, bool, error) {
+// and returns a copy of the resulting list excluding the signatures of excluded share indexes.
+// If an external value mismatches the stored value of the same share index, the share index is
+// excluded for the rest of the duty and the stored value is returned as equivocation.
+func (db *MemDB) store(k key, value core.ParSignedData, external bool) ([]core.ParSignedData, bool, *core.ParSignedData, error) {
 	db.mu.Lock()
 	defer db.mu.Unlock()
 
@@ -150,20 +203,27 @@ func (db *MemDB) store(k key, value core.ParSignedData) ([]core.ParSignedData, b
 		if s.ShareIdx == value.ShareIdx {
 			equal, err := parSignedDataEqual(s, value)
 			if err != nil {
-				return nil, false, err
+				return nil, false, nil, err
+			} else if !equal && external {
+				if db.excluded[k.Duty] == nil {
+					db.excluded[k.Duty] = make(map[int]bool)
+				}
+				db.excluded[k.Duty][s.ShareIdx] = true
+
+				return nil, false, &s, nil
 			} else if !equal {
-				return nil, false, errors.New("mismatching partial signed data",
+				return nil, false, nil, errors.New("mismatching partial signed data",
 					z.Any("pubkey", k.PubKey), z.Int("share_idx", s.ShareIdx))
 			}
 
-			return nil, false, nil
+			return nil, false, nil, nil
 		}
 	}
 
 	// Clone before storing.
 	clone, err := value.Clone()
 	if err != nil {
-		return nil, false, err
+		return nil, false, nil, err
 	}
 
 	db.entries[k] = append(db.entries[k], clone)
@@ -173,7 +233,15 @@ func (db *MemDB) store(k key, value core.ParSignedData) ([]core.ParSignedData, b
 		exitCounter.WithLabelValues(k.PubKey.String()).Inc()
 	}
 
-	return append([]core.ParSignedData(nil), db.entries[k]...), true, nil
+	var resp []core.ParSignedData
+	for _, s := range db.entries[k] {
+		if db.excluded[k.Duty][s.ShareIdx] {
+			continue
+		}
+		resp = append(resp, s)
+	}
+
+	return resp, true, nil, nil
 }
 
 // clone returns a deep copy of the provided map.
@@ -195,13 +263,23 @@ func clone(output map[core.PubKey][]core.ParSignedData) map[core.PubKey][]core.P
 }
 
 // getThresholdMatching returns true and threshold number of partial signed data with identical data or false.
-func getThresholdMatching(typ core.DutyType, sigs []core.ParSignedData, threshold int) ([]core.ParSignedData, bool, error) {
+// If retry is true, it returns true and all partial signed data with identical data if at least threshold.
+func getThresholdMatching(typ core.DutyType, sigs []core.ParSignedData, threshold int, retry bool) ([]core.ParSignedData, bool, error) {
 	if len(sigs) < threshold {
 		return nil, false, nil
 	}
+
+	matches := func(n int) bool {
+		if retry {
+			return n >= threshold
+		}
+
+		return n == threshold
+	}
+
 	if typ == core.DutySignature {
 		// Signatures do not support message roots.
-		return sigs, len(sigs) == threshold, nil
+		return sigs, matches(len(sigs)), nil
 	}
 
 	sigsByMsgRoot := make(map[[32]byte][]core.ParSignedData)
@@ -216,7 +294,7 @@ func getThresholdMatching(typ core.DutyType, sigs []core.ParSignedData, threshol
 
 	// Return true if we have "threshold" number of signatures.
 	for _, set := range sigsByMsgRoot {
-		if len(set) == threshold {
+		if matches(len(set)) {
 			return set, true, nil
 		}
 	}
@@ -4,14 +4,21 @@ package parsigdb
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/cluster"
 	"github.com/obolnetwork/charon/core"
+	pbv1 "github.com/obolnetwork/charon/core/corepb/v1"
 	"github.com/obolnetwork/charon/eth2util/eth2exp"
 	"github.com/obolnetwork/charon/testutil"
 )
@@ -92,7 +99,7 @@ func TestGetThresholdMatching(t *testing.T) {
 
 					th := cluster.Threshold(n)
 
-					out, ok, err := getThresholdMatching(1, datas, th)
+					out, ok, err := getThresholdMatching(1, datas, th, false)
 					require.NoError(t, err)
 					require.Equal(t, len(out) == th, ok)
 
@@ -149,6 +156,118 @@ func TestMemDBThreshold(t *testing.T) {
 	require.Equal(t, 2, timesCalled)
 }
 
+func TestMemDBThresholdRetry(t *testing.T) {
+	const th = 3
+
+	db := NewMemDB(th, newTestDeadliner())
+
+	var (
+		calls     [][]int
+		failFirst = true
+		duty      = core.NewAttesterDuty(123)
+		pubkey    = testutil.RandomCorePubKey(t)
+		att       = testutil.RandomAttestation()
+		store     = func(shareIdx int) error {
+			return db.StoreExternal(context.Background(), duty, core.ParSignedDataSet{
+				pubkey: core.NewPartialAttestation(att, shareIdx),
+			})
+		}
+	)
+	db.SubscribeThreshold(func(_ context.Context, _ core.Duty, set map[core.PubKey][]core.ParSignedData) error {
+		var shares []int
+		for _, sig := range set[pubkey] {
+			shares = append(shares, sig.ShareIdx)
+		}
+		calls = append(calls, shares)
+
+		if failFirst {
+			failFirst = false
+			return errors.New("aggregation failed")
+		}
+
+		return nil
+	})
+
+	require.NoError(t, store(1))
+	require.NoError(t, store(2))
+	require.ErrorContains(t, store(3), "aggregation failed")
+	require.Equal(t, [][]int{{1, 2, 3}}, calls)
+
+	// Failed threshold subscribers are retried with all matching partial signed data.
+	require.NoError(t, store(4))
+	require.Equal(t, [][]int{{1, 2, 3}, {1, 2, 3, 4}}, calls)
+
+	// Successful threshold subscribers are not called again.
+	require.NoError(t, store(5))
+	require.Len(t, calls, 2)
+}
+
+func TestMemDBEquivocation(t *testing.T) {
+	const th = 3
+
+	evidencePath := filepath.Join(t.TempDir(), EvidenceFilename)
+	db := NewMemDB(th, newTestDeadliner(), WithEvidenceFile(evidencePath))
+
+	var thresholdShares []int
+	db.SubscribeThreshold(func(_ context.Context, _ core.Duty, set map[core.PubKey][]core.ParSignedData) error {
+		for _, sigs := range set {
+			for _, sig := range sigs {
+				thresholdShares = append(thresholdShares, sig.ShareIdx)
+			}
+		}
+
+		return nil
+	})
+
+	duty := core.NewAttesterDuty(123)
+	pubkey := testutil.RandomCorePubKey(t)
+	att := testutil.RandomAttestation()
+	conflicting := testutil.RandomAttestation()
+
+	sender := peer.ID("sender")
+	ctx := core.WithParSigSender(context.Background(), sender)
+
+	store := func(ctx context.Context, att *eth2p0.Attestation, shareIdx int) error {
+		return db.StoreExternal(ctx, duty, core.ParSignedDataSet{
+			pubkey: core.NewPartialAttestation(att, shareIdx),
+		})
+	}
+
+	require.NoError(t, store(ctx, att, 1))
+
+	// Internally stored mismatching data is still refused.
+	require.ErrorContains(t, store(context.Background(), conflicting, 1), "mismatching partial signed data")
+
+	// Externally received mismatching data is recorded as equivocation and excludes the share.
+	require.NoError(t, store(ctx, conflicting, 1))
+	require.NoError(t, store(ctx, att, 2))
+	require.NoError(t, store(ctx, att, 3))
+	require.Empty(t, thresholdShares)
+
+	require.NoError(t, store(ctx, att, 4))
+	require.Equal(t, []int{2, 3, 4}, thresholdShares)
+
+	b, err := os.ReadFile(evidencePath)
+	require.NoError(t, err)
+
+	var evidence Equivocation
+	require.NoError(t, json.Unmarshal(b, &evidence))
+	require.Equal(t, duty.String(), evidence.Duty)
+	require.Equal(t, pubkey, evidence.PubKey)
+	require.Equal(t, 1, evidence.ShareIdx)
+	require.Equal(t, sender.String(), evidence.Sender)
+
+	for i, expect := range []*eth2p0.Attestation{att, conflicting} {
+		msg := new(pbv1.ParSigExMsg)
+		require.NoError(t, protojson.Unmarshal(evidence.Messages[i], msg))
+		require.Equal(t, duty, core.DutyFromProto(msg.Duty))
+
+		set, err := core.ParSignedDataSetFromProto(duty.Type, msg.DataSet)
+		require.NoError(t, err)
+		require.Equal(t, core.NewPartialAttestation(expect, 1), set[pubkey])
+	}
+}
+
 func newTestDeadliner() *testDeadliner {
 	return &testDeadliner{
 		ch: make(chan core.Duty),
@@ -14,3 +14,10 @@ var exitCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 	Name:      "exit_total",
 	Help:      "Total number of partially signed voluntary exits per public key",
 }, []string{"pubkey"}) // Ok to use pubkey (high cardinality) here since these are very rare
+
+var equivocationCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "core",
+	Subsystem: "parsigdb",
+	Name:      "equivocation_total",
+	Help:      "Total number of equivocating partially signed data received per share index",
+}, []string{"share_idx"})
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"google.golang.org/protobuf/proto"
@@ -28,8 +29,8 @@ func Protocols() []protocol.ID {
 }
 
 func NewParSigEx(tcpNode host.Host, sendFunc p2p.SendFunc, peerIdx int, peers []peer.ID,
-	verifyFunc func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error,
-	gaterFunc core.DutyGaterFunc,
+	verifyFunc func(context.Context, core.Duty, core.ParSignedDataSet) error,
+	gaterFunc core.DutyGaterFunc, opts ...Option,
 ) *ParSigEx {
 	parSigEx := &ParSigEx{
 		tcpNode:    tcpNode,
@@ -40,6 +41,10 @@ func NewParSigEx(tcpNode host.Host, sendFunc p2p.SendFunc, peerIdx int, peers []
 		gaterFunc:  gaterFunc,
 	}
 
+	for _, opt := range opts {
+		opt(parSigEx)
+	}
+
 	newReq := func() proto.Message { return new(pbv1.ParSigExMsg) }
 	p2p.RegisterHandler("parsigex", tcpNode, protocolID2, newReq, parSigEx.handle)
 
@@ -53,12 +58,24 @@ type ParSigEx struct {
 	sendFunc   p2p.SendFunc
 	peerIdx    int
 	peers      []peer.ID
-	verifyFunc func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error
+	verifyFunc func(context.Context, core.Duty, core.ParSignedDataSet) error
 	gaterFunc  core.DutyGaterFunc
 	subs       []func(context.Context, core.Duty, core.ParSignedDataSet) error
+	relay      *relayer // Optional relayer, see WithRelay.
+	relayHops  int
+	// relayCtx is the context of relaying partial signatures, cancelled by Stop.
+	relayCtx    context.Context //nolint:containedctx // Outlives the handler contexts of the relayed partial signatures.
+	relayCancel context.CancelFunc
 }
 
-func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (proto.Message, bool, error) {
+// Stop stops relaying partial signatures, abandoning pending relays.
+func (m *ParSigEx) Stop() {
+	if m.relayCancel != nil {
+		m.relayCancel()
+	}
+}
+
+func (m *ParSigEx) handle(ctx context.Context, pID peer.ID, req proto.Message) (proto.Message, bool, error) {
 	pb, ok := req.(*pbv1.ParSigExMsg)
 	if !ok {
 		return nil, false, errors.New("invalid request type")
@@ -80,16 +97,44 @@ func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (pr
 		return nil, false, errors.Wrap(err, "convert parsigex proto")
 	}
 
+	ctx = core.WithParSigSender(ctx, pID)
 	ctx, span := core.StartDutyTrace(ctx, duty, "core/parsigex.Handle")
 	defer span.End()
 
-	// Verify partial signature
-	for pubkey, data := range set {
-		if err = m.verifyFunc(ctx, duty, pubkey, data); err != nil {
-			return nil, false, errors.Wrap(err, "invalid partial signature")
+	if m.relay != nil {
+		// Only verify partial signatures not received before, the sender acknowledges the others.
+		set = m.relay.Unseen(duty, pID, set)
+		if len(set) == 0 {
+			return nil, false, nil // Ignore duplicate partial signatures.
 		}
 	}
 
+	// Verify partial signatures
+	if err = m.verifyFunc(ctx, duty, set); err != nil {
+		return nil, false, errors.Wrap(err, "invalid partial signature")
+	}
+
+	if m.relay == nil {
+		m.callSubs(ctx, duty, set)
+		return nil, false, nil
+	}
+
+	var toRelay []relayKey
+	set, toRelay = m.relay.Received(duty, pID, set, pb.RelayTtl)
+	if len(toRelay) > 0 {
+		m.relayAsync(log.CopyFields(m.relayCtx, ctx), duty, toRelay, pb.RelayTtl-1)
+	}
+
+	// Attribute relayed partial signatures to the peers of their shares, not to the relaying peer.
+	for origin, originSet := range m.byOrigin(pID, set) {
+		m.callSubs(core.WithParSigSender(ctx, origin), duty, originSet)
+	}
+
+	return nil, false, nil
+}
+
+// callSubs calls the subscribers with the received partially signed duty data set.
+func (m *ParSigEx) callSubs(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) {
 	for _, sub := range m.subs {
 		// TODO(corver): Call this async
 		err := sub(ctx, duty, set)
@@ -97,22 +142,38 @@ func (m *ParSigEx) handle(ctx context.Context, _ peer.ID, req proto.Message) (pr
 			log.Error(ctx, "Subscribe error", err)
 		}
 	}
+}
 
-	return nil, false, nil
+// byOrigin returns the partially signed duty data set split by the peers of the shares, defaulting to the sender.
+func (m *ParSigEx) byOrigin(sender peer.ID, set core.ParSignedDataSet) map[peer.ID]core.ParSignedDataSet {
+	resp := make(map[peer.ID]core.ParSignedDataSet)
+	for pubkey, data := range set {
+		origin := sender
+		if data.ShareIdx > 0 && data.ShareIdx <= len(m.peers) {
+			origin = m.peers[data.ShareIdx-1]
+		}
+
+		if resp[origin] == nil {
+			resp[origin] = make(core.ParSignedDataSet)
+		}
+		resp[origin][pubkey] = data
+	}
+
+	return resp
 }
 
 // Broadcast broadcasts the partially signed duty data set to all peers.
 func (m *ParSigEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
 	ctx = log.WithTopic(ctx, "parsigex")
 
-	pb, err := core.ParSignedDataSetToProto(set)
-	if err != nil {
-		return err
-	}
+	var ttl uint32
+	if m.relay != nil {
+		m.relay.Broadcasted(duty, set)
 
-	msg := pbv1.ParSigExMsg{
-		Duty:    core.DutyToProto(duty),
-		DataSet: pb,
+		// Only allow relaying if some peers may not be reachable directly.
+		if !m.connectedToAll() {
+			ttl = uint32(m.relayHops)
+		}
 	}
 
 	for i, p := range m.peers {
@@ -121,7 +182,7 @@ func (m *ParSigEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSi
 			continue
 		}
 
-		if err := m.sendFunc(ctx, m.tcpNode, protocolID2, p, &msg); err != nil {
+		if err := m.send(ctx, duty, p, set, ttl); err != nil {
 			return err
 		}
 	}
@@ -129,6 +190,33 @@ func (m *ParSigEx) Broadcast(ctx context.Context, duty core.Duty, set core.ParSi
 	return nil
 }
 
+// connectedToAll returns true if this node is connected to all peers.
+func (m *ParSigEx) connectedToAll() bool {
+	for i, p := range m.peers {
+		if i != m.peerIdx && m.tcpNode.Network().Connectedness(p) != network.Connected {
+			return false
+		}
+	}
+
+	return true
+}
+
+// send sends the partially signed duty data set to the peer, allowing it to be relayed ttl times.
+func (m *ParSigEx) send(ctx context.Context, duty core.Duty, p peer.ID, set core.ParSignedDataSet, ttl uint32) error {
+	pb, err := core.ParSignedDataSetToProto(set)
+	if err != nil {
+		return err
+	}
+
+	msg := pbv1.ParSigExMsg{
+		Duty:     core.DutyToProto(duty),
+		DataSet:  pb,
+		RelayTtl: ttl,
+	}
+
+	return m.sendFunc(ctx, m.tcpNode, protocolID2, p, &msg)
+}
+
 // Subscribe registers a callback when a partially signed duty set
 // is received from a peer. This is not thread safe, it must be called before starting to use parsigex.
 func (m *ParSigEx) Subscribe(fn func(context.Context, core.Duty, core.ParSignedDataSet) error) {
@@ -136,26 +224,44 @@ func (m *ParSigEx) Subscribe(fn func(context.Context, core.Duty, core.ParSignedD
 }
 
 // NewEth2Verifier returns a partial signature verification function for core workflow eth2 signatures.
-func NewEth2Verifier(eth2Cl eth2wrap.Client, pubSharesByKey map[core.PubKey]map[int]tbls.PublicKey) (func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error, error) {
-	return func(ctx context.Context, duty core.Duty, pubkey core.PubKey, data core.ParSignedData) error {
-		pubshares, ok := pubSharesByKey[pubkey]
-		if !ok {
-			return errors.New("unknown pubkey, not part of cluster lock")
-		}
+// It batch verifies all partial signatures of the set, identifying the invalid signature if the batch fails.
+func NewEth2Verifier(eth2Cl eth2wrap.Client, pubSharesByKey map[core.PubKey]map[int]tbls.PublicKey) (func(context.Context, core.Duty, core.ParSignedDataSet) error, error) {
+	return func(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+		var (
+			pubkeys   []core.PubKey
+			pubshares []tbls.PublicKey
+			datas     []core.Eth2SignedData
+		)
+		for pubkey, data := range set {
+			shares, ok := pubSharesByKey[pubkey]
+			if !ok {
+				return errors.New("unknown pubkey, not part of cluster lock", z.Any("pubkey", pubkey))
+			}
 
-		pubshare, ok := pubshares[data.ShareIdx]
-		if !ok {
-			return errors.New("invalid shareIdx")
-		}
+			pubshare, ok := shares[data.ShareIdx]
+			if !ok {
+				return errors.New("invalid shareIdx", z.Any("pubkey", pubkey))
+			}
+
+			eth2Signed, ok := data.SignedData.(core.Eth2SignedData)
+			if !ok {
+				return errors.New("invalid eth2 signed data", z.Any("pubkey", pubkey))
+			}
 
-		eth2Signed, ok := data.SignedData.(core.Eth2SignedData)
-		if !ok {
-			return errors.New("invalid eth2 signed data")
+			pubkeys = append(pubkeys, pubkey)
+			pubshares = append(pubshares, pubshare)
+			datas = append(datas, eth2Signed)
 		}
 
-		err := core.VerifyEth2SignedData(ctx, eth2Cl, eth2Signed, pubshare)
+		errs, err := core.VerifyEth2SignedDataBatch(ctx, eth2Cl, datas, pubshares)
 		if err != nil {
-			return errors.Wrap(err, "invalid signature", z.Str("duty", duty.String()))
+			return err
+		}
+
+		for i, err := range errs {
+			if err != nil {
+				return errors.Wrap(err, "invalid signature", z.Str("duty", duty.String()), z.Any("pubkey", pubkeys[i]))
+			}
 		}
 
 		return nil
@@ -6,13 +6,16 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/obolnetwork/charon/core"
 	"github.com/obolnetwork/charon/core/parsigex"
@@ -68,7 +71,7 @@ func TestParSigEx(t *testing.T) {
 			hosts[i].Peerstore().AddAddrs(hostsInfo[k].ID, hostsInfo[k].Addrs, peerstore.PermanentAddrTTL)
 		}
 	}
-	verifyFunc := func(context.Context, core.Duty, core.PubKey, core.ParSignedData) error {
+	verifyFunc := func(context.Context, core.Duty, core.ParSignedDataSet) error {
 		return nil
 	}
 
@@ -105,6 +108,214 @@ func TestParSigEx(t *testing.T) {
 	wg.Wait()
 }
 
+func TestParSigExRelay(t *testing.T) {
+	t.Run("relayed", func(t *testing.T) {
+		testParSigExRelay(t, false)
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		testParSigExRelay(t, true)
+	})
+}
+
+// testParSigExRelay tests relaying partial signatures between peers that cannot reach each other,
+// optionally stopping the relaying peer before broadcasting.
+func testParSigExRelay(t *testing.T, stopped bool) {
+	t.Helper()
+
+	const n = 3
+
+	duty := core.Duty{Slot: 123, Type: core.DutyRandao}
+	pubkey := testutil.RandomCorePubKey(t)
+	data := core.ParSignedDataSet{
+		pubkey: core.NewPartialSignedRandao(123, testutil.RandomEth2Signature(), 1),
+	}
+
+	var (
+		peers []peer.ID
+		hosts []host.Host
+	)
+	for i := 0; i < n; i++ {
+		h := testutil.CreateHost(t, testutil.AvailableAddr(t))
+		peers = append(peers, h.ID())
+		hosts = append(hosts, h)
+	}
+
+	// Peers 0 and 1 cannot reach each other, but both can reach peer 2.
+	for i := 0; i < n; i++ {
+		for k := 0; k < n; k++ {
+			if i == k || i+k == 1 {
+				continue
+			}
+			hosts[i].Peerstore().AddAddrs(hosts[k].ID(), hosts[k].Addrs(), peerstore.PermanentAddrTTL)
+		}
+	}
+
+	verifyFunc := func(context.Context, core.Duty, core.ParSignedDataSet) error {
+		return nil
+	}
+
+	gaterFunc := func(core.Duty) bool {
+		return true
+	}
+
+	// Ignore send errors like the async sender.
+	sendFunc := func(ctx context.Context, h host.Host, protoID protocol.ID, p peer.ID, msg proto.Message, opts ...p2p.SendRecvOption) error {
+		_ = p2p.Send(ctx, h, protoID, p, msg, opts...)
+		return nil
+	}
+
+	var (
+		mu       sync.Mutex
+		received = make(map[int]int)
+	)
+
+	var parsigexs []*parsigex.ParSigEx
+	for i := 0; i < n; i++ {
+		node := i
+		sigex := parsigex.NewParSigEx(hosts[i], sendFunc, i, peers, verifyFunc, gaterFunc, parsigex.WithRelay(parsigex.DefaultRelayHops))
+		sigex.Subscribe(func(ctx context.Context, d core.Duty, set core.ParSignedDataSet) error {
+			require.Equal(t, duty, d)
+			require.Equal(t, data, set)
+
+			// Relayed partial signatures are attributed to the peer of the share.
+			sender, ok := core.ParSigSender(ctx)
+			require.True(t, ok)
+			require.Equal(t, peers[0], sender)
+
+			mu.Lock()
+			defer mu.Unlock()
+			received[node]++
+
+			return nil
+		})
+		parsigexs = append(parsigexs, sigex)
+	}
+
+	if stopped {
+		parsigexs[2].Stop()
+	}
+
+	require.NoError(t, parsigexs[0].Broadcast(context.Background(), duty, data))
+
+	if stopped {
+		// The stopped peer 2 receives the partial signature, but doesn't relay it to peer 1.
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return received[2] == 1
+		}, time.Second*5, time.Millisecond*10)
+
+		time.Sleep(time.Second * 2)
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, map[int]int{2: 1}, received)
+
+		return
+	}
+
+	// Peer 1 receives the partial signature relayed by peer 2.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return received[1] == 1
+	}, time.Second*5, time.Millisecond*10)
+
+	// Relayed partial signatures are not relayed back or delivered twice.
+	time.Sleep(time.Second * 2)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, map[int]int{1: 1, 2: 1}, received)
+}
+
+func TestParSigExRelayConnected(t *testing.T) {
+	const n = 4
+
+	ctx := context.Background()
+	duty := core.Duty{Slot: 123, Type: core.DutyRandao}
+	data := core.ParSignedDataSet{
+		testutil.RandomCorePubKey(t): core.NewPartialSignedRandao(123, testutil.RandomEth2Signature(), 1),
+	}
+
+	var (
+		peers []peer.ID
+		hosts []host.Host
+	)
+	for i := 0; i < n; i++ {
+		h := testutil.CreateHost(t, testutil.AvailableAddr(t))
+		peers = append(peers, h.ID())
+		hosts = append(hosts, h)
+	}
+
+	// All peers are connected.
+	for i := 0; i < n; i++ {
+		for k := 0; k < n; k++ {
+			if i == k {
+				continue
+			}
+			hosts[i].Peerstore().AddAddrs(hosts[k].ID(), hosts[k].Addrs(), peerstore.PermanentAddrTTL)
+			require.NoError(t, hosts[i].Connect(ctx, peer.AddrInfo{ID: hosts[k].ID(), Addrs: hosts[k].Addrs()}))
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		sent     int
+		verified int
+		received int
+	)
+
+	sendFunc := func(ctx context.Context, h host.Host, protoID protocol.ID, p peer.ID, msg proto.Message, opts ...p2p.SendRecvOption) error {
+		mu.Lock()
+		sent++
+		mu.Unlock()
+
+		return p2p.Send(ctx, h, protoID, p, msg, opts...)
+	}
+
+	verifyFunc := func(context.Context, core.Duty, core.ParSignedDataSet) error {
+		mu.Lock()
+		defer mu.Unlock()
+		verified++
+
+		return nil
+	}
+
+	gaterFunc := func(core.Duty) bool {
+		return true
+	}
+
+	var parsigexs []*parsigex.ParSigEx
+	for i := 0; i < n; i++ {
+		sigex := parsigex.NewParSigEx(hosts[i], sendFunc, i, peers, verifyFunc, gaterFunc, parsigex.WithRelay(parsigex.DefaultRelayHops))
+		sigex.Subscribe(func(context.Context, core.Duty, core.ParSignedDataSet) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received++
+
+			return nil
+		})
+		parsigexs = append(parsigexs, sigex)
+	}
+
+	// Broadcast twice, duplicate partial signatures are neither verified nor delivered again.
+	require.NoError(t, parsigexs[0].Broadcast(ctx, duty, data))
+	require.NoError(t, parsigexs[0].Broadcast(ctx, duty, data))
+
+	// Wait for any relaying.
+	time.Sleep(time.Second * 2)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 2*(n-1), sent) // No relay traffic.
+	require.Equal(t, n-1, verified)
+	require.Equal(t, n-1, received)
+}
+
 func TestParSigExVerifier(t *testing.T) {
 	ctx := context.Background()
 
@@ -153,7 +364,7 @@ func TestParSigExVerifier(t *testing.T) {
 		require.NoError(t, err)
 		att.Signature = sign(sigData[:])
 		data := core.NewPartialAttestation(att, shareIdx)
-		require.NoError(t, verifyFunc(ctx, core.NewAttesterDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewAttesterDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify block", func(t *testing.T) {
@@ -167,7 +378,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data, err := core.NewPartialVersionedSignedBeaconBlock(block, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewProposerDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify blinded block", func(t *testing.T) {
@@ -181,7 +392,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data, err := core.NewPartialVersionedSignedBlindedBeaconBlock(&blindedBlock.VersionedSignedBlindedBeaconBlock, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewBuilderProposerDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewBuilderProposerDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify Randao", func(t *testing.T) {
@@ -193,7 +404,7 @@ func TestParSigExVerifier(t *testing.T) {
 
 		randao := core.NewPartialSignedRandao(epoch, sign(sigData[:]), shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewRandaoDuty(slot), pubkey, randao))
+		require.NoError(t, verifyFunc(ctx, core.NewRandaoDuty(slot), core.ParSignedDataSet{pubkey: randao}))
 	})
 
 	t.Run("Verify Voluntary Exit", func(t *testing.T) {
@@ -207,7 +418,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data := core.NewPartialSignedVoluntaryExit(exit, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewVoluntaryExit(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewVoluntaryExit(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify validator registration", func(t *testing.T) {
@@ -223,7 +434,7 @@ func TestParSigExVerifier(t *testing.T) {
 		data, err := core.NewPartialVersionedSignedValidatorRegistration(&reg.VersionedSignedValidatorRegistration, shareIdx)
 		require.NoError(t, err)
 
-		require.NoError(t, verifyFunc(ctx, core.NewBuilderRegistrationDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewBuilderRegistrationDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify beacon committee selection", func(t *testing.T) {
@@ -236,7 +447,7 @@ func TestParSigExVerifier(t *testing.T) {
 		selection.SelectionProof = sign(sigData[:])
 		data := core.NewPartialSignedBeaconCommitteeSelection(selection, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewPrepareAggregatorDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewPrepareAggregatorDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("Verify aggregate and proof", func(t *testing.T) {
@@ -255,7 +466,7 @@ func TestParSigExVerifier(t *testing.T) {
 		agg.Signature = sign(sigData[:])
 		data := core.NewPartialSignedAggregateAndProof(agg, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewAggregatorDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewAggregatorDuty(slot), core.ParSignedDataSet{pubkey: data}))
 	})
 
 	t.Run("verify sync committee message", func(t *testing.T) {
@@ -267,11 +478,11 @@ func TestParSigExVerifier(t *testing.T) {
 		msg.Signature = sign(sigData[:])
 
 		data := core.NewPartialSignedSyncMessage(msg, shareIdx)
-		require.NoError(t, verifyFunc(ctx, core.NewSyncMessageDuty(slot), pubkey, data))
+		require.NoError(t, verifyFunc(ctx, core.NewSyncMessageDuty(slot), core.ParSignedDataSet{pubkey: data}))
 
 		// Invalid sync committee message.
 		data = core.NewPartialSignedRandao(epoch, testutil.RandomEth2Signature(), shareIdx)
-		err = verifyFunc(ctx, core.NewSyncMessageDuty(slot), pubkey, data)
+		err = verifyFunc(ctx, core.NewSyncMessageDuty(slot), core.ParSignedDataSet{pubkey: data})
 		require.Error(t, err)
 		require.ErrorContains(t, err, "invalid signature")
 	})
@@ -293,7 +504,7 @@ func TestParSigExVerifier(t *testing.T) {
 
 		parSigData := core.NewPartialSignedSyncCommitteeSelection(selection, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), pubkey, parSigData))
+		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), core.ParSignedDataSet{pubkey: parSigData}))
 	})
 
 	t.Run("verify sync committee contribution and proof", func(t *testing.T) {
@@ -309,6 +520,82 @@ func TestParSigExVerifier(t *testing.T) {
 
 		parSigData := core.NewPartialSignedSyncContributionAndProof(proof, shareIdx)
 
-		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), pubkey, parSigData))
+		require.NoError(t, verifyFunc(ctx, core.NewPrepareSyncContributionDuty(slot), core.ParSignedDataSet{pubkey: parSigData}))
 	})
 }
+
+func TestParSigExBatchVerifier(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	verifyFunc, set := newRandaoSet(t, bmock, 10)
+	require.NoError(t, verifyFunc(ctx, core.NewRandaoDuty(123), set))
+
+	// Signature of another validator is invalid.
+	var pubkeys []core.PubKey
+	for pubkey := range set {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	set[pubkeys[0]] = set[pubkeys[1]]
+
+	err = verifyFunc(ctx, core.NewRandaoDuty(123), set)
+	require.ErrorContains(t, err, "invalid signature: signature not verified")
+}
+
+// BenchmarkParSigExVerifier benchmarks the verification of a peer's partial signatures of a 500 validator cluster.
+func BenchmarkParSigExVerifier(b *testing.B) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(b, err)
+
+	verifyFunc, set := newRandaoSet(b, bmock, 500)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, verifyFunc(ctx, core.NewRandaoDuty(123), set))
+	}
+}
+
+// newRandaoSet returns an eth2 verifier and a partial signed randao set of n validators.
+func newRandaoSet(t testing.TB, bmock beaconmock.Mock, n int) (func(context.Context, core.Duty, core.ParSignedDataSet) error, core.ParSignedDataSet) {
+	t.Helper()
+
+	const shareIdx = 1
+
+	ctx := context.Background()
+	epoch := eth2p0.Epoch(3)
+
+	sigEpoch := eth2util.SignedEpoch{Epoch: epoch}
+	sigRoot, err := sigEpoch.HashTreeRoot()
+	require.NoError(t, err)
+	sigData, err := signing.GetDataRoot(ctx, bmock, signing.DomainRandao, epoch, sigRoot)
+	require.NoError(t, err)
+
+	pubshares := make(map[core.PubKey]map[int]tbls.PublicKey)
+	set := make(core.ParSignedDataSet)
+	for i := 0; i < n; i++ {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+
+		pk, err := tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+
+		sig, err := tbls.Sign(secret, sigData[:])
+		require.NoError(t, err)
+
+		pubkey, err := core.PubKeyFromBytes(pk[:])
+		require.NoError(t, err)
+
+		pubshares[pubkey] = map[int]tbls.PublicKey{shareIdx: pk}
+		set[pubkey] = core.NewPartialSignedRandao(epoch, eth2p0.BLSSignature(sig), shareIdx)
+	}
+
+	verifyFunc, err := parsigex.NewEth2Verifier(bmock, pubshares)
+	require.NoError(t, err)
+
+	return verifyFunc, set
+}
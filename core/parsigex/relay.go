@@ -0,0 +1,252 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package parsigex
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
+)
+
+const (
+	// DefaultRelayHops is the default number of times partial signatures are relayed by receiving peers.
+	// Two hops ensure partial signatures reach peers connected via a chain of up to three peers.
+	DefaultRelayHops = 2
+	// relayDelay is the minimum delay before relaying received partial signatures, allowing the receipt of the same
+	// partial signatures from other peers, which acknowledges that they already have them.
+	// The actual delay is randomised between relayDelay and twice relayDelay to stagger relaying peers.
+	relayDelay = time.Millisecond * 500
+	// relayExpiry is the duration after which relay state of partial signatures is deleted.
+	relayExpiry = time.Minute * 10
+	// relayTimeout is the timeout of relaying partial signatures to a peer.
+	relayTimeout = time.Second * 10
+)
+
+// Option configures a ParSigEx.
+type Option func(*ParSigEx)
+
+// WithRelay returns an option that enables relaying of received partial signatures to peers that haven't
+// acknowledged them, up to hops times. This enables partial signature exchange in partially connected clusters.
+// Partial signatures are only relayed if the broadcasting peer isn't connected to all peers.
+// Relayed partial signatures are verified against the public shares like directly received partial signatures.
+func WithRelay(hops int) Option {
+	return func(m *ParSigEx) {
+		m.relay = newRelayer(m.peers, m.peerIdx)
+		m.relayHops = hops
+		m.relayCtx, m.relayCancel = context.WithCancel(context.Background())
+	}
+}
+
+// relayKey identifies a partial signature. It includes the signature to distinguish equivocating partial signatures.
+type relayKey struct {
+	Duty      core.Duty
+	PubKey    core.PubKey
+	ShareIdx  int
+	Signature eth2p0.BLSSignature
+}
+
+// relayEntry is the relay state of a partial signature.
+type relayEntry struct {
+	Created   time.Time
+	Data      core.ParSignedData
+	Holders   map[peer.ID]bool // Peers that acknowledged the partial signature.
+	Scheduled bool             // True if the partial signature was scheduled for relaying.
+}
+
+// newRelayer returns a new relayer.
+func newRelayer(peers []peer.ID, peerIdx int) *relayer {
+	return &relayer{
+		peers:   peers,
+		peerIdx: peerIdx,
+		entries: make(map[relayKey]*relayEntry),
+		now:     time.Now,
+	}
+}
+
+// relayer deduplicates received partial signatures and tracks the peers that acknowledged them,
+// i.e., the peers known to have them.
+type relayer struct {
+	mu      sync.Mutex
+	peers   []peer.ID
+	peerIdx int
+	entries map[relayKey]*relayEntry
+	now     func() time.Time
+}
+
+// Broadcasted records the partial signatures broadcasted by this node to all peers, so they are not relayed back.
+func (r *relayer) Broadcasted(duty core.Duty, set core.ParSignedDataSet) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for pubkey, data := range set {
+		entry, _ := r.getOrCreate(duty, pubkey, data)
+		entry.Scheduled = true
+		for _, p := range r.peers {
+			entry.Holders[p] = true
+		}
+	}
+}
+
+// Unseen returns the partial signatures not received before. The sender is recorded as holder of the others.
+func (r *relayer) Unseen(duty core.Duty, sender peer.ID, set core.ParSignedDataSet) core.ParSignedDataSet {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.trim()
+
+	resp := make(core.ParSignedDataSet)
+	for pubkey, data := range set {
+		entry, ok := r.entries[keyOf(duty, pubkey, data)]
+		if !ok {
+			resp[pubkey] = data
+			continue
+		}
+
+		entry.Holders[sender] = true
+	}
+
+	return resp
+}
+
+// Received records the verified partial signatures received from the sender. It returns the partial signatures
+// not received before and the partial signatures to schedule for relaying if ttl is positive.
+func (r *relayer) Received(duty core.Duty, sender peer.ID, set core.ParSignedDataSet, ttl uint32,
+) (core.ParSignedDataSet, []relayKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newSet := make(core.ParSignedDataSet)
+	var toRelay []relayKey
+	for pubkey, data := range set {
+		entry, created := r.getOrCreate(duty, pubkey, data)
+		entry.Holders[sender] = true
+		if created {
+			newSet[pubkey] = data
+		}
+
+		if ttl == 0 || entry.Scheduled {
+			continue
+		}
+
+		entry.Scheduled = true
+		toRelay = append(toRelay, keyOf(duty, pubkey, data))
+	}
+
+	return newSet, toRelay
+}
+
+// Targets returns the partial signatures to relay by peer, excluding peers that acknowledged them since they
+// were scheduled. The peers are recorded as holders, so the partial signatures are relayed at most once to each peer.
+// Partial signatures of different shares of the same DV are returned in separate sets.
+func (r *relayer) Targets(keys []relayKey) map[peer.ID][]core.ParSignedDataSet {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resp := make(map[peer.ID][]core.ParSignedDataSet)
+	for _, key := range keys {
+		entry, ok := r.entries[key]
+		if !ok {
+			continue // Expired
+		}
+
+		for i, p := range r.peers {
+			if i == r.peerIdx || entry.Holders[p] {
+				continue
+			}
+
+			resp[p] = addToSets(resp[p], key.PubKey, entry.Data)
+			entry.Holders[p] = true
+		}
+	}
+
+	return resp
+}
+
+// getOrCreate returns the entry of the partial signature and true if it was created.
+// The peer of the partial signature's share is always a holder.
+func (r *relayer) getOrCreate(duty core.Duty, pubkey core.PubKey, data core.ParSignedData) (*relayEntry, bool) {
+	key := keyOf(duty, pubkey, data)
+	if entry, ok := r.entries[key]; ok {
+		return entry, false
+	}
+
+	entry := &relayEntry{
+		Created: r.now(),
+		Data:    data,
+		Holders: make(map[peer.ID]bool),
+	}
+	if data.ShareIdx > 0 && data.ShareIdx <= len(r.peers) {
+		entry.Holders[r.peers[data.ShareIdx-1]] = true
+	}
+	r.entries[key] = entry
+
+	return entry, true
+}
+
+// trim deletes expired entries.
+func (r *relayer) trim() {
+	for key, entry := range r.entries {
+		if r.now().Sub(entry.Created) > relayExpiry {
+			delete(r.entries, key)
+		}
+	}
+}
+
+// addToSets adds the partial signature to the first set without partial signature of the DV,
+// or to a new set if all sets contain one.
+func addToSets(sets []core.ParSignedDataSet, pubkey core.PubKey, data core.ParSignedData) []core.ParSignedDataSet {
+	for _, set := range sets {
+		if _, ok := set[pubkey]; !ok {
+			set[pubkey] = data
+			return sets
+		}
+	}
+
+	return append(sets, core.ParSignedDataSet{pubkey: data})
+}
+
+// keyOf returns the relay key of the partial signature.
+func keyOf(duty core.Duty, pubkey core.PubKey, data core.ParSignedData) relayKey {
+	return relayKey{
+		Duty:      duty,
+		PubKey:    pubkey,
+		ShareIdx:  data.ShareIdx,
+		Signature: data.Signature().ToETH2(),
+	}
+}
+
+// randomRelayDelay returns a random delay between relayDelay and twice relayDelay.
+func randomRelayDelay() time.Duration {
+	return relayDelay + time.Duration(rand.Int63n(int64(relayDelay))) //nolint:gosec // Not security critical.
+}
+
+// relayAsync relays the partial signatures to peers that haven't acknowledged them after a random delay.
+// Pending relays are abandoned when the context is cancelled, see Stop.
+func (m *ParSigEx) relayAsync(ctx context.Context, duty core.Duty, keys []relayKey, ttl uint32) {
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(randomRelayDelay()):
+		}
+
+		for p, sets := range m.relay.Targets(keys) {
+			for _, set := range sets {
+				ctx, cancel := context.WithTimeout(ctx, relayTimeout)
+				if err := m.send(ctx, duty, p, set, ttl); err != nil {
+					log.Warn(ctx, "Failed relaying partial signatures", err, z.Str("peer", p2p.PeerName(p)))
+				}
+				cancel()
+			}
+		}
+	}()
+}
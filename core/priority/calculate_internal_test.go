@@ -239,3 +239,30 @@ func fromAny(a *anypb.Any) string {
 
 	return string(pb.(*pbv1.ParSignedData).Data)
 }
+
+func TestProposedByAll(t *testing.T) {
+	const peers = 5
+
+	require.True(t, ScoredPriority{Score: 5000}.ProposedByAll(peers))  // Proposed first by all peers.
+	require.True(t, ScoredPriority{Score: 4995}.ProposedByAll(peers))  // Proposed second by all peers.
+	require.False(t, ScoredPriority{Score: 4000}.ProposedByAll(peers)) // Proposed first by all but one peer.
+	require.False(t, ScoredPriority{Score: 3999}.ProposedByAll(peers))
+}
+
+func TestProposedFromProto(t *testing.T) {
+	var msgs []*pbv1.PriorityMsg
+	for _, proposals := range []TopicProposal{
+		{Topic: "a", Priorities: []string{"3", "1"}},
+		{Topic: "a", Priorities: []string{"1"}},
+		{Topic: "b", Priorities: []string{"2"}},
+	} {
+		pb, err := topicProposalToProto(proposals)
+		require.NoError(t, err)
+		msgs = append(msgs, &pbv1.PriorityMsg{Topics: []*pbv1.PriorityTopicProposal{pb}})
+	}
+
+	// Priorities proposed by a single peer are included.
+	proposed, err := proposedFromProto(msgs, "a")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "3"}, proposed)
+}
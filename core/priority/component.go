@@ -4,6 +4,7 @@ package priority
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
@@ -31,6 +32,9 @@ type TopicProposal struct {
 type TopicResult struct {
 	Topic      string
 	Priorities []ScoredPriority
+	// Proposed are the sorted priorities proposed by any peer, including those
+	// not proposed by enough peers to be included in Priorities.
+	Proposed []string
 }
 
 // PrioritiesOnly returns the priorities without scores.
@@ -49,6 +53,12 @@ type ScoredPriority struct {
 	Score    int
 }
 
+// ProposedByAll returns true if the priority was proposed by all the peers.
+func (p ScoredPriority) ProposedByAll(peers int) bool {
+	// Each peer contributes at most countWeight to the score.
+	return p.Score > (peers-1)*countWeight
+}
+
 // coreConsensus is an interface for the core/consensus.Component.
 type coreConsensus interface {
 	ProposePriority(context.Context, core.Duty, *pbv1.PriorityResult) error
@@ -97,12 +107,17 @@ func (c *Component) Subscribe(fn func(context.Context, core.Duty, []TopicResult)
 	c.prioritiser.Subscribe(func(ctx context.Context, duty core.Duty, result *pbv1.PriorityResult) error {
 		var results []TopicResult
 		for _, topic := range result.Topics {
-			result, err := topicResultFromProto(topic)
+			topicResult, err := topicResultFromProto(topic)
+			if err != nil {
+				return err
+			}
+
+			topicResult.Proposed, err = proposedFromProto(result.Msgs, topicResult.Topic)
 			if err != nil {
 				return err
 			}
 
-			results = append(results, result)
+			results = append(results, topicResult)
 		}
 
 		return fn(ctx, duty, results)
@@ -287,3 +302,51 @@ func topicResultFromProto(p *pbv1.PriorityTopicResult) (TopicResult, error) {
 		Priorities: priorities,
 	}, nil
 }
+
+// proposedFromProto returns the sorted priorities of the topic proposed by any of the peers' messages.
+func proposedFromProto(msgs []*pbv1.PriorityMsg, topic string) ([]string, error) {
+	var (
+		resp  []string
+		dedup = newDeduper[string]()
+	)
+	for _, msg := range msgs {
+		for _, proposal := range msg.Topics {
+			proposalTopic, err := stringFromAny(proposal.Topic)
+			if err != nil {
+				return nil, err
+			} else if proposalTopic != topic {
+				continue
+			}
+
+			for _, prio := range proposal.Priorities {
+				priority, err := stringFromAny(prio)
+				if err != nil {
+					return nil, err
+				} else if dedup(priority) {
+					continue
+				}
+
+				resp = append(resp, priority)
+			}
+		}
+	}
+
+	sort.Strings(resp)
+
+	return resp, nil
+}
+
+// stringFromAny returns the string value of the anypb structpb value.
+func stringFromAny(a *anypb.Any) (string, error) {
+	val := new(structpb.Value)
+	if err := a.UnmarshalTo(val); err != nil {
+		return "", errors.Wrap(err, "anypb value")
+	}
+
+	str, ok := val.AsInterface().(string)
+	if !ok {
+		return "", errors.New("value not a string")
+	}
+
+	return str, nil
+}
@@ -47,6 +47,10 @@ func TestParSignedDataSetProto(t *testing.T) {
 			Type: core.DutyProposer,
 			Data: testutil.RandomCapellaCoreVersionedSignedBeaconBlock(),
 		},
+		{
+			Type: core.DutyProposer,
+			Data: testutil.RandomDenebCoreVersionedSignedBeaconBlock(),
+		},
 		{
 			Type: core.DutyBuilderProposer,
 			Data: testutil.RandomBellatrixVersionedSignedBlindedBeaconBlock(),
@@ -55,6 +59,10 @@ func TestParSignedDataSetProto(t *testing.T) {
 			Type: core.DutyBuilderProposer,
 			Data: testutil.RandomCapellaVersionedSignedBlindedBeaconBlock(),
 		},
+		{
+			Type: core.DutyBuilderProposer,
+			Data: testutil.RandomDenebVersionedSignedBlindedBeaconBlock(),
+		},
 		{
 			Type: core.DutyBuilderRegistration,
 			Data: testutil.RandomCoreVersionedSignedValidatorRegistration(t),
@@ -62,6 +62,20 @@ var (
 		Name:      "skipped_slots_total",
 		Help:      "Total number times slots were skipped",
 	})
+
+	rescheduledCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "scheduler",
+		Name:      "duty_rescheduled_total",
+		Help:      "The total count of duties rescheduled due to duty dependent root changes by type",
+	}, []string{"duty"})
+
+	reorgCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "core",
+		Subsystem: "scheduler",
+		Name:      "chain_reorg_total",
+		Help:      "Total number of chain reorg events received from beacon nodes",
+	})
 )
 
 // instrumentSlot sets the current slot and epoch metrics.
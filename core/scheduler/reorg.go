@@ -0,0 +1,353 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+const (
+	topicHead       = "head"
+	topicChainReorg = "chain_reorg"
+	topicBlock      = "block"
+)
+
+// eventKey identifies a beacon node event by topic and block root.
+type eventKey struct {
+	Topic string
+	Root  eth2p0.Root
+}
+
+// newSeenEvents returns a new seenEvents.
+func newSeenEvents() seenEvents {
+	return make(seenEvents)
+}
+
+// seenEvents tracks the slots of processed events by key, since events are received once per beacon node.
+type seenEvents map[eventKey]eth2p0.Slot
+
+// Add returns true if the event wasn't seen before and records it. Events of slots
+// older than trimEpochOffset epochs before the current slot are trimmed.
+func (s seenEvents) Add(current core.Slot, key eventKey, slot eth2p0.Slot) bool {
+	for k, seen := range s {
+		if int64(seen) < current.Slot-trimEpochOffset*current.SlotsPerEpoch {
+			delete(s, k)
+		}
+	}
+
+	if _, ok := s[key]; ok {
+		return false
+	}
+	s[key] = slot
+
+	return true
+}
+
+// eventKeyAndSlot returns the key and slot of the supported beacon node events or false otherwise.
+func eventKeyAndSlot(event *eth2v1.Event) (eventKey, eth2p0.Slot, bool) {
+	switch data := event.Data.(type) {
+	case *eth2v1.HeadEvent:
+		return eventKey{Topic: topicHead, Root: data.Block}, data.Slot, true
+	case *eth2v1.ChainReorgEvent:
+		return eventKey{Topic: topicChainReorg, Root: data.NewHeadBlock}, data.Slot, true
+	case *eth2v1.BlockEvent:
+		return eventKey{Topic: topicBlock, Root: data.Block}, data.Slot, true
+	default:
+		return eventKey{}, 0, false
+	}
+}
+
+// newDependentRoots returns a new dependentRoots.
+func newDependentRoots() dependentRoots {
+	return dependentRoots{
+		attester: make(map[int64]eth2p0.Root),
+		proposer: make(map[int64]eth2p0.Root),
+	}
+}
+
+// dependentRoots tracks the duty dependent roots by epoch as reported by beacon node head events.
+// Duties resolved for an epoch are stale if its dependent root changes.
+type dependentRoots struct {
+	// attester roots of epoch N are the block root at the end of epoch N-2, also used for sync committee duties.
+	attester map[int64]eth2p0.Root
+	// proposer roots of epoch N are the block root at the end of epoch N-1.
+	proposer map[int64]eth2p0.Root
+}
+
+// Update records the dependent roots of a head event in the epoch and returns the (sorted) epochs whose
+// dependent roots changed. Zero roots are ignored.
+func (d dependentRoots) Update(epoch int64, previous, current eth2p0.Root) []int64 {
+	changed := make(map[int64]bool)
+	set := func(roots map[int64]eth2p0.Root, epoch int64, root eth2p0.Root) {
+		if root == (eth2p0.Root{}) {
+			return
+		}
+
+		if prev, ok := roots[epoch]; ok && prev != root {
+			changed[epoch] = true
+		}
+		roots[epoch] = root
+	}
+
+	set(d.proposer, epoch, current)
+	set(d.attester, epoch, previous)
+	set(d.attester, epoch+1, current)
+
+	for _, roots := range []map[int64]eth2p0.Root{d.attester, d.proposer} {
+		for e := range roots {
+			if e < epoch-trimEpochOffset {
+				delete(roots, e)
+			}
+		}
+	}
+
+	var resp []int64
+	for e := range changed {
+		resp = append(resp, e)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i] < resp[j]
+	})
+
+	return resp
+}
+
+// handleEvent enqueues beacon node events for processing by the scheduler run loop.
+func (s *Scheduler) handleEvent(event *eth2v1.Event) {
+	select {
+	case s.events <- event:
+	default:
+		// Dropping events is fine, since subsequent head events contain the latest dependent roots.
+	}
+}
+
+// processEvent logs chain reorgs and reschedules duties of resolved epochs whose dependent roots changed.
+// Duplicate events, received from multiple beacon nodes, are ignored.
+func (s *Scheduler) processEvent(ctx context.Context, slot core.Slot, event *eth2v1.Event) {
+	key, eventSlot, ok := eventKeyAndSlot(event)
+	if !ok || !s.seenEvents.Add(slot, key, eventSlot) {
+		return
+	}
+
+	switch data := event.Data.(type) {
+	case *eth2v1.BlockEvent:
+		log.Debug(ctx, "Beacon block imported",
+			z.U64("slot", uint64(data.Slot)),
+			z.Str("block", fmt.Sprintf("%#x", data.Block)),
+		)
+	case *eth2v1.ChainReorgEvent:
+		reorgCounter.Inc()
+		log.Warn(ctx, "Beacon chain reorg", nil,
+			z.U64("slot", uint64(data.Slot)),
+			z.U64("depth", data.Depth),
+			z.Str("old_head", fmt.Sprintf("%#x", data.OldHeadBlock)),
+			z.Str("new_head", fmt.Sprintf("%#x", data.NewHeadBlock)),
+		)
+	case *eth2v1.HeadEvent:
+		epoch := int64(data.Slot) / slot.SlotsPerEpoch
+		for _, changed := range s.dependentRoots.Update(epoch, data.PreviousDutyDependentRoot, data.CurrentDutyDependentRoot) {
+			if changed < slot.Epoch() || !s.isEpochResolved(changed) {
+				continue // Past duties cannot be rescheduled and unresolved duties will be resolved normally.
+			}
+
+			// Only reschedule upcoming slots since duties of the current slot are already triggered.
+			from := slot.Next()
+			for from.Epoch() < changed {
+				from = from.Next()
+			}
+			if from.Epoch() != changed {
+				continue
+			}
+
+			log.Warn(ctx, "Duty dependent root changed, rescheduling duties", nil,
+				z.I64("epoch", changed), z.I64("from_slot", from.Slot))
+
+			if err := s.reschedule(ctx, from); err != nil {
+				log.Warn(ctx, "Rescheduling duties error (keeping previous duties)", err, z.I64("epoch", changed))
+			}
+		}
+	}
+}
+
+// reschedule re-resolves the duties of the slot's epoch from the slot onwards, logging and instrumenting the duties that changed.
+// The previous duties are restored on error.
+func (s *Scheduler) reschedule(ctx context.Context, from core.Slot) error {
+	prev := s.deleteDutiesFrom(from)
+
+	if err := s.resolveEpochDuties(ctx, from); err != nil {
+		s.deleteDutiesFrom(from)
+		s.setDuties(from.Epoch(), prev)
+
+		return err
+	}
+
+	s.removeAttested(ctx, from)
+	next := s.getDutiesFrom(from)
+
+	var duties []core.Duty
+	for duty := range prev {
+		duties = append(duties, duty)
+	}
+	for duty := range next {
+		if _, ok := prev[duty]; !ok {
+			duties = append(duties, duty)
+		}
+	}
+	sort.Slice(duties, func(i, j int) bool {
+		if duties[i].Slot == duties[j].Slot {
+			return duties[i].Type < duties[j].Type
+		}
+
+		return duties[i].Slot < duties[j].Slot
+	})
+
+	for _, duty := range duties {
+		pubkeys := changedPubKeys(prev[duty], next[duty])
+		if len(pubkeys) == 0 {
+			continue
+		}
+
+		rescheduledCounter.WithLabelValues(duty.Type.String()).Add(float64(len(pubkeys)))
+		log.Info(ctx, "Rescheduled duty", z.Any("duty", duty), z.Any("pubkeys", pubkeys),
+			z.Int("before", len(prev[duty])), z.Int("after", len(next[duty])))
+	}
+
+	return nil
+}
+
+// changedPubKeys returns the public keys whose duty definitions differ between the sets.
+func changedPubKeys(prev, next core.DutyDefinitionSet) []core.PubKey {
+	var resp []core.PubKey
+	for pubkey, prevDef := range prev {
+		nextDef, ok := next[pubkey]
+		if !ok || !equalDefinitions(prevDef, nextDef) {
+			resp = append(resp, pubkey)
+		}
+	}
+	for pubkey := range next {
+		if _, ok := prev[pubkey]; !ok {
+			resp = append(resp, pubkey)
+		}
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i] < resp[j]
+	})
+
+	return resp
+}
+
+// equalDefinitions returns true if the duty definitions are equal.
+func equalDefinitions(a, b core.DutyDefinition) bool {
+	ab, err := a.MarshalJSON()
+	if err != nil {
+		return false
+	}
+
+	bb, err := b.MarshalJSON()
+	if err != nil {
+		return false
+	}
+
+	return string(ab) == string(bb)
+}
+
+// deleteDutiesFrom deletes and returns the duties of the slot's epoch from the slot onwards.
+func (s *Scheduler) deleteDutiesFrom(from core.Slot) map[core.Duty]core.DutyDefinitionSet {
+	s.dutiesMutex.Lock()
+	defer s.dutiesMutex.Unlock()
+
+	resp := make(map[core.Duty]core.DutyDefinitionSet)
+
+	var remaining []core.Duty
+	for _, duty := range s.dutiesByEpoch[from.Epoch()] {
+		if duty.Slot < from.Slot {
+			remaining = append(remaining, duty)
+			continue
+		}
+
+		if defSet, ok := s.duties[duty]; ok {
+			resp[duty] = defSet
+			delete(s.duties, duty)
+		}
+	}
+	s.dutiesByEpoch[from.Epoch()] = remaining
+
+	return resp
+}
+
+// removeAttested removes attester and aggregator duties of the slot's epoch from the slot onwards for validators that
+// had attester duties earlier in the epoch, since attesting twice in an epoch is slashable.
+func (s *Scheduler) removeAttested(ctx context.Context, from core.Slot) {
+	s.dutiesMutex.Lock()
+	defer s.dutiesMutex.Unlock()
+
+	attested := make(map[core.PubKey]bool)
+	for _, duty := range s.dutiesByEpoch[from.Epoch()] {
+		if duty.Type != core.DutyAttester || duty.Slot >= from.Slot {
+			continue
+		}
+
+		for pubkey := range s.duties[duty] {
+			attested[pubkey] = true
+		}
+	}
+
+	for _, duty := range s.dutiesByEpoch[from.Epoch()] {
+		if duty.Slot < from.Slot || (duty.Type != core.DutyAttester && duty.Type != core.DutyAggregator) {
+			continue
+		}
+
+		defSet, ok := s.duties[duty]
+		if !ok {
+			continue
+		}
+
+		for pubkey := range defSet {
+			if !attested[pubkey] {
+				continue
+			}
+
+			log.Warn(ctx, "Ignoring rescheduled duty of validator that already attested in epoch", nil,
+				z.Any("duty", duty), z.Any("pubkey", pubkey))
+			delete(defSet, pubkey)
+		}
+
+		if len(defSet) == 0 {
+			delete(s.duties, duty)
+		}
+	}
+}
+
+// getDutiesFrom returns the duties of the slot's epoch from the slot onwards.
+func (s *Scheduler) getDutiesFrom(from core.Slot) map[core.Duty]core.DutyDefinitionSet {
+	s.dutiesMutex.Lock()
+	defer s.dutiesMutex.Unlock()
+
+	resp := make(map[core.Duty]core.DutyDefinitionSet)
+	for _, duty := range s.dutiesByEpoch[from.Epoch()] {
+		if defSet, ok := s.duties[duty]; ok && duty.Slot >= from.Slot {
+			resp[duty] = defSet
+		}
+	}
+
+	return resp
+}
+
+// setDuties sets the duty definitions of the epoch.
+func (s *Scheduler) setDuties(epoch int64, duties map[core.Duty]core.DutyDefinitionSet) {
+	for duty, defSet := range duties {
+		for pubkey, def := range defSet {
+			s.setDutyDefinition(duty, epoch, pubkey, def)
+		}
+	}
+}
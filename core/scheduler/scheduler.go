@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
@@ -56,6 +57,9 @@ func New(pubkeys []core.PubKey, eth2Cl eth2wrap.Client, builderEnabled core.Buil
 		metricSubmitter: newMetricSubmitter(),
 		resolvedEpoch:   math.MaxInt64,
 		builderEnabled:  builderEnabled,
+		events:          make(chan *eth2v1.Event, 64),
+		dependentRoots:  newDependentRoots(),
+		seenEvents:      newSeenEvents(),
 	}, nil
 }
 
@@ -73,6 +77,9 @@ type Scheduler struct {
 	dutySubs        []func(context.Context, core.Duty, core.DutyDefinitionSet) error
 	slotSubs        []func(context.Context, core.Slot) error
 	builderEnabled  core.BuilderEnabled
+	events          chan *eth2v1.Event
+	dependentRoots  dependentRoots // Only accessed by the run loop.
+	seenEvents      seenEvents     // Only accessed by the run loop.
 }
 
 // SubscribeDuties subscribes a callback function for triggered duties.
@@ -106,6 +113,12 @@ func (s *Scheduler) Run() error {
 		return err
 	}
 
+	err = s.eth2Cl.Events(ctx, []string{topicHead, topicChainReorg, topicBlock}, s.handleEvent)
+	if err != nil {
+		log.Warn(ctx, "Failed subscribing to beacon node events, duties will not be rescheduled on reorgs", err)
+	}
+
+	var currentSlot core.Slot
 	for {
 		select {
 		case <-s.quit:
@@ -118,6 +131,13 @@ func (s *Scheduler) Run() error {
 			go s.emitCoreSlot(ctx, slot)
 
 			s.scheduleSlot(ctx, slot)
+			currentSlot = slot
+		case event := <-s.events:
+			if currentSlot.SlotsPerEpoch == 0 {
+				continue // Ignore events before the first slot.
+			}
+
+			s.processEvent(ctx, currentSlot, event)
 		}
 	}
 }
@@ -244,6 +264,19 @@ func delaySlotOffset(ctx context.Context, slot core.Slot, duty core.Duty, delayF
 
 // resolveDuties resolves the duties for the slot's epoch, caching the results.
 func (s *Scheduler) resolveDuties(ctx context.Context, slot core.Slot) error {
+	err := s.resolveEpochDuties(ctx, slot)
+	if err != nil {
+		return err
+	}
+
+	s.setResolvedEpoch(slot.Epoch())
+	s.trimDuties(slot.Epoch() - trimEpochOffset)
+
+	return nil
+}
+
+// resolveEpochDuties resolves the duties for the slot's epoch from the slot onwards, caching the results.
+func (s *Scheduler) resolveEpochDuties(ctx context.Context, slot core.Slot) error {
 	vals, err := resolveActiveValidators(ctx, s.eth2Cl, s.pubkeys, s.metricSubmitter)
 	if err != nil {
 		return err
@@ -253,8 +286,6 @@ func (s *Scheduler) resolveDuties(ctx context.Context, slot core.Slot) error {
 
 	if len(vals) == 0 {
 		log.Info(ctx, "No active validators for slot", z.I64("slot", slot.Slot))
-		s.setResolvedEpoch(slot.Epoch())
-
 		return nil
 	}
 
@@ -268,15 +299,7 @@ func (s *Scheduler) resolveDuties(ctx context.Context, slot core.Slot) error {
 		return err
 	}
 
-	err = s.resolveSyncCommDuties(ctx, slot, vals)
-	if err != nil {
-		return err
-	}
-
-	s.setResolvedEpoch(slot.Epoch())
-	s.trimDuties(slot.Epoch() - trimEpochOffset)
-
-	return nil
+	return s.resolveSyncCommDuties(ctx, slot, vals)
 }
 
 // resolveAttDuties resolves attester duties for the given validators.
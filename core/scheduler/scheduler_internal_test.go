@@ -129,3 +129,133 @@ func TestResolveSyncCommDuties(t *testing.T) {
 		SlotsPerEpoch: 1,
 	}, schedVals), "invalid sync committee duty pubkey")
 }
+
+func TestDependentRoots(t *testing.T) {
+	var (
+		roots = newDependentRoots()
+		root1 = testutil.RandomRoot()
+		root2 = testutil.RandomRoot()
+		root3 = testutil.RandomRoot()
+	)
+
+	// First head events only record roots.
+	require.Empty(t, roots.Update(1, root1, root2))
+	require.Empty(t, roots.Update(1, root1, root2))
+
+	// Epoch 2's previous root is epoch 1's current root.
+	require.Empty(t, roots.Update(2, root2, root3))
+
+	// Zero roots are ignored.
+	require.Empty(t, roots.Update(2, eth2p0.Root{}, eth2p0.Root{}))
+
+	// Changed current root affects proposer duties of this epoch and attester duties of next epoch.
+	require.Equal(t, []int64{2, 3}, roots.Update(2, root2, root1))
+
+	// Changed previous root affects attester duties of this epoch.
+	require.Equal(t, []int64{2}, roots.Update(2, root3, root1))
+}
+
+func TestSeenEvents(t *testing.T) {
+	seen := newSeenEvents()
+	slot := func(slot int64) core.Slot {
+		return core.Slot{Slot: slot, SlotsPerEpoch: 4}
+	}
+
+	head := eventKey{Topic: topicHead, Root: eth2p0.Root{1}}
+	block := eventKey{Topic: topicBlock, Root: eth2p0.Root{1}}
+
+	require.True(t, seen.Add(slot(1), head, 1))
+	require.False(t, seen.Add(slot(1), head, 1)) // Same event from another beacon node.
+	require.True(t, seen.Add(slot(1), block, 1)) // Different topic.
+
+	// Events older than trimEpochOffset epochs are trimmed.
+	require.True(t, seen.Add(slot(1+trimEpochOffset*4+1), eventKey{Topic: topicHead, Root: eth2p0.Root{2}}, 14))
+	require.Len(t, seen, 1)
+	require.True(t, seen.Add(slot(14), head, 1))
+}
+
+func TestReschedule(t *testing.T) {
+	ctx := context.Background()
+	valSet := beaconmock.ValidatorSetA
+
+	eth2Cl, err := beaconmock.New(
+		beaconmock.WithValidatorSet(valSet),
+		beaconmock.WithDeterministicAttesterDuties(1),
+		beaconmock.WithNoProposerDuties(),
+		beaconmock.WithSlotsPerEpoch(4),
+	)
+	require.NoError(t, err)
+
+	// After the reorg, all attester duties of epoch 1 are in slot 7.
+	var reorged bool
+	spreadFunc := eth2Cl.AttesterDutiesFunc
+	eth2Cl.AttesterDutiesFunc = func(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*eth2v1.AttesterDuty, error) {
+		duties, err := spreadFunc(ctx, epoch, indices)
+		for _, duty := range duties {
+			if reorged {
+				duty.Slot = 7
+			}
+		}
+
+		return duties, err
+	}
+
+	pubkeys, err := valSet.CorePubKeys()
+	require.NoError(t, err)
+
+	sched, err := New(pubkeys, eth2Cl, func(int64) bool { return false })
+	require.NoError(t, err)
+
+	// Attester duties of epoch 1 are spread over slots 4, 5 and 6.
+	slot := core.Slot{
+		Slot:          4,
+		Time:          time.Now(),
+		SlotsPerEpoch: 4,
+		SlotDuration:  time.Second,
+	}
+	require.NoError(t, sched.resolveDuties(ctx, slot))
+
+	headEvent := func(block, previous eth2p0.Root) *eth2v1.Event {
+		return &eth2v1.Event{
+			Topic: topicHead,
+			Data: &eth2v1.HeadEvent{
+				Slot:                      4,
+				Block:                     block,
+				PreviousDutyDependentRoot: previous,
+				CurrentDutyDependentRoot:  eth2p0.Root{1},
+			},
+		}
+	}
+
+	sched.processEvent(ctx, slot, headEvent(eth2p0.Root{4}, eth2p0.Root{2}))
+	reorged = true
+	sched.processEvent(ctx, slot, headEvent(eth2p0.Root{5}, eth2p0.Root{3}))
+
+	assertDuty := func(slot int64, pubkeys ...core.PubKey) {
+		t.Helper()
+
+		for _, duty := range []core.Duty{core.NewAttesterDuty(slot), core.NewAggregatorDuty(slot)} {
+			defSet, ok := sched.getDutyDefinitionSet(duty)
+			require.Equal(t, len(pubkeys) > 0, ok)
+			require.Len(t, defSet, len(pubkeys))
+
+			for _, pubkey := range pubkeys {
+				require.Contains(t, defSet, pubkey)
+			}
+		}
+	}
+
+	pubkey := func(vIdx eth2p0.ValidatorIndex) core.PubKey {
+		t.Helper()
+
+		pk, err := core.PubKeyFromBytes(valSet[vIdx].Validator.PublicKey[:])
+		require.NoError(t, err)
+
+		return pk
+	}
+
+	assertDuty(4, pubkey(1)) // Current slot duties are not rescheduled.
+	assertDuty(5)
+	assertDuty(6)
+	assertDuty(7, pubkey(2), pubkey(3)) // First validator already attested in epoch.
+}
@@ -8,6 +8,7 @@ package sigagg
 
 import (
 	"context"
+	"sort"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
@@ -15,28 +16,43 @@ import (
 	"github.com/obolnetwork/charon/app/tracer"
 	"github.com/obolnetwork/charon/app/z"
 	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/p2p"
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/tbls/tblsconv"
 )
 
-// New returns a new aggregator instance.
-func New(threshold int, verifyFunc func(context.Context, core.PubKey, core.SignedData) error) (*Aggregator, error) {
+// maxSubsets is the maximum number of threshold subsets of valid partial signatures tried per DV
+// if the aggregate signature of the first threshold partial signatures is invalid.
+const maxSubsets = 16
+
+// VerifyFunc verifies the signatures of the eth2 signed datas against the respective public keys.
+// It returns a verification error per data, or an error if verification could not be performed.
+type VerifyFunc func(ctx context.Context, datas []core.Eth2SignedData, pubkeys []tbls.PublicKey) ([]error, error)
+
+// New returns a new aggregator instance. The public shares by DV public key are used to verify partial signatures
+// if the aggregate signature is invalid, DVs without public shares are aggregated without partial signature verification.
+// The optional peers are used to identify the peers of invalid partial signatures.
+func New(threshold int, peers []p2p.Peer, pubSharesByKey map[core.PubKey]map[int]tbls.PublicKey, verifyFunc VerifyFunc) (*Aggregator, error) {
 	if threshold <= 0 {
 		return nil, errors.New("invalid threshold", z.Int("threshold", threshold))
 	}
 
 	return &Aggregator{
-		threshold:  threshold,
-		verifyFunc: verifyFunc,
+		threshold:      threshold,
+		peers:          peers,
+		pubSharesByKey: pubSharesByKey,
+		verifyFunc:     verifyFunc,
 	}, nil
 }
 
 // Aggregator aggregates *threshold* partial signed duty data objects
 // into an aggregated signed duty data object ready to be broadcasted.
 type Aggregator struct {
-	threshold  int
-	verifyFunc func(context.Context, core.PubKey, core.SignedData) error
-	subs       []func(context.Context, core.Duty, core.SignedDataSet) error
+	threshold      int
+	peers          []p2p.Peer
+	pubSharesByKey map[core.PubKey]map[int]tbls.PublicKey
+	verifyFunc     VerifyFunc
+	subs           []func(context.Context, core.Duty, core.SignedDataSet) error
 }
 
 // Subscribe registers a callback for aggregated signed duty data.
@@ -45,6 +61,10 @@ func (a *Aggregator) Subscribe(fn func(context.Context, core.Duty, core.SignedDa
 }
 
 // Aggregate aggregates the partially signed duty datas for the set of DVs.
+// The first threshold partial signatures (by share index) of each DV are aggregated and the aggregate signatures
+// are batch verified. Invalid or inconsistent partial signatures of DVs that failed to aggregate or with invalid
+// aggregate signatures are excluded and other threshold subsets of the remaining partial signatures are tried.
+// The successfully aggregated DVs are published even if others failed, in which case an error for the failed DVs is returned.
 func (a *Aggregator) Aggregate(ctx context.Context, duty core.Duty, set map[core.PubKey][]core.ParSignedData) error {
 	ctx = log.WithTopic(ctx, "sigagg")
 
@@ -52,16 +72,62 @@ func (a *Aggregator) Aggregate(ctx context.Context, duty core.Duty, set map[core
 		return errors.New("empty partial signed data set")
 	}
 
-	output := make(core.SignedDataSet)
+	var (
+		output = make(core.SignedDataSet)
+		failed = make(map[core.PubKey]error)
+		retry  = make(map[core.PubKey]error)
+	)
 	for pubkey, parSigs := range set {
-		signed, err := a.aggregate(ctx, pubkey, parSigs)
+		signed, err := a.aggregate(ctx, parSigs)
+		if err != nil && len(uniqueSorted(parSigs)) < a.threshold {
+			failed[pubkey] = err
+			continue
+		} else if err != nil {
+			// Inconsistent partial signed data, e.g. mismatching sidecars, so exclude the offending partial signatures.
+			retry[pubkey] = err
+			continue
+		}
+
+		output[pubkey] = signed
+	}
+
+	if len(output) > 0 {
+		invalid, err := a.verify(ctx, output)
 		if err != nil {
-			return errors.Wrap(err, "threshold aggregate", z.Any("pubkey", pubkey))
+			return err
+		}
+
+		for pubkey, verifyErr := range invalid {
+			delete(output, pubkey)
+			retry[pubkey] = verifyErr
+		}
+	}
+
+	for pubkey, retryErr := range retry {
+		log.Debug(ctx, "Threshold aggregation failed, verifying partial signatures", z.Any("pubkey", pubkey), z.Str("err", retryErr.Error()))
+
+		signed, err := a.aggregateValid(ctx, pubkey, uniqueSorted(set[pubkey]))
+		if err != nil {
+			failed[pubkey] = err
+			continue
 		}
 
 		output[pubkey] = signed
 	}
 
+	if err := a.publish(ctx, duty, output); err != nil {
+		return err
+	}
+
+	return failedError(failed)
+}
+
+// publish calls the subscriptions with the aggregated signed data set if it isn't empty.
+func (a *Aggregator) publish(ctx context.Context, duty core.Duty, output core.SignedDataSet) error {
+	if len(output) == 0 {
+		return nil
+	}
+
 	log.Debug(ctx, "Threshold aggregated partial signatures")
 
 	// Call subscriptions.
@@ -80,26 +146,329 @@ func (a *Aggregator) Aggregate(ctx context.Context, duty core.Duty, set map[core
 	return nil
 }
 
-// aggregate threshold aggregates the partial signed data for a provided DV.
-func (a *Aggregator) aggregate(ctx context.Context, pubkey core.PubKey, parSigs []core.ParSignedData) (core.SignedData, error) {
+// failedError returns the error of the first failed DV by public key including all failed DVs, or nil if none failed.
+func failedError(failed map[core.PubKey]error) error {
+	if len(failed) == 0 {
+		return nil
+	}
+
+	var pubkeys []core.PubKey
+	for pubkey := range failed {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	sort.Slice(pubkeys, func(i, j int) bool {
+		return pubkeys[i] < pubkeys[j]
+	})
+
+	return errors.Wrap(failed[pubkeys[0]], "threshold aggregate", z.Any("pubkeys", pubkeys))
+}
+
+// aggregate threshold aggregates the first threshold partial signed data by share index for a provided DV.
+func (a *Aggregator) aggregate(ctx context.Context, parSigs []core.ParSignedData) (core.SignedData, error) {
 	if len(parSigs) < a.threshold {
 		return nil, errors.New("require threshold signatures")
 	}
 
-	// Get all partial signatures.
+	parSigs = uniqueSorted(parSigs)
+	if len(parSigs) < a.threshold {
+		return nil, errors.New("number of partial signatures less than threshold", z.Int("threshold", a.threshold), z.Int("got", len(parSigs)))
+	}
+
+	return thresholdAggregate(ctx, parSigs[:a.threshold])
+}
+
+// aggregateValid verifies the partial signed data for a provided DV against the public shares, excluding
+// invalid partial signatures and those with mismatching sidecars, and returns the first aggregate of a threshold
+// subset that verifies. The partial signed data must be unique by share index and sorted.
+func (a *Aggregator) aggregateValid(ctx context.Context, pubkey core.PubKey, parSigs []core.ParSignedData) (core.SignedData, error) {
+	parSigs, invalidShares, err := excludeMismatchingSidecars(parSigs)
+	if err != nil {
+		return nil, err
+	}
+
+	if pubshares, ok := a.pubSharesByKey[pubkey]; ok {
+		var invalidPartials []int
+		parSigs, invalidPartials, err = a.verifyPartials(ctx, pubshares, parSigs)
+		if err != nil {
+			return nil, err
+		}
+
+		invalidShares = append(invalidShares, invalidPartials...)
+		sort.Ints(invalidShares)
+	}
+
+	if len(invalidShares) > 0 {
+		log.Warn(ctx, "Excluding invalid partial signatures from threshold aggregation", nil,
+			z.Any("pubkey", pubkey), z.Any("share_idxs", invalidShares), z.Any("peers", a.peerNames(invalidShares)))
+	}
+
+	if len(parSigs) < a.threshold {
+		return nil, errors.Wrap(core.InvalidSharesError{ShareIdxs: invalidShares}, "insufficient valid partial signatures",
+			z.Int("threshold", a.threshold), z.Int("valid", len(parSigs)))
+	}
+
+	tblsPubkey, err := tblsconv.PubkeyFromCore(pubkey)
+	if err != nil {
+		return nil, errors.Wrap(err, "pubkey from core")
+	}
+
+	for _, subset := range thresholdSubsets(len(parSigs), a.threshold, maxSubsets) {
+		var subsetSigs []core.ParSignedData
+		for _, i := range subset {
+			subsetSigs = append(subsetSigs, parSigs[i])
+		}
+
+		signed, err := thresholdAggregate(ctx, subsetSigs)
+		if err != nil {
+			log.Debug(ctx, "Threshold subset aggregation failed", z.Any("pubkey", pubkey), z.Str("err", err.Error()))
+			continue
+		}
+
+		eth2Signed, ok := signed.(core.Eth2SignedData)
+		if !ok {
+			return nil, errors.New("invalid eth2 signed data")
+		}
+
+		errs, err := a.verifyFunc(ctx, []core.Eth2SignedData{eth2Signed}, []tbls.PublicKey{tblsPubkey})
+		if err != nil {
+			return nil, err
+		} else if errs[0] == nil {
+			return signed, nil
+		}
+	}
+
+	if len(invalidShares) > 0 {
+		return nil, errors.Wrap(core.InvalidSharesError{ShareIdxs: invalidShares}, "no threshold subset of valid partial signatures verified")
+	}
+
+	return nil, errors.New("no threshold subset of partial signatures verified")
+}
+
+// peerNames returns the names of the peers of the share indexes.
+func (a *Aggregator) peerNames(shareIdxs []int) []string {
+	var resp []string
+	for _, shareIdx := range shareIdxs {
+		for _, p := range a.peers {
+			if p.ShareIdx() == shareIdx {
+				resp = append(resp, p.Name)
+			}
+		}
+	}
+
+	return resp
+}
+
+// verify batch verifies the aggregate signatures of the set and returns the verification errors of invalid signatures by DV.
+func (a *Aggregator) verify(ctx context.Context, set core.SignedDataSet) (map[core.PubKey]error, error) {
+	var (
+		pubkeys     []core.PubKey
+		tblsPubkeys []tbls.PublicKey
+		datas       []core.Eth2SignedData
+	)
+	for pubkey, data := range set {
+		tblsPubkey, err := tblsconv.PubkeyFromCore(pubkey)
+		if err != nil {
+			return nil, errors.Wrap(err, "pubkey from core")
+		}
+
+		eth2Signed, ok := data.(core.Eth2SignedData)
+		if !ok {
+			return nil, errors.New("invalid eth2 signed data", z.Any("pubkey", pubkey))
+		}
+
+		pubkeys = append(pubkeys, pubkey)
+		tblsPubkeys = append(tblsPubkeys, tblsPubkey)
+		datas = append(datas, eth2Signed)
+	}
+
+	errs, err := a.verifyFunc(ctx, datas, tblsPubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	invalid := make(map[core.PubKey]error)
+	for i, err := range errs {
+		if err != nil {
+			invalid[pubkeys[i]] = err
+		}
+	}
+
+	return invalid, nil
+}
+
+// excludeMismatchingSidecars returns the partial signed data with the sidecars that most partial signed data
+// agree on and the share indexes of those with mismatching sidecars. Sidecars aren't included in the message root,
+// so partial signed data with matching message roots can still have different sidecars.
+// Ties are broken by the lowest share index. The partial signed data must be sorted by share index.
+func excludeMismatchingSidecars(parSigs []core.ParSignedData) ([]core.ParSignedData, []int, error) {
+	var (
+		keys      []string
+		byKey     = make(map[string][]core.ParSignedData)
+		bestCount int
+		bestKey   string
+	)
+	for _, parSig := range parSigs {
+		key, err := sidecarsKey(parSig.SignedData)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], parSig)
+	}
+
+	for _, key := range keys {
+		if len(byKey[key]) > bestCount {
+			bestCount = len(byKey[key])
+			bestKey = key
+		}
+	}
+
+	var mismatching []int
+	for _, key := range keys {
+		if key == bestKey {
+			continue
+		}
+
+		for _, parSig := range byKey[key] {
+			mismatching = append(mismatching, parSig.ShareIdx)
+		}
+	}
+
+	sort.Ints(mismatching)
+
+	return byKey[bestKey], mismatching, nil
+}
+
+// sidecarsKey returns the concatenated message roots of the sidecars of the signed data,
+// or an empty key if it doesn't have sidecars.
+func sidecarsKey(data core.SignedData) (string, error) {
+	sidecarData, ok := data.(core.SidecarSignedData)
+	if !ok {
+		return "", nil
+	}
+
+	var key []byte
+	for _, sidecar := range sidecarData.Sidecars() {
+		root, err := sidecar.MessageRoot()
+		if err != nil {
+			return "", errors.Wrap(err, "sidecar message root")
+		}
+
+		key = append(key, root[:]...)
+	}
+
+	return string(key), nil
+}
+
+// verifyPartials batch verifies the partial signed data against the public shares.
+// It returns the valid partial signed data and the share indexes of the invalid partial signatures.
+func (a *Aggregator) verifyPartials(ctx context.Context, pubshares map[int]tbls.PublicKey, parSigs []core.ParSignedData,
+) ([]core.ParSignedData, []int, error) {
+	var (
+		candidates  []core.ParSignedData
+		tblsPubkeys []tbls.PublicKey
+		datas       []core.Eth2SignedData
+		invalid     []int
+	)
+	for _, parSig := range parSigs {
+		pubshare, ok := pubshares[parSig.ShareIdx]
+		if !ok {
+			invalid = append(invalid, parSig.ShareIdx)
+			continue
+		}
+
+		eth2Signed, ok := parSig.SignedData.(core.Eth2SignedData)
+		if !ok {
+			return nil, nil, errors.New("invalid eth2 signed data")
+		}
+
+		candidates = append(candidates, parSig)
+		tblsPubkeys = append(tblsPubkeys, pubshare)
+		datas = append(datas, eth2Signed)
+	}
+
+	errs, err := a.verifyFunc(ctx, datas, tblsPubkeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var valid []core.ParSignedData
+	for i, err := range errs {
+		if err != nil {
+			invalid = append(invalid, candidates[i].ShareIdx)
+			continue
+		}
+
+		valid = append(valid, candidates[i])
+	}
+
+	sort.Ints(invalid)
+
+	return valid, invalid, nil
+}
+
+// thresholdAggregate threshold aggregates the partial signed data.
+func thresholdAggregate(ctx context.Context, parSigs []core.ParSignedData) (core.SignedData, error) {
+	sig, err := thresholdAggregateSigs(ctx, parSigs, func(parSig core.ParSignedData) (core.Signature, error) {
+		return parSig.Signature(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject signature into one of the parSigs resulting in aggregate signed data.
+	resp, err := parSigs[0].SetSignature(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	sidecarData, ok := resp.(core.SidecarSignedData)
+	if !ok || len(sidecarData.Sidecars()) == 0 {
+		return resp, nil
+	}
+
+	// Also aggregate the signatures of each sidecar, e.g. deneb blob sidecars.
+	var sidecarSigs []core.Signature
+	for i := range sidecarData.Sidecars() {
+		sig, err := thresholdAggregateSigs(ctx, parSigs, func(parSig core.ParSignedData) (core.Signature, error) {
+			parSidecarData, ok := parSig.SignedData.(core.SidecarSignedData)
+			if !ok || len(parSidecarData.Sidecars()) != len(sidecarData.Sidecars()) {
+				return nil, errors.New("mismatching partial signed sidecars", z.Int("share_idx", parSig.ShareIdx))
+			}
+
+			return parSidecarData.Sidecars()[i].Signature(), nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "aggregate sidecar signatures", z.Int("sidecar", i))
+		}
+
+		sidecarSigs = append(sidecarSigs, sig)
+	}
+
+	return sidecarData.SetSidecarSignatures(sidecarSigs)
+}
+
+// thresholdAggregateSigs returns the threshold aggregated signature of the partial signatures returned by sigFunc.
+func thresholdAggregateSigs(ctx context.Context, parSigs []core.ParSignedData,
+	sigFunc func(core.ParSignedData) (core.Signature, error),
+) (core.Signature, error) {
 	blsSigs := make(map[int]tbls.Signature)
 	for _, parSig := range parSigs {
-		sig, err := tblsconv.SigFromCore(parSig.Signature())
+		coreSig, err := sigFunc(parSig)
+		if err != nil {
+			return nil, err
+		}
+
+		sig, err := tblsconv.SigFromCore(coreSig)
 		if err != nil {
 			return nil, errors.Wrap(err, "signature from core")
 		}
 		blsSigs[parSig.ShareIdx] = sig
 	}
 
-	if len(blsSigs) < a.threshold {
-		return nil, errors.New("number of partial signatures less than threshold", z.Int("threshold", a.threshold), z.Int("got", len(blsSigs)))
-	}
-
 	// Aggregate signatures
 	_, span := tracer.Start(ctx, "tbls.Aggregate")
 	sig, err := tbls.ThresholdAggregate(blsSigs)
@@ -108,37 +477,59 @@ func (a *Aggregator) aggregate(ctx context.Context, pubkey core.PubKey, parSigs
 		return nil, err
 	}
 
-	// Inject signature into one of the parSigs resulting in aggregate signed data.
-	aggSig, err := parSigs[0].SetSignature(tblsconv.SigToCore(sig))
-	if err != nil {
-		return nil, err
+	return tblsconv.SigToCore(sig), nil
+}
+
+// uniqueSorted returns the partial signed data sorted by share index, excluding duplicate share indexes.
+func uniqueSorted(parSigs []core.ParSignedData) []core.ParSignedData {
+	byIdx := make(map[int]core.ParSignedData)
+	for _, parSig := range parSigs {
+		byIdx[parSig.ShareIdx] = parSig
 	}
 
-	if err := a.verifyFunc(ctx, pubkey, aggSig); err != nil {
-		return nil, err
+	var resp []core.ParSignedData
+	for _, parSig := range byIdx {
+		resp = append(resp, parSig)
 	}
 
-	return aggSig, nil
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].ShareIdx < resp[j].ShareIdx
+	})
+
+	return resp
 }
 
-// NewVerifier returns a signature verification function for aggregated signatures.
-func NewVerifier(eth2Cl eth2wrap.Client) func(context.Context, core.PubKey, core.SignedData) error {
-	return func(ctx context.Context, pubkey core.PubKey, data core.SignedData) error {
-		tblsPubkey, err := tblsconv.PubkeyFromCore(pubkey)
-		if err != nil {
-			return errors.Wrap(err, "pubkey from core")
+// thresholdSubsets returns up to limit subsets of size k of the indexes [0, n) in lexicographic order.
+func thresholdSubsets(n, k, limit int) [][]int {
+	var (
+		resp    [][]int
+		subset  []int
+		recurse func(start int)
+	)
+	recurse = func(start int) {
+		if len(resp) >= limit {
+			return
 		}
 
-		eth2Signed, ok := data.(core.Eth2SignedData)
-		if !ok {
-			return errors.New("invalid eth2 signed data")
+		if len(subset) == k {
+			resp = append(resp, append([]int(nil), subset...))
+			return
 		}
 
-		err = core.VerifyEth2SignedData(ctx, eth2Cl, eth2Signed, tblsPubkey)
-		if err != nil {
-			return errors.Wrap(err, "aggregate signature verification failed")
+		for i := start; i <= n-(k-len(subset)); i++ {
+			subset = append(subset, i)
+			recurse(i + 1)
+			subset = subset[:len(subset)-1]
 		}
+	}
+	recurse(0)
 
-		return nil
+	return resp
+}
+
+// NewVerifier returns a signature verification function that batch verifies the signatures of eth2 signed data.
+func NewVerifier(eth2Cl eth2wrap.Client) VerifyFunc {
+	return func(ctx context.Context, datas []core.Eth2SignedData, pubkeys []tbls.PublicKey) ([]error, error) {
+		return core.VerifyEth2SignedDataBatch(ctx, eth2Cl, datas, pubkeys)
 	}
 }
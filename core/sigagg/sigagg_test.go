@@ -14,6 +14,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
@@ -38,12 +39,12 @@ func TestSigAgg(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("invalid threshold", func(t *testing.T) {
-		_, err := sigagg.New(0, sigagg.NewVerifier(bmock))
+		_, err := sigagg.New(0, nil, nil, sigagg.NewVerifier(bmock))
 		require.ErrorContains(t, err, "invalid threshold")
 	})
 
 	t.Run("threshold sigs", func(t *testing.T) {
-		agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock))
+		agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
 		require.NoError(t, err)
 		err = agg.Aggregate(ctx, core.Duty{}, map[core.PubKey][]core.ParSignedData{"": nil})
 		require.ErrorContains(t, err, "require threshold signatures")
@@ -59,7 +60,7 @@ func TestSigAgg(t *testing.T) {
 			parsigs = append(parsigs, parsig)
 		}
 
-		agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock))
+		agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
 		require.NoError(t, err)
 		err = agg.Aggregate(ctx, core.Duty{}, map[core.PubKey][]core.ParSignedData{"": parsigs})
 		require.ErrorContains(t, err, "number of partial signatures less than threshold")
@@ -123,7 +124,7 @@ func TestSigAgg_DutyAttester(t *testing.T) {
 	require.NoError(t, err)
 	expect := tblsconv.SigToCore(aggSig)
 
-	agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock))
+	agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
 	require.NoError(t, err)
 
 	corePubKey := core.PubKeyFrom48Bytes(pubKey)
@@ -200,7 +201,7 @@ func TestSigAgg_DutyRandao(t *testing.T) {
 	require.NoError(t, err)
 	expect := tblsconv.SigToCore(aggSig)
 
-	agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock))
+	agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
 	require.NoError(t, err)
 
 	corePubkey := core.PubKeyFrom48Bytes(pubKey)
@@ -222,6 +223,128 @@ func TestSigAgg_DutyRandao(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSigAgg_InvalidShares(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		threshold = 3
+		peers     = 4
+		epoch     = 123
+	)
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	randao := core.NewSignedRandao(epoch, eth2p0.BLSSignature{})
+	randaoRoot, err := randao.MessageRoot()
+	require.NoError(t, err)
+
+	msg, err := signing.GetDataRoot(ctx, bmock, randao.DomainName(), epoch, randaoRoot)
+	require.NoError(t, err)
+
+	secretKey, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	pubKey, err := tbls.SecretToPublicKey(secretKey)
+	require.NoError(t, err)
+
+	secrets, err := tbls.ThresholdSplit(secretKey, peers, threshold)
+	require.NoError(t, err)
+
+	corePubkey := core.PubKeyFrom48Bytes(pubKey)
+	pubshares := make(map[int]tbls.PublicKey)
+	for idx, secret := range secrets {
+		pubshares[idx], err = tbls.SecretToPublicKey(secret)
+		require.NoError(t, err)
+	}
+
+	// newParSigs returns partial signatures of all shares, with the invalid shares signed by a random key.
+	newParSigs := func(invalidShares ...int) []core.ParSignedData {
+		var parsigs []core.ParSignedData
+		for idx, secret := range secrets {
+			for _, invalid := range invalidShares {
+				if idx == invalid {
+					secret, err = tbls.GenerateSecretKey()
+					require.NoError(t, err)
+				}
+			}
+
+			sig, err := tbls.Sign(secret, msg[:])
+			require.NoError(t, err)
+
+			parsigs = append(parsigs, core.NewPartialSignedRandao(epoch, tblsconv.SigToETH2(sig), idx))
+		}
+
+		return parsigs
+	}
+
+	t.Run("one invalid share", func(t *testing.T) {
+		agg, err := sigagg.New(threshold, nil, map[core.PubKey]map[int]tbls.PublicKey{corePubkey: pubshares}, sigagg.NewVerifier(bmock))
+		require.NoError(t, err)
+
+		var called bool
+		agg.Subscribe(func(_ context.Context, _ core.Duty, set core.SignedDataSet) error {
+			sig, err := tblsconv.SigFromCore(set[corePubkey].Signature())
+			require.NoError(t, err)
+			require.NoError(t, tbls.Verify(pubKey, msg[:], sig))
+			called = true
+
+			return nil
+		})
+
+		err = agg.Aggregate(ctx, core.Duty{Type: core.DutyRandao}, toMap(corePubkey, newParSigs(2)))
+		require.NoError(t, err)
+		require.True(t, called)
+	})
+
+	t.Run("insufficient valid shares", func(t *testing.T) {
+		agg, err := sigagg.New(threshold, nil, map[core.PubKey]map[int]tbls.PublicKey{corePubkey: pubshares}, sigagg.NewVerifier(bmock))
+		require.NoError(t, err)
+
+		agg.Subscribe(func(context.Context, core.Duty, core.SignedDataSet) error {
+			require.Fail(t, "unexpected aggregate")
+			return nil
+		})
+
+		err = agg.Aggregate(ctx, core.Duty{Type: core.DutyRandao}, toMap(corePubkey, newParSigs(1, 3)))
+		require.ErrorContains(t, err, "insufficient valid partial signatures")
+
+		var sharesErr core.InvalidSharesError
+		require.ErrorAs(t, err, &sharesErr)
+		require.Equal(t, []int{1, 3}, sharesErr.ShareIdxs)
+	})
+
+	t.Run("publish aggregated DVs", func(t *testing.T) {
+		agg, err := sigagg.New(threshold, nil, map[core.PubKey]map[int]tbls.PublicKey{corePubkey: pubshares}, sigagg.NewVerifier(bmock))
+		require.NoError(t, err)
+
+		var called bool
+		agg.Subscribe(func(_ context.Context, _ core.Duty, set core.SignedDataSet) error {
+			require.Len(t, set, 1)
+			require.Contains(t, set, corePubkey)
+			called = true
+
+			return nil
+		})
+
+		failedPubkey := testutil.RandomCorePubKey(t)
+		err = agg.Aggregate(ctx, core.Duty{Type: core.DutyRandao}, map[core.PubKey][]core.ParSignedData{
+			corePubkey:   newParSigs(2),
+			failedPubkey: newParSigs()[:threshold-1],
+		})
+		require.ErrorContains(t, err, "require threshold signatures")
+		require.True(t, called)
+	})
+
+	t.Run("unknown pubshares", func(t *testing.T) {
+		agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
+		require.NoError(t, err)
+
+		err = agg.Aggregate(ctx, core.Duty{Type: core.DutyRandao}, toMap(corePubkey, newParSigs(1)))
+		require.NoError(t, err)
+	})
+}
+
 func TestSigAgg_DutyExit(t *testing.T) {
 	ctx := context.Background()
 
@@ -281,7 +404,7 @@ func TestSigAgg_DutyExit(t *testing.T) {
 	require.NoError(t, err)
 	expect := tblsconv.SigToCore(aggSig)
 
-	agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock))
+	agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
 	require.NoError(t, err)
 
 	corePubkey := core.PubKeyFrom48Bytes(pubKey)
@@ -420,7 +543,7 @@ func TestSigAgg_DutyProposer(t *testing.T) {
 			require.NoError(t, err)
 			expect := tblsconv.SigToCore(aggSig)
 
-			agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock))
+			agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
 			require.NoError(t, err)
 
 			corePubkey := core.PubKeyFrom48Bytes(pubKey)
@@ -444,6 +567,114 @@ func TestSigAgg_DutyProposer(t *testing.T) {
 	}
 }
 
+func TestSigAgg_DutyProposerBlockContents(t *testing.T) {
+	ctx := context.Background()
+
+	const (
+		threshold = 3
+		peers     = 4
+	)
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	secretKey, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+
+	pubKey, err := tbls.SecretToPublicKey(secretKey)
+	require.NoError(t, err)
+
+	secrets, err := tbls.ThresholdSplit(secretKey, peers, threshold)
+	require.NoError(t, err)
+
+	block := testutil.RandomDenebCoreVersionedSignedBeaconBlock()
+	block.SignedBlobSidecars = []*deneb.SignedBlobSidecar{
+		testutil.RandomDenebSignedBlobSidecar(0),
+		testutil.RandomDenebSignedBlobSidecar(1),
+	}
+
+	signRoot := func(secret tbls.PrivateKey, data core.Eth2SignedData) core.Signature {
+		msgRoot, err := data.MessageRoot()
+		require.NoError(t, err)
+
+		epoch, err := data.Epoch(ctx, bmock)
+		require.NoError(t, err)
+
+		msg, err := signing.GetDataRoot(ctx, bmock, data.DomainName(), epoch, msgRoot)
+		require.NoError(t, err)
+
+		sig, err := tbls.Sign(secret, msg[:])
+		require.NoError(t, err)
+
+		return tblsconv.SigToCore(sig)
+	}
+
+	// Sign the block and each blob sidecar with each share.
+	var parsigs []core.ParSignedData
+	for idx, secret := range secrets {
+		var sidecarSigs []core.Signature
+		for _, sidecar := range block.Sidecars() {
+			sidecarSigs = append(sidecarSigs, signRoot(secret, sidecar))
+		}
+
+		signed, err := block.SetSidecarSignatures(sidecarSigs)
+		require.NoError(t, err)
+
+		signed, err = signed.SetSignature(signRoot(secret, block))
+		require.NoError(t, err)
+
+		parsigs = append(parsigs, core.ParSignedData{SignedData: signed, ShareIdx: idx})
+	}
+
+	// The first share signs the same block with a mismatching sidecar.
+	mismatching := block
+	mismatching.SignedBlobSidecars = []*deneb.SignedBlobSidecar{testutil.RandomDenebSignedBlobSidecar(0)}
+
+	mismatchingSigned, err := mismatching.SetSidecarSignatures([]core.Signature{signRoot(secrets[1], mismatching.Sidecars()[0])})
+	require.NoError(t, err)
+
+	mismatchingSigned, err = mismatchingSigned.SetSignature(signRoot(secrets[1], mismatching))
+	require.NoError(t, err)
+
+	corePubkey := core.PubKeyFrom48Bytes(pubKey)
+
+	tests := []struct {
+		name    string
+		parsigs []core.ParSignedData
+	}{
+		{
+			name:    "matching sidecars",
+			parsigs: parsigs,
+		},
+		{
+			name:    "one mismatching sidecar",
+			parsigs: append(parsigs, core.ParSignedData{SignedData: mismatchingSigned, ShareIdx: 1}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
+			require.NoError(t, err)
+
+			var done bool
+			agg.Subscribe(func(_ context.Context, _ core.Duty, set core.SignedDataSet) error {
+				signed, ok := set[corePubkey].(core.VersionedSignedBeaconBlock)
+				require.True(t, ok)
+				require.Len(t, signed.SignedBlobSidecars, 2)
+				require.NoError(t, core.VerifyEth2SignedData(ctx, bmock, signed, pubKey))
+				done = true
+
+				return nil
+			})
+
+			err = agg.Aggregate(ctx, core.Duty{Type: core.DutyProposer}, toMap(corePubkey, test.parsigs))
+			require.NoError(t, err)
+			require.True(t, done)
+		})
+	}
+}
+
 func TestSigAgg_DutyBuilderProposer(t *testing.T) {
 	ctx := context.Background()
 
@@ -541,7 +772,7 @@ func TestSigAgg_DutyBuilderProposer(t *testing.T) {
 			require.NoError(t, err)
 			expect := tblsconv.SigToCore(aggSig)
 
-			agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock))
+			agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
 			require.NoError(t, err)
 
 			corePubkey := core.PubKeyFrom48Bytes(pubKey)
@@ -650,7 +881,7 @@ func TestSigAgg_DutyBuilderRegistration(t *testing.T) {
 			require.NoError(t, err)
 			expect := tblsconv.SigToCore(aggSig)
 
-			agg, err := sigagg.New(threshold, sigagg.NewVerifier(bmock))
+			agg, err := sigagg.New(threshold, nil, nil, sigagg.NewVerifier(bmock))
 			require.NoError(t, err)
 
 			corePubkey := core.PubKeyFrom48Bytes(pubKey)
@@ -40,6 +40,12 @@ var (
 	_ SignedData = SyncContributionAndProof{}
 	_ SignedData = SignedSyncContributionAndProof{}
 	_ SignedData = SyncCommitteeSelection{}
+	_ SignedData = SignedBlobSidecar{}
+	_ SignedData = SignedBlindedBlobSidecar{}
+
+	// Deneb blocks also contain signed blob sidecars.
+	_ SidecarSignedData = VersionedSignedBeaconBlock{}
+	_ SidecarSignedData = VersionedSignedBlindedBeaconBlock{}
 
 	// Some types support SSZ marshalling and unmarshalling.
 	_ ssz.Marshaler   = VersionedSignedBeaconBlock{}
@@ -173,9 +179,81 @@ func NewPartialVersionedSignedBeaconBlock(block *eth2spec.VersionedSignedBeaconB
 	}, nil
 }
 
+// NewVersionedSignedBeaconBlockContents validates and returns a new wrapped VersionedSignedBeaconBlock
+// including the signed blob sidecars of deneb signed block contents.
+func NewVersionedSignedBeaconBlockContents(contents *eth2deneb.SignedBlockContents) (VersionedSignedBeaconBlock, error) {
+	resp, err := NewVersionedSignedBeaconBlock(&eth2spec.VersionedSignedBeaconBlock{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   contents.SignedBlock,
+	})
+	if err != nil {
+		return VersionedSignedBeaconBlock{}, err
+	}
+
+	resp.SignedBlobSidecars = nonEmpty(contents.SignedBlobSidecars)
+
+	return resp, nil
+}
+
+// NewPartialVersionedSignedBeaconBlockContents is a convenience function that returns a new partial signed block
+// including the partial signed blob sidecars of deneb signed block contents.
+func NewPartialVersionedSignedBeaconBlockContents(contents *eth2deneb.SignedBlockContents, shareIdx int) (ParSignedData, error) {
+	wrap, err := NewVersionedSignedBeaconBlockContents(contents)
+	if err != nil {
+		return ParSignedData{}, err
+	}
+
+	return ParSignedData{
+		SignedData: wrap,
+		ShareIdx:   shareIdx,
+	}, nil
+}
+
 // VersionedSignedBeaconBlock is a signed versioned beacon block and implements SignedData.
 type VersionedSignedBeaconBlock struct {
 	eth2spec.VersionedSignedBeaconBlock // Could subtype instead of embed, but aligning with Attestation that cannot subtype.
+	// SignedBlobSidecars are the signed blob sidecars of deneb signed block contents, nil otherwise.
+	SignedBlobSidecars []*deneb.SignedBlobSidecar
+}
+
+// Contents returns the deneb signed block contents including the signed blob sidecars.
+func (b VersionedSignedBeaconBlock) Contents() (*eth2deneb.SignedBlockContents, error) {
+	if b.Version != eth2spec.DataVersionDeneb {
+		return nil, errors.New("block contents only supported by deneb")
+	}
+
+	return &eth2deneb.SignedBlockContents{
+		SignedBlock:        b.Deneb,
+		SignedBlobSidecars: b.SignedBlobSidecars,
+	}, nil
+}
+
+// Sidecars returns the signed blob sidecars.
+func (b VersionedSignedBeaconBlock) Sidecars() []Eth2SignedData {
+	var resp []Eth2SignedData
+	for _, sidecar := range b.SignedBlobSidecars {
+		resp = append(resp, NewSignedBlobSidecar(sidecar))
+	}
+
+	return resp
+}
+
+// SetSidecarSignatures returns a copy of the signed block with the signed blob sidecar signatures replaced.
+func (b VersionedSignedBeaconBlock) SetSidecarSignatures(sigs []Signature) (SignedData, error) {
+	if len(sigs) != len(b.SignedBlobSidecars) {
+		return nil, errors.New("mismatching blob sidecar signatures")
+	}
+
+	resp, err := b.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, sig := range sigs {
+		resp.SignedBlobSidecars[i].Signature = sig.ToETH2()
+	}
+
+	return resp, nil
 }
 
 func (b VersionedSignedBeaconBlock) MessageRoot() ([32]byte, error) {
@@ -282,9 +360,15 @@ func (b VersionedSignedBeaconBlock) MarshalJSON() ([]byte, error) {
 		return nil, errors.Wrap(err, "convert version")
 	}
 
+	sidecars, err := marshalSidecarsJSON(b.SignedBlobSidecars)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := json.Marshal(versionedRawBlockJSON{
-		Version: version,
-		Block:   block,
+		Version:  version,
+		Block:    block,
+		Sidecars: sidecars,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal wrapper")
@@ -335,7 +419,13 @@ func (b *VersionedSignedBeaconBlock) UnmarshalJSON(input []byte) error {
 		return errors.New("unknown version")
 	}
 
+	sidecars, err := unmarshalSidecarsJSON[*deneb.SignedBlobSidecar](raw.Sidecars, resp.Version)
+	if err != nil {
+		return err
+	}
+
 	b.VersionedSignedBeaconBlock = resp
+	b.SignedBlobSidecars = sidecars
 
 	return nil
 }
@@ -375,9 +465,81 @@ func NewPartialVersionedSignedBlindedBeaconBlock(block *eth2api.VersionedSignedB
 	}, nil
 }
 
+// NewVersionedSignedBlindedBeaconBlockContents validates and returns a new wrapped VersionedSignedBlindedBeaconBlock
+// including the signed blinded blob sidecars of deneb signed blinded block contents.
+func NewVersionedSignedBlindedBeaconBlockContents(contents *eth2deneb.SignedBlindedBlockContents) (VersionedSignedBlindedBeaconBlock, error) {
+	resp, err := NewVersionedSignedBlindedBeaconBlock(&eth2api.VersionedSignedBlindedBeaconBlock{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   contents.SignedBlindedBlock,
+	})
+	if err != nil {
+		return VersionedSignedBlindedBeaconBlock{}, err
+	}
+
+	resp.SignedBlindedBlobSidecars = nonEmpty(contents.SignedBlindedBlobSidecars)
+
+	return resp, nil
+}
+
+// NewPartialVersionedSignedBlindedBeaconBlockContents is a convenience function that returns a new partial signed
+// blinded block including the partial signed blinded blob sidecars of deneb signed blinded block contents.
+func NewPartialVersionedSignedBlindedBeaconBlockContents(contents *eth2deneb.SignedBlindedBlockContents, shareIdx int) (ParSignedData, error) {
+	wrap, err := NewVersionedSignedBlindedBeaconBlockContents(contents)
+	if err != nil {
+		return ParSignedData{}, err
+	}
+
+	return ParSignedData{
+		SignedData: wrap,
+		ShareIdx:   shareIdx,
+	}, nil
+}
+
 // VersionedSignedBlindedBeaconBlock is a signed versioned blinded beacon block and implements SignedData.
 type VersionedSignedBlindedBeaconBlock struct {
 	eth2api.VersionedSignedBlindedBeaconBlock // Could subtype instead of embed, but aligning with Attestation that cannot subtype.
+	// SignedBlindedBlobSidecars are the signed blinded blob sidecars of deneb signed blinded block contents, nil otherwise.
+	SignedBlindedBlobSidecars []*eth2deneb.SignedBlindedBlobSidecar
+}
+
+// Contents returns the deneb signed blinded block contents including the signed blinded blob sidecars.
+func (b VersionedSignedBlindedBeaconBlock) Contents() (*eth2deneb.SignedBlindedBlockContents, error) {
+	if b.Version != eth2spec.DataVersionDeneb {
+		return nil, errors.New("blinded block contents only supported by deneb")
+	}
+
+	return &eth2deneb.SignedBlindedBlockContents{
+		SignedBlindedBlock:        b.Deneb,
+		SignedBlindedBlobSidecars: b.SignedBlindedBlobSidecars,
+	}, nil
+}
+
+// Sidecars returns the signed blinded blob sidecars.
+func (b VersionedSignedBlindedBeaconBlock) Sidecars() []Eth2SignedData {
+	var resp []Eth2SignedData
+	for _, sidecar := range b.SignedBlindedBlobSidecars {
+		resp = append(resp, NewSignedBlindedBlobSidecar(sidecar))
+	}
+
+	return resp
+}
+
+// SetSidecarSignatures returns a copy of the signed blinded block with the signed blinded blob sidecar signatures replaced.
+func (b VersionedSignedBlindedBeaconBlock) SetSidecarSignatures(sigs []Signature) (SignedData, error) {
+	if len(sigs) != len(b.SignedBlindedBlobSidecars) {
+		return nil, errors.New("mismatching blinded blob sidecar signatures")
+	}
+
+	resp, err := b.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, sig := range sigs {
+		resp.SignedBlindedBlobSidecars[i].Signature = sig.ToETH2()
+	}
+
+	return resp, nil
 }
 
 func (b VersionedSignedBlindedBeaconBlock) MessageRoot() ([32]byte, error) {
@@ -470,9 +632,15 @@ func (b VersionedSignedBlindedBeaconBlock) MarshalJSON() ([]byte, error) {
 		return nil, errors.Wrap(err, "convert version")
 	}
 
+	sidecars, err := marshalSidecarsJSON(b.SignedBlindedBlobSidecars)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := json.Marshal(versionedRawBlockJSON{
-		Version: version,
-		Block:   block,
+		Version:  version,
+		Block:    block,
+		Sidecars: sidecars,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal wrapper")
@@ -511,15 +679,61 @@ func (b *VersionedSignedBlindedBeaconBlock) UnmarshalJSON(input []byte) error {
 		return errors.New("unknown version")
 	}
 
+	sidecars, err := unmarshalSidecarsJSON[*eth2deneb.SignedBlindedBlobSidecar](raw.Sidecars, resp.Version)
+	if err != nil {
+		return err
+	}
+
 	b.VersionedSignedBlindedBeaconBlock = resp
+	b.SignedBlindedBlobSidecars = sidecars
 
 	return nil
 }
 
 // versionedRawBlockJSON is a custom VersionedSignedBeaconBlock or VersionedSignedBlindedBeaconBlock serialiser.
 type versionedRawBlockJSON struct {
-	Version eth2util.DataVersion `json:"version"`
-	Block   json.RawMessage      `json:"block"`
+	Version  eth2util.DataVersion `json:"version"`
+	Block    json.RawMessage      `json:"block"`
+	Sidecars json.RawMessage      `json:"blob_sidecars,omitempty"`
+}
+
+// marshalSidecarsJSON returns the json encoded blob sidecars or nil if there are none.
+func marshalSidecarsJSON[T any](sidecars []T) (json.RawMessage, error) {
+	if len(sidecars) == 0 {
+		return nil, nil
+	}
+
+	resp, err := json.Marshal(sidecars)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal blob sidecars")
+	}
+
+	return resp, nil
+}
+
+// unmarshalSidecarsJSON returns the json decoded blob sidecars of a block of the version or nil if there are none.
+func unmarshalSidecarsJSON[T any](raw json.RawMessage, version eth2spec.DataVersion) ([]T, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	} else if version != eth2spec.DataVersionDeneb {
+		return nil, errors.New("blob sidecars only supported by deneb")
+	}
+
+	var resp []T
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal blob sidecars")
+	}
+
+	return nonEmpty(resp), nil
+}
+
+// nonEmpty returns the slice or nil if it is empty.
+func nonEmpty[T any](slice []T) []T {
+	if len(slice) == 0 {
+		return nil
+	}
+
+	return slice
 }
 
 // NewAttestation is a convenience function that returns a new wrapped attestation.
@@ -660,6 +874,112 @@ func (e *SignedVoluntaryExit) UnmarshalJSON(b []byte) error {
 	return e.SignedVoluntaryExit.UnmarshalJSON(b)
 }
 
+// NewSignedBlobSidecar is a convenience function that returns a new signed blob sidecar.
+func NewSignedBlobSidecar(sidecar *deneb.SignedBlobSidecar) SignedBlobSidecar {
+	return SignedBlobSidecar{SignedBlobSidecar: *sidecar}
+}
+
+// SignedBlobSidecar is a signed deneb blob sidecar of a proposed block and implements SignedData.
+type SignedBlobSidecar struct {
+	deneb.SignedBlobSidecar
+}
+
+func (s SignedBlobSidecar) MessageRoot() ([32]byte, error) {
+	return s.Message.HashTreeRoot()
+}
+
+func (s SignedBlobSidecar) Clone() (SignedData, error) {
+	return s.clone()
+}
+
+// clone returns a copy of the SignedBlobSidecar.
+// It is similar to Clone that returns the SignedData interface.
+func (s SignedBlobSidecar) clone() (SignedBlobSidecar, error) {
+	var resp SignedBlobSidecar
+	err := cloneJSONMarshaler(s, &resp)
+	if err != nil {
+		return SignedBlobSidecar{}, errors.Wrap(err, "clone blob sidecar")
+	}
+
+	return resp, nil
+}
+
+func (s SignedBlobSidecar) Signature() Signature {
+	return SigFromETH2(s.SignedBlobSidecar.Signature)
+}
+
+func (s SignedBlobSidecar) SetSignature(sig Signature) (SignedData, error) {
+	resp, err := s.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.SignedBlobSidecar.Signature = sig.ToETH2()
+
+	return resp, nil
+}
+
+func (s SignedBlobSidecar) MarshalJSON() ([]byte, error) {
+	return s.SignedBlobSidecar.MarshalJSON()
+}
+
+func (s *SignedBlobSidecar) UnmarshalJSON(b []byte) error {
+	return s.SignedBlobSidecar.UnmarshalJSON(b)
+}
+
+// NewSignedBlindedBlobSidecar is a convenience function that returns a new signed blinded blob sidecar.
+func NewSignedBlindedBlobSidecar(sidecar *eth2deneb.SignedBlindedBlobSidecar) SignedBlindedBlobSidecar {
+	return SignedBlindedBlobSidecar{SignedBlindedBlobSidecar: *sidecar}
+}
+
+// SignedBlindedBlobSidecar is a signed deneb blinded blob sidecar of a proposed blinded block and implements SignedData.
+type SignedBlindedBlobSidecar struct {
+	eth2deneb.SignedBlindedBlobSidecar
+}
+
+func (s SignedBlindedBlobSidecar) MessageRoot() ([32]byte, error) {
+	return s.Message.HashTreeRoot()
+}
+
+func (s SignedBlindedBlobSidecar) Clone() (SignedData, error) {
+	return s.clone()
+}
+
+// clone returns a copy of the SignedBlindedBlobSidecar.
+// It is similar to Clone that returns the SignedData interface.
+func (s SignedBlindedBlobSidecar) clone() (SignedBlindedBlobSidecar, error) {
+	var resp SignedBlindedBlobSidecar
+	err := cloneJSONMarshaler(s, &resp)
+	if err != nil {
+		return SignedBlindedBlobSidecar{}, errors.Wrap(err, "clone blinded blob sidecar")
+	}
+
+	return resp, nil
+}
+
+func (s SignedBlindedBlobSidecar) Signature() Signature {
+	return SigFromETH2(s.SignedBlindedBlobSidecar.Signature)
+}
+
+func (s SignedBlindedBlobSidecar) SetSignature(sig Signature) (SignedData, error) {
+	resp, err := s.clone()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.SignedBlindedBlobSidecar.Signature = sig.ToETH2()
+
+	return resp, nil
+}
+
+func (s SignedBlindedBlobSidecar) MarshalJSON() ([]byte, error) {
+	return s.SignedBlindedBlobSidecar.MarshalJSON()
+}
+
+func (s *SignedBlindedBlobSidecar) UnmarshalJSON(b []byte) error {
+	return s.SignedBlindedBlobSidecar.UnmarshalJSON(b)
+}
+
 // versionedRawValidatorRegistrationJSON is a custom VersionedSignedValidator serialiser.
 type versionedRawValidatorRegistrationJSON struct {
 	Version      eth2util.BuilderVersion `json:"version"`
@@ -0,0 +1,61 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package core
+
+import (
+	"context"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+)
+
+// WithEmbeddedSigner wires the embedded signer as an additional source of partial signatures next to the validator API.
+// The signer signs scheduled duties before fetching and only signs unsigned data sets successfully stored in
+// the DutyDB, so it is subject to the same slashing protection as a validator client.
+func WithEmbeddedSigner(signer EmbeddedSigner) WireOption {
+	return func(w *wireFuncs) {
+		clone := *w
+
+		w.FetcherFetch = func(ctx context.Context, duty Duty, set DutyDefinitionSet) error {
+			// Fetching may depend on the signed data (e.g. randao), so sign first but fetch regardless.
+			if err := signer.SignDuty(ctx, duty, set); err != nil {
+				log.Error(ctx, "Embedded signer failed to sign duty", err)
+			}
+
+			return clone.FetcherFetch(ctx, duty, set)
+		}
+		w.DutyDBStore = func(ctx context.Context, duty Duty, set UnsignedDataSet) error {
+			if err := clone.DutyDBStore(ctx, duty, set); err != nil {
+				return err
+			}
+
+			if err := signer.Sign(ctx, duty, set); err != nil {
+				return errors.Wrap(err, "embedded signer")
+			}
+
+			return nil
+		}
+		w.VAPIRegisterGetDutyDefinition = func(fn func(context.Context, Duty) (DutyDefinitionSet, error)) {
+			clone.VAPIRegisterGetDutyDefinition(fn)
+			signer.RegisterGetDutyDefinition(fn)
+		}
+		w.VAPIRegisterAwaitAggSigDB = func(fn func(context.Context, Duty, PubKey) (SignedData, error)) {
+			clone.VAPIRegisterAwaitAggSigDB(fn)
+			signer.RegisterAwaitAggSigDB(fn)
+		}
+		w.VAPISubscribe = func(fn func(context.Context, Duty, ParSignedDataSet) error) {
+			clone.VAPISubscribe(func(ctx context.Context, duty Duty, set ParSignedDataSet) error {
+				// The other nodes don't have a validator client submitting voluntary exits, so they would never
+				// reach threshold. Voluntary exits are signed by all nodes using the `charon exit` commands instead.
+				if duty.Type == DutyExit {
+					return errors.New("voluntary exits submitted by a validator client are not supported with the embedded signer, use charon exit instead")
+				}
+
+				return fn(ctx, duty, set)
+			})
+			signer.Subscribe(fn)
+		}
+
+		clone.SchedulerSubscribeSlots(signer.SignSlot)
+	}
+}
@@ -0,0 +1,579 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package signer provides an embedded signer that signs duties with this node's validator key shares,
+// replacing the need for a separate validator client.
+package signer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/eth2exp"
+	"github.com/obolnetwork/charon/eth2util/registration"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+)
+
+var _ core.EmbeddedSigner = (*Signer)(nil)
+
+// SignFunc returns the partial signature of the signed data (with an empty signature) by this node's key share
+// of the distributed validator. The sidecars of core.SidecarSignedData are signed before the data itself,
+// so the data is provided with signed sidecars.
+type SignFunc func(ctx context.Context, pubkey core.PubKey, data core.SignedData) (core.Signature, error)
+
+// NewKeyShareSignFunc returns a sign function using the provided secret shares of this node indexed by distributed validator public key.
+func NewKeyShareSignFunc(eth2Cl eth2wrap.Client, secrets map[core.PubKey]tbls.PrivateKey) SignFunc {
+	return func(ctx context.Context, pubkey core.PubKey, data core.SignedData) (core.Signature, error) {
+		secret, ok := secrets[pubkey]
+		if !ok {
+			return nil, errors.New("missing validator key share")
+		}
+
+		sigData, err := signingRoot(ctx, eth2Cl, data)
+		if err != nil {
+			return nil, err
+		}
+
+		sig, err := tbls.Sign(secret, sigData[:])
+		if err != nil {
+			return nil, err
+		}
+
+		return tblsconv.SigToCore(sig), nil
+	}
+}
+
+// Option configures the embedded signer.
+type Option func(*Signer)
+
+// WithBuilderRegistrations returns an option enabling signing validator registrations of the provided validators
+// at the start of every epoch the builder API is enabled, similar to a validator client. The registration timestamp
+// is the start of the epoch, so all nodes sign the same registration.
+func WithBuilderRegistrations(pubkeys []core.PubKey, builderEnabled core.BuilderEnabled,
+	feeRecipientFunc func(core.PubKey) string, gasLimit uint64,
+) Option {
+	return func(s *Signer) {
+		s.regPubkeys = pubkeys
+		s.builderEnabled = builderEnabled
+		s.feeRecipientFunc = feeRecipientFunc
+		s.gasLimit = gasLimit
+	}
+}
+
+// New returns a new embedded signer signing with the provided sign function.
+func New(shareIdx int, eth2Cl eth2wrap.Client, signFunc SignFunc, opts ...Option) *Signer {
+	s := &Signer{
+		eth2Cl:         eth2Cl,
+		shareIdx:       shareIdx,
+		signFunc:       signFunc,
+		builderEnabled: func(int64) bool { return false },
+		warned:         make(map[core.PubKey]bool),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Signer signs duties with this node's validator key shares. It provides the partial signatures
+// that a validator client would otherwise submit via the validator API.
+type Signer struct {
+	eth2Cl            eth2wrap.Client
+	shareIdx          int
+	signFunc          SignFunc
+	getDutyDefFunc    func(context.Context, core.Duty) (core.DutyDefinitionSet, error)
+	awaitAggSigDBFunc func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
+	subs              []func(context.Context, core.Duty, core.ParSignedDataSet) error
+
+	// Optional builder registration config, see WithBuilderRegistrations.
+	regPubkeys       []core.PubKey
+	builderEnabled   core.BuilderEnabled
+	feeRecipientFunc func(core.PubKey) string
+	gasLimit         uint64
+
+	warnedMu sync.Mutex
+	warned   map[core.PubKey]bool
+}
+
+// warnOnce returns true the first time it is called for the pubkey.
+func (s *Signer) warnOnce(pubkey core.PubKey) bool {
+	s.warnedMu.Lock()
+	defer s.warnedMu.Unlock()
+
+	if s.warned[pubkey] {
+		return false
+	}
+	s.warned[pubkey] = true
+
+	return true
+}
+
+// RegisterGetDutyDefinition registers a function to query duty definitions.
+// It supports a single function, since it is an input of the component.
+func (s *Signer) RegisterGetDutyDefinition(fn func(context.Context, core.Duty) (core.DutyDefinitionSet, error)) {
+	s.getDutyDefFunc = fn
+}
+
+// RegisterAwaitAggSigDB registers a function to query aggregated signed data from aggSigDB.
+// It supports a single function, since it is an input of the component.
+func (s *Signer) RegisterAwaitAggSigDB(fn func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)) {
+	s.awaitAggSigDBFunc = fn
+}
+
+// Subscribe registers a partial signed data set store function.
+// Note this is not thread safe should be called *before* any signing.
+func (s *Signer) Subscribe(fn func(context.Context, core.Duty, core.ParSignedDataSet) error) {
+	s.subs = append(s.subs, fn)
+}
+
+// SignDuty signs the data a validator client provides when a duty is scheduled,
+// i.e., randao reveals for proposers and beacon committee selection proofs for attesters.
+func (s *Signer) SignDuty(ctx context.Context, duty core.Duty, defSet core.DutyDefinitionSet) error {
+	switch duty.Type {
+	case core.DutyProposer, core.DutyBuilderProposer:
+		return s.signRandao(ctx, duty.Slot, defSet)
+	case core.DutyAttester:
+		return s.signBeaconCommitteeSelections(ctx, duty.Slot, defSet)
+	default:
+		return nil
+	}
+}
+
+// SignSlot signs the validator registrations at the start of every epoch and the sync committee messages
+// and selection proofs of the slot a third into the slot, since neither is scheduled as a core duty.
+func (s *Signer) SignSlot(ctx context.Context, slot core.Slot) error {
+	if s.builderEnabled(slot.Slot) && slot.FirstInEpoch() {
+		if err := s.signRegistrations(ctx, slot); err != nil {
+			return err
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(slot.Time.Add(slot.SlotDuration / 3))):
+	}
+
+	defSet, err := s.getDutyDefFunc(ctx, core.NewSyncContributionDuty(slot.Slot))
+	if errors.Is(err, core.ErrNotFound) {
+		return nil // No sync committee duties this slot.
+	} else if err != nil {
+		return err
+	}
+
+	return s.signSyncCommittee(ctx, slot.Slot, defSet)
+}
+
+// Sign signs the unsigned data set decided by consensus. It must only be called
+// after the data set was stored in the DutyDB, which refuses slashable data.
+func (s *Signer) Sign(ctx context.Context, duty core.Duty, unsignedSet core.UnsignedDataSet) error {
+	set := make(map[core.PubKey]core.SignedData)
+	for pubkey, unsigned := range unsignedSet {
+		data, err := s.signedData(ctx, duty, pubkey, unsigned)
+		if err != nil {
+			return errors.Wrap(err, "prepare signed data", z.Any("pubkey", pubkey))
+		} else if data == nil {
+			continue // Duty not signed by the validator client.
+		}
+
+		set[pubkey] = data
+	}
+
+	return s.signAndEmit(ctx, duty, set)
+}
+
+// signedData returns the unsigned data wrapped in the signed data type the validator client would submit for the duty.
+func (s *Signer) signedData(ctx context.Context, duty core.Duty, pubkey core.PubKey, unsigned core.UnsignedData) (core.SignedData, error) {
+	switch duty.Type {
+	case core.DutyAttester:
+		attData, ok := unsigned.(core.AttestationData)
+		if !ok {
+			return nil, errors.New("invalid attestation data")
+		}
+
+		aggBits := bitfield.NewBitlist(attData.Duty.CommitteeLength)
+		aggBits.SetBitAt(attData.Duty.ValidatorCommitteeIndex, true)
+
+		return core.NewAttestation(&eth2p0.Attestation{
+			AggregationBits: aggBits,
+			Data:            &attData.Data,
+		}), nil
+	case core.DutyProposer:
+		block, ok := unsigned.(core.VersionedBeaconBlock)
+		if !ok {
+			return nil, errors.New("invalid beacon block")
+		}
+
+		return newSignedBeaconBlock(block)
+	case core.DutyBuilderProposer:
+		block, ok := unsigned.(core.VersionedBlindedBeaconBlock)
+		if !ok {
+			return nil, errors.New("invalid blinded beacon block")
+		}
+
+		return newSignedBlindedBeaconBlock(block)
+	case core.DutyAggregator:
+		agg, ok := unsigned.(core.AggregatedAttestation)
+		if !ok {
+			return nil, errors.New("invalid aggregated attestation")
+		}
+
+		valIdx, err := s.validatorIndex(ctx, duty, pubkey)
+		if err != nil {
+			return nil, err
+		}
+
+		selection, err := s.awaitAggSigDBFunc(ctx, core.NewPrepareAggregatorDuty(duty.Slot), pubkey)
+		if err != nil {
+			return nil, err
+		}
+
+		return core.NewSignedAggregateAndProof(&eth2p0.SignedAggregateAndProof{
+			Message: &eth2p0.AggregateAndProof{
+				AggregatorIndex: valIdx,
+				Aggregate:       &agg.Attestation,
+				SelectionProof:  selection.Signature().ToETH2(),
+			},
+		}), nil
+	case core.DutySyncContribution:
+		contrib, ok := unsigned.(core.SyncContribution)
+		if !ok {
+			return nil, errors.New("invalid sync contribution")
+		}
+
+		valIdx, err := s.validatorIndex(ctx, duty, pubkey)
+		if err != nil {
+			return nil, err
+		}
+
+		selection, err := s.awaitAggSigDBFunc(ctx, core.NewPrepareSyncContributionDuty(duty.Slot), pubkey)
+		if err != nil {
+			return nil, err
+		}
+
+		return core.NewSignedSyncContributionAndProof(&altair.SignedContributionAndProof{
+			Message: &altair.ContributionAndProof{
+				AggregatorIndex: valIdx,
+				Contribution:    &contrib.SyncCommitteeContribution,
+				SelectionProof:  selection.Signature().ToETH2(),
+			},
+		}), nil
+	default:
+		return nil, nil //nolint:nilnil // Other duties are not signed by the validator client.
+	}
+}
+
+// validatorIndex returns the validator index of the pubkey from the duty definition.
+func (s *Signer) validatorIndex(ctx context.Context, duty core.Duty, pubkey core.PubKey) (eth2p0.ValidatorIndex, error) {
+	defSet, err := s.getDutyDefFunc(ctx, duty)
+	if err != nil {
+		return 0, err
+	}
+
+	switch def := defSet[pubkey].(type) {
+	case core.AttesterDefinition:
+		return def.ValidatorIndex, nil
+	case core.SyncCommitteeDefinition:
+		return def.ValidatorIndex, nil
+	default:
+		return 0, errors.New("duty definition not found")
+	}
+}
+
+// signRandao signs the randao reveals of the proposers.
+func (s *Signer) signRandao(ctx context.Context, slot int64, defSet core.DutyDefinitionSet) error {
+	epoch, err := eth2util.EpochFromSlot(ctx, s.eth2Cl, eth2p0.Slot(slot))
+	if err != nil {
+		return err
+	}
+
+	set := make(map[core.PubKey]core.SignedData)
+	for pubkey := range defSet {
+		set[pubkey] = core.NewSignedRandao(epoch, eth2p0.BLSSignature{})
+	}
+
+	return s.signAndEmit(ctx, core.NewRandaoDuty(slot), set)
+}
+
+// signBeaconCommitteeSelections signs the beacon committee selection proofs of the attesters.
+func (s *Signer) signBeaconCommitteeSelections(ctx context.Context, slot int64, defSet core.DutyDefinitionSet) error {
+	set := make(map[core.PubKey]core.SignedData)
+	for pubkey, def := range defSet {
+		attDef, ok := def.(core.AttesterDefinition)
+		if !ok {
+			return errors.New("invalid attester definition")
+		}
+
+		set[pubkey] = core.NewBeaconCommitteeSelection(&eth2exp.BeaconCommitteeSelection{
+			ValidatorIndex: attDef.ValidatorIndex,
+			Slot:           eth2p0.Slot(slot),
+		})
+	}
+
+	return s.signAndEmit(ctx, core.NewPrepareAggregatorDuty(slot), set)
+}
+
+// signRegistrations signs the validator registrations of the validators with the start of the slot as timestamp.
+func (s *Signer) signRegistrations(ctx context.Context, slot core.Slot) error {
+	set := make(map[core.PubKey]core.SignedData)
+	for _, pubkey := range s.regPubkeys {
+		eth2Pubkey, err := pubkey.ToETH2()
+		if err != nil {
+			return err
+		}
+
+		msg, err := registration.NewMessage(eth2Pubkey, s.feeRecipientFunc(pubkey), s.gasLimit, slot.Time)
+		if err != nil {
+			return err
+		}
+
+		reg, err := core.NewVersionedSignedValidatorRegistration(&eth2api.VersionedSignedValidatorRegistration{
+			Version: eth2spec.BuilderVersionV1,
+			V1:      &eth2v1.SignedValidatorRegistration{Message: msg},
+		})
+		if err != nil {
+			return err
+		}
+
+		set[pubkey] = reg
+	}
+
+	return s.signAndEmit(ctx, core.NewBuilderRegistrationDuty(slot.Slot), set)
+}
+
+// signSyncCommittee signs the sync committee messages of the current head block
+// and the sync committee selection proofs of the sync committee members.
+func (s *Signer) signSyncCommittee(ctx context.Context, slot int64, defSet core.DutyDefinitionSet) error {
+	blockRoot, err := s.eth2Cl.BeaconBlockRoot(ctx, "head")
+	if err != nil {
+		return err
+	}
+
+	subcommSize, err := syncSubcommitteeSize(ctx, s.eth2Cl)
+	if err != nil {
+		return err
+	}
+
+	var (
+		msgs       = make(map[core.PubKey]core.SignedData)
+		selections = make(map[core.PubKey]core.SignedData)
+	)
+	for pubkey, def := range defSet {
+		syncDef, ok := def.(core.SyncCommitteeDefinition)
+		if !ok {
+			return errors.New("invalid sync committee definition")
+		} else if len(syncDef.ValidatorSyncCommitteeIndices) == 0 {
+			return errors.New("sync committee definition without indices")
+		}
+
+		// The core workflow aggregates a single sync contribution per validator, so only the first subcommittee
+		// is selected. The sync committee message is used for all subcommittees by the beacon node.
+		subcommIdx := uint64(syncDef.ValidatorSyncCommitteeIndices[0]) / subcommSize
+		for _, idx := range syncDef.ValidatorSyncCommitteeIndices[1:] {
+			if uint64(idx)/subcommSize != subcommIdx && s.warnOnce(pubkey) {
+				log.Warn(ctx, "Embedded signer only aggregates sync contributions of the first sync subcommittee of a validator", nil,
+					z.Any("pubkey", pubkey), z.U64("subcommittee", subcommIdx), z.U64("ignored_subcommittee", uint64(idx)/subcommSize))
+
+				break
+			}
+		}
+
+		msgs[pubkey] = core.NewSignedSyncMessage(&altair.SyncCommitteeMessage{
+			Slot:            eth2p0.Slot(slot),
+			BeaconBlockRoot: *blockRoot,
+			ValidatorIndex:  syncDef.ValidatorIndex,
+		})
+
+		selections[pubkey] = core.NewSyncCommitteeSelection(&eth2exp.SyncCommitteeSelection{
+			ValidatorIndex:    syncDef.ValidatorIndex,
+			Slot:              eth2p0.Slot(slot),
+			SubcommitteeIndex: eth2p0.CommitteeIndex(subcommIdx),
+		})
+	}
+
+	if err := s.signAndEmit(ctx, core.NewSyncMessageDuty(slot), msgs); err != nil {
+		return err
+	}
+
+	return s.signAndEmit(ctx, core.NewPrepareSyncContributionDuty(slot), selections)
+}
+
+// signAndEmit signs the data set with the secret shares and calls the subscribers with the partial signed data set.
+func (s *Signer) signAndEmit(ctx context.Context, duty core.Duty, set map[core.PubKey]core.SignedData) error {
+	if len(set) == 0 {
+		return nil
+	}
+
+	parSigSet := make(core.ParSignedDataSet)
+	for pubkey, data := range set {
+		signed, err := s.sign(ctx, pubkey, data)
+		if err != nil {
+			return errors.Wrap(err, "sign duty data", z.Any("duty", duty), z.Any("pubkey", pubkey))
+		}
+
+		parSigSet[pubkey] = core.ParSignedData{
+			SignedData: signed,
+			ShareIdx:   s.shareIdx,
+		}
+	}
+
+	log.Debug(ctx, "Embedded signer signed duty", z.Any("duty", duty), z.Int("validators", len(parSigSet)))
+
+	for _, sub := range s.subs {
+		// No need to clone since sub auto clones.
+		if err := sub(ctx, duty, parSigSet); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sign returns the data signed by this node's key share of the distributed validator,
+// including its sidecars if it is core.SidecarSignedData.
+func (s *Signer) sign(ctx context.Context, pubkey core.PubKey, data core.SignedData) (core.SignedData, error) {
+	if sidecarData, ok := data.(core.SidecarSignedData); ok && len(sidecarData.Sidecars()) > 0 {
+		var sigs []core.Signature
+		for _, sidecar := range sidecarData.Sidecars() {
+			sig, err := s.signFunc(ctx, pubkey, sidecar)
+			if err != nil {
+				return nil, errors.Wrap(err, "sign sidecar")
+			}
+			sigs = append(sigs, sig)
+		}
+
+		var err error
+		data, err = sidecarData.SetSidecarSignatures(sigs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sig, err := s.signFunc(ctx, pubkey, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.SetSignature(sig)
+}
+
+// signingRoot returns the signing root of the signed data.
+func signingRoot(ctx context.Context, eth2Cl eth2wrap.Client, data core.SignedData) ([32]byte, error) {
+	eth2Data, ok := data.(core.Eth2SignedData)
+	if !ok {
+		return [32]byte{}, errors.New("unsupported signed data")
+	}
+
+	epoch, err := eth2Data.Epoch(ctx, eth2Cl)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	msgRoot, err := eth2Data.MessageRoot()
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return signing.GetDataRoot(ctx, eth2Cl, eth2Data.DomainName(), epoch, msgRoot)
+}
+
+// syncSubcommitteeSize returns the number of validators per sync subcommittee.
+func syncSubcommitteeSize(ctx context.Context, eth2Cl eth2wrap.Client) (uint64, error) {
+	spec, err := eth2Cl.Spec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	commSize, ok := spec["SYNC_COMMITTEE_SIZE"].(uint64)
+	if !ok {
+		return 0, errors.New("invalid SYNC_COMMITTEE_SIZE")
+	}
+
+	subnetCount, ok := spec["SYNC_COMMITTEE_SUBNET_COUNT"].(uint64)
+	if !ok || subnetCount == 0 {
+		return 0, errors.New("invalid SYNC_COMMITTEE_SUBNET_COUNT")
+	}
+
+	return commSize / subnetCount, nil
+}
+
+// newSignedBeaconBlock returns an unsigned versioned signed beacon block of the beacon block
+// including unsigned blob sidecars of deneb block contents.
+func newSignedBeaconBlock(block core.VersionedBeaconBlock) (core.SignedData, error) {
+	signed := &eth2spec.VersionedSignedBeaconBlock{Version: block.Version}
+	switch block.Version {
+	case eth2spec.DataVersionPhase0:
+		signed.Phase0 = &eth2p0.SignedBeaconBlock{Message: block.Phase0}
+	case eth2spec.DataVersionAltair:
+		signed.Altair = &altair.SignedBeaconBlock{Message: block.Altair}
+	case eth2spec.DataVersionBellatrix:
+		signed.Bellatrix = &bellatrix.SignedBeaconBlock{Message: block.Bellatrix}
+	case eth2spec.DataVersionCapella:
+		signed.Capella = &capella.SignedBeaconBlock{Message: block.Capella}
+	case eth2spec.DataVersionDeneb:
+		signed.Deneb = &deneb.SignedBeaconBlock{Message: block.Deneb}
+	default:
+		return nil, errors.New("unknown version")
+	}
+
+	resp, err := core.NewVersionedSignedBeaconBlock(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sidecar := range block.BlobSidecars {
+		resp.SignedBlobSidecars = append(resp.SignedBlobSidecars, &deneb.SignedBlobSidecar{Message: sidecar})
+	}
+
+	return resp, nil
+}
+
+// newSignedBlindedBeaconBlock returns an unsigned versioned signed blinded beacon block of the blinded beacon block
+// including unsigned blinded blob sidecars of deneb blinded block contents.
+func newSignedBlindedBeaconBlock(block core.VersionedBlindedBeaconBlock) (core.SignedData, error) {
+	signed := &eth2api.VersionedSignedBlindedBeaconBlock{Version: block.Version}
+	switch block.Version {
+	case eth2spec.DataVersionBellatrix:
+		signed.Bellatrix = &eth2bellatrix.SignedBlindedBeaconBlock{Message: block.Bellatrix}
+	case eth2spec.DataVersionCapella:
+		signed.Capella = &eth2capella.SignedBlindedBeaconBlock{Message: block.Capella}
+	case eth2spec.DataVersionDeneb:
+		signed.Deneb = &eth2deneb.SignedBlindedBeaconBlock{Message: block.Deneb}
+	default:
+		return nil, errors.New("unknown version")
+	}
+
+	resp, err := core.NewVersionedSignedBlindedBeaconBlock(signed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sidecar := range block.BlindedBlobSidecars {
+		resp.SignedBlindedBlobSidecars = append(resp.SignedBlindedBlobSidecars, &eth2deneb.SignedBlindedBlobSidecar{Message: sidecar})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,220 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package signer_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/signer"
+	"github.com/obolnetwork/charon/eth2util/eth2exp"
+	"github.com/obolnetwork/charon/eth2util/registration"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+const (
+	shareIdx = 2
+	valIdx   = 7
+	slot     = 99
+)
+
+func TestSigner(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubshare, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+	pubkey := testutil.RandomCorePubKey(t)
+
+	s := signer.New(shareIdx, bmock, signer.NewKeyShareSignFunc(bmock, map[core.PubKey]tbls.PrivateKey{pubkey: secret}))
+
+	attDef := core.NewAttesterDefinition(&eth2v1.AttesterDuty{
+		Slot:                    slot,
+		ValidatorIndex:          valIdx,
+		CommitteeLength:         8,
+		ValidatorCommitteeIndex: 3,
+	})
+	syncDef := core.NewSyncCommitteeDefinition(&eth2v1.SyncCommitteeDuty{
+		ValidatorIndex:                valIdx,
+		ValidatorSyncCommitteeIndices: []eth2p0.CommitteeIndex{300},
+	})
+
+	s.RegisterGetDutyDefinition(func(_ context.Context, duty core.Duty) (core.DutyDefinitionSet, error) {
+		switch duty.Type {
+		case core.DutyAggregator:
+			return core.DutyDefinitionSet{pubkey: attDef}, nil
+		case core.DutySyncContribution:
+			return core.DutyDefinitionSet{pubkey: syncDef}, nil
+		default:
+			return nil, core.ErrNotFound
+		}
+	})
+
+	selectionProof := testutil.RandomEth2Signature()
+	s.RegisterAwaitAggSigDB(func(_ context.Context, duty core.Duty, _ core.PubKey) (core.SignedData, error) {
+		switch duty.Type {
+		case core.DutyPrepareAggregator:
+			return core.NewBeaconCommitteeSelection(&eth2exp.BeaconCommitteeSelection{SelectionProof: selectionProof}), nil
+		case core.DutyPrepareSyncContribution:
+			return core.NewSyncCommitteeSelection(&eth2exp.SyncCommitteeSelection{SelectionProof: selectionProof}), nil
+		default:
+			return nil, core.ErrNotFound
+		}
+	})
+
+	signed := make(map[core.Duty]core.ParSignedData)
+	s.Subscribe(func(_ context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+		require.Len(t, set, 1)
+		require.Equal(t, shareIdx, set[pubkey].ShareIdx)
+
+		eth2Data, ok := set[pubkey].SignedData.(core.Eth2SignedData)
+		require.True(t, ok)
+		require.NoError(t, core.VerifyEth2SignedData(ctx, bmock, eth2Data, pubshare))
+
+		signed[duty] = set[pubkey]
+
+		return nil
+	})
+
+	t.Run("sign duty", func(t *testing.T) {
+		require.NoError(t, s.SignDuty(ctx, core.NewProposerDuty(slot), core.DutyDefinitionSet{pubkey: core.ProposerDefinition{}}))
+		require.IsType(t, core.SignedRandao{}, signed[core.NewRandaoDuty(slot)].SignedData)
+
+		require.NoError(t, s.SignDuty(ctx, core.NewAttesterDuty(slot), core.DutyDefinitionSet{pubkey: attDef}))
+		selection, ok := signed[core.NewPrepareAggregatorDuty(slot)].SignedData.(core.BeaconCommitteeSelection)
+		require.True(t, ok)
+		require.EqualValues(t, valIdx, selection.ValidatorIndex)
+	})
+
+	t.Run("sign slot", func(t *testing.T) {
+		require.NoError(t, s.SignSlot(ctx, core.Slot{Slot: slot, Time: time.Now().Add(-time.Minute), SlotDuration: time.Second, SlotsPerEpoch: 32}))
+
+		msg, ok := signed[core.NewSyncMessageDuty(slot)].SignedData.(core.SignedSyncMessage)
+		require.True(t, ok)
+		require.EqualValues(t, valIdx, msg.ValidatorIndex)
+
+		selection, ok := signed[core.NewPrepareSyncContributionDuty(slot)].SignedData.(core.SyncCommitteeSelection)
+		require.True(t, ok)
+		require.EqualValues(t, 2, selection.SubcommitteeIndex) // 300 / (512 / 4)
+	})
+
+	t.Run("sign", func(t *testing.T) {
+		attData := testutil.RandomAttestationData()
+		attData.Slot = slot
+		require.NoError(t, s.Sign(ctx, core.NewAttesterDuty(slot), core.UnsignedDataSet{
+			pubkey: core.AttestationData{Data: *attData, Duty: attDef.AttesterDuty},
+		}))
+		att, ok := signed[core.NewAttesterDuty(slot)].SignedData.(core.Attestation)
+		require.True(t, ok)
+		require.Equal(t, []int{3}, att.AggregationBits.BitIndices())
+
+		// Blob sidecars are signed along with the block and verified by the subscriber.
+		block := testutil.RandomDenebCoreVersionedBeaconBlock()
+		block.Deneb.Slot = slot
+		for i := 0; i < 2; i++ {
+			sidecar := testutil.RandomDenebBlobSidecar(deneb.BlobIndex(i))
+			sidecar.Slot = slot
+			block.BlobSidecars = append(block.BlobSidecars, sidecar)
+		}
+		require.NoError(t, s.Sign(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{pubkey: block}))
+		signedBlock, ok := signed[core.NewProposerDuty(slot)].SignedData.(core.VersionedSignedBeaconBlock)
+		require.True(t, ok)
+		require.Len(t, signedBlock.SignedBlobSidecars, 2)
+		require.Equal(t, block.BlobSidecars[1], signedBlock.SignedBlobSidecars[1].Message)
+
+		blinded := testutil.RandomDenebVersionedBlindedBeaconBlock()
+		blinded.Deneb.Slot = slot
+		sidecar := testutil.RandomDenebBlindedBlobSidecar(0)
+		sidecar.Slot = slot
+		blinded.BlindedBlobSidecars = append(blinded.BlindedBlobSidecars, sidecar)
+		require.NoError(t, s.Sign(ctx, core.NewBuilderProposerDuty(slot), core.UnsignedDataSet{pubkey: blinded}))
+		signedBlinded, ok := signed[core.NewBuilderProposerDuty(slot)].SignedData.(core.VersionedSignedBlindedBeaconBlock)
+		require.True(t, ok)
+		require.Len(t, signedBlinded.SignedBlindedBlobSidecars, 1)
+		require.Equal(t, sidecar, signedBlinded.SignedBlindedBlobSidecars[0].Message)
+
+		aggAtt := testutil.RandomAttestation()
+		aggAtt.Data.Slot = slot
+		require.NoError(t, s.Sign(ctx, core.NewAggregatorDuty(slot), core.UnsignedDataSet{pubkey: core.NewAggregatedAttestation(aggAtt)}))
+		agg, ok := signed[core.NewAggregatorDuty(slot)].SignedData.(core.SignedAggregateAndProof)
+		require.True(t, ok)
+		require.EqualValues(t, valIdx, agg.Message.AggregatorIndex)
+		require.Equal(t, selectionProof, agg.Message.SelectionProof)
+
+		contrib := core.NewSyncContribution(&altair.SyncCommitteeContribution{Slot: slot, AggregationBits: make([]byte, 16)})
+		require.NoError(t, s.Sign(ctx, core.NewSyncContributionDuty(slot), core.UnsignedDataSet{pubkey: contrib}))
+		contribProof, ok := signed[core.NewSyncContributionDuty(slot)].SignedData.(core.SignedSyncContributionAndProof)
+		require.True(t, ok)
+		require.EqualValues(t, valIdx, contribProof.Message.AggregatorIndex)
+		require.Equal(t, selectionProof, contribProof.Message.SelectionProof)
+	})
+
+	t.Run("sign registrations", func(t *testing.T) {
+		const (
+			epochSlot    = 96
+			feeRecipient = "0x000000000000000000000000000000000000dEaD"
+		)
+
+		builderEnabled := func(int64) bool { return true }
+		feeRecipientFunc := func(core.PubKey) string { return feeRecipient }
+
+		s := signer.New(shareIdx, bmock, signer.NewKeyShareSignFunc(bmock, map[core.PubKey]tbls.PrivateKey{pubkey: secret}),
+			signer.WithBuilderRegistrations([]core.PubKey{pubkey}, builderEnabled, feeRecipientFunc, registration.DefaultGasLimit))
+		s.RegisterGetDutyDefinition(func(context.Context, core.Duty) (core.DutyDefinitionSet, error) {
+			return nil, core.ErrNotFound
+		})
+
+		var regs []core.VersionedSignedValidatorRegistration
+		s.Subscribe(func(_ context.Context, duty core.Duty, set core.ParSignedDataSet) error {
+			require.Equal(t, core.NewBuilderRegistrationDuty(epochSlot), duty)
+
+			eth2Data, ok := set[pubkey].SignedData.(core.Eth2SignedData)
+			require.True(t, ok)
+			require.NoError(t, core.VerifyEth2SignedData(ctx, bmock, eth2Data, pubshare))
+
+			reg, ok := set[pubkey].SignedData.(core.VersionedSignedValidatorRegistration)
+			require.True(t, ok)
+			regs = append(regs, reg)
+
+			return nil
+		})
+
+		epochStart := time.Unix(time.Now().Add(-time.Minute).Unix(), 0)
+		for i := int64(0); i < 2; i++ {
+			require.NoError(t, s.SignSlot(ctx, core.Slot{
+				Slot:          epochSlot + i,
+				Time:          epochStart.Add(time.Duration(i) * time.Second),
+				SlotDuration:  time.Second,
+				SlotsPerEpoch: 32,
+			}))
+		}
+
+		// Only signed at the start of the epoch.
+		require.Len(t, regs, 1)
+		require.Equal(t, strings.ToLower(feeRecipient), fmt.Sprintf("%#x", regs[0].V1.Message.FeeRecipient))
+		require.EqualValues(t, registration.DefaultGasLimit, regs[0].V1.Message.GasLimit)
+		require.Equal(t, epochStart.Unix(), regs[0].V1.Message.Timestamp.Unix())
+	})
+
+	t.Run("missing key share", func(t *testing.T) {
+		other := testutil.RandomCorePubKey(t)
+		err := s.SignDuty(ctx, core.NewProposerDuty(slot), core.DutyDefinitionSet{other: core.ProposerDefinition{}})
+		require.ErrorContains(t, err, "missing validator key share")
+	})
+}
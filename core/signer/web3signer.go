@@ -0,0 +1,236 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+// NewWeb3SignerSignFunc returns a sign function requesting signatures from a Web3Signer holding this node's
+// key shares, identified by the provided public shares indexed by distributed validator public key.
+// The sidecars of core.SidecarSignedData are signed by separate requests before the data itself,
+// so the remote signature is verified along with the sidecar signatures.
+func NewWeb3SignerSignFunc(eth2Cl eth2wrap.Client, client web3signer.Client, pubshares map[core.PubKey]tbls.PublicKey) SignFunc {
+	return func(ctx context.Context, pubkey core.PubKey, data core.SignedData) (core.Signature, error) {
+		pubshare, ok := pubshares[pubkey]
+		if !ok {
+			return nil, errors.New("missing validator public share")
+		}
+
+		req, err := newSignRequest(ctx, eth2Cl, data)
+		if err != nil {
+			return nil, err
+		}
+
+		sig, err := client.Sign(ctx, eth2p0.BLSPubKey(pubshare), req)
+		if err != nil {
+			return nil, err
+		}
+
+		// Verify the remote signature, since partial signatures from this node are not verified by the ParSigDB.
+		signed, err := data.SetSignature(core.SigFromETH2(sig))
+		if err != nil {
+			return nil, err
+		}
+
+		eth2Signed, ok := signed.(core.Eth2SignedData)
+		if !ok {
+			return nil, errors.New("unsupported signed data")
+		}
+
+		if err := core.VerifyEth2SignedData(ctx, eth2Cl, eth2Signed, pubshare); err != nil {
+			return nil, errors.Wrap(err, "verify web3signer signature", z.Str("type", string(req.Type)))
+		}
+
+		return core.SigFromETH2(sig), nil
+	}
+}
+
+// newSignRequest returns the Web3Signer sign request of the signed data.
+func newSignRequest(ctx context.Context, eth2Cl eth2wrap.Client, data core.SignedData) (web3signer.SignRequest, error) {
+	eth2Data, ok := data.(core.Eth2SignedData)
+	if !ok {
+		return web3signer.SignRequest{}, errors.New("unsupported signed data")
+	}
+
+	var (
+		req web3signer.SignRequest
+		err error
+	)
+	switch d := data.(type) {
+	case core.Attestation:
+		req.Type = web3signer.TypeAttestation
+		req.Attestation = d.Data
+	case core.VersionedSignedBeaconBlock:
+		req.Type = web3signer.TypeBlockV2
+		req.BeaconBlock, err = newBeaconBlock(d)
+	case core.VersionedSignedBlindedBeaconBlock:
+		req.Type = web3signer.TypeBlockV2
+		req.BeaconBlock, err = newBlindedBeaconBlock(d)
+	case core.SignedRandao:
+		req.Type = web3signer.TypeRandaoReveal
+		req.RandaoReveal = &web3signer.RandaoReveal{Epoch: d.SignedEpoch.Epoch}
+	case core.BeaconCommitteeSelection:
+		req.Type = web3signer.TypeAggregationSlot
+		req.AggregationSlot = &web3signer.AggregationSlot{Slot: d.Slot}
+	case core.SignedAggregateAndProof:
+		req.Type = web3signer.TypeAggregateAndProof
+		req.AggregateAndProof = d.Message
+	case core.SignedSyncMessage:
+		req.Type = web3signer.TypeSyncCommitteeMessage
+		req.SyncCommitteeMessage = &web3signer.SyncCommitteeMessage{
+			BeaconBlockRoot: d.BeaconBlockRoot,
+			Slot:            d.Slot,
+		}
+	case core.SyncCommitteeSelection:
+		req.Type = web3signer.TypeSyncCommitteeSelectionProof
+		req.SyncAggregatorSelectionData = &web3signer.SyncAggregatorSelectionData{
+			Slot:              d.Slot,
+			SubcommitteeIndex: uint64(d.SubcommitteeIndex),
+		}
+	case core.SignedSyncContributionAndProof:
+		req.Type = web3signer.TypeSyncCommitteeContributionAndProof
+		req.ContributionAndProof = d.Message
+	case core.SignedVoluntaryExit:
+		req.Type = web3signer.TypeVoluntaryExit
+		req.VoluntaryExit = d.Message
+	case core.VersionedSignedValidatorRegistration:
+		if d.V1 == nil {
+			return web3signer.SignRequest{}, errors.New("unknown registration version")
+		}
+		req.Type = web3signer.TypeValidatorRegistration
+		req.ValidatorRegistration = d.V1.Message
+	case core.SignedBlobSidecar:
+		req.Type = web3signer.TypeBlobSidecar
+		req.BlobSidecar, err = blindBlobSidecar(d.Message)
+	case core.SignedBlindedBlobSidecar:
+		req.Type = web3signer.TypeBlobSidecar
+		req.BlobSidecar = d.Message
+	default:
+		return web3signer.SignRequest{}, errors.New("unsupported web3signer signed data type")
+	}
+	if err != nil {
+		return web3signer.SignRequest{}, err
+	}
+
+	// Builder domain doesn't depend on the fork.
+	if req.Type != web3signer.TypeValidatorRegistration {
+		epoch, err := eth2Data.Epoch(ctx, eth2Cl)
+		if err != nil {
+			return web3signer.SignRequest{}, err
+		}
+
+		req.ForkInfo, err = web3signer.NewForkInfo(ctx, eth2Cl, epoch)
+		if err != nil {
+			return web3signer.SignRequest{}, err
+		}
+	}
+
+	sigRoot, err := signingRoot(ctx, eth2Cl, data)
+	if err != nil {
+		return web3signer.SignRequest{}, err
+	}
+	req.SigningRoot = (*eth2p0.Root)(&sigRoot)
+
+	return req, nil
+}
+
+// newBeaconBlock returns the Web3Signer beacon block of the block.
+func newBeaconBlock(block core.VersionedSignedBeaconBlock) (*web3signer.BeaconBlock, error) {
+	version := strings.ToUpper(block.Version.String())
+
+	switch block.Version {
+	case eth2spec.DataVersionPhase0:
+		return newFullBeaconBlock(version, block.Phase0.Message)
+	case eth2spec.DataVersionAltair:
+		return newFullBeaconBlock(version, block.Altair.Message)
+	case eth2spec.DataVersionBellatrix:
+		b := block.Bellatrix.Message
+		return newBeaconBlockHeader(version, b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, b.Body)
+	case eth2spec.DataVersionCapella:
+		b := block.Capella.Message
+		return newBeaconBlockHeader(version, b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, b.Body)
+	case eth2spec.DataVersionDeneb:
+		b := block.Deneb.Message
+		return newBeaconBlockHeader(version, b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, b.Body)
+	default:
+		return nil, errors.New("unknown version")
+	}
+}
+
+// newBlindedBeaconBlock returns the Web3Signer beacon block of the blinded block.
+func newBlindedBeaconBlock(block core.VersionedSignedBlindedBeaconBlock) (*web3signer.BeaconBlock, error) {
+	version := strings.ToUpper(block.Version.String())
+
+	switch block.Version {
+	case eth2spec.DataVersionBellatrix:
+		b := block.Bellatrix.Message
+		return newBeaconBlockHeader(version, b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, b.Body)
+	case eth2spec.DataVersionCapella:
+		b := block.Capella.Message
+		return newBeaconBlockHeader(version, b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, b.Body)
+	case eth2spec.DataVersionDeneb:
+		b := block.Deneb.Message
+		return newBeaconBlockHeader(version, b.Slot, b.ProposerIndex, b.ParentRoot, b.StateRoot, b.Body)
+	default:
+		return nil, errors.New("unknown version")
+	}
+}
+
+// blindBlobSidecar returns the blinded blob sidecar of the blob sidecar, which Web3Signer signs
+// since it has the same root as the blob sidecar.
+func blindBlobSidecar(sidecar *deneb.BlobSidecar) (*eth2deneb.BlindedBlobSidecar, error) {
+	blinded, err := eth2util.BlindBlobSidecars([]*deneb.BlobSidecar{sidecar})
+	if err != nil {
+		return nil, err
+	}
+
+	return blinded[0], nil
+}
+
+// newFullBeaconBlock returns a Web3Signer beacon block containing the full block, as required for phase0 and altair.
+func newFullBeaconBlock(version string, block any) (*web3signer.BeaconBlock, error) {
+	b, err := json.Marshal(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal block")
+	}
+
+	return &web3signer.BeaconBlock{Version: version, Block: b}, nil
+}
+
+// newBeaconBlockHeader returns a Web3Signer beacon block containing only the block header.
+func newBeaconBlockHeader(version string, slot eth2p0.Slot, proposerIdx eth2p0.ValidatorIndex,
+	parentRoot, stateRoot eth2p0.Root, body ssz.HashRoot,
+) (*web3signer.BeaconBlock, error) {
+	bodyRoot, err := body.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "hash block body")
+	}
+
+	return &web3signer.BeaconBlock{
+		Version: version,
+		BlockHeader: &eth2p0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: proposerIdx,
+			ParentRoot:    parentRoot,
+			StateRoot:     stateRoot,
+			BodyRoot:      bodyRoot,
+		},
+	}, nil
+}
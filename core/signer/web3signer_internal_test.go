@@ -0,0 +1,67 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package signer
+
+import (
+	"context"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestNewSignRequest(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	forks, err := bmock.ForkSchedule(ctx)
+	require.NoError(t, err)
+	require.Greater(t, len(forks), 1)
+
+	t.Run("voluntary exit", func(t *testing.T) {
+		// Exits use the fork of the exit epoch.
+		exit := core.NewSignedVoluntaryExit(&eth2p0.SignedVoluntaryExit{
+			Message: &eth2p0.VoluntaryExit{Epoch: forks[1].Epoch, ValidatorIndex: 7},
+		})
+
+		req, err := newSignRequest(ctx, bmock, exit)
+		require.NoError(t, err)
+		require.Equal(t, web3signer.TypeVoluntaryExit, req.Type)
+		require.Equal(t, exit.Message, req.VoluntaryExit)
+		require.NotNil(t, req.ForkInfo)
+		require.Equal(t, forks[1], req.ForkInfo.Fork)
+
+		sigRoot, err := signingRoot(ctx, bmock, exit)
+		require.NoError(t, err)
+		require.EqualValues(t, sigRoot, *req.SigningRoot)
+	})
+
+	t.Run("validator registration", func(t *testing.T) {
+		reg := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+
+		req, err := newSignRequest(ctx, bmock, reg)
+		require.NoError(t, err)
+		require.Equal(t, web3signer.TypeValidatorRegistration, req.Type)
+		require.Equal(t, reg.V1.Message, req.ValidatorRegistration)
+		require.Nil(t, req.ForkInfo) // Builder domain doesn't depend on the fork.
+
+		sigRoot, err := signingRoot(ctx, bmock, reg)
+		require.NoError(t, err)
+		require.EqualValues(t, sigRoot, *req.SigningRoot)
+	})
+
+	t.Run("unknown registration version", func(t *testing.T) {
+		reg := testutil.RandomCoreVersionedSignedValidatorRegistration(t)
+		reg.V1 = nil
+
+		_, err := newSignRequest(ctx, bmock, reg)
+		require.Error(t, err)
+	})
+}
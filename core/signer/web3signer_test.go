@@ -0,0 +1,161 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package signer_test
+
+import (
+	"context"
+	"testing"
+
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/core/signer"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+	"github.com/obolnetwork/charon/testutil/web3signermock"
+)
+
+func TestWeb3SignerSignFunc(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	secret, err := tbls.GenerateSecretKey()
+	require.NoError(t, err)
+	pubshare, err := tbls.SecretToPublicKey(secret)
+	require.NoError(t, err)
+	pubkey := testutil.RandomCorePubKey(t)
+
+	addr := web3signermock.New(t, bmock, secret)
+	web3SignFunc := signer.NewWeb3SignerSignFunc(bmock, web3signer.New(addr), map[core.PubKey]tbls.PublicKey{pubkey: pubshare})
+	keyShareSignFunc := signer.NewKeyShareSignFunc(bmock, map[core.PubKey]tbls.PrivateKey{pubkey: secret})
+
+	phase0Block := testutil.RandomBellatrixCoreVersionedSignedBeaconBlock()
+	phase0Block.Version = eth2spec.DataVersionPhase0
+	phase0Block.Phase0 = &eth2p0.SignedBeaconBlock{Message: testutil.RandomPhase0BeaconBlock()}
+	phase0Block.Bellatrix = nil
+
+	tests := []struct {
+		name string
+		data core.SignedData
+	}{
+		{
+			name: "attestation",
+			data: core.NewAttestation(testutil.RandomAttestation()),
+		},
+		{
+			name: "phase0 beacon block",
+			data: phase0Block,
+		},
+		{
+			name: "bellatrix beacon block",
+			data: testutil.RandomBellatrixCoreVersionedSignedBeaconBlock(),
+		},
+		{
+			name: "deneb beacon block",
+			data: testutil.RandomDenebCoreVersionedSignedBeaconBlock(),
+		},
+		{
+			name: "capella blinded beacon block",
+			data: testutil.RandomCapellaVersionedSignedBlindedBeaconBlock(),
+		},
+		{
+			name: "randao",
+			data: testutil.RandomCoreSignedRandao(),
+		},
+		{
+			name: "voluntary exit",
+			data: core.NewSignedVoluntaryExit(testutil.RandomExit()),
+		},
+		{
+			name: "registration",
+			data: testutil.RandomCoreVersionedSignedValidatorRegistration(t),
+		},
+		{
+			name: "beacon committee selection",
+			data: testutil.RandomCoreBeaconCommitteeSelection(),
+		},
+		{
+			name: "aggregate and proof",
+			data: core.SignedAggregateAndProof{
+				SignedAggregateAndProof: eth2p0.SignedAggregateAndProof{
+					Message: testutil.RandomAggregateAndProof(),
+				},
+			},
+		},
+		{
+			name: "sync committee message",
+			data: core.NewSignedSyncMessage(testutil.RandomSyncCommitteeMessage()),
+		},
+		{
+			name: "sync committee selection",
+			data: testutil.RandomCoreSyncCommitteeSelection(),
+		},
+		{
+			name: "sync contribution and proof",
+			data: testutil.RandomCoreSignedSyncContributionAndProof(),
+		},
+		{
+			name: "blob sidecar",
+			data: core.NewSignedBlobSidecar(testutil.RandomDenebSignedBlobSidecar(0)),
+		},
+		{
+			name: "blinded blob sidecar",
+			data: core.NewSignedBlindedBlobSidecar(testutil.RandomDenebSignedBlindedBlobSidecar(1)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sig, err := web3SignFunc(ctx, pubkey, test.data)
+			require.NoError(t, err)
+
+			// BLS signatures are deterministic.
+			expect, err := keyShareSignFunc(ctx, pubkey, test.data)
+			require.NoError(t, err)
+			require.Equal(t, expect, sig)
+		})
+	}
+
+	t.Run("deneb block contents", func(t *testing.T) {
+		s := signer.New(shareIdx, bmock, web3SignFunc)
+
+		var signed core.SignedData
+		s.Subscribe(func(_ context.Context, _ core.Duty, set core.ParSignedDataSet) error {
+			signed = set[pubkey].SignedData
+			return nil
+		})
+
+		// The blob sidecars are signed by separate requests and verified along with the block.
+		block := testutil.RandomDenebCoreVersionedBeaconBlock()
+		block.Deneb.Slot = slot
+		for i := 0; i < 2; i++ {
+			sidecar := testutil.RandomDenebBlobSidecar(deneb.BlobIndex(i))
+			sidecar.Slot = slot
+			block.BlobSidecars = append(block.BlobSidecars, sidecar)
+		}
+		require.NoError(t, s.Sign(ctx, core.NewProposerDuty(slot), core.UnsignedDataSet{pubkey: block}))
+
+		signedBlock, ok := signed.(core.VersionedSignedBeaconBlock)
+		require.True(t, ok)
+		require.Len(t, signedBlock.SignedBlobSidecars, 2)
+		require.NoError(t, core.VerifyEth2SignedData(ctx, bmock, signedBlock, pubshare))
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		other, err := tbls.GenerateSecretKey()
+		require.NoError(t, err)
+		otherPubshare, err := tbls.SecretToPublicKey(other)
+		require.NoError(t, err)
+
+		signFunc := signer.NewWeb3SignerSignFunc(bmock, web3signer.New(addr), map[core.PubKey]tbls.PublicKey{pubkey: otherPubshare})
+		_, err = signFunc(ctx, pubkey, testutil.RandomCoreSignedRandao())
+		require.ErrorContains(t, err, "failed web3signer sign request")
+	})
+}
@@ -47,12 +47,12 @@ func (b VersionedSignedBeaconBlock) MarshalSSZTo(buf []byte) ([]byte, error) {
 		return nil, errors.Wrap(err, "invalid version")
 	}
 
-	return marshalSSZVersionedTo(buf, version, b.sszValFromVersion)
+	return marshalSSZVersionedTo(buf, version, b.sszContentsFromVersion)
 }
 
 // UnmarshalSSZ ssz unmarshals the VersionedSignedBeaconBlock object.
 func (b *VersionedSignedBeaconBlock) UnmarshalSSZ(buf []byte) error {
-	version, err := unmarshalSSZVersioned(buf, b.sszValFromVersion)
+	version, err := unmarshalSSZVersioned(buf, b.sszContentsFromVersion)
 	if err != nil {
 		return errors.Wrap(err, "unmarshal VersionedSignedBeaconBlock")
 	}
@@ -70,7 +70,7 @@ func (b VersionedSignedBeaconBlock) SizeSSZ() int {
 		return 0
 	}
 
-	val, err := b.sszValFromVersion(version)
+	val, err := b.sszContentsFromVersion(version)
 	if err != nil {
 		// SSZMarshaller interface doesn't return an error, so we can't either.
 		return 0
@@ -79,6 +79,27 @@ func (b VersionedSignedBeaconBlock) SizeSSZ() int {
 	return sizeSSZVersioned(val)
 }
 
+// sszContentsFromVersion returns the ssz value of the VersionedSignedBeaconBlock object for a given version,
+// which is the deneb signed block contents including the signed blob sidecars or the internal value of other versions.
+func (b *VersionedSignedBeaconBlock) sszContentsFromVersion(version eth2util.DataVersion) (sszType, error) {
+	if version != eth2util.DataVersionDeneb {
+		return b.sszValFromVersion(version)
+	}
+
+	contents := &eth2deneb.SignedBlockContents{
+		SignedBlock:        b.Deneb,
+		SignedBlobSidecars: b.SignedBlobSidecars,
+	}
+
+	return contentsSSZ{
+		sszType: contents,
+		onUnmarshal: func() {
+			b.Deneb = contents.SignedBlock
+			b.SignedBlobSidecars = nonEmpty(contents.SignedBlobSidecars)
+		},
+	}, nil
+}
+
 // sszValFromVersion returns the internal value of the VersionedSignedBeaconBlock object for a given version.
 func (b *VersionedSignedBeaconBlock) sszValFromVersion(version eth2util.DataVersion) (sszType, error) {
 	switch version {
@@ -136,12 +157,12 @@ func (b VersionedBeaconBlock) MarshalSSZTo(buf []byte) ([]byte, error) {
 		return nil, errors.Wrap(err, "invalid version")
 	}
 
-	return marshalSSZVersionedTo(buf, version, b.sszValFromVersion)
+	return marshalSSZVersionedTo(buf, version, b.sszContentsFromVersion)
 }
 
 // UnmarshalSSZ ssz unmarshals the VersionedBeaconBlock object.
 func (b *VersionedBeaconBlock) UnmarshalSSZ(buf []byte) error {
-	version, err := unmarshalSSZVersioned(buf, b.sszValFromVersion)
+	version, err := unmarshalSSZVersioned(buf, b.sszContentsFromVersion)
 	if err != nil {
 		return errors.Wrap(err, "unmarshal VersionedSignedBeaconBlock")
 	}
@@ -159,7 +180,7 @@ func (b VersionedBeaconBlock) SizeSSZ() int {
 		return 0
 	}
 
-	val, err := b.sszValFromVersion(version)
+	val, err := b.sszContentsFromVersion(version)
 	if err != nil {
 		// SSZMarshaller interface doesn't return an error, so we can't either.
 		return 0
@@ -168,6 +189,27 @@ func (b VersionedBeaconBlock) SizeSSZ() int {
 	return sizeSSZVersioned(val)
 }
 
+// sszContentsFromVersion returns the ssz value of the VersionedBeaconBlock object for a given version,
+// which is the deneb block contents including the blob sidecars or the internal value of other versions.
+func (b *VersionedBeaconBlock) sszContentsFromVersion(version eth2util.DataVersion) (sszType, error) {
+	if version != eth2util.DataVersionDeneb {
+		return b.sszValFromVersion(version)
+	}
+
+	contents := &eth2deneb.BlockContents{
+		Block:        b.Deneb,
+		BlobSidecars: b.BlobSidecars,
+	}
+
+	return contentsSSZ{
+		sszType: contents,
+		onUnmarshal: func() {
+			b.Deneb = contents.Block
+			b.BlobSidecars = nonEmpty(contents.BlobSidecars)
+		},
+	}, nil
+}
+
 // sszValFromVersion returns the internal value of the VersionedBeaconBlock object for a given version.
 func (b *VersionedBeaconBlock) sszValFromVersion(version eth2util.DataVersion) (sszType, error) {
 	switch version {
@@ -225,12 +267,12 @@ func (b VersionedSignedBlindedBeaconBlock) MarshalSSZTo(buf []byte) ([]byte, err
 		return nil, errors.Wrap(err, "invalid version")
 	}
 
-	return marshalSSZVersionedTo(buf, version, b.sszValFromVersion)
+	return marshalSSZVersionedTo(buf, version, b.sszContentsFromVersion)
 }
 
 // UnmarshalSSZ ssz unmarshals the VersionedSignedBlindedBeaconBlock object.
 func (b *VersionedSignedBlindedBeaconBlock) UnmarshalSSZ(buf []byte) error {
-	version, err := unmarshalSSZVersioned(buf, b.sszValFromVersion)
+	version, err := unmarshalSSZVersioned(buf, b.sszContentsFromVersion)
 	if err != nil {
 		return errors.Wrap(err, "unmarshal VersionedSignedBlindedBeaconBlock")
 	}
@@ -248,7 +290,7 @@ func (b VersionedSignedBlindedBeaconBlock) SizeSSZ() int {
 		return 0
 	}
 
-	val, err := b.sszValFromVersion(version)
+	val, err := b.sszContentsFromVersion(version)
 	if err != nil {
 		// SSZMarshaller interface doesn't return an error, so we can't either.
 		return 0
@@ -257,6 +299,27 @@ func (b VersionedSignedBlindedBeaconBlock) SizeSSZ() int {
 	return sizeSSZVersioned(val)
 }
 
+// sszContentsFromVersion returns the ssz value of the VersionedSignedBlindedBeaconBlock object for a given version,
+// which is the deneb signed blinded block contents including the signed blinded blob sidecars or the internal value of other versions.
+func (b *VersionedSignedBlindedBeaconBlock) sszContentsFromVersion(version eth2util.DataVersion) (sszType, error) {
+	if version != eth2util.DataVersionDeneb {
+		return b.sszValFromVersion(version)
+	}
+
+	contents := &eth2deneb.SignedBlindedBlockContents{
+		SignedBlindedBlock:        b.Deneb,
+		SignedBlindedBlobSidecars: b.SignedBlindedBlobSidecars,
+	}
+
+	return contentsSSZ{
+		sszType: contents,
+		onUnmarshal: func() {
+			b.Deneb = contents.SignedBlindedBlock
+			b.SignedBlindedBlobSidecars = nonEmpty(contents.SignedBlindedBlobSidecars)
+		},
+	}, nil
+}
+
 // sszValFromVersion returns the internal value of the VersionedSignedBlindedBeaconBlock object for a given version.
 func (b *VersionedSignedBlindedBeaconBlock) sszValFromVersion(version eth2util.DataVersion) (sszType, error) {
 	switch version {
@@ -296,23 +359,69 @@ func (b VersionedBlindedBeaconBlock) MarshalSSZ() ([]byte, error) {
 }
 
 // MarshalSSZTo ssz marshals the VersionedBlindedBeaconBlock object to a target array.
+// The local block is appended after the versioned value if present, see localBlockOffset.
 func (b VersionedBlindedBeaconBlock) MarshalSSZTo(buf []byte) ([]byte, error) {
 	version, err := eth2util.DataVersionFromETH2(b.Version)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid version")
 	}
 
-	return marshalSSZVersionedTo(buf, version, b.sszValFromVersion)
+	if b.LocalBlock == nil {
+		return marshalSSZVersionedTo(buf, version, b.sszContentsFromVersion)
+	}
+
+	val, err := b.sszContentsFromVersion(version)
+	if err != nil {
+		return nil, errors.Wrap(err, "sszValFromVersion from version")
+	}
+
+	// Field (0) 'Version'
+	buf = ssz.MarshalUint64(buf, version.ToUint64())
+
+	// Offset (1) 'Value'
+	buf = ssz.WriteOffset(buf, localBlockOffset)
+
+	// Offset (2) 'LocalBlock'
+	buf = ssz.WriteOffset(buf, localBlockOffset+val.SizeSSZ())
+
+	// Field (1) 'Value'
+	if buf, err = val.MarshalSSZTo(buf); err != nil {
+		return nil, errors.Wrap(err, "marshal sszValFromVersion")
+	}
+
+	// Field (2) 'LocalBlock'
+	if buf, err = b.LocalBlock.MarshalSSZTo(buf); err != nil {
+		return nil, errors.Wrap(err, "marshal local block")
+	}
+
+	return buf, nil
 }
 
 // UnmarshalSSZ ssz unmarshals the VersionedBlindedBeaconBlock object.
 func (b *VersionedBlindedBeaconBlock) UnmarshalSSZ(buf []byte) error {
-	version, err := unmarshalSSZVersioned(buf, b.sszValFromVersion)
+	var local *VersionedBeaconBlock
+	if len(buf) >= localBlockOffset && ssz.ReadOffset(buf[8:12]) == localBlockOffset {
+		// Offset (2) 'LocalBlock'
+		o2 := ssz.ReadOffset(buf[12:16])
+		if o2 < localBlockOffset || o2 > uint64(len(buf)) {
+			return errors.Wrap(ssz.ErrOffset, "local block offset")
+		}
+
+		local = new(VersionedBeaconBlock)
+		if err := local.UnmarshalSSZ(buf[o2:]); err != nil {
+			return errors.Wrap(err, "unmarshal local block")
+		}
+
+		buf = buf[:o2]
+	}
+
+	version, err := unmarshalSSZVersioned(buf, b.sszContentsFromVersion)
 	if err != nil {
 		return errors.Wrap(err, "unmarshal VersionedSignedBeaconBlock")
 	}
 
 	b.Version = version.ToETH2()
+	b.LocalBlock = local
 
 	return nil
 }
@@ -325,15 +434,40 @@ func (b VersionedBlindedBeaconBlock) SizeSSZ() int {
 		return 0
 	}
 
-	val, err := b.sszValFromVersion(version)
+	val, err := b.sszContentsFromVersion(version)
 	if err != nil {
 		// SSZMarshaller interface doesn't return an error, so we can't either.
 		return 0
 	}
 
+	if b.LocalBlock != nil {
+		return localBlockOffset + val.SizeSSZ() + b.LocalBlock.SizeSSZ()
+	}
+
 	return sizeSSZVersioned(val)
 }
 
+// sszContentsFromVersion returns the ssz value of the VersionedBlindedBeaconBlock object for a given version,
+// which is the deneb blinded block contents including the blinded blob sidecars or the internal value of other versions.
+func (b *VersionedBlindedBeaconBlock) sszContentsFromVersion(version eth2util.DataVersion) (sszType, error) {
+	if version != eth2util.DataVersionDeneb {
+		return b.sszValFromVersion(version)
+	}
+
+	contents := &eth2deneb.BlindedBlockContents{
+		BlindedBlock:        b.Deneb,
+		BlindedBlobSidecars: b.BlindedBlobSidecars,
+	}
+
+	return contentsSSZ{
+		sszType: contents,
+		onUnmarshal: func() {
+			b.Deneb = contents.BlindedBlock
+			b.BlindedBlobSidecars = nonEmpty(contents.BlindedBlobSidecars)
+		},
+	}, nil
+}
+
 // sszValFromVersion returns the internal value of the VersionedBlindedBeaconBlock object for a given version.
 func (b *VersionedBlindedBeaconBlock) sszValFromVersion(version eth2util.DataVersion) (sszType, error) {
 	switch version {
@@ -360,9 +494,29 @@ func (b *VersionedBlindedBeaconBlock) sszValFromVersion(version eth2util.DataVer
 	}
 }
 
+// contentsSSZ wraps deneb block contents, updating the versioned block from the contents after unmarshalling.
+type contentsSSZ struct {
+	sszType
+	onUnmarshal func()
+}
+
+func (c contentsSSZ) UnmarshalSSZ(buf []byte) error {
+	if err := c.sszType.UnmarshalSSZ(buf); err != nil {
+		return errors.Wrap(err, "unmarshal block contents")
+	}
+
+	c.onUnmarshal()
+
+	return nil
+}
+
 // versionedOffset is the offset of a versioned ssz encoded object.
 const versionedOffset = 8 + 4 // version (uint64) + offset (uint32)
 
+// localBlockOffset is the offset of a versioned ssz encoded blinded block with a local block. The value offset
+// indicates the extra local block offset, so blinded blocks without local blocks are encoded as before.
+const localBlockOffset = versionedOffset + 4 // version (uint64) + offset (uint32) + local block offset (uint32)
+
 // marshalSSZVersionedTo marshals a versioned object to a target array.
 func marshalSSZVersionedTo(dst []byte, version eth2util.DataVersion, valFunc func(eth2util.DataVersion) (sszType, error)) ([]byte, error) {
 	// Field (0) 'Version'
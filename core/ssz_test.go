@@ -3,6 +3,7 @@
 package core_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"reflect"
@@ -10,6 +11,8 @@ import (
 	"testing"
 	"time"
 
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -90,6 +93,56 @@ func TestSSZ(t *testing.T) {
 	}
 }
 
+// TestBlockContents tests SSZ and JSON marshalling and unmarshalling of deneb blocks with blob sidecars
+// and of blinded blocks with local blocks.
+func TestBlockContents(t *testing.T) {
+	block := testutil.RandomDenebCoreVersionedBeaconBlock()
+	block.BlobSidecars = []*deneb.BlobSidecar{testutil.RandomDenebBlobSidecar(0), testutil.RandomDenebBlobSidecar(1)}
+
+	blinded := testutil.RandomDenebVersionedBlindedBeaconBlock()
+	blinded.BlindedBlobSidecars = []*eth2deneb.BlindedBlobSidecar{testutil.RandomDenebBlindedBlobSidecar(0)}
+
+	localBlinded := testutil.RandomDenebVersionedBlindedBeaconBlock()
+	localBlinded.BlindedBlobSidecars = []*eth2deneb.BlindedBlobSidecar{testutil.RandomDenebBlindedBlobSidecar(0)}
+	localBlinded.LocalBlock = &block
+
+	signed := testutil.RandomDenebCoreVersionedSignedBeaconBlock()
+	signed.SignedBlobSidecars = []*deneb.SignedBlobSidecar{testutil.RandomDenebSignedBlobSidecar(0)}
+
+	signedBlinded := testutil.RandomDenebVersionedSignedBlindedBeaconBlock()
+	signedBlinded.SignedBlindedBlobSidecars = []*eth2deneb.SignedBlindedBlobSidecar{testutil.RandomDenebSignedBlindedBlobSidecar(0)}
+
+	tests := []struct {
+		val  any
+		zero func() any
+	}{
+		{val: &block, zero: func() any { return new(core.VersionedBeaconBlock) }},
+		{val: &blinded, zero: func() any { return new(core.VersionedBlindedBeaconBlock) }},
+		{val: &localBlinded, zero: func() any { return new(core.VersionedBlindedBeaconBlock) }},
+		{val: &signed, zero: func() any { return new(core.VersionedSignedBeaconBlock) }},
+		{val: &signedBlinded, zero: func() any { return new(core.VersionedSignedBlindedBeaconBlock) }},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%T", test.val), func(t *testing.T) {
+			b, err := test.val.(ssz.Marshaler).MarshalSSZ()
+			testutil.RequireNoError(t, err)
+
+			sszVal := test.zero()
+			err = sszVal.(ssz.Unmarshaler).UnmarshalSSZ(b)
+			testutil.RequireNoError(t, err)
+			require.Equal(t, test.val, sszVal)
+
+			b, err = json.Marshal(test.val)
+			require.NoError(t, err)
+
+			jsonVal := test.zero()
+			require.NoError(t, json.Unmarshal(b, jsonVal))
+			require.Equal(t, test.val, jsonVal)
+		})
+	}
+}
+
 func TestMarshalUnsignedProto(t *testing.T) {
 	tests := []struct {
 		unsignedPtr func() any // Need any pointer to avoid wrapping in interface which doesnt' support fuzzing.
@@ -0,0 +1,85 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"fmt"
+
+	"github.com/obolnetwork/charon/core"
+)
+
+// Option configures a Tracker.
+type Option func(*Tracker)
+
+// WithArchiver returns an option that provides the tracked history of each duty to the archiver
+// when the duty is deleted, before its events are dropped.
+func WithArchiver(archiver func(core.Duty, ArchivedDuty)) Option {
+	return func(t *Tracker) {
+		t.archiver = archiver
+	}
+}
+
+// ArchivedDuty is the tracked history of a duty.
+type ArchivedDuty struct {
+	Events []ArchivedEvent `json:"events"`
+	// Analysed is true if the duty was analysed, see Failed, FailedStep, Reason and Error.
+	Analysed   bool   `json:"analysed"`
+	Failed     bool   `json:"failed"`
+	FailedStep string `json:"failed_step,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// ArchivedEvent is an event emitted by a core workflow step for a duty.
+type ArchivedEvent struct {
+	Step   string      `json:"step"`
+	PubKey core.PubKey `json:"pubkey"`
+	Error  string      `json:"error,omitempty"`
+	// ShareIdx and MsgRoot identify the partial signature of validatorAPI, parSigDBInternal and parSigEx events.
+	ShareIdx int    `json:"share_idx,omitempty"`
+	MsgRoot  string `json:"msg_root,omitempty"`
+}
+
+// analysis is the result of a duty's failure analysis.
+type analysis struct {
+	failed bool
+	step   step
+	reason reason
+	err    error
+}
+
+// newArchivedDuty returns the archived duty of the events and the optional analysis.
+func newArchivedDuty(events []event, analysis *analysis) ArchivedDuty {
+	var resp ArchivedDuty
+	for _, e := range events {
+		archived := ArchivedEvent{
+			Step:   e.step.String(),
+			PubKey: e.pubkey,
+		}
+		if e.stepErr != nil {
+			archived.Error = e.stepErr.Error()
+		}
+		if e.parSig != nil {
+			archived.ShareIdx = e.parSig.ShareIdx
+			if root, err := e.parSig.MessageRoot(); err == nil {
+				archived.MsgRoot = fmt.Sprintf("%#x", root)
+			}
+		}
+
+		resp.Events = append(resp.Events, archived)
+	}
+
+	if analysis != nil {
+		resp.Analysed = true
+		resp.Failed = analysis.failed
+		if analysis.failed {
+			resp.FailedStep = analysis.step.String()
+			resp.Reason = analysis.reason.Code
+		}
+		if analysis.err != nil {
+			resp.Error = analysis.err.Error()
+		}
+	}
+
+	return resp
+}
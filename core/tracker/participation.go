@@ -0,0 +1,60 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package tracker
+
+import (
+	"sync"
+
+	"github.com/obolnetwork/charon/p2p"
+)
+
+// participationAlpha is the weight of the latest duty in the exponentially weighted moving average participation rates.
+const participationAlpha = 0.1
+
+// newParticipationRates returns a new participationRates of the peers, initialised to full participation.
+func newParticipationRates(peers []p2p.Peer) *participationRates {
+	rates := make(map[int]float64)
+	for _, p := range peers {
+		rates[p.ShareIdx()] = 1
+	}
+
+	return &participationRates{rates: rates}
+}
+
+// participationRates tracks the exponentially weighted moving average duty participation rate of each peer.
+type participationRates struct {
+	mu    sync.Mutex
+	rates map[int]float64
+}
+
+// Update updates the rates with the participation of a duty.
+func (r *participationRates) Update(participatedShares map[int]int, expectedPerPeer int) {
+	if expectedPerPeer == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for shareIdx, rate := range r.rates {
+		participated := participatedShares[shareIdx]
+		if participated > expectedPerPeer {
+			participated = expectedPerPeer
+		}
+
+		r.rates[shareIdx] = (1-participationAlpha)*rate + participationAlpha*float64(participated)/float64(expectedPerPeer)
+	}
+}
+
+// Rates returns a copy of the rates by share index.
+func (r *participationRates) Rates() map[int]float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resp := make(map[int]float64)
+	for shareIdx, rate := range r.rates {
+		resp[shareIdx] = rate
+	}
+
+	return resp
+}
@@ -175,6 +175,12 @@ var (
 		Long:  "Reason `sig_agg` indicates that BLS threshold aggregation of sufficient partial signatures failed. This indicates inconsistent signed data. This indicates a bug in charon as it is unexpected.",
 	}
 
+	reasonSigAggInvalidShares = reason{
+		Code:  "sig_agg_invalid_shares",
+		Short: "insufficient valid partial signatures due to invalid partial signatures of some peers",
+		Long:  "Reason `sig_agg_invalid_shares` indicates that threshold aggregation failed since some peers submitted partial signatures that are invalid for their public shares in the cluster lock, and insufficient valid partial signatures were received from the other peers. This indicates misconfigured peers, for example peers using the wrong validator keys. The duty failed error identifies the peers that submitted invalid partial signatures.",
+	}
+
 	reasonAggSigDB = reason{
 		Code:  "agg_sig_db",
 		Short: "bug: failed to store aggregated signature in aggsigdb",
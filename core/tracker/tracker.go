@@ -95,6 +95,8 @@ type Tracker struct {
 	// fromSlot indicates the slot to start tracking events from.
 	fromSlot int64
 	quit     chan struct{}
+	// peers are the peers of the cluster used to attribute failures to peers.
+	peers []p2p.Peer
 
 	// parSigReporter instruments partial signature data inconsistencies.
 	parSigReporter func(ctx context.Context, duty core.Duty, parsigMsgs parsigsByMsg)
@@ -104,20 +106,35 @@ type Tracker struct {
 
 	// participationReporter instruments duty peer participation.
 	participationReporter func(ctx context.Context, duty core.Duty, failed bool, participatedShares map[int]int, unexpectedPeers map[int]int, expectedPerPeer int)
+
+	// participation tracks the recent duty participation rates of peers.
+	participation *participationRates
+
+	// archiver optionally archives the tracked history of duties before deletion.
+	archiver func(core.Duty, ArchivedDuty)
+	// analyses stores the analysis results of duties to archive.
+	analyses map[core.Duty]analysis
 }
 
 // New returns a new Tracker. The deleter deadliner must return well after analyser deadliner since duties of the same slot are often analysed together.
-func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, fromSlot int64) *Tracker {
+func New(analyser core.Deadliner, deleter core.Deadliner, peers []p2p.Peer, fromSlot int64, opts ...Option) *Tracker {
 	t := &Tracker{
 		input:                 make(chan event),
 		events:                make(map[core.Duty][]event),
+		analyses:              make(map[core.Duty]analysis),
 		quit:                  make(chan struct{}),
 		analyser:              analyser,
 		deleter:               deleter,
 		fromSlot:              fromSlot,
+		peers:                 peers,
 		parSigReporter:        reportParSigs,
 		failedDutyReporter:    newFailedDutyReporter(),
 		participationReporter: newParticipationReporter(peers),
+		participation:         newParticipationRates(peers),
+	}
+
+	for _, opt := range opts {
+		opt(t)
 	}
 
 	return t
@@ -156,17 +173,41 @@ func (t *Tracker) Run(ctx context.Context) error {
 				continue // Ignore unsupported duties
 			}
 
+			if reason == reasonSigAggInvalidShares {
+				failedErr = attributeInvalidShares(failedErr, t.peers)
+			}
+
 			t.failedDutyReporter(ctx, duty, failed, failedStep, reason, failedErr)
+			if t.archiver != nil {
+				t.analyses[duty] = analysis{failed: failed, step: failedStep, reason: reason, err: failedErr}
+			}
 
 			// Analyse peer participation
 			participatedShares, unexpectedShares, expectedPerPeer := analyseParticipation(duty, t.events)
 			t.participationReporter(ctx, duty, failed, participatedShares, unexpectedShares, expectedPerPeer)
+			if len(participatedShares) > 0 || failed {
+				t.participation.Update(participatedShares, expectedPerPeer)
+			}
 		case duty := <-t.deleter.C():
+			if t.archiver != nil {
+				var dutyAnalysis *analysis
+				if a, ok := t.analyses[duty]; ok {
+					dutyAnalysis = &a
+				}
+				t.archiver(duty, newArchivedDuty(t.events[duty], dutyAnalysis))
+				delete(t.analyses, duty)
+			}
 			delete(t.events, duty)
 		}
 	}
 }
 
+// ParticipationRates returns the recent duty participation rate (between 0 and 1) of each peer by share index.
+// The rates are exponentially weighted moving averages over analysed duties, initialised to full participation.
+func (t *Tracker) ParticipationRates() map[int]float64 {
+	return t.participation.Rates()
+}
+
 // dutyFailedStep returns true if the duty failed. It also returns the step where the
 // duty got stuck and the last error that component returned.
 // If the duty didn't fail, it returns false and the zero step and a nil error.
@@ -262,7 +303,10 @@ func analyseDutyFailed(duty core.Duty, allEvents map[core.Duty][]event, msgRootC
 			}
 		}
 	case sigAgg:
-		if failedErr != nil {
+		var sharesErr core.InvalidSharesError
+		if errors.As(failedErr, &sharesErr) {
+			reason = reasonSigAggInvalidShares
+		} else if failedErr != nil {
 			reason = reasonSigAgg
 		}
 	case aggSigDB:
@@ -288,6 +332,25 @@ func analyseDutyFailed(duty core.Duty, allEvents map[core.Duty][]event, msgRootC
 	return true, failedStep, reason, failedErr
 }
 
+// attributeInvalidShares returns the invalid shares error wrapped with the names of the peers of the invalid shares.
+func attributeInvalidShares(err error, peers []p2p.Peer) error {
+	var sharesErr core.InvalidSharesError
+	if !errors.As(err, &sharesErr) {
+		return err
+	}
+
+	var names []string
+	for _, shareIdx := range sharesErr.ShareIdxs {
+		for _, p := range peers {
+			if p.ShareIdx() == shareIdx {
+				names = append(names, p.Name)
+			}
+		}
+	}
+
+	return errors.Wrap(err, "invalid partial signatures by peers", z.Any("peers", names))
+}
+
 // analyseFetcherFailed returns whether the duty that got stuck in fetcher actually failed
 // and the reason which might actually be due a pre-requisite duty that failed.
 func analyseFetcherFailed(duty core.Duty, allEvents map[core.Duty][]event, fetchErr error) (bool, step, reason, error) {
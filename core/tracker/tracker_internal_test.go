@@ -4,6 +4,7 @@ package tracker
 
 import (
 	"context"
+	"math"
 	"math/rand"
 	"net/http"
 	"reflect"
@@ -123,6 +124,58 @@ func TestTrackerFailedDuty(t *testing.T) {
 	})
 }
 
+func TestTrackerArchiver(t *testing.T) {
+	const slot = 1
+	testData, _ := setupData(t, []int{slot}, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	analyser := testDeadliner{deadlineChan: make(chan core.Duty)}
+	deleter := testDeadliner{deadlineChan: make(chan core.Duty)}
+	consensusErr := errors.New("consensus error")
+
+	var count int
+	archiver := func(duty core.Duty, archived ArchivedDuty) {
+		require.Equal(t, testData[count].duty, duty)
+		require.Len(t, archived.Events, 2*len(testData[count].defSet))
+		require.True(t, archived.Analysed)
+		require.True(t, archived.Failed)
+		require.Equal(t, consensus.String(), archived.FailedStep)
+		require.Equal(t, reasonConsensus.Code, archived.Reason)
+		require.Contains(t, archived.Error, consensusErr.Error())
+
+		for _, e := range archived.Events {
+			if e.Step == consensus.String() {
+				require.Equal(t, consensusErr.Error(), e.Error)
+			} else {
+				require.Equal(t, fetcher.String(), e.Step)
+				require.Empty(t, e.Error)
+			}
+		}
+
+		count++
+		if count == len(testData) {
+			cancel()
+		}
+	}
+
+	tr := New(analyser, deleter, []p2p.Peer{}, 0, WithArchiver(archiver))
+	tr.failedDutyReporter = func(context.Context, core.Duty, bool, step, reason, error) {}
+	tr.participationReporter = func(context.Context, core.Duty, bool, map[int]int, map[int]int, int) {}
+
+	go func() {
+		for _, td := range testData {
+			tr.FetcherFetched(td.duty, td.defSet, nil)
+			tr.ConsensusProposed(td.duty, td.unsignedDataSet, consensusErr)
+
+			analyser.deadlineChan <- td.duty
+			deleter.deadlineChan <- td.duty
+		}
+	}()
+
+	require.ErrorIs(t, tr.Run(ctx), context.Canceled)
+	require.Equal(t, len(testData), count)
+}
+
 func TestAnalyseDutyFailed(t *testing.T) {
 	slot := 1
 	attDuty := core.NewAttesterDuty(int64(slot))
@@ -248,6 +301,26 @@ func TestAnalyseDutyFailed(t *testing.T) {
 		require.Equal(t, reason, reasonParSigDBInconsistentSync)
 	})
 
+	t.Run("Failed at sigagg due to invalid shares", func(t *testing.T) {
+		sigAggErr := errors.Wrap(core.InvalidSharesError{ShareIdxs: []int{2}}, "insufficient valid partial signatures")
+		events[attDuty] = append(events[attDuty], event{
+			duty:    attDuty,
+			step:    sigAgg,
+			stepErr: sigAggErr,
+		})
+
+		failed, step, reason, err := analyseDutyFailed(attDuty, events, true)
+		require.ErrorIs(t, err, sigAggErr)
+		require.True(t, failed)
+		require.Equal(t, step, sigAgg)
+		require.Equal(t, reason, reasonSigAggInvalidShares)
+
+		peers := []p2p.Peer{{Index: 0, Name: "peer-a"}, {Index: 1, Name: "peer-b"}}
+		err = attributeInvalidShares(err, peers)
+		require.ErrorContains(t, err, "invalid partial signatures by peers")
+		require.ErrorIs(t, err, sigAggErr)
+	})
+
 	t.Run("Failed at bcast", func(t *testing.T) {
 		bcastErr := errors.New("bcast failed")
 		events[attDuty] = append(events[attDuty], event{
@@ -1305,3 +1378,22 @@ func TestIgnoreUnsupported(t *testing.T) {
 func randomStep() step {
 	return step(rand.Intn(int(sentinel)))
 }
+
+func TestParticipationRates(t *testing.T) {
+	peers := []p2p.Peer{{Index: 0}, {Index: 1}, {Index: 2}}
+	rates := newParticipationRates(peers)
+	require.Equal(t, map[int]float64{1: 1, 2: 1, 3: 1}, rates.Rates())
+
+	// Noop duties are ignored.
+	rates.Update(nil, 0)
+	require.Equal(t, map[int]float64{1: 1, 2: 1, 3: 1}, rates.Rates())
+
+	for i := 0; i < 10; i++ {
+		rates.Update(map[int]int{1: 2, 2: 1}, 2)
+	}
+
+	actual := rates.Rates()
+	require.InDelta(t, 1, actual[1], 0.001)
+	require.InDelta(t, 0.5+0.5*math.Pow(0.9, 10), actual[2], 0.001)
+	require.InDelta(t, math.Pow(0.9, 10), actual[3], 0.001)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/obolnetwork/charon/app/errors"
 	"github.com/obolnetwork/charon/app/eth2wrap"
@@ -19,6 +20,16 @@ import (
 // ErrNotFound is returned by a component when a resource is not found.
 var ErrNotFound = errors.NewSentinel("not found")
 
+// InvalidSharesError is returned by the aggregator when threshold aggregation failed due to invalid partial signatures.
+// It identifies the share indexes of the invalid partial signatures.
+type InvalidSharesError struct {
+	ShareIdxs []int
+}
+
+func (e InvalidSharesError) Error() string {
+	return fmt.Sprintf("invalid partial signatures of shares %v", e.ShareIdxs)
+}
+
 // DutyType enumerates the different types of duties.
 type DutyType int
 
@@ -39,9 +50,10 @@ const (
 	DutyPrepareSyncContribution DutyType = 11
 	DutySyncContribution        DutyType = 12
 	DutyInfoSync                DutyType = 13
+	DutyConsensusBatch          DutyType = 14
 	// Only ever append new types here...
 
-	dutySentinel DutyType = 14 // Must always be last
+	dutySentinel DutyType = 15 // Must always be last
 )
 
 func (d DutyType) Valid() bool {
@@ -64,6 +76,7 @@ func (d DutyType) String() string {
 		DutyPrepareSyncContribution: "prepare_sync_contribution",
 		DutySyncContribution:        "sync_contribution",
 		DutyInfoSync:                "info_sync",
+		DutyConsensusBatch:          "consensus_batch",
 	}[d]
 }
 
@@ -268,6 +281,15 @@ func NewInfoSyncDuty(slot int64) Duty {
 	}
 }
 
+// NewConsensusBatchDuty returns a new consensus batch duty. It is a convenience function that is
+// slightly more readable and concise than the struct literal equivalent.
+func NewConsensusBatchDuty(slot int64) Duty {
+	return Duty{
+		Slot: slot,
+		Type: DutyConsensusBatch,
+	}
+}
+
 const (
 	pkLen  = 98 // "0x" + hex.Encode([48]byte) = 2+2*48
 	sigLen = 96
@@ -407,6 +429,16 @@ type Eth2SignedData interface {
 	Epoch(ctx context.Context, eth2Cl eth2wrap.Client) (eth2p0.Epoch, error)
 }
 
+// SidecarSignedData is signed duty data that also contains signed sidecars, e.g. deneb blocks with signed blob sidecars.
+// The sidecar signatures are verified and threshold aggregated along with the signed duty data signature.
+type SidecarSignedData interface {
+	SignedData
+	// Sidecars returns the signed sidecars, which may be empty.
+	Sidecars() []Eth2SignedData
+	// SetSidecarSignatures returns a copy of signed duty data with the sidecar signatures replaced.
+	SetSidecarSignatures([]Signature) (SignedData, error)
+}
+
 // ParSignedData is a partially signed duty data only signed by a single threshold BLS share.
 type ParSignedData struct {
 	// SignedData is a partially signed duty data.
@@ -447,6 +479,21 @@ func (s ParSignedDataSet) Clone() (ParSignedDataSet, error) {
 	return resp, nil
 }
 
+// parSigSenderKey is the context key of the peer that sent an externally received partially signed data set.
+type parSigSenderKey struct{}
+
+// WithParSigSender returns a copy of the context with the peer that sent the partially signed data set.
+// For relayed partially signed data, it is the peer of the share, not the relaying peer.
+func WithParSigSender(ctx context.Context, sender peer.ID) context.Context {
+	return context.WithValue(ctx, parSigSenderKey{}, sender)
+}
+
+// ParSigSender returns the peer that sent the partially signed data set or false if it wasn't received from a peer.
+func ParSigSender(ctx context.Context) (peer.ID, bool) {
+	sender, ok := ctx.Value(parSigSenderKey{}).(peer.ID)
+	return sender, ok
+}
+
 // SignedDataSet is a set of signed duty data objects, one per validator.
 type SignedDataSet map[PubKey]SignedData
 
@@ -170,8 +170,25 @@ func NewVersionedBeaconBlock(block *eth2spec.VersionedBeaconBlock) (VersionedBea
 	return VersionedBeaconBlock{VersionedBeaconBlock: *block}, nil
 }
 
+// NewVersionedBeaconBlockContents validates and returns a new wrapped VersionedBeaconBlock including the blob sidecars
+// of deneb block contents.
+func NewVersionedBeaconBlockContents(block *eth2spec.VersionedBeaconBlock, sidecars []*deneb.BlobSidecar) (VersionedBeaconBlock, error) {
+	resp, err := NewVersionedBeaconBlock(block)
+	if err != nil {
+		return VersionedBeaconBlock{}, err
+	} else if len(sidecars) > 0 && block.Version != eth2spec.DataVersionDeneb {
+		return VersionedBeaconBlock{}, errors.New("blob sidecars only supported by deneb")
+	}
+
+	resp.BlobSidecars = nonEmpty(sidecars)
+
+	return resp, nil
+}
+
 type VersionedBeaconBlock struct {
 	eth2spec.VersionedBeaconBlock
+	// BlobSidecars are the blob sidecars of deneb block contents, nil otherwise.
+	BlobSidecars []*deneb.BlobSidecar
 }
 
 func (b VersionedBeaconBlock) Clone() (UnsignedData, error) {
@@ -212,9 +229,15 @@ func (b VersionedBeaconBlock) MarshalJSON() ([]byte, error) {
 		return nil, errors.Wrap(err, "convert version")
 	}
 
+	sidecars, err := marshalSidecarsJSON(b.BlobSidecars)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := json.Marshal(versionedRawBlockJSON{
-		Version: version,
-		Block:   block,
+		Version:  version,
+		Block:    block,
+		Sidecars: sidecars,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal wrapper")
@@ -265,13 +288,23 @@ func (b *VersionedBeaconBlock) UnmarshalJSON(input []byte) error {
 		return errors.New("unknown version")
 	}
 
-	*b = VersionedBeaconBlock{VersionedBeaconBlock: resp}
+	sidecars, err := unmarshalSidecarsJSON[*deneb.BlobSidecar](raw.Sidecars, resp.Version)
+	if err != nil {
+		return err
+	}
+
+	*b = VersionedBeaconBlock{VersionedBeaconBlock: resp, BlobSidecars: sidecars}
 
 	return nil
 }
 
 type VersionedBlindedBeaconBlock struct {
 	eth2api.VersionedBlindedBeaconBlock
+	// BlindedBlobSidecars are the blinded blob sidecars of deneb blinded block contents, nil otherwise.
+	BlindedBlobSidecars []*eth2deneb.BlindedBlobSidecar
+	// LocalBlock is the local block if it is proposed as this blinded block, nil otherwise.
+	// It is decided along with the blinded block, so all peers can unblind the signed blinded block.
+	LocalBlock *VersionedBeaconBlock
 }
 
 // NewVersionedBlindedBeaconBlock validates and returns a new wrapped VersionedBlindedBeaconBlock.
@@ -296,6 +329,21 @@ func NewVersionedBlindedBeaconBlock(block *eth2api.VersionedBlindedBeaconBlock)
 	return VersionedBlindedBeaconBlock{VersionedBlindedBeaconBlock: *block}, nil
 }
 
+// NewVersionedBlindedBeaconBlockContents validates and returns a new wrapped VersionedBlindedBeaconBlock including
+// the blinded blob sidecars of deneb blinded block contents.
+func NewVersionedBlindedBeaconBlockContents(block *eth2api.VersionedBlindedBeaconBlock, sidecars []*eth2deneb.BlindedBlobSidecar) (VersionedBlindedBeaconBlock, error) {
+	resp, err := NewVersionedBlindedBeaconBlock(block)
+	if err != nil {
+		return VersionedBlindedBeaconBlock{}, err
+	} else if len(sidecars) > 0 && block.Version != eth2spec.DataVersionDeneb {
+		return VersionedBlindedBeaconBlock{}, errors.New("blinded blob sidecars only supported by deneb")
+	}
+
+	resp.BlindedBlobSidecars = nonEmpty(sidecars)
+
+	return resp, nil
+}
+
 func (b VersionedBlindedBeaconBlock) Clone() (UnsignedData, error) {
 	var resp VersionedBlindedBeaconBlock
 	err := cloneJSONMarshaler(b, &resp)
@@ -330,9 +378,26 @@ func (b VersionedBlindedBeaconBlock) MarshalJSON() ([]byte, error) {
 		return nil, errors.Wrap(err, "convert version")
 	}
 
-	resp, err := json.Marshal(versionedRawBlockJSON{
-		Version: version,
-		Block:   block,
+	sidecars, err := marshalSidecarsJSON(b.BlindedBlobSidecars)
+	if err != nil {
+		return nil, err
+	}
+
+	var local json.RawMessage
+	if b.LocalBlock != nil {
+		local, err = b.LocalBlock.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal local block")
+		}
+	}
+
+	resp, err := json.Marshal(versionedRawBlindedBlockJSON{
+		versionedRawBlockJSON: versionedRawBlockJSON{
+			Version:  version,
+			Block:    block,
+			Sidecars: sidecars,
+		},
+		LocalBlock: local,
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal wrapper")
@@ -342,7 +407,7 @@ func (b VersionedBlindedBeaconBlock) MarshalJSON() ([]byte, error) {
 }
 
 func (b *VersionedBlindedBeaconBlock) UnmarshalJSON(input []byte) error {
-	var raw versionedRawBlockJSON
+	var raw versionedRawBlindedBlockJSON
 	if err := json.Unmarshal(input, &raw); err != nil {
 		return errors.Wrap(err, "unmarshal block")
 	}
@@ -371,11 +436,30 @@ func (b *VersionedBlindedBeaconBlock) UnmarshalJSON(input []byte) error {
 		return errors.New("unknown version")
 	}
 
-	*b = VersionedBlindedBeaconBlock{VersionedBlindedBeaconBlock: resp}
+	sidecars, err := unmarshalSidecarsJSON[*eth2deneb.BlindedBlobSidecar](raw.Sidecars, resp.Version)
+	if err != nil {
+		return err
+	}
+
+	var local *VersionedBeaconBlock
+	if len(raw.LocalBlock) > 0 {
+		local = new(VersionedBeaconBlock)
+		if err := local.UnmarshalJSON(raw.LocalBlock); err != nil {
+			return errors.Wrap(err, "unmarshal local block")
+		}
+	}
+
+	*b = VersionedBlindedBeaconBlock{VersionedBlindedBeaconBlock: resp, BlindedBlobSidecars: sidecars, LocalBlock: local}
 
 	return nil
 }
 
+// versionedRawBlindedBlockJSON is the json wrapper of a blinded block including its optional local block.
+type versionedRawBlindedBlockJSON struct {
+	versionedRawBlockJSON
+	LocalBlock json.RawMessage `json:"local_block,omitempty"`
+}
+
 // NewSyncContribution returns a new SyncContribution.
 func NewSyncContribution(c *altair.SyncCommitteeContribution) SyncContribution {
 	return SyncContribution{SyncCommitteeContribution: *c}
@@ -36,6 +36,14 @@ func TestUnsignedDataClone(t *testing.T) {
 			name: "versioned blinded beacon block capella",
 			data: testutil.RandomCapellaVersionedBlindedBeaconBlock(),
 		},
+		{
+			name: "versioned beacon block deneb",
+			data: testutil.RandomDenebCoreVersionedBeaconBlock(),
+		},
+		{
+			name: "versioned blinded beacon block deneb",
+			data: testutil.RandomDenebVersionedBlindedBeaconBlock(),
+		},
 		{
 			name: "aggregated attestation",
 			data: core.NewAggregatedAttestation(testutil.RandomAttestation()),
@@ -0,0 +1,119 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/log"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/core"
+)
+
+type authKey struct{}
+
+// AuthToken is a bearer token authorised to submit partial signatures of the listed distributed validators.
+type AuthToken struct {
+	Token   string        `json:"token"`
+	PubKeys []core.PubKey `json:"pubkeys"`
+}
+
+// LoadAuthTokens returns the bearer tokens from the json file at the provided path.
+// The file contains a list of objects with a "token" and a list of hex encoded "pubkeys".
+func LoadAuthTokens(path string) ([]AuthToken, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read auth tokens file", z.Str("path", path))
+	}
+
+	var tokens []AuthToken
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		return nil, errors.Wrap(err, "unmarshal auth tokens file", z.Str("path", path))
+	}
+
+	for i, token := range tokens {
+		if token.Token == "" {
+			return nil, errors.New("empty auth token", z.Int("index", i))
+		} else if len(token.PubKeys) == 0 {
+			return nil, errors.New("auth token without pubkeys", z.Int("index", i))
+		}
+
+		for j, pubkey := range token.PubKeys {
+			b, err := pubkey.Bytes()
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid auth token pubkey", z.Int("index", i))
+			}
+
+			// Normalise hex encoding
+			tokens[i].PubKeys[j], err = core.PubKeyFromBytes(b)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return tokens, nil
+}
+
+// NewAuthHandler returns a handler that rejects requests without a valid bearer token
+// and restricts partial signature submissions to the validators authorised for the token.
+func NewAuthHandler(handler http.Handler, tokens []AuthToken) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := log.WithTopic(r.Context(), "vapi")
+
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			writeError(ctx, w, "auth", apiError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "missing bearer token",
+			})
+
+			return
+		}
+
+		var allowed map[core.PubKey]bool
+		for _, token := range tokens {
+			// Compare all tokens in constant time to not leak which tokens exist.
+			if subtle.ConstantTimeCompare([]byte(bearer), []byte(token.Token)) != 1 {
+				continue
+			}
+
+			allowed = make(map[core.PubKey]bool)
+			for _, pubkey := range token.PubKeys {
+				allowed[pubkey] = true
+			}
+		}
+
+		if allowed == nil {
+			writeError(ctx, w, "auth", apiError{
+				StatusCode: http.StatusUnauthorized,
+				Message:    "invalid bearer token",
+			})
+
+			return
+		}
+
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authKey{}, allowed)))
+	})
+}
+
+// checkAuthorised returns an error if the request context was authenticated with a bearer token
+// that isn't authorised for the provided distributed validator.
+func checkAuthorised(ctx context.Context, pubkey core.PubKey) error {
+	allowed, ok := ctx.Value(authKey{}).(map[core.PubKey]bool)
+	if !ok || allowed[pubkey] {
+		return nil
+	}
+
+	return apiError{
+		StatusCode: http.StatusForbidden,
+		Message:    "bearer token not authorised for validator",
+		Err:        errors.New("unauthorised validator", z.Str("pubkey", pubkey.String())),
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package validatorapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/core"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestLoadAuthTokens(t *testing.T) {
+	pubkey := testutil.RandomCorePubKey(t)
+
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+
+		path := filepath.Join(t.TempDir(), "tokens.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		return path
+	}
+
+	tokens, err := LoadAuthTokens(write(t, `[{"token":"secret","pubkeys":["`+strings.ToUpper(string(pubkey))+`"]}]`))
+	require.NoError(t, err)
+	require.Equal(t, []AuthToken{{Token: "secret", PubKeys: []core.PubKey{pubkey}}}, tokens)
+
+	_, err = LoadAuthTokens(write(t, `[{"token":"","pubkeys":["`+string(pubkey)+`"]}]`))
+	require.ErrorContains(t, err, "empty auth token")
+
+	_, err = LoadAuthTokens(write(t, `[{"token":"secret"}]`))
+	require.ErrorContains(t, err, "auth token without pubkeys")
+
+	_, err = LoadAuthTokens(write(t, `[{"token":"secret","pubkeys":["0x1234"]}]`))
+	require.ErrorContains(t, err, "invalid auth token pubkey")
+}
+
+func TestAuthHandler(t *testing.T) {
+	allowed := testutil.RandomCorePubKey(t)
+	other := testutil.RandomCorePubKey(t)
+
+	vapi, err := NewComponentInsecure(t, nil, 1)
+	require.NoError(t, err)
+
+	var verifyErrs []error
+	handler := NewAuthHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verifyErrs = []error{
+			vapi.verifyPartialSig(r.Context(), core.ParSignedData{}, allowed),
+			vapi.verifyPartialSig(r.Context(), core.ParSignedData{}, other),
+		}
+	}), []AuthToken{
+		{Token: "token1", PubKeys: []core.PubKey{allowed}},
+		{Token: "token2", PubKeys: []core.PubKey{other}},
+	})
+
+	serve := func(header string) int {
+		verifyErrs = nil
+
+		req := httptest.NewRequest(http.MethodGet, "/eth/v1/node/version", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusUnauthorized, serve(""))
+	require.Equal(t, http.StatusUnauthorized, serve("Bearer invalid"))
+	require.Equal(t, http.StatusUnauthorized, serve("token1"))
+	require.Nil(t, verifyErrs)
+
+	require.Equal(t, http.StatusOK, serve("Bearer token1"))
+	require.Len(t, verifyErrs, 2)
+	require.NoError(t, verifyErrs[0])
+
+	var aerr apiError
+	require.True(t, errors.As(verifyErrs[1], &aerr))
+	require.Equal(t, http.StatusForbidden, aerr.StatusCode)
+
+	// Requests without authentication are not restricted.
+	require.NoError(t, vapi.verifyPartialSig(context.Background(), core.ParSignedData{}, other))
+}
@@ -25,10 +25,12 @@ import (
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/gorilla/mux"
@@ -63,6 +65,7 @@ type Handler interface {
 	eth2exp.BeaconCommitteeSelectionAggregator
 	eth2client.BlindedBeaconBlockProposalProvider
 	eth2client.BlindedBeaconBlockSubmitter
+	eth2wrap.BlockContentsProvider
 	eth2client.NodeVersionProvider
 	eth2client.ProposerDutiesProvider
 	eth2client.SyncCommitteeContributionProvider
@@ -508,8 +511,9 @@ func submitContributionAndProofs(s eth2client.SyncCommitteeContributionsSubmitte
 	}
 }
 
-// proposeBlock receives the randao from the validator and returns the unsigned BeaconBlock.
-func proposeBlock(p eth2client.BeaconBlockProposalProvider) handlerFunc {
+// proposeBlock receives the randao from the validator and returns the unsigned BeaconBlock,
+// or the unsigned deneb BlockContents including the blob sidecars.
+func proposeBlock(p eth2wrap.BlockContentsProvider) handlerFunc {
 	return func(ctx context.Context, params map[string]string, query url.Values, _ contentType, _ []byte) (any, http.Header, error) {
 		slot, err := uintParam(params, "slot")
 		if err != nil {
@@ -526,10 +530,11 @@ func proposeBlock(p eth2client.BeaconBlockProposalProvider) handlerFunc {
 			return nil, nil, err
 		}
 
-		block, err := p.BeaconBlockProposal(ctx, eth2p0.Slot(slot), randao, graffiti)
+		proposal, err := p.BlockContentsProposal(ctx, eth2p0.Slot(slot), randao, graffiti)
 		if err != nil {
 			return nil, nil, err
 		}
+		block := proposal.Block
 
 		resHeaders := make(http.Header)
 		resHeaders.Add("Eth-Consensus-Version", block.Version.String())
@@ -578,7 +583,10 @@ func proposeBlock(p eth2client.BeaconBlockProposalProvider) handlerFunc {
 
 			return proposeBlockResponseDeneb{
 				Version: eth2spec.DataVersionDeneb.String(),
-				Data:    block.Deneb,
+				Data: &eth2deneb.BlockContents{
+					Block:        block.Deneb,
+					BlobSidecars: nonNil(proposal.BlobSidecars),
+				},
 			}, resHeaders, nil
 		default:
 			return 0, nil, errors.New("invalid block")
@@ -586,8 +594,9 @@ func proposeBlock(p eth2client.BeaconBlockProposalProvider) handlerFunc {
 	}
 }
 
-// proposeBlindedBlock receives the randao from the validator and returns the unsigned BlindedBeaconBlock.
-func proposeBlindedBlock(p eth2client.BlindedBeaconBlockProposalProvider) handlerFunc {
+// proposeBlindedBlock receives the randao from the validator and returns the unsigned BlindedBeaconBlock,
+// or the unsigned deneb BlindedBlockContents including the blinded blob sidecars.
+func proposeBlindedBlock(p eth2wrap.BlockContentsProvider) handlerFunc {
 	return func(ctx context.Context, params map[string]string, query url.Values, _ contentType, _ []byte) (any, http.Header, error) {
 		slot, err := uintParam(params, "slot")
 		if err != nil {
@@ -599,10 +608,11 @@ func proposeBlindedBlock(p eth2client.BlindedBeaconBlockProposalProvider) handle
 			return nil, nil, err
 		}
 
-		block, err := p.BlindedBeaconBlockProposal(ctx, eth2p0.Slot(slot), randao, nil)
+		proposal, err := p.BlindedBlockContentsProposal(ctx, eth2p0.Slot(slot), randao, nil)
 		if err != nil {
 			return nil, nil, err
 		}
+		block := proposal.Block
 
 		resHeaders := make(http.Header)
 		resHeaders.Add("Eth-Consensus-Version", block.Version.String())
@@ -633,7 +643,10 @@ func proposeBlindedBlock(p eth2client.BlindedBeaconBlockProposalProvider) handle
 
 			return proposeBlindedBlockResponseDeneb{
 				Version: "DENEB",
-				Data:    block.Deneb,
+				Data: &eth2deneb.BlindedBlockContents{
+					BlindedBlock:        block.Deneb,
+					BlindedBlobSidecars: nonNil(proposal.BlindedBlobSidecars),
+				},
 			}, resHeaders, nil
 		default:
 			return 0, nil, errors.New("invalid block")
@@ -641,10 +654,34 @@ func proposeBlindedBlock(p eth2client.BlindedBeaconBlockProposalProvider) handle
 	}
 }
 
-func submitBlock(p eth2client.BeaconBlockSubmitter) handlerFunc {
+// blockSubmitter submits signed blocks and deneb signed block contents.
+type blockSubmitter interface {
+	eth2client.BeaconBlockSubmitter
+	eth2client.BlindedBeaconBlockSubmitter
+	eth2wrap.BlockContentsProvider
+}
+
+func submitBlock(p blockSubmitter) handlerFunc {
 	return func(ctx context.Context, _ map[string]string, _ url.Values, typ contentType, body []byte) (any, http.Header, error) {
+		denebContents := new(eth2deneb.SignedBlockContents)
+		err := unmarshal(typ, body, denebContents)
+		if err == nil {
+			return nil, nil, p.SubmitBlockContents(ctx, denebContents)
+		}
+
+		denebBlock := new(deneb.SignedBeaconBlock)
+		err = unmarshal(typ, body, denebBlock)
+		if err == nil {
+			block := &eth2spec.VersionedSignedBeaconBlock{
+				Version: eth2spec.DataVersionDeneb,
+				Deneb:   denebBlock,
+			}
+
+			return nil, nil, p.SubmitBeaconBlock(ctx, block)
+		}
+
 		capellaBlock := new(capella.SignedBeaconBlock)
-		err := unmarshal(typ, body, capellaBlock)
+		err = unmarshal(typ, body, capellaBlock)
 		if err == nil {
 			block := &eth2spec.VersionedSignedBeaconBlock{
 				Version: eth2spec.DataVersionCapella,
@@ -691,11 +728,28 @@ func submitBlock(p eth2client.BeaconBlockSubmitter) handlerFunc {
 	}
 }
 
-func submitBlindedBlock(p eth2client.BlindedBeaconBlockSubmitter) handlerFunc {
+func submitBlindedBlock(p blockSubmitter) handlerFunc {
 	return func(ctx context.Context, _ map[string]string, _ url.Values, typ contentType, body []byte) (any, http.Header, error) {
-		// The blinded block maybe either bellatrix or capella.
+		// The blinded block maybe either bellatrix, capella or deneb.
+		denebContents := new(eth2deneb.SignedBlindedBlockContents)
+		err := unmarshal(typ, body, denebContents)
+		if err == nil {
+			return nil, nil, p.SubmitBlindedBlockContents(ctx, denebContents)
+		}
+
+		denebBlock := new(eth2deneb.SignedBlindedBeaconBlock)
+		err = unmarshal(typ, body, denebBlock)
+		if err == nil {
+			block := &eth2api.VersionedSignedBlindedBeaconBlock{
+				Version: eth2spec.DataVersionDeneb,
+				Deneb:   denebBlock,
+			}
+
+			return nil, nil, p.SubmitBlindedBeaconBlock(ctx, block)
+		}
+
 		capellaBlock := new(eth2capella.SignedBlindedBeaconBlock)
-		err := unmarshal(typ, body, capellaBlock)
+		err = unmarshal(typ, body, capellaBlock)
 		if err == nil {
 			block := &eth2api.VersionedSignedBlindedBeaconBlock{
 				Version: eth2spec.DataVersionCapella,
@@ -1237,3 +1291,12 @@ func getCtxDuration(ctx context.Context) z.Field {
 
 	return z.Str("duration", time.Since(t0).String())
 }
+
+// nonNil returns an empty slice if the slice is nil, since deneb block contents require non-nil blob sidecars.
+func nonNil[T any](slice []T) []T {
+	if slice == nil {
+		return []T{}
+	}
+
+	return slice
+}
@@ -22,12 +22,14 @@ import (
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2http "github.com/attestantio/go-eth2-client/http"
 	eth2mock "github.com/attestantio/go-eth2-client/mock"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	ssz "github.com/ferranbt/fastssz"
 	"github.com/stretchr/testify/require"
@@ -319,6 +321,102 @@ func TestRawRouter(t *testing.T) {
 		testRawRouter(t, handler, callback)
 	})
 
+	t.Run("submit deneb block contents", func(t *testing.T) {
+		block := testutil.RandomDenebVersionedSignedBeaconBlock()
+
+		var submitted *eth2deneb.SignedBlockContents
+		handler := testHandler{
+			SubmitBlockContentsFunc: func(ctx context.Context, actual *eth2deneb.SignedBlockContents) error {
+				submitted = actual
+				return nil
+			},
+		}
+
+		callback := func(ctx context.Context, baseURL string) {
+			for _, sidecars := range [][]*deneb.SignedBlobSidecar{
+				{},
+				{testutil.RandomDenebSignedBlobSidecar(0), testutil.RandomDenebSignedBlobSidecar(1)},
+			} {
+				contents := &eth2deneb.SignedBlockContents{
+					SignedBlock:        block.Deneb,
+					SignedBlobSidecars: sidecars,
+				}
+				b, err := json.Marshal(contents)
+				require.NoError(t, err)
+
+				submitted = nil
+				res, err := http.Post(baseURL+"/eth/v1/beacon/blocks", "application/json", bytes.NewReader(b))
+				require.NoError(t, err)
+				require.Equal(t, http.StatusOK, res.StatusCode)
+				require.Equal(t, contents, submitted)
+			}
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
+	t.Run("submit deneb blinded block contents", func(t *testing.T) {
+		block := testutil.RandomDenebVersionedSignedBlindedBeaconBlock()
+
+		var submitted *eth2deneb.SignedBlindedBlockContents
+		handler := testHandler{
+			SubmitBlindedBlockContentsFunc: func(ctx context.Context, actual *eth2deneb.SignedBlindedBlockContents) error {
+				submitted = actual
+				return nil
+			},
+		}
+
+		callback := func(ctx context.Context, baseURL string) {
+			contents := &eth2deneb.SignedBlindedBlockContents{
+				SignedBlindedBlock: block.Deneb,
+				SignedBlindedBlobSidecars: []*eth2deneb.SignedBlindedBlobSidecar{
+					testutil.RandomDenebSignedBlindedBlobSidecar(0),
+				},
+			}
+			b, err := json.Marshal(contents)
+			require.NoError(t, err)
+
+			res, err := http.Post(baseURL+"/eth/v1/beacon/blinded_blocks", "application/json", bytes.NewReader(b))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			require.Equal(t, contents, submitted)
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
+	t.Run("propose deneb block contents", func(t *testing.T) {
+		block := &eth2spec.VersionedBeaconBlock{
+			Version: eth2spec.DataVersionDeneb,
+			Deneb:   testutil.RandomDenebBeaconBlock(),
+		}
+		sidecars := []*deneb.BlobSidecar{testutil.RandomDenebBlobSidecar(0), testutil.RandomDenebBlobSidecar(1)}
+
+		handler := testHandler{
+			BlockContentsProposalFunc: func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BeaconBlockProposal, error) {
+				return eth2wrap.BeaconBlockProposal{Block: block, BlobSidecars: sidecars}, nil
+			},
+		}
+
+		callback := func(ctx context.Context, baseURL string) {
+			randao := testutil.RandomEth2Signature()
+			res, err := http.Get(fmt.Sprintf("%s/eth/v2/validator/blocks/1?randao_reveal=%#x", baseURL, randao))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			var resp struct {
+				Version string                   `json:"version"`
+				Data    *eth2deneb.BlockContents `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&resp))
+			require.Equal(t, "deneb", resp.Version)
+			require.Equal(t, block.Deneb, resp.Data.Block)
+			require.Equal(t, sidecars, resp.Data.BlobSidecars)
+		}
+
+		testRawRouter(t, handler, callback)
+	})
+
 	t.Run("submit bellatrix ssz beacon block", func(t *testing.T) {
 		var done atomic.Bool
 		coreBlock := testutil.RandomBellatrixCoreVersionedSignedBeaconBlock()
@@ -913,6 +1011,46 @@ func TestRouter(t *testing.T) {
 		testRouter(t, handler, callback)
 	})
 
+	t.Run("submit block deneb", func(t *testing.T) {
+		block1 := testutil.RandomDenebVersionedSignedBeaconBlock()
+		handler := testHandler{
+			SubmitBeaconBlockFunc: func(ctx context.Context, block *eth2spec.VersionedSignedBeaconBlock) error {
+				require.Equal(t, block1, block)
+				return nil
+			},
+		}
+
+		callback := func(ctx context.Context, cl *eth2http.Service) {
+			err := cl.SubmitBeaconBlock(ctx, block1)
+			require.NoError(t, err)
+		}
+
+		testRouter(t, handler, callback)
+	})
+
+	t.Run("submit blinded block deneb", func(t *testing.T) {
+		block1 := &eth2api.VersionedSignedBlindedBeaconBlock{
+			Version: eth2spec.DataVersionDeneb,
+			Deneb: &eth2deneb.SignedBlindedBeaconBlock{
+				Message:   testutil.RandomDenebBlindedBeaconBlock(),
+				Signature: testutil.RandomEth2Signature(),
+			},
+		}
+		handler := testHandler{
+			SubmitBlindedBeaconBlockFunc: func(ctx context.Context, block *eth2api.VersionedSignedBlindedBeaconBlock) error {
+				require.Equal(t, block1, block)
+				return nil
+			},
+		}
+
+		callback := func(ctx context.Context, cl *eth2http.Service) {
+			err := cl.SubmitBlindedBeaconBlock(ctx, block1)
+			require.NoError(t, err)
+		}
+
+		testRouter(t, handler, callback)
+	})
+
 	t.Run("submit validator registration", func(t *testing.T) {
 		expect := []*eth2api.VersionedSignedValidatorRegistration{
 			{
@@ -1197,6 +1335,10 @@ type testHandler struct {
 	SubmitBeaconBlockFunc                  func(ctx context.Context, block *eth2spec.VersionedSignedBeaconBlock) error
 	BlindedBeaconBlockProposalFunc         func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (*eth2api.VersionedBlindedBeaconBlock, error)
 	SubmitBlindedBeaconBlockFunc           func(ctx context.Context, block *eth2api.VersionedSignedBlindedBeaconBlock) error
+	BlockContentsProposalFunc              func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BeaconBlockProposal, error)
+	BlindedBlockContentsProposalFunc       func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BlindedBeaconBlockProposal, error)
+	SubmitBlockContentsFunc                func(ctx context.Context, contents *eth2deneb.SignedBlockContents) error
+	SubmitBlindedBlockContentsFunc         func(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error
 	ProposerDutiesFunc                     func(ctx context.Context, epoch eth2p0.Epoch, il []eth2p0.ValidatorIndex) ([]*eth2v1.ProposerDuty, error)
 	NodeVersionFunc                        func(ctx context.Context) (string, error)
 	ValidatorsFunc                         func(ctx context.Context, stateID string, indices []eth2p0.ValidatorIndex) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error)
@@ -1235,6 +1377,42 @@ func (h testHandler) SubmitBlindedBeaconBlock(ctx context.Context, block *eth2ap
 	return h.SubmitBlindedBeaconBlockFunc(ctx, block)
 }
 
+// BlockContentsProposal returns the BlockContentsProposalFunc result if set, otherwise the BeaconBlockProposalFunc result.
+func (h testHandler) BlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BeaconBlockProposal, error) {
+	if h.BlockContentsProposalFunc != nil {
+		return h.BlockContentsProposalFunc(ctx, slot, randaoReveal, graffiti)
+	}
+
+	block, err := h.BeaconBlockProposalFunc(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return eth2wrap.BeaconBlockProposal{}, err
+	}
+
+	return eth2wrap.BeaconBlockProposal{Block: block}, nil
+}
+
+// BlindedBlockContentsProposal returns the BlindedBlockContentsProposalFunc result if set, otherwise the BlindedBeaconBlockProposalFunc result.
+func (h testHandler) BlindedBlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BlindedBeaconBlockProposal, error) {
+	if h.BlindedBlockContentsProposalFunc != nil {
+		return h.BlindedBlockContentsProposalFunc(ctx, slot, randaoReveal, graffiti)
+	}
+
+	block, err := h.BlindedBeaconBlockProposalFunc(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return eth2wrap.BlindedBeaconBlockProposal{}, err
+	}
+
+	return eth2wrap.BlindedBeaconBlockProposal{Block: block}, nil
+}
+
+func (h testHandler) SubmitBlockContents(ctx context.Context, contents *eth2deneb.SignedBlockContents) error {
+	return h.SubmitBlockContentsFunc(ctx, contents)
+}
+
+func (h testHandler) SubmitBlindedBlockContents(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error {
+	return h.SubmitBlindedBlockContentsFunc(ctx, contents)
+}
+
 func (h testHandler) Validators(ctx context.Context, stateID string, indices []eth2p0.ValidatorIndex) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error) {
 	return h.ValidatorsFunc(ctx, stateID, indices)
 }
@@ -11,6 +11,7 @@ import (
 
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
@@ -155,8 +156,8 @@ type Component struct {
 
 	pubKeyByAttFunc           func(ctx context.Context, slot, commIdx, valCommIdx int64) (core.PubKey, error)
 	awaitAttFunc              func(ctx context.Context, slot, commIdx int64) (*eth2p0.AttestationData, error)
-	awaitBlockFunc            func(ctx context.Context, slot int64) (*eth2spec.VersionedBeaconBlock, error)
-	awaitBlindedBlockFunc     func(ctx context.Context, slot int64) (*eth2api.VersionedBlindedBeaconBlock, error)
+	awaitBlockFunc            func(ctx context.Context, slot int64) (*core.VersionedBeaconBlock, error)
+	awaitBlindedBlockFunc     func(ctx context.Context, slot int64) (*core.VersionedBlindedBeaconBlock, error)
 	awaitSyncContributionFunc func(ctx context.Context, slot, subcommIdx int64, beaconBlockRoot eth2p0.Root) (*altair.SyncCommitteeContribution, error)
 	awaitAggAttFunc           func(ctx context.Context, slot int64, attestationRoot eth2p0.Root) (*eth2p0.Attestation, error)
 	awaitAggSigDBFunc         func(context.Context, core.Duty, core.PubKey) (core.SignedData, error)
@@ -166,13 +167,13 @@ type Component struct {
 
 // RegisterAwaitBeaconBlock registers a function to query unsigned beacon block.
 // It supports a single function, since it is an input of the component.
-func (c *Component) RegisterAwaitBeaconBlock(fn func(ctx context.Context, slot int64) (*eth2spec.VersionedBeaconBlock, error)) {
+func (c *Component) RegisterAwaitBeaconBlock(fn func(ctx context.Context, slot int64) (*core.VersionedBeaconBlock, error)) {
 	c.awaitBlockFunc = fn
 }
 
 // RegisterAwaitBlindedBeaconBlock registers a function to query unsigned blinded beacon block.
 // It supports a single function, since it is an input of the component.
-func (c *Component) RegisterAwaitBlindedBeaconBlock(fn func(ctx context.Context, slot int64) (*eth2api.VersionedBlindedBeaconBlock, error)) {
+func (c *Component) RegisterAwaitBlindedBeaconBlock(fn func(ctx context.Context, slot int64) (*core.VersionedBlindedBeaconBlock, error)) {
 	c.awaitBlindedBlockFunc = fn
 }
 
@@ -296,16 +297,27 @@ func (c Component) SubmitAttestations(ctx context.Context, attestations []*eth2p
 }
 
 // BeaconBlockProposal submits the randao for aggregation and inclusion in DutyProposer and then queries the dutyDB for an unsigned beacon block.
-func (c Component) BeaconBlockProposal(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, _ []byte) (*eth2spec.VersionedBeaconBlock, error) {
+func (c Component) BeaconBlockProposal(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte) (*eth2spec.VersionedBeaconBlock, error) {
+	proposal, err := c.BlockContentsProposal(ctx, slot, randao, graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	return proposal.Block, nil
+}
+
+// BlockContentsProposal submits the randao for aggregation and inclusion in DutyProposer and then queries the dutyDB for
+// an unsigned beacon block including its blob sidecars from deneb.
+func (c Component) BlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, _ []byte) (eth2wrap.BeaconBlockProposal, error) {
 	// Get proposer pubkey (this is a blocking query).
 	pubkey, err := c.getProposerPubkey(ctx, core.NewProposerDuty(int64(slot)))
 	if err != nil {
-		return nil, err
+		return eth2wrap.BeaconBlockProposal{}, err
 	}
 
 	epoch, err := eth2util.EpochFromSlot(ctx, c.eth2Cl, slot)
 	if err != nil {
-		return nil, err
+		return eth2wrap.BeaconBlockProposal{}, err
 	}
 
 	sigEpoch := eth2util.SignedEpoch{
@@ -319,7 +331,7 @@ func (c Component) BeaconBlockProposal(ctx context.Context, slot eth2p0.Slot, ra
 	// Verify randao signature
 	err = c.verifyPartialSig(ctx, parSig, pubkey)
 	if err != nil {
-		return nil, err
+		return eth2wrap.BeaconBlockProposal{}, err
 	}
 
 	for _, sub := range c.subs {
@@ -329,7 +341,7 @@ func (c Component) BeaconBlockProposal(ctx context.Context, slot eth2p0.Slot, ra
 		}
 		err := sub(ctx, duty, parsigSet)
 		if err != nil {
-			return nil, err
+			return eth2wrap.BeaconBlockProposal{}, err
 		}
 	}
 
@@ -348,10 +360,13 @@ func (c Component) BeaconBlockProposal(ctx context.Context, slot eth2p0.Slot, ra
 	// Query unsigned block (this is blocking).
 	block, err := c.awaitBlockFunc(ctx, int64(slot))
 	if err != nil {
-		return nil, err
+		return eth2wrap.BeaconBlockProposal{}, err
 	}
 
-	return block, nil
+	return eth2wrap.BeaconBlockProposal{
+		Block:        &block.VersionedBeaconBlock,
+		BlobSidecars: block.BlobSidecars,
+	}, nil
 }
 
 func (c Component) SubmitBeaconBlock(ctx context.Context, block *eth2spec.VersionedSignedBeaconBlock) error {
@@ -361,27 +376,52 @@ func (c Component) SubmitBeaconBlock(ctx context.Context, block *eth2spec.Versio
 		return err
 	}
 
-	pubkey, err := c.getProposerPubkey(ctx, core.NewProposerDuty(int64(slot)))
+	signedData, err := core.NewPartialVersionedSignedBeaconBlock(block, c.shareIdx)
 	if err != nil {
 		return err
 	}
 
-	// Save Partially Signed Block to ParSigDB
-	duty := core.NewProposerDuty(int64(slot))
-	ctx = log.WithCtx(ctx, z.Any("duty", duty))
+	return c.submitBlock(ctx, slot, signedData)
+}
 
-	signedData, err := core.NewPartialVersionedSignedBeaconBlock(block, c.shareIdx)
+// SubmitBlockContents receives the partially signed deneb block and blob sidecars.
+func (c Component) SubmitBlockContents(ctx context.Context, contents *eth2deneb.SignedBlockContents) error {
+	if contents.SignedBlock == nil || contents.SignedBlock.Message == nil {
+		return errors.New("no deneb block")
+	}
+
+	signedData, err := core.NewPartialVersionedSignedBeaconBlockContents(contents, c.shareIdx)
 	if err != nil {
 		return err
 	}
 
-	// Verify block signature
+	return c.submitBlock(ctx, contents.SignedBlock.Message.Slot, signedData)
+}
+
+// submitBlock verifies and submits the partially signed block, including its blob sidecars, to DutyProposer.
+func (c Component) submitBlock(ctx context.Context, slot eth2p0.Slot, signedData core.ParSignedData) error {
+	block, ok := signedData.SignedData.(core.VersionedSignedBeaconBlock)
+	if !ok {
+		return errors.New("invalid block")
+	}
+
+	pubkey, err := c.getProposerPubkey(ctx, core.NewProposerDuty(int64(slot)))
+	if err != nil {
+		return err
+	}
+
+	// Save Partially Signed Block to ParSigDB
+	duty := core.NewProposerDuty(int64(slot))
+	ctx = log.WithCtx(ctx, z.Any("duty", duty))
+
+	// Verify block and blob sidecar signatures
 	err = c.verifyPartialSig(ctx, signedData, pubkey)
 	if err != nil {
 		return err
 	}
 
-	log.Debug(ctx, "Beacon block submitted by validator client", z.Str("block_version", block.Version.String()))
+	log.Debug(ctx, "Beacon block submitted by validator client", z.Str("block_version", block.Version.String()),
+		z.Int("blob_sidecars", len(block.SignedBlobSidecars)))
 
 	set := core.ParSignedDataSet{pubkey: signedData}
 	for _, sub := range c.subs {
@@ -396,16 +436,27 @@ func (c Component) SubmitBeaconBlock(ctx context.Context, block *eth2spec.Versio
 }
 
 // BlindedBeaconBlockProposal submits the randao for aggregation and inclusion in DutyBuilderProposer and then queries the dutyDB for an unsigned blinded beacon block.
-func (c Component) BlindedBeaconBlockProposal(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, _ []byte) (*eth2api.VersionedBlindedBeaconBlock, error) {
+func (c Component) BlindedBeaconBlockProposal(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, graffiti []byte) (*eth2api.VersionedBlindedBeaconBlock, error) {
+	proposal, err := c.BlindedBlockContentsProposal(ctx, slot, randao, graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	return proposal.Block, nil
+}
+
+// BlindedBlockContentsProposal submits the randao for aggregation and inclusion in DutyBuilderProposer and then queries
+// the dutyDB for an unsigned blinded beacon block including its blinded blob sidecars from deneb.
+func (c Component) BlindedBlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randao eth2p0.BLSSignature, _ []byte) (eth2wrap.BlindedBeaconBlockProposal, error) {
 	// Get proposer pubkey (this is a blocking query).
 	pubkey, err := c.getProposerPubkey(ctx, core.NewBuilderProposerDuty(int64(slot)))
 	if err != nil {
-		return nil, err
+		return eth2wrap.BlindedBeaconBlockProposal{}, err
 	}
 
 	epoch, err := eth2util.EpochFromSlot(ctx, c.eth2Cl, slot)
 	if err != nil {
-		return nil, err
+		return eth2wrap.BlindedBeaconBlockProposal{}, err
 	}
 
 	sigEpoch := eth2util.SignedEpoch{
@@ -419,7 +470,7 @@ func (c Component) BlindedBeaconBlockProposal(ctx context.Context, slot eth2p0.S
 	// Verify randao signature
 	err = c.verifyPartialSig(ctx, parSig, pubkey)
 	if err != nil {
-		return nil, err
+		return eth2wrap.BlindedBeaconBlockProposal{}, err
 	}
 
 	for _, sub := range c.subs {
@@ -429,7 +480,7 @@ func (c Component) BlindedBeaconBlockProposal(ctx context.Context, slot eth2p0.S
 		}
 		err := sub(ctx, duty, parsigSet)
 		if err != nil {
-			return nil, err
+			return eth2wrap.BlindedBeaconBlockProposal{}, err
 		}
 	}
 
@@ -448,10 +499,13 @@ func (c Component) BlindedBeaconBlockProposal(ctx context.Context, slot eth2p0.S
 	// Query unsigned block (this is blocking).
 	block, err := c.awaitBlindedBlockFunc(ctx, int64(slot))
 	if err != nil {
-		return nil, err
+		return eth2wrap.BlindedBeaconBlockProposal{}, err
 	}
 
-	return block, nil
+	return eth2wrap.BlindedBeaconBlockProposal{
+		Block:               &block.VersionedBlindedBeaconBlock,
+		BlindedBlobSidecars: block.BlindedBlobSidecars,
+	}, nil
 }
 
 func (c Component) SubmitBlindedBeaconBlock(ctx context.Context, block *eth2api.VersionedSignedBlindedBeaconBlock) error {
@@ -461,21 +515,41 @@ func (c Component) SubmitBlindedBeaconBlock(ctx context.Context, block *eth2api.
 		return err
 	}
 
-	pubkey, err := c.getProposerPubkey(ctx, core.NewBuilderProposerDuty(int64(slot)))
+	signedData, err := core.NewPartialVersionedSignedBlindedBeaconBlock(block, c.shareIdx)
 	if err != nil {
 		return err
 	}
 
-	// Save Partially Signed Blinded Block to ParSigDB
-	duty := core.NewBuilderProposerDuty(int64(slot))
-	ctx = log.WithCtx(ctx, z.Any("duty", duty))
+	return c.submitBlindedBlock(ctx, slot, signedData)
+}
 
-	signedData, err := core.NewPartialVersionedSignedBlindedBeaconBlock(block, c.shareIdx)
+// SubmitBlindedBlockContents receives the partially signed deneb blinded block and blinded blob sidecars.
+func (c Component) SubmitBlindedBlockContents(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error {
+	if contents.SignedBlindedBlock == nil || contents.SignedBlindedBlock.Message == nil {
+		return errors.New("no deneb blinded block")
+	}
+
+	signedData, err := core.NewPartialVersionedSignedBlindedBeaconBlockContents(contents, c.shareIdx)
 	if err != nil {
 		return err
 	}
 
-	// Verify Blinded block signature
+	return c.submitBlindedBlock(ctx, contents.SignedBlindedBlock.Message.Slot, signedData)
+}
+
+// submitBlindedBlock verifies and submits the partially signed blinded block, including its blinded blob sidecars,
+// to DutyBuilderProposer.
+func (c Component) submitBlindedBlock(ctx context.Context, slot eth2p0.Slot, signedData core.ParSignedData) error {
+	pubkey, err := c.getProposerPubkey(ctx, core.NewBuilderProposerDuty(int64(slot)))
+	if err != nil {
+		return err
+	}
+
+	// Save Partially Signed Blinded Block to ParSigDB
+	duty := core.NewBuilderProposerDuty(int64(slot))
+	ctx = log.WithCtx(ctx, z.Any("duty", duty))
+
+	// Verify blinded block and blinded blob sidecar signatures
 	err = c.verifyPartialSig(ctx, signedData, pubkey)
 	if err != nil {
 		return err
@@ -1061,6 +1135,10 @@ func (c Component) getProposerPubkey(ctx context.Context, duty core.Duty) (core.
 }
 
 func (c Component) verifyPartialSig(ctx context.Context, parSig core.ParSignedData, pubkey core.PubKey) error {
+	if err := checkAuthorised(ctx, pubkey); err != nil {
+		return err
+	}
+
 	if c.insecureTest {
 		return nil
 	}
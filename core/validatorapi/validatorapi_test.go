@@ -377,8 +377,8 @@ func TestComponent_BeaconBlockProposal(t *testing.T) {
 		return core.DutyDefinitionSet{pubkey: nil}, nil
 	})
 
-	component.RegisterAwaitBeaconBlock(func(ctx context.Context, slot int64) (*eth2spec.VersionedBeaconBlock, error) {
-		return block1, nil
+	component.RegisterAwaitBeaconBlock(func(ctx context.Context, slot int64) (*core.VersionedBeaconBlock, error) {
+		return &core.VersionedBeaconBlock{VersionedBeaconBlock: *block1}, nil
 	})
 
 	component.Subscribe(func(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
@@ -693,8 +693,8 @@ func TestComponent_BlindedBeaconBlockProposal(t *testing.T) {
 		return core.DutyDefinitionSet{pubkey: nil}, nil
 	})
 
-	component.RegisterAwaitBlindedBeaconBlock(func(ctx context.Context, slot int64) (*eth2api.VersionedBlindedBeaconBlock, error) {
-		return block1, nil
+	component.RegisterAwaitBlindedBeaconBlock(func(ctx context.Context, slot int64) (*core.VersionedBlindedBeaconBlock, error) {
+		return &core.VersionedBlindedBeaconBlock{VersionedBlindedBeaconBlock: *block1}, nil
 	})
 
 	component.Subscribe(func(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
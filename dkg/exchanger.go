@@ -89,7 +89,7 @@ type exchanger struct {
 
 func newExchanger(tcpNode host.Host, peerIdx int, peers []peer.ID, vals int, sigTypes []sigType) *exchanger {
 	// Partial signature roots not known yet, so skip verification in parsigex, rather verify before we aggregate.
-	noopVerifier := func(ctx context.Context, duty core.Duty, key core.PubKey, data core.ParSignedData) error {
+	noopVerifier := func(ctx context.Context, duty core.Duty, set core.ParSignedDataSet) error {
 		return nil
 	}
 
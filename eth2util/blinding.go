@@ -0,0 +1,335 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2util
+
+import (
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	ssz "github.com/ferranbt/fastssz"
+
+	"github.com/obolnetwork/charon/app/errors"
+)
+
+const (
+	// maxTransactions is the maximum number of transactions in an execution payload.
+	maxTransactions = 1048576
+	// maxBytesPerTransaction is the maximum size of a transaction in an execution payload.
+	maxBytesPerTransaction = 1073741824
+	// maxWithdrawals is the maximum number of withdrawals in an execution payload.
+	maxWithdrawals = 16
+)
+
+// BlindBlock converts a block into a blinded block by replacing the execution payload with its header.
+// The blinded block has the same root as the block, so signatures of either are valid for both.
+func BlindBlock(block *spec.VersionedBeaconBlock) (*eth2api.VersionedBlindedBeaconBlock, error) {
+	var resp *eth2api.VersionedBlindedBeaconBlock
+	// Blinded blocks are only available from bellatrix.
+	switch block.Version {
+	case spec.DataVersionBellatrix:
+		txRoot, err := transactionsRoot(block.Bellatrix.Body.ExecutionPayload.Transactions)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = &eth2api.VersionedBlindedBeaconBlock{
+			Version: block.Version,
+			Bellatrix: &eth2bellatrix.BlindedBeaconBlock{
+				Slot:          block.Bellatrix.Slot,
+				ProposerIndex: block.Bellatrix.ProposerIndex,
+				ParentRoot:    block.Bellatrix.ParentRoot,
+				StateRoot:     block.Bellatrix.StateRoot,
+				Body: &eth2bellatrix.BlindedBeaconBlockBody{
+					RANDAOReveal:      block.Bellatrix.Body.RANDAOReveal,
+					ETH1Data:          block.Bellatrix.Body.ETH1Data,
+					Graffiti:          block.Bellatrix.Body.Graffiti,
+					ProposerSlashings: block.Bellatrix.Body.ProposerSlashings,
+					AttesterSlashings: block.Bellatrix.Body.AttesterSlashings,
+					Attestations:      block.Bellatrix.Body.Attestations,
+					Deposits:          block.Bellatrix.Body.Deposits,
+					VoluntaryExits:    block.Bellatrix.Body.VoluntaryExits,
+					SyncAggregate:     block.Bellatrix.Body.SyncAggregate,
+					ExecutionPayloadHeader: &bellatrix.ExecutionPayloadHeader{
+						ParentHash:       block.Bellatrix.Body.ExecutionPayload.ParentHash,
+						FeeRecipient:     block.Bellatrix.Body.ExecutionPayload.FeeRecipient,
+						StateRoot:        block.Bellatrix.Body.ExecutionPayload.StateRoot,
+						ReceiptsRoot:     block.Bellatrix.Body.ExecutionPayload.ReceiptsRoot,
+						LogsBloom:        block.Bellatrix.Body.ExecutionPayload.LogsBloom,
+						PrevRandao:       block.Bellatrix.Body.ExecutionPayload.PrevRandao,
+						BlockNumber:      block.Bellatrix.Body.ExecutionPayload.BlockNumber,
+						GasLimit:         block.Bellatrix.Body.ExecutionPayload.GasLimit,
+						GasUsed:          block.Bellatrix.Body.ExecutionPayload.GasUsed,
+						Timestamp:        block.Bellatrix.Body.ExecutionPayload.Timestamp,
+						ExtraData:        block.Bellatrix.Body.ExecutionPayload.ExtraData,
+						BaseFeePerGas:    block.Bellatrix.Body.ExecutionPayload.BaseFeePerGas,
+						BlockHash:        block.Bellatrix.Body.ExecutionPayload.BlockHash,
+						TransactionsRoot: txRoot,
+					},
+				},
+			},
+		}
+	case spec.DataVersionCapella:
+		txRoot, err := transactionsRoot(block.Capella.Body.ExecutionPayload.Transactions)
+		if err != nil {
+			return nil, err
+		}
+
+		wdRoot, err := withdrawalsRoot(block.Capella.Body.ExecutionPayload.Withdrawals)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = &eth2api.VersionedBlindedBeaconBlock{
+			Version: block.Version,
+			Capella: &eth2capella.BlindedBeaconBlock{
+				Slot:          block.Capella.Slot,
+				ProposerIndex: block.Capella.ProposerIndex,
+				ParentRoot:    block.Capella.ParentRoot,
+				StateRoot:     block.Capella.StateRoot,
+				Body: &eth2capella.BlindedBeaconBlockBody{
+					RANDAOReveal:          block.Capella.Body.RANDAOReveal,
+					ETH1Data:              block.Capella.Body.ETH1Data,
+					Graffiti:              block.Capella.Body.Graffiti,
+					ProposerSlashings:     block.Capella.Body.ProposerSlashings,
+					AttesterSlashings:     block.Capella.Body.AttesterSlashings,
+					Attestations:          block.Capella.Body.Attestations,
+					Deposits:              block.Capella.Body.Deposits,
+					VoluntaryExits:        block.Capella.Body.VoluntaryExits,
+					SyncAggregate:         block.Capella.Body.SyncAggregate,
+					BLSToExecutionChanges: block.Capella.Body.BLSToExecutionChanges,
+					ExecutionPayloadHeader: &capella.ExecutionPayloadHeader{
+						ParentHash:       block.Capella.Body.ExecutionPayload.ParentHash,
+						FeeRecipient:     block.Capella.Body.ExecutionPayload.FeeRecipient,
+						StateRoot:        block.Capella.Body.ExecutionPayload.StateRoot,
+						ReceiptsRoot:     block.Capella.Body.ExecutionPayload.ReceiptsRoot,
+						LogsBloom:        block.Capella.Body.ExecutionPayload.LogsBloom,
+						PrevRandao:       block.Capella.Body.ExecutionPayload.PrevRandao,
+						BlockNumber:      block.Capella.Body.ExecutionPayload.BlockNumber,
+						GasLimit:         block.Capella.Body.ExecutionPayload.GasLimit,
+						GasUsed:          block.Capella.Body.ExecutionPayload.GasUsed,
+						Timestamp:        block.Capella.Body.ExecutionPayload.Timestamp,
+						ExtraData:        block.Capella.Body.ExecutionPayload.ExtraData,
+						BaseFeePerGas:    block.Capella.Body.ExecutionPayload.BaseFeePerGas,
+						BlockHash:        block.Capella.Body.ExecutionPayload.BlockHash,
+						TransactionsRoot: txRoot,
+						WithdrawalsRoot:  wdRoot,
+					},
+				},
+			},
+		}
+	case spec.DataVersionDeneb:
+		txRoot, err := transactionsRoot(block.Deneb.Body.ExecutionPayload.Transactions)
+		if err != nil {
+			return nil, err
+		}
+
+		wdRoot, err := withdrawalsRoot(block.Deneb.Body.ExecutionPayload.Withdrawals)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = &eth2api.VersionedBlindedBeaconBlock{
+			Version: block.Version,
+			Deneb: &eth2deneb.BlindedBeaconBlock{
+				Slot:          block.Deneb.Slot,
+				ProposerIndex: block.Deneb.ProposerIndex,
+				ParentRoot:    block.Deneb.ParentRoot,
+				StateRoot:     block.Deneb.StateRoot,
+				Body: &eth2deneb.BlindedBeaconBlockBody{
+					RANDAOReveal:          block.Deneb.Body.RANDAOReveal,
+					ETH1Data:              block.Deneb.Body.ETH1Data,
+					Graffiti:              block.Deneb.Body.Graffiti,
+					ProposerSlashings:     block.Deneb.Body.ProposerSlashings,
+					AttesterSlashings:     block.Deneb.Body.AttesterSlashings,
+					Attestations:          block.Deneb.Body.Attestations,
+					Deposits:              block.Deneb.Body.Deposits,
+					VoluntaryExits:        block.Deneb.Body.VoluntaryExits,
+					SyncAggregate:         block.Deneb.Body.SyncAggregate,
+					BLSToExecutionChanges: block.Deneb.Body.BLSToExecutionChanges,
+					ExecutionPayloadHeader: &deneb.ExecutionPayloadHeader{
+						ParentHash:       block.Deneb.Body.ExecutionPayload.ParentHash,
+						FeeRecipient:     block.Deneb.Body.ExecutionPayload.FeeRecipient,
+						StateRoot:        block.Deneb.Body.ExecutionPayload.StateRoot,
+						ReceiptsRoot:     block.Deneb.Body.ExecutionPayload.ReceiptsRoot,
+						LogsBloom:        block.Deneb.Body.ExecutionPayload.LogsBloom,
+						PrevRandao:       block.Deneb.Body.ExecutionPayload.PrevRandao,
+						BlockNumber:      block.Deneb.Body.ExecutionPayload.BlockNumber,
+						GasLimit:         block.Deneb.Body.ExecutionPayload.GasLimit,
+						GasUsed:          block.Deneb.Body.ExecutionPayload.GasUsed,
+						Timestamp:        block.Deneb.Body.ExecutionPayload.Timestamp,
+						ExtraData:        block.Deneb.Body.ExecutionPayload.ExtraData,
+						BaseFeePerGas:    block.Deneb.Body.ExecutionPayload.BaseFeePerGas,
+						BlockHash:        block.Deneb.Body.ExecutionPayload.BlockHash,
+						TransactionsRoot: txRoot,
+						WithdrawalsRoot:  wdRoot,
+						BlobGasUsed:      block.Deneb.Body.ExecutionPayload.BlobGasUsed,
+						ExcessBlobGas:    block.Deneb.Body.ExecutionPayload.ExcessBlobGas,
+					},
+					BlobKzgCommitments: block.Deneb.Body.BlobKzgCommitments,
+				},
+			},
+		}
+	default:
+		return nil, errors.New("unsupported blinded block version")
+	}
+
+	return resp, nil
+}
+
+// UnblindSignedBlock returns the signed block by combining the block with the signature of the signed blinded block.
+// It returns an error if the blinded block is not the blinded version of the block.
+func UnblindSignedBlock(signed *eth2api.VersionedSignedBlindedBeaconBlock, block *spec.VersionedBeaconBlock) (*spec.VersionedSignedBeaconBlock, error) {
+	if signed.Version != block.Version {
+		return nil, errors.New("block version mismatch")
+	}
+
+	blockRoot, err := block.Root()
+	if err != nil {
+		return nil, errors.Wrap(err, "block root")
+	}
+
+	resp := &spec.VersionedSignedBeaconBlock{Version: block.Version}
+	var blindedRoot eth2p0.Root
+	switch signed.Version {
+	case spec.DataVersionBellatrix:
+		blindedRoot, err = signed.Bellatrix.Message.HashTreeRoot()
+		resp.Bellatrix = &bellatrix.SignedBeaconBlock{Message: block.Bellatrix, Signature: signed.Bellatrix.Signature}
+	case spec.DataVersionCapella:
+		blindedRoot, err = signed.Capella.Message.HashTreeRoot()
+		resp.Capella = &capella.SignedBeaconBlock{Message: block.Capella, Signature: signed.Capella.Signature}
+	case spec.DataVersionDeneb:
+		blindedRoot, err = signed.Deneb.Message.HashTreeRoot()
+		resp.Deneb = &deneb.SignedBeaconBlock{Message: block.Deneb, Signature: signed.Deneb.Signature}
+	default:
+		return nil, errors.New("unsupported blinded block version")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "blinded block root")
+	} else if blindedRoot != blockRoot {
+		return nil, errors.New("blinded block root mismatch")
+	}
+
+	return resp, nil
+}
+
+// BlindBlobSidecars converts blob sidecars into blinded blob sidecars by replacing the blobs with their roots.
+// The blinded blob sidecars have the same roots as the blob sidecars, so signatures of either are valid for both.
+func BlindBlobSidecars(sidecars []*deneb.BlobSidecar) ([]*eth2deneb.BlindedBlobSidecar, error) {
+	var resp []*eth2deneb.BlindedBlobSidecar
+	for _, sidecar := range sidecars {
+		root, err := blobRoot(sidecar.Blob)
+		if err != nil {
+			return nil, err
+		}
+
+		resp = append(resp, &eth2deneb.BlindedBlobSidecar{
+			BlockRoot:       sidecar.BlockRoot,
+			Index:           sidecar.Index,
+			Slot:            sidecar.Slot,
+			BlockParentRoot: sidecar.BlockParentRoot,
+			ProposerIndex:   sidecar.ProposerIndex,
+			BlobRoot:        root,
+			KzgCommitment:   sidecar.KzgCommitment,
+			KzgProof:        sidecar.KzgProof,
+		})
+	}
+
+	return resp, nil
+}
+
+// UnblindSignedBlobSidecars returns the signed blob sidecars by combining the blob sidecars with the signatures of the
+// signed blinded blob sidecars at the same index. It returns an error if the blinded blob sidecars are not the blinded
+// versions of the blob sidecars.
+func UnblindSignedBlobSidecars(signed []*eth2deneb.SignedBlindedBlobSidecar, sidecars []*deneb.BlobSidecar) ([]*deneb.SignedBlobSidecar, error) {
+	if len(signed) != len(sidecars) {
+		return nil, errors.New("blob sidecar count mismatch")
+	}
+
+	var resp []*deneb.SignedBlobSidecar
+	for i, sidecar := range sidecars {
+		root, err := sidecar.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "blob sidecar root")
+		}
+
+		blindedRoot, err := signed[i].Message.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "blinded blob sidecar root")
+		} else if blindedRoot != root {
+			return nil, errors.New("blinded blob sidecar root mismatch")
+		}
+
+		resp = append(resp, &deneb.SignedBlobSidecar{Message: sidecar, Signature: signed[i].Signature})
+	}
+
+	return resp, nil
+}
+
+// blobRoot returns the hash tree root of the blob.
+func blobRoot(blob deneb.Blob) (eth2p0.Root, error) {
+	hh := ssz.NewHasher()
+	hh.PutBytes(blob[:])
+
+	root, err := hh.HashRoot()
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "hash blob")
+	}
+
+	return root, nil
+}
+
+// transactionsRoot returns the hash tree root of the execution payload transactions.
+func transactionsRoot(txs []bellatrix.Transaction) (eth2p0.Root, error) {
+	if len(txs) > maxTransactions {
+		return eth2p0.Root{}, errors.New("too many transactions")
+	}
+
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	for _, tx := range txs {
+		if len(tx) > maxBytesPerTransaction {
+			return eth2p0.Root{}, errors.New("transaction too large")
+		}
+
+		elemIndx := hh.Index()
+		hh.AppendBytes32(tx)
+		hh.MerkleizeWithMixin(elemIndx, uint64(len(tx)), (maxBytesPerTransaction+31)/32)
+	}
+	hh.MerkleizeWithMixin(indx, uint64(len(txs)), maxTransactions)
+
+	root, err := hh.HashRoot()
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "hash transactions")
+	}
+
+	return root, nil
+}
+
+// withdrawalsRoot returns the hash tree root of the execution payload withdrawals.
+func withdrawalsRoot(withdrawals []*capella.Withdrawal) (eth2p0.Root, error) {
+	if len(withdrawals) > maxWithdrawals {
+		return eth2p0.Root{}, errors.New("too many withdrawals")
+	}
+
+	hh := ssz.NewHasher()
+	indx := hh.Index()
+	for _, withdrawal := range withdrawals {
+		if err := withdrawal.HashTreeRootWith(hh); err != nil {
+			return eth2p0.Root{}, errors.Wrap(err, "hash withdrawal")
+		}
+	}
+	hh.MerkleizeWithMixin(indx, uint64(len(withdrawals)), maxWithdrawals)
+
+	root, err := hh.HashRoot()
+	if err != nil {
+		return eth2p0.Root{}, errors.Wrap(err, "hash withdrawals")
+	}
+
+	return root, nil
+}
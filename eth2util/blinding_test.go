@@ -0,0 +1,117 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eth2util_test
+
+import (
+	"testing"
+
+	eth2api "github.com/attestantio/go-eth2-client/api"
+	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
+	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+func TestBlindBlock(t *testing.T) {
+	txs := []bellatrix.Transaction{
+		testutil.RandomBytes32(),
+		testutil.RandomBytes96(),
+	}
+
+	bellatrixBlock := testutil.RandomBellatrixBeaconBlock()
+	bellatrixBlock.Body.ExecutionPayload.Transactions = txs
+	capellaBlock := testutil.RandomCapellaBeaconBlock()
+	capellaBlock.Body.ExecutionPayload.Transactions = txs
+	denebBlock := testutil.RandomDenebBeaconBlock()
+	denebBlock.Body.ExecutionPayload.Transactions = txs
+
+	blocks := []*spec.VersionedBeaconBlock{
+		{Version: spec.DataVersionBellatrix, Bellatrix: bellatrixBlock},
+		{Version: spec.DataVersionCapella, Capella: capellaBlock},
+		{Version: spec.DataVersionDeneb, Deneb: denebBlock},
+	}
+
+	for _, block := range blocks {
+		t.Run(block.Version.String(), func(t *testing.T) {
+			blinded, err := eth2util.BlindBlock(block)
+			require.NoError(t, err)
+
+			blockRoot, err := block.Root()
+			require.NoError(t, err)
+			blindedRoot, err := blinded.Root()
+			require.NoError(t, err)
+			require.Equal(t, blockRoot, blindedRoot)
+
+			sig := testutil.RandomEth2Signature()
+			signedBlinded := &eth2api.VersionedSignedBlindedBeaconBlock{Version: blinded.Version}
+			switch blinded.Version {
+			case spec.DataVersionBellatrix:
+				signedBlinded.Bellatrix = &eth2bellatrix.SignedBlindedBeaconBlock{Message: blinded.Bellatrix, Signature: sig}
+			case spec.DataVersionCapella:
+				signedBlinded.Capella = &eth2capella.SignedBlindedBeaconBlock{Message: blinded.Capella, Signature: sig}
+			case spec.DataVersionDeneb:
+				signedBlinded.Deneb = &eth2deneb.SignedBlindedBeaconBlock{Message: blinded.Deneb, Signature: sig}
+			}
+
+			signed, err := eth2util.UnblindSignedBlock(signedBlinded, block)
+			require.NoError(t, err)
+
+			signedRoot, err := signed.Root()
+			require.NoError(t, err)
+			require.Equal(t, blockRoot, signedRoot)
+
+			// Unblinding a different block fails.
+			_, err = eth2util.UnblindSignedBlock(signedBlinded, &spec.VersionedBeaconBlock{
+				Version:   spec.DataVersionBellatrix,
+				Bellatrix: testutil.RandomBellatrixBeaconBlock(),
+			})
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestBlindBlobSidecars(t *testing.T) {
+	sidecars := []*deneb.BlobSidecar{
+		testutil.RandomDenebBlobSidecar(0),
+		testutil.RandomDenebBlobSidecar(1),
+	}
+
+	blinded, err := eth2util.BlindBlobSidecars(sidecars)
+	require.NoError(t, err)
+	require.Len(t, blinded, len(sidecars))
+
+	var signedBlinded []*eth2deneb.SignedBlindedBlobSidecar
+	for i, sidecar := range sidecars {
+		root, err := sidecar.HashTreeRoot()
+		require.NoError(t, err)
+		blindedRoot, err := blinded[i].HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, root, blindedRoot)
+
+		signedBlinded = append(signedBlinded, &eth2deneb.SignedBlindedBlobSidecar{
+			Message:   blinded[i],
+			Signature: testutil.RandomEth2Signature(),
+		})
+	}
+
+	signed, err := eth2util.UnblindSignedBlobSidecars(signedBlinded, sidecars)
+	require.NoError(t, err)
+	require.Len(t, signed, len(sidecars))
+	for i := range signed {
+		require.Equal(t, sidecars[i], signed[i].Message)
+		require.Equal(t, signedBlinded[i].Signature, signed[i].Signature)
+	}
+
+	// Unblinding different blob sidecars fails.
+	_, err = eth2util.UnblindSignedBlobSidecars(signedBlinded, []*deneb.BlobSidecar{
+		testutil.RandomDenebBlobSidecar(0),
+		testutil.RandomDenebBlobSidecar(1),
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,203 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package blschange provides functions to create BLS to execution change files.
+package blschange
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+var (
+	// https://github.com/ethereum/consensus-specs/blob/dev/specs/phase0/validator.md#bls_withdrawal_prefix
+	blsWithdrawalPrefix = byte(0x00)
+
+	// DOMAIN_BLS_TO_EXECUTION_CHANGE. See spec: https://github.com/ethereum/consensus-specs/blob/dev/specs/capella/beacon-chain.md#domain-types
+	blsToExecutionChangeDomainType = eth2p0.DomainType([4]byte{0x0A, 0x00, 0x00, 0x00})
+
+	depositCliVersion = "2.7.0"
+)
+
+// NewMessage returns a BLS to execution change message created using the provided parameters.
+func NewMessage(valIdx eth2p0.ValidatorIndex, fromPubkey eth2p0.BLSPubKey, toAddr string) (*capella.BLSToExecutionChange, error) {
+	if _, err := eth2util.ChecksumAddress(toAddr); err != nil {
+		return nil, errors.Wrap(err, "invalid execution address", z.Str("addr", toAddr))
+	}
+
+	addrBytes, err := hex.DecodeString(strings.TrimPrefix(toAddr, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "decode address")
+	}
+
+	var addr bellatrix.ExecutionAddress
+	copy(addr[:], addrBytes)
+
+	return &capella.BLSToExecutionChange{
+		ValidatorIndex:     valIdx,
+		FromBLSPubkey:      fromPubkey,
+		ToExecutionAddress: addr,
+	}, nil
+}
+
+// VerifyWithdrawalCredentials returns an error if the withdrawal credentials are not
+// '0x00' BLS withdrawal credentials of the provided public key.
+func VerifyWithdrawalCredentials(creds []byte, pubkey eth2p0.BLSPubKey) error {
+	if len(creds) != 32 {
+		return errors.New("invalid withdrawal credentials length")
+	} else if creds[0] != blsWithdrawalPrefix {
+		return errors.New("withdrawal credentials are not BLS withdrawal credentials", z.Str("creds", fmt.Sprintf("%#x", creds)))
+	}
+
+	hash := sha256.Sum256(pubkey[:])
+	if string(hash[1:]) != string(creds[1:]) {
+		return errors.New("withdrawal credentials don't match BLS public key", z.Str("creds", fmt.Sprintf("%#x", creds)))
+	}
+
+	return nil
+}
+
+// GetMessageSigningRoot returns the BLS to execution change message signing root for the provided network.
+func GetMessageSigningRoot(msg *capella.BLSToExecutionChange, network string) ([32]byte, error) {
+	msgRoot, err := msg.HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "bls to execution change root")
+	}
+
+	domain, err := getDomain(network)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	resp, err := (&eth2p0.SigningData{ObjectRoot: msgRoot, Domain: domain}).HashTreeRoot()
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "signing data root")
+	}
+
+	return resp, nil
+}
+
+// getDomain returns the BLS to execution change signature domain which is always
+// computed using the genesis fork version, see https://github.com/ethereum/consensus-specs/blob/dev/specs/capella/beacon-chain.md#new-process_bls_to_execution_change.
+func getDomain(network string) (eth2p0.Domain, error) {
+	forkVersion, err := eth2util.NetworkToForkVersionBytes(network)
+	if err != nil {
+		return eth2p0.Domain{}, err
+	}
+
+	gvr, err := eth2util.ForkVersionToGenesisValidatorsRoot(forkVersion)
+	if err != nil {
+		return eth2p0.Domain{}, err
+	}
+
+	forkData := &eth2p0.ForkData{
+		CurrentVersion:        eth2p0.Version(forkVersion),
+		GenesisValidatorsRoot: eth2p0.Root(gvr),
+	}
+	root, err := forkData.HashTreeRoot()
+	if err != nil {
+		return eth2p0.Domain{}, errors.Wrap(err, "hash fork data")
+	}
+
+	var domain eth2p0.Domain
+	copy(domain[0:], blsToExecutionChangeDomainType[:])
+	copy(domain[4:], root[:])
+
+	return domain, nil
+}
+
+// MarshalChanges serializes a list of signed BLS to execution changes into a single
+// bls_to_execution_changes.json file as created by the staking-deposit-cli.
+func MarshalChanges(changes []*capella.SignedBLSToExecutionChange, network string) ([]byte, error) {
+	forkVersion, err := eth2util.NetworkToForkVersionBytes(network)
+	if err != nil {
+		return nil, err
+	}
+
+	gvr, err := eth2util.ForkVersionToGenesisValidatorsRoot(forkVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []changeJSON
+	for _, change := range changes {
+		// Verify BLS to execution change signature
+		sigData, err := GetMessageSigningRoot(change.Message, network)
+		if err != nil {
+			return nil, err
+		}
+
+		err = tbls.Verify(tbls.PublicKey(change.Message.FromBLSPubkey), sigData[:], tbls.Signature(change.Signature))
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid bls to execution change signature")
+		}
+
+		resp = append(resp, changeJSON{
+			Message:   change.Message,
+			Signature: change.Signature,
+			Metadata: metadataJSON{
+				NetworkName:           network,
+				GenesisValidatorsRoot: fmt.Sprintf("%#x", gvr),
+				DepositCliVersion:     depositCliVersion,
+			},
+		})
+	}
+
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Message.ValidatorIndex < resp[j].Message.ValidatorIndex
+	})
+
+	b, err := json.MarshalIndent(resp, "", " ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal bls to execution changes")
+	}
+
+	return b, nil
+}
+
+// UnmarshalChanges returns the signed BLS to execution changes of a bls_to_execution_changes.json file.
+func UnmarshalChanges(b []byte) ([]*capella.SignedBLSToExecutionChange, error) {
+	var changes []changeJSON
+	if err := json.Unmarshal(b, &changes); err != nil {
+		return nil, errors.Wrap(err, "unmarshal bls to execution changes")
+	}
+
+	var resp []*capella.SignedBLSToExecutionChange
+	for _, change := range changes {
+		if change.Message == nil {
+			return nil, errors.New("missing bls to execution change message")
+		}
+
+		resp = append(resp, &capella.SignedBLSToExecutionChange{
+			Message:   change.Message,
+			Signature: change.Signature,
+		})
+	}
+
+	return resp, nil
+}
+
+// changeJSON is the json representation of a signed BLS to execution change as created by the staking-deposit-cli.
+type changeJSON struct {
+	Message   *capella.BLSToExecutionChange `json:"message"`
+	Signature eth2p0.BLSSignature           `json:"signature"`
+	Metadata  metadataJSON                  `json:"metadata"`
+}
+
+type metadataJSON struct {
+	NetworkName           string `json:"network_name"`
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	DepositCliVersion     string `json:"deposit_cli_version"`
+}
@@ -0,0 +1,103 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package blschange_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util"
+	"github.com/obolnetwork/charon/eth2util/blschange"
+	"github.com/obolnetwork/charon/tbls"
+	"github.com/obolnetwork/charon/tbls/tblsconv"
+	"github.com/obolnetwork/charon/testutil"
+)
+
+//go:generate go test . -run=TestMarshalChanges -update -clean
+
+func TestMarshalChanges(t *testing.T) {
+	privKeys := []string{
+		"01477d4bfbbcebe1fef8d4d6f624ecbb6e3178558bb1b0d6286c816c66842a6d",
+		"5b77c0f0ef7c4ddc123d55b8bd93daeefbd7116764a941c0061a496649e145b5",
+		"1dabcbfc9258f0f28606bf9e3b1c9f06d15a6e4eb0fbc28a43835eaaed7623fc",
+	}
+	executionAddrs := []string{
+		"0x321dcb529f3945bc94fecea9d3bc5caf35253b94",
+		"0x08ef6a66a4f315aa250d2e748de0bfe5a6121096",
+		"0x05f9f73f74c205f2b9267c04296e3069767531fb",
+	}
+
+	var (
+		changes []*capella.SignedBLSToExecutionChange
+		network = eth2util.Goerli.Name
+	)
+	for i := 0; i < len(privKeys); i++ {
+		sk, pk := getKeys(t, privKeys[i])
+
+		msg, err := blschange.NewMessage(eth2p0.ValidatorIndex(10-i), pk, executionAddrs[i])
+		require.NoError(t, err)
+
+		sigRoot, err := blschange.GetMessageSigningRoot(msg, network)
+		require.NoError(t, err)
+
+		sig, err := tbls.Sign(sk, sigRoot[:])
+		require.NoError(t, err)
+
+		changes = append(changes, &capella.SignedBLSToExecutionChange{
+			Message:   msg,
+			Signature: tblsconv.SigToETH2(sig),
+		})
+	}
+
+	actual, err := blschange.MarshalChanges(changes, network)
+	require.NoError(t, err)
+
+	testutil.RequireGoldenBytes(t, actual)
+
+	unmarshalled, err := blschange.UnmarshalChanges(actual)
+	require.NoError(t, err)
+	require.ElementsMatch(t, changes, unmarshalled)
+
+	// Invalid signatures are refused.
+	changes[0].Signature = changes[1].Signature
+	_, err = blschange.MarshalChanges(changes, network)
+	require.ErrorContains(t, err, "invalid bls to execution change signature")
+}
+
+func TestVerifyWithdrawalCredentials(t *testing.T) {
+	_, pk := getKeys(t, "01477d4bfbbcebe1fef8d4d6f624ecbb6e3178558bb1b0d6286c816c66842a6d")
+
+	creds := sha256.Sum256(pk[:])
+	creds[0] = 0x00
+	require.NoError(t, blschange.VerifyWithdrawalCredentials(creds[:], pk))
+
+	creds[0] = 0x01
+	require.ErrorContains(t, blschange.VerifyWithdrawalCredentials(creds[:], pk), "not BLS withdrawal credentials")
+
+	creds[0] = 0x00
+	creds[31]++
+	require.ErrorContains(t, blschange.VerifyWithdrawalCredentials(creds[:], pk), "don't match BLS public key")
+}
+
+func getKeys(t *testing.T, privKey string) (tbls.PrivateKey, eth2p0.BLSPubKey) {
+	t.Helper()
+
+	privKeyBytes, err := hex.DecodeString(privKey)
+	require.NoError(t, err)
+
+	sk, err := tblsconv.PrivkeyFromBytes(privKeyBytes)
+	require.NoError(t, err)
+
+	pk, err := tbls.SecretToPublicKey(sk)
+	require.NoError(t, err)
+
+	pubkey, err := tblsconv.PubkeyToETH2(pk)
+	require.NoError(t, err)
+
+	return sk, pubkey
+}
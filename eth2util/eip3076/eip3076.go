@@ -0,0 +1,175 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package eip3076 provides the EIP-3076 slashing protection interchange format.
+// See https://eips.ethereum.org/EIPS/eip-3076.
+package eip3076
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// FormatVersion is the only supported interchange format version.
+const FormatVersion = "5"
+
+// Interchange is an EIP-3076 slashing protection interchange.
+type Interchange struct {
+	Metadata Metadata    `json:"metadata"`
+	Data     []Validator `json:"data"`
+}
+
+// Metadata is the interchange metadata.
+type Metadata struct {
+	InterchangeFormatVersion string      `json:"interchange_format_version"`
+	GenesisValidatorsRoot    eth2p0.Root `json:"genesis_validators_root"`
+}
+
+// Validator is the slashing protection history of a single validator.
+type Validator struct {
+	Pubkey             eth2p0.BLSPubKey    `json:"pubkey"`
+	SignedBlocks       []SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []SignedAttestation `json:"signed_attestations"`
+}
+
+// SignedBlock is a signed block in the slashing protection history.
+type SignedBlock struct {
+	Slot        eth2p0.Slot  `json:"slot,string"`
+	SigningRoot *eth2p0.Root `json:"signing_root,omitempty"`
+}
+
+// SignedAttestation is a signed attestation in the slashing protection history.
+type SignedAttestation struct {
+	SourceEpoch eth2p0.Epoch `json:"source_epoch,string"`
+	TargetEpoch eth2p0.Epoch `json:"target_epoch,string"`
+	SigningRoot *eth2p0.Root `json:"signing_root,omitempty"`
+}
+
+// validatorJSON is the json formatter of Validator.
+type validatorJSON struct {
+	Pubkey             string              `json:"pubkey"`
+	SignedBlocks       []SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []SignedAttestation `json:"signed_attestations"`
+}
+
+func (v Validator) MarshalJSON() ([]byte, error) {
+	resp, err := json.Marshal(validatorJSON{
+		Pubkey:             "0x" + hex.EncodeToString(v.Pubkey[:]),
+		SignedBlocks:       nonNil(v.SignedBlocks),
+		SignedAttestations: nonNil(v.SignedAttestations),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal validator")
+	}
+
+	return resp, nil
+}
+
+func (v *Validator) UnmarshalJSON(input []byte) error {
+	var raw validatorJSON
+	if err := json.Unmarshal(input, &raw); err != nil {
+		return errors.Wrap(err, "unmarshal validator")
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(raw.Pubkey, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "decode pubkey", z.Str("pubkey", raw.Pubkey))
+	} else if len(b) != len(eth2p0.BLSPubKey{}) {
+		return errors.New("invalid pubkey length", z.Str("pubkey", raw.Pubkey))
+	}
+
+	*v = Validator{
+		Pubkey:             eth2p0.BLSPubKey(b),
+		SignedBlocks:       raw.SignedBlocks,
+		SignedAttestations: raw.SignedAttestations,
+	}
+
+	return nil
+}
+
+// MaxBlockSlot returns the highest signed block slot and true or false if no blocks were signed.
+func (v Validator) MaxBlockSlot() (eth2p0.Slot, bool) {
+	var (
+		resp eth2p0.Slot
+		ok   bool
+	)
+	for _, block := range v.SignedBlocks {
+		if !ok || block.Slot > resp {
+			resp = block.Slot
+			ok = true
+		}
+	}
+
+	return resp, ok
+}
+
+// MaxAttestationEpochs returns the highest signed attestation source and target epochs
+// and true or false if no attestations were signed.
+func (v Validator) MaxAttestationEpochs() (eth2p0.Epoch, eth2p0.Epoch, bool) {
+	var (
+		source, target eth2p0.Epoch
+		ok             bool
+	)
+	for _, att := range v.SignedAttestations {
+		if !ok || att.SourceEpoch > source {
+			source = att.SourceEpoch
+		}
+		if !ok || att.TargetEpoch > target {
+			target = att.TargetEpoch
+		}
+		ok = true
+	}
+
+	return source, target, ok
+}
+
+// Load returns the interchange read from the provided file.
+// It returns an error if the interchange format version isn't supported.
+func Load(path string) (Interchange, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Interchange{}, errors.Wrap(err, "read interchange file", z.Str("path", path))
+	}
+
+	var resp Interchange
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return Interchange{}, errors.Wrap(err, "unmarshal interchange file", z.Str("path", path))
+	}
+
+	if resp.Metadata.InterchangeFormatVersion != FormatVersion {
+		return Interchange{}, errors.New("unsupported interchange format version",
+			z.Str("version", resp.Metadata.InterchangeFormatVersion))
+	}
+
+	return resp, nil
+}
+
+// Write writes the interchange to the provided file.
+func Write(path string, interchange Interchange) error {
+	b, err := json.MarshalIndent(interchange, "", " ")
+	if err != nil {
+		return errors.Wrap(err, "marshal interchange")
+	}
+
+	//nolint:gosec // Interchange files are not secret.
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return errors.Wrap(err, "write interchange file", z.Str("path", path))
+	}
+
+	return nil
+}
+
+// nonNil returns an empty slice if the provided slice is nil, since the interchange format requires lists.
+func nonNil[T any](slice []T) []T {
+	if slice == nil {
+		return []T{}
+	}
+
+	return slice
+}
@@ -0,0 +1,65 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package eip3076_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/eip3076"
+)
+
+func TestLoad(t *testing.T) {
+	interchange, err := eip3076.Load("testdata/interchange.json")
+	require.NoError(t, err)
+
+	require.Equal(t, eip3076.FormatVersion, interchange.Metadata.InterchangeFormatVersion)
+	require.Equal(t, "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673",
+		interchange.Metadata.GenesisValidatorsRoot.String())
+	require.Len(t, interchange.Data, 1)
+
+	val := interchange.Data[0]
+	require.Equal(t, "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed",
+		val.Pubkey.String())
+	require.NotNil(t, val.SignedBlocks[0].SigningRoot)
+	require.Nil(t, val.SignedBlocks[1].SigningRoot)
+
+	slot, ok := val.MaxBlockSlot()
+	require.True(t, ok)
+	require.Equal(t, eth2p0.Slot(81952), slot)
+
+	source, target, ok := val.MaxAttestationEpochs()
+	require.True(t, ok)
+	require.Equal(t, eth2p0.Epoch(2290), source)
+	require.Equal(t, eth2p0.Epoch(3008), target)
+
+	// Roundtrip
+	file := filepath.Join(t.TempDir(), "interchange.json")
+	require.NoError(t, eip3076.Write(file, interchange))
+
+	interchange2, err := eip3076.Load(file)
+	require.NoError(t, err)
+	require.Equal(t, interchange, interchange2)
+}
+
+func TestEmpty(t *testing.T) {
+	val := eip3076.Validator{}
+
+	_, ok := val.MaxBlockSlot()
+	require.False(t, ok)
+
+	_, _, ok = val.MaxAttestationEpochs()
+	require.False(t, ok)
+
+	file := filepath.Join(t.TempDir(), "interchange.json")
+	require.NoError(t, eip3076.Write(file, eip3076.Interchange{
+		Metadata: eip3076.Metadata{InterchangeFormatVersion: "4"},
+		Data:     []eip3076.Validator{val},
+	}))
+
+	_, err := eip3076.Load(file)
+	require.ErrorContains(t, err, "unsupported interchange format version")
+}
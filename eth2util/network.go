@@ -23,39 +23,46 @@ type Network struct {
 	GenesisForkVersionHex string
 	// GenesisTimestamp represents genesis timestamp of the network in unix format
 	GenesisTimestamp int64
+	// GenesisValidatorsRootHex represents genesis validators root of the network in hex.
+	GenesisValidatorsRootHex string
 }
 
 var (
 	Mainnet = Network{
-		ChainID:               1,
-		Name:                  "mainnet",
-		GenesisForkVersionHex: "0x00000000",
-		GenesisTimestamp:      1606824023,
+		ChainID:                  1,
+		Name:                     "mainnet",
+		GenesisForkVersionHex:    "0x00000000",
+		GenesisTimestamp:         1606824023,
+		GenesisValidatorsRootHex: "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95",
 	}
 	Goerli = Network{
-		ChainID:               5,
-		Name:                  "goerli",
-		GenesisForkVersionHex: "0x00001020",
-		GenesisTimestamp:      1616508000,
+		ChainID:                  5,
+		Name:                     "goerli",
+		GenesisForkVersionHex:    "0x00001020",
+		GenesisTimestamp:         1616508000,
+		GenesisValidatorsRootHex: "0x043db0d9a83813551ee2f33450d23797757d430911a9320530ad8a0eabc43efb",
 	}
 	Gnosis = Network{
-		ChainID:               100,
-		Name:                  "gnosis",
-		GenesisForkVersionHex: "0x00000064",
-		GenesisTimestamp:      1638993340,
+		ChainID:                  100,
+		Name:                     "gnosis",
+		GenesisForkVersionHex:    "0x00000064",
+		GenesisTimestamp:         1638993340,
+		GenesisValidatorsRootHex: "0xf5dcb5564e829aab27264b9becd5dfaa017085611224cb3036f573368dbb9d47",
 	}
 	Sepolia = Network{
-		ChainID:               11155111,
-		Name:                  "sepolia",
-		GenesisForkVersionHex: "0x90000069",
-		GenesisTimestamp:      1655733600,
+		ChainID:                  11155111,
+		Name:                     "sepolia",
+		GenesisForkVersionHex:    "0x90000069",
+		GenesisTimestamp:         1655733600,
+		GenesisValidatorsRootHex: "0xd8ea171f3c94aea21ebc42a1ed61052acf3f9209c00e4efbaaddac09ed9b8078",
 	}
 	// Holesky metadata taken from https://github.com/eth-clients/holesky#metadata.
 	Holesky = Network{
-		ChainID:               17000,
-		Name:                  "holesky",
-		GenesisForkVersionHex: "0x00017000",
-		GenesisTimestamp:      1696000704,
+		ChainID:                  17000,
+		Name:                     "holesky",
+		GenesisForkVersionHex:    "0x00017000",
+		GenesisTimestamp:         1696000704,
+		GenesisValidatorsRootHex: "0x9143aa7c615a7f7115e2b6aac319c03529df8242ae705fba9df39b79c59fa8b1",
 	}
 )
 
@@ -141,3 +148,19 @@ func ForkVersionToGenesisTime(forkVersion []byte) (time.Time, error) {
 
 	return time.Time{}, errors.New("invalid network name")
 }
+
+// ForkVersionToGenesisValidatorsRoot returns the genesis validators root bytes corresponding to the provided fork version.
+func ForkVersionToGenesisValidatorsRoot(forkVersion []byte) ([]byte, error) {
+	for _, network := range supportedNetworks {
+		if fmt.Sprintf("%#x", forkVersion) == network.GenesisForkVersionHex {
+			b, err := hex.DecodeString(strings.TrimPrefix(network.GenesisValidatorsRootHex, "0x"))
+			if err != nil {
+				return nil, errors.Wrap(err, "decode genesis validators root hex")
+			}
+
+			return b, nil
+		}
+	}
+
+	return nil, errors.New("invalid fork version")
+}
@@ -82,3 +82,16 @@ func TestSupportedNetwork(t *testing.T) {
 		require.False(t, eth2util.ValidNetwork("ropsten"))
 	})
 }
+
+func TestForkVersionToGenesisValidatorsRoot(t *testing.T) {
+	mainnetForkVersion, err := hex.DecodeString(strings.TrimPrefix(eth2util.Mainnet.GenesisForkVersionHex, "0x"))
+	require.NoError(t, err)
+
+	gvr, err := eth2util.ForkVersionToGenesisValidatorsRoot(mainnetForkVersion)
+	require.NoError(t, err)
+	require.Equal(t, eth2util.Mainnet.GenesisValidatorsRootHex, "0x"+hex.EncodeToString(gvr))
+
+	gvr, err = eth2util.ForkVersionToGenesisValidatorsRoot(invalidForkVersion)
+	require.ErrorContains(t, err, "invalid fork version")
+	require.Nil(t, gvr)
+}
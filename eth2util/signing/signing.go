@@ -30,9 +30,11 @@ const (
 	DomainSyncCommitteeSelectionProof DomainName = "DOMAIN_SYNC_COMMITTEE_SELECTION_PROOF"
 	DomainContributionAndProof        DomainName = "DOMAIN_CONTRIBUTION_AND_PROOF"
 	DomainDeposit                     DomainName = "DOMAIN_DEPOSIT"
+	DomainBlobSidecar                 DomainName = "DOMAIN_BLOB_SIDECAR"
 )
 
 // GetDomain returns the beacon domain for the provided type.
+// Voluntary exits of epochs after the Capella fork are signed with the Capella fork domain, see EIP-7044.
 func GetDomain(ctx context.Context, eth2Cl eth2wrap.Client, name DomainName, epoch eth2p0.Epoch) (eth2p0.Domain, error) {
 	spec, err := eth2Cl.Spec(ctx)
 	if err != nil {
@@ -49,6 +51,12 @@ func GetDomain(ctx context.Context, eth2Cl eth2wrap.Client, name DomainName, epo
 		return eth2p0.Domain{}, errors.New("invalid domain type")
 	}
 
+	if name == DomainExit {
+		if capellaEpoch, ok := spec["CAPELLA_FORK_EPOCH"].(uint64); ok && uint64(epoch) > capellaEpoch {
+			epoch = eth2p0.Epoch(capellaEpoch)
+		}
+	}
+
 	return eth2Cl.Domain(ctx, domainTyped, epoch)
 }
 
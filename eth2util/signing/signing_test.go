@@ -108,3 +108,47 @@ func TestConstantApplicationBuilder(t *testing.T) {
 		require.Equal(t, expect, domain, "domain for fork schedule %d", i)
 	}
 }
+
+func TestExitDomain(t *testing.T) {
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	// The beacon mock fork schedule contains forks at epochs 36660 and 112260, treat the first as the Capella fork.
+	const capellaEpoch = 36660
+	eth2Cl := capellaSpec{Mock: bmock, capellaEpoch: capellaEpoch}
+
+	getDomain := func(t *testing.T, name signing.DomainName, epoch eth2p0.Epoch) eth2p0.Domain {
+		t.Helper()
+
+		domain, err := signing.GetDomain(context.Background(), eth2Cl, name, epoch)
+		require.NoError(t, err)
+
+		return domain
+	}
+
+	// Exits before the Capella fork use the domain of their epoch.
+	require.NotEqual(t, getDomain(t, signing.DomainExit, 5), getDomain(t, signing.DomainExit, capellaEpoch))
+
+	// Exits after the Capella fork use the Capella fork domain.
+	require.Equal(t, getDomain(t, signing.DomainExit, capellaEpoch), getDomain(t, signing.DomainExit, 200000))
+
+	// Other domains use the domain of their epoch.
+	require.NotEqual(t, getDomain(t, signing.DomainRandao, capellaEpoch), getDomain(t, signing.DomainRandao, 200000))
+}
+
+// capellaSpec wraps a beacon mock adding the Capella fork epoch to its spec.
+type capellaSpec struct {
+	beaconmock.Mock
+	capellaEpoch uint64
+}
+
+func (c capellaSpec) Spec(ctx context.Context) (map[string]any, error) {
+	spec, err := c.Mock.Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spec["CAPELLA_FORK_EPOCH"] = c.capellaEpoch
+
+	return spec, nil
+}
@@ -0,0 +1,193 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package web3signer provides a client for the Web3Signer eth2 signing API (https://consensys.github.io/web3signer/web3signer-eth2.html).
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/app/z"
+)
+
+// SigningType is the type of the data to sign.
+type SigningType string
+
+const (
+	TypeAttestation                       SigningType = "ATTESTATION"
+	TypeBlockV2                           SigningType = "BLOCK_V2"
+	TypeRandaoReveal                      SigningType = "RANDAO_REVEAL"
+	TypeAggregationSlot                   SigningType = "AGGREGATION_SLOT"
+	TypeAggregateAndProof                 SigningType = "AGGREGATE_AND_PROOF"
+	TypeSyncCommitteeMessage              SigningType = "SYNC_COMMITTEE_MESSAGE"
+	TypeSyncCommitteeSelectionProof       SigningType = "SYNC_COMMITTEE_SELECTION_PROOF"
+	TypeSyncCommitteeContributionAndProof SigningType = "SYNC_COMMITTEE_CONTRIBUTION_AND_PROOF"
+	TypeVoluntaryExit                     SigningType = "VOLUNTARY_EXIT"
+	TypeValidatorRegistration             SigningType = "VALIDATOR_REGISTRATION"
+	// TypeBlobSidecar is the type of both blob sidecars and blinded blob sidecars,
+	// since Web3Signer signs the blinded blob sidecar which has the same root.
+	TypeBlobSidecar SigningType = "BLOB_SIDECAR"
+)
+
+// ForkInfo is the fork and genesis validators root used to calculate the signing domain.
+type ForkInfo struct {
+	Fork                  *eth2p0.Fork `json:"fork"`
+	GenesisValidatorsRoot eth2p0.Root  `json:"genesis_validators_root"`
+}
+
+// BeaconBlock is the beacon block to sign. Phase0 and altair blocks are provided in full,
+// later versions only provide the block header.
+type BeaconBlock struct {
+	Version     string                    `json:"version"`
+	Block       json.RawMessage           `json:"block,omitempty"`
+	BlockHeader *eth2p0.BeaconBlockHeader `json:"block_header,omitempty"`
+}
+
+// RandaoReveal is the epoch of a randao reveal.
+type RandaoReveal struct {
+	Epoch eth2p0.Epoch `json:"epoch,string"`
+}
+
+// AggregationSlot is the slot of an aggregation selection proof.
+type AggregationSlot struct {
+	Slot eth2p0.Slot `json:"slot,string"`
+}
+
+// SyncCommitteeMessage is the block root and slot of a sync committee message.
+type SyncCommitteeMessage struct {
+	BeaconBlockRoot eth2p0.Root `json:"beacon_block_root"`
+	Slot            eth2p0.Slot `json:"slot,string"`
+}
+
+// SyncAggregatorSelectionData is the slot and subcommittee of a sync committee selection proof.
+type SyncAggregatorSelectionData struct {
+	Slot              eth2p0.Slot `json:"slot,string"`
+	SubcommitteeIndex uint64      `json:"subcommittee_index,string"`
+}
+
+// SignRequest is the body of a Web3Signer eth2 sign request. Only the field matching the type is populated.
+type SignRequest struct {
+	Type                        SigningType                   `json:"type"`
+	ForkInfo                    *ForkInfo                     `json:"fork_info,omitempty"`
+	SigningRoot                 *eth2p0.Root                  `json:"signingRoot,omitempty"`
+	Attestation                 *eth2p0.AttestationData       `json:"attestation,omitempty"`
+	BeaconBlock                 *BeaconBlock                  `json:"beacon_block,omitempty"`
+	RandaoReveal                *RandaoReveal                 `json:"randao_reveal,omitempty"`
+	AggregationSlot             *AggregationSlot              `json:"aggregation_slot,omitempty"`
+	AggregateAndProof           *eth2p0.AggregateAndProof     `json:"aggregate_and_proof,omitempty"`
+	SyncCommitteeMessage        *SyncCommitteeMessage         `json:"sync_committee_message,omitempty"`
+	SyncAggregatorSelectionData *SyncAggregatorSelectionData  `json:"sync_aggregator_selection_data,omitempty"`
+	ContributionAndProof        *altair.ContributionAndProof  `json:"contribution_and_proof,omitempty"`
+	VoluntaryExit               *eth2p0.VoluntaryExit         `json:"voluntary_exit,omitempty"`
+	ValidatorRegistration       *eth2v1.ValidatorRegistration `json:"validator_registration,omitempty"`
+	BlobSidecar                 *eth2deneb.BlindedBlobSidecar `json:"blob_sidecar,omitempty"`
+}
+
+// signResponse is the json response of a Web3Signer sign request.
+type signResponse struct {
+	Signature eth2p0.BLSSignature `json:"signature"`
+}
+
+// New returns a new Client.
+func New(baseURL string) Client {
+	return Client{baseURL: baseURL}
+}
+
+// Client is the REST client for Web3Signer eth2 sign requests.
+type Client struct {
+	baseURL string // Base Web3Signer URL
+}
+
+// Sign returns the signature of the request by the key identified by the public key.
+// The HTTP request times out after 10s.
+func (c Client) Sign(ctx context.Context, pubkey eth2p0.BLSPubKey, signReq SignRequest) (eth2p0.BLSSignature, error) {
+	addr, err := url.JoinPath(c.baseURL, "/api/v1/eth2/sign", fmt.Sprintf("%#x", pubkey))
+	if err != nil {
+		return eth2p0.BLSSignature{}, errors.Wrap(err, "invalid base url", z.Str("base_url", c.baseURL))
+	}
+
+	reqBytes, err := json.Marshal(signReq)
+	if err != nil {
+		return eth2p0.BLSSignature{}, errors.Wrap(err, "marshal web3signer request body")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addr, bytes.NewReader(reqBytes))
+	if err != nil {
+		return eth2p0.BLSSignature{}, errors.Wrap(err, "new post request", z.Str("url", addr))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := new(http.Client).Do(req)
+	if err != nil {
+		return eth2p0.BLSSignature{}, errors.Wrap(err, "post web3signer sign request")
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return eth2p0.BLSSignature{}, errors.Wrap(err, "read response")
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return eth2p0.BLSSignature{}, errors.New("failed web3signer sign request", z.Int("status", resp.StatusCode),
+			z.Str("type", string(signReq.Type)), z.Str("body", string(data)))
+	}
+
+	// Web3Signer responds with a plain text signature if it doesn't support json.
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		data = []byte(fmt.Sprintf("{\"signature\":%q}", strings.TrimSpace(string(data))))
+	}
+
+	var signResp signResponse
+	if err := json.Unmarshal(data, &signResp); err != nil {
+		return eth2p0.BLSSignature{}, errors.Wrap(err, "unmarshal web3signer response")
+	}
+
+	return signResp.Signature, nil
+}
+
+// NewForkInfo returns the fork info of the provided epoch, selecting the latest scheduled fork not after the epoch.
+func NewForkInfo(ctx context.Context, eth2Cl eth2wrap.Client, epoch eth2p0.Epoch) (*ForkInfo, error) {
+	forks, err := eth2Cl.ForkSchedule(ctx)
+	if err != nil {
+		return nil, err
+	} else if len(forks) == 0 {
+		return nil, errors.New("empty fork schedule")
+	}
+
+	fork := forks[0]
+	for _, f := range forks {
+		if f.Epoch > epoch {
+			break
+		}
+		fork = f
+	}
+
+	genesis, err := eth2Cl.Genesis(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ForkInfo{
+		Fork:                  fork,
+		GenesisValidatorsRoot: genesis.GenesisValidatorsRoot,
+	}, nil
+}
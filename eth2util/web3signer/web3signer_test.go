@@ -0,0 +1,242 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+package web3signer_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+
+	"github.com/obolnetwork/charon/eth2util/web3signer"
+	"github.com/obolnetwork/charon/testutil"
+	"github.com/obolnetwork/charon/testutil/beaconmock"
+)
+
+func TestSign(t *testing.T) {
+	ctx := context.Background()
+	pubkey := testutil.RandomEth2PubKey(t)
+	sig := testutil.RandomEth2Signature()
+
+	tests := []struct {
+		name        string
+		contentType string
+		status      int
+		body        string
+		errMsg      string
+	}{
+		{
+			name:        "json response",
+			contentType: "application/json",
+			status:      http.StatusOK,
+			body:        fmt.Sprintf(`{"signature":"%#x"}`, sig),
+		},
+		{
+			name:        "plain text response",
+			contentType: "text/plain",
+			status:      http.StatusOK,
+			body:        fmt.Sprintf("%#x\n", sig),
+		},
+		{
+			name:        "key not found",
+			contentType: "text/plain",
+			status:      http.StatusNotFound,
+			body:        "Public Key not found",
+			errMsg:      "failed web3signer sign request",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, fmt.Sprintf("/api/v1/eth2/sign/%#x", pubkey), r.URL.Path)
+
+				var req web3signer.SignRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				require.Equal(t, web3signer.TypeRandaoReveal, req.Type)
+				require.Equal(t, eth2p0.Epoch(3), req.RandaoReveal.Epoch)
+
+				w.Header().Set("Content-Type", test.contentType)
+				w.WriteHeader(test.status)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer srv.Close()
+
+			resp, err := web3signer.New(srv.URL).Sign(ctx, pubkey, web3signer.SignRequest{
+				Type:         web3signer.TypeRandaoReveal,
+				RandaoReveal: &web3signer.RandaoReveal{Epoch: 3},
+			})
+			if test.errMsg != "" {
+				require.ErrorContains(t, err, test.errMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, sig, resp)
+		})
+	}
+}
+
+func TestSignRequestJSON(t *testing.T) {
+	forkInfo := &web3signer.ForkInfo{
+		Fork: &eth2p0.Fork{
+			PreviousVersion: eth2p0.Version{0x01, 0x00, 0x00, 0x00},
+			CurrentVersion:  eth2p0.Version{0x02, 0x00, 0x00, 0x00},
+			Epoch:           10,
+		},
+		GenesisValidatorsRoot: eth2p0.Root{0xaa},
+	}
+	const forkInfoJSON = `{
+		"fork": {"previous_version": "0x01000000", "current_version": "0x02000000", "epoch": "10"},
+		"genesis_validators_root": "0xaa00000000000000000000000000000000000000000000000000000000000000"
+	}`
+
+	tests := []struct {
+		name string
+		req  web3signer.SignRequest
+		json string
+	}{
+		{
+			name: "randao reveal",
+			req: web3signer.SignRequest{
+				Type:         web3signer.TypeRandaoReveal,
+				ForkInfo:     forkInfo,
+				RandaoReveal: &web3signer.RandaoReveal{Epoch: 12},
+			},
+			json: `{"type": "RANDAO_REVEAL", "fork_info": ` + forkInfoJSON + `, "randao_reveal": {"epoch": "12"}}`,
+		},
+		{
+			name: "aggregation slot",
+			req: web3signer.SignRequest{
+				Type:            web3signer.TypeAggregationSlot,
+				ForkInfo:        forkInfo,
+				AggregationSlot: &web3signer.AggregationSlot{Slot: 385},
+			},
+			json: `{"type": "AGGREGATION_SLOT", "fork_info": ` + forkInfoJSON + `, "aggregation_slot": {"slot": "385"}}`,
+		},
+		{
+			name: "sync committee message",
+			req: web3signer.SignRequest{
+				Type:     web3signer.TypeSyncCommitteeMessage,
+				ForkInfo: forkInfo,
+				SyncCommitteeMessage: &web3signer.SyncCommitteeMessage{
+					BeaconBlockRoot: eth2p0.Root{0xbb},
+					Slot:            386,
+				},
+			},
+			json: `{"type": "SYNC_COMMITTEE_MESSAGE", "fork_info": ` + forkInfoJSON + `, "sync_committee_message": {
+				"beacon_block_root": "0xbb00000000000000000000000000000000000000000000000000000000000000",
+				"slot": "386"
+			}}`,
+		},
+		{
+			name: "sync committee selection proof",
+			req: web3signer.SignRequest{
+				Type:     web3signer.TypeSyncCommitteeSelectionProof,
+				ForkInfo: forkInfo,
+				SyncAggregatorSelectionData: &web3signer.SyncAggregatorSelectionData{
+					Slot:              387,
+					SubcommitteeIndex: 3,
+				},
+			},
+			json: `{"type": "SYNC_COMMITTEE_SELECTION_PROOF", "fork_info": ` + forkInfoJSON + `, "sync_aggregator_selection_data": {
+				"slot": "387",
+				"subcommittee_index": "3"
+			}}`,
+		},
+		{
+			name: "voluntary exit",
+			req: web3signer.SignRequest{
+				Type:          web3signer.TypeVoluntaryExit,
+				ForkInfo:      forkInfo,
+				VoluntaryExit: &eth2p0.VoluntaryExit{Epoch: 13, ValidatorIndex: 7},
+			},
+			json: `{"type": "VOLUNTARY_EXIT", "fork_info": ` + forkInfoJSON + `, "voluntary_exit": {"epoch": "13", "validator_index": "7"}}`,
+		},
+		{
+			name: "validator registration",
+			req: web3signer.SignRequest{
+				Type: web3signer.TypeValidatorRegistration,
+				ValidatorRegistration: &eth2v1.ValidatorRegistration{
+					FeeRecipient: bellatrix.ExecutionAddress{0xcc},
+					GasLimit:     30000000,
+					Timestamp:    time.Unix(1606824023, 0),
+					Pubkey:       eth2p0.BLSPubKey{0xdd},
+				},
+			},
+			json: `{"type": "VALIDATOR_REGISTRATION", "validator_registration": {
+				"fee_recipient": "0xCc00000000000000000000000000000000000000",
+				"gas_limit": "30000000",
+				"timestamp": "1606824023",
+				"pubkey": "0xdd0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
+			}}`,
+		},
+		{
+			name: "blob sidecar",
+			req: web3signer.SignRequest{
+				Type:     web3signer.TypeBlobSidecar,
+				ForkInfo: forkInfo,
+				BlobSidecar: &eth2deneb.BlindedBlobSidecar{
+					BlockRoot:       eth2p0.Root{0xee},
+					Index:           1,
+					Slot:            388,
+					BlockParentRoot: eth2p0.Root{0xff},
+					ProposerIndex:   8,
+					BlobRoot:        eth2p0.Root{0x11},
+					KzgCommitment:   deneb.KzgCommitment{0x22},
+					KzgProof:        deneb.KzgProof{0x33},
+				},
+			},
+			json: `{"type": "BLOB_SIDECAR", "fork_info": ` + forkInfoJSON + `, "blob_sidecar": {
+				"block_root": "0xee00000000000000000000000000000000000000000000000000000000000000",
+				"index": "1",
+				"slot": "388",
+				"block_parent_root": "0xff00000000000000000000000000000000000000000000000000000000000000",
+				"proposer_index": "8",
+				"blob_root": "0x1100000000000000000000000000000000000000000000000000000000000000",
+				"kzg_commitment": "0x220000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+				"kzg_proof": "0x330000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"
+			}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := json.Marshal(test.req)
+			require.NoError(t, err)
+			require.JSONEq(t, test.json, string(b))
+		})
+	}
+}
+
+func TestNewForkInfo(t *testing.T) {
+	ctx := context.Background()
+
+	bmock, err := beaconmock.New()
+	require.NoError(t, err)
+
+	forks, err := bmock.ForkSchedule(ctx)
+	require.NoError(t, err)
+	require.Greater(t, len(forks), 1)
+
+	genesis, err := bmock.Genesis(ctx)
+	require.NoError(t, err)
+
+	for _, fork := range forks {
+		forkInfo, err := web3signer.NewForkInfo(ctx, bmock, fork.Epoch)
+		require.NoError(t, err)
+		require.Equal(t, genesis.GenesisValidatorsRoot, forkInfo.GenesisValidatorsRoot)
+		require.Equal(t, fork.Epoch, forkInfo.Fork.Epoch)
+	}
+}
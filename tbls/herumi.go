@@ -3,6 +3,7 @@
 package tbls
 
 import (
+	"crypto/rand"
 	"fmt"
 	"io"
 	"strconv"
@@ -312,6 +313,87 @@ func (Herumi) Sign(privateKey PrivateKey, data []byte) (Signature, error) {
 	return *(*Signature)(sigBytes), nil
 }
 
+// BatchVerify verifies all signatures at once by checking that e(-g1, Σ r_i*sig_i) * Π e(Σ r_i*pk_i, H(m)) == 1,
+// with random 64-bit coefficients r_i and the public keys grouped by message, so that invalid signatures
+// cannot cancel each other out and signatures of the same message only require a single pairing.
+func (Herumi) BatchVerify(compressedPublicKeys []PublicKey, data [][]byte, signatures []Signature) error {
+	if len(compressedPublicKeys) != len(signatures) || len(data) != len(signatures) {
+		return errors.New("mismatching batch verification input lengths")
+	} else if len(signatures) == 0 {
+		return nil
+	}
+
+	randomness := make([]byte, 8*len(signatures))
+	if _, err := rand.Read(randomness); err != nil {
+		return errors.Wrap(err, "read randomness")
+	}
+
+	type group struct {
+		pubkeys []bls.G1
+		coeffs  []bls.Fr
+	}
+
+	var (
+		sigs   = make([]bls.G2, len(signatures))
+		coeffs = make([]bls.Fr, len(signatures))
+		groups = make(map[string]*group)
+		msgs   []string
+	)
+	for i := range signatures {
+		var sig bls.Sign
+		if err := sig.Deserialize(signatures[i][:]); err != nil {
+			return errors.Wrap(err, "cannot unmarshal signature into Herumi signature", z.Int("index", i))
+		}
+
+		var pubkey bls.PublicKey
+		if err := pubkey.Deserialize(compressedPublicKeys[i][:]); err != nil {
+			return errors.Wrap(err, "cannot set compressed public key in Herumi format", z.Int("index", i))
+		}
+
+		if err := coeffs[i].SetLittleEndian(randomness[i*8 : (i+1)*8]); err != nil {
+			return errors.Wrap(err, "set coefficient")
+		}
+		sigs[i] = *bls.CastFromSign(&sig)
+
+		g, ok := groups[string(data[i])]
+		if !ok {
+			g = new(group)
+			groups[string(data[i])] = g
+			msgs = append(msgs, string(data[i]))
+		}
+		g.pubkeys = append(g.pubkeys, *bls.CastFromPublicKey(&pubkey))
+		g.coeffs = append(g.coeffs, coeffs[i])
+	}
+
+	var generator bls.PublicKey
+	bls.GetGeneratorOfPublicKey(&generator)
+
+	g1s := make([]bls.G1, len(msgs)+1)
+	g2s := make([]bls.G2, len(msgs)+1)
+	bls.G1Neg(&g1s[0], bls.CastFromPublicKey(&generator))
+	bls.G2MulVec(&g2s[0], sigs, coeffs)
+
+	for i, msg := range msgs {
+		hash := bls.HashAndMapToSignature([]byte(msg))
+		if hash == nil {
+			return errors.New("cannot hash message to curve")
+		}
+
+		bls.G1MulVec(&g1s[i+1], groups[msg].pubkeys, groups[msg].coeffs)
+		g2s[i+1] = *bls.CastFromSign(hash)
+	}
+
+	var result bls.GT
+	bls.MillerLoopVec(&result, g1s, g2s)
+	bls.FinalExp(&result, &result)
+
+	if !result.IsOne() {
+		return errors.New("batch signature verification failed")
+	}
+
+	return nil
+}
+
 func (Herumi) VerifyAggregate(publicShares []PublicKey, signature Signature, data []byte) error {
 	var (
 		rawShares []bls.PublicKey
@@ -6,6 +6,8 @@ import (
 	"io"
 	"sync"
 	"testing"
+
+	"github.com/obolnetwork/charon/app/errors"
 )
 
 var (
@@ -55,6 +57,11 @@ type Implementation interface {
 	// the provided data.
 	Verify(compressedPublicKey PublicKey, data []byte, signature Signature) error
 
+	// BatchVerify verifies that each signature has been produced with the private key associated with the
+	// compressed public key at the same index, on the data at the same index. All signatures are verified at once
+	// using a random linear combination, so it returns an error if any signature is invalid without identifying it.
+	BatchVerify(compressedPublicKeys []PublicKey, data [][]byte, signatures []Signature) error
+
 	// Sign signs data with the provided private key, and returns the resulting signature.
 	// This function works on both shares of private keys, and complete private keys.
 	Sign(privateKey PrivateKey, data []byte) (Signature, error)
@@ -122,6 +129,33 @@ func Verify(compressedPublicKey PublicKey, data []byte, signature Signature) err
 	return impl.Verify(compressedPublicKey, data, signature)
 }
 
+// BatchVerify verifies that each signature has been produced with the private key associated with the
+// compressed public key at the same index, on the data at the same index. All signatures are verified at once
+// using a random linear combination, so it returns an error if any signature is invalid without identifying it.
+func BatchVerify(compressedPublicKeys []PublicKey, data [][]byte, signatures []Signature) error {
+	return impl.BatchVerify(compressedPublicKeys, data, signatures)
+}
+
+// BatchVerifyEach verifies the signatures with BatchVerify and only if the batch fails, verifies each signature
+// individually to identify the invalid signatures. It returns the verification error of each signature by index,
+// which is nil if the signature is valid.
+func BatchVerifyEach(compressedPublicKeys []PublicKey, data [][]byte, signatures []Signature) ([]error, error) {
+	if len(compressedPublicKeys) != len(signatures) || len(data) != len(signatures) {
+		return nil, errors.New("mismatching batch verification input lengths")
+	}
+
+	resp := make([]error, len(signatures))
+	if err := impl.BatchVerify(compressedPublicKeys, data, signatures); err == nil {
+		return resp, nil
+	}
+
+	for i := range signatures {
+		resp[i] = impl.Verify(compressedPublicKeys[i], data[i], signatures[i])
+	}
+
+	return resp, nil
+}
+
 // Sign signs data with the provided private key, and returns the resulting signature.
 // This function works on both shares of private keys, and complete private keys.
 func Sign(privateKey PrivateKey, data []byte) (Signature, error) {
@@ -4,6 +4,7 @@ package tbls_test
 
 import (
 	"crypto/rand"
+	"fmt"
 	"io"
 	"math/big"
 	"testing"
@@ -167,6 +168,56 @@ func (ts *TestSuite) Test_VerifyAggregate() {
 	require.NoError(ts.T(), tbls.VerifyAggregate(pshares, sig, data))
 }
 
+func (ts *TestSuite) Test_BatchVerify() {
+	const n = 10
+
+	var (
+		pubkeys []tbls.PublicKey
+		datas   [][]byte
+		sigs    []tbls.Signature
+	)
+	for i := 0; i < n; i++ {
+		secret, err := tbls.GenerateSecretKey()
+		require.NoError(ts.T(), err)
+
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		require.NoError(ts.T(), err)
+
+		data := []byte(fmt.Sprintf("hello obol %d!", i%3)) // Some signatures of the same data.
+
+		sig, err := tbls.Sign(secret, data)
+		require.NoError(ts.T(), err)
+
+		pubkeys = append(pubkeys, pubkey)
+		datas = append(datas, data)
+		sigs = append(sigs, sig)
+	}
+
+	require.NoError(ts.T(), tbls.BatchVerify(nil, nil, nil))
+	require.NoError(ts.T(), tbls.BatchVerify(pubkeys, datas, sigs))
+
+	errs, err := tbls.BatchVerifyEach(pubkeys, datas, sigs)
+	require.NoError(ts.T(), err)
+	require.Equal(ts.T(), make([]error, n), errs)
+
+	_, err = tbls.BatchVerifyEach(pubkeys, datas, sigs[1:])
+	require.Error(ts.T(), err)
+
+	// Signatures of the same data swapped between public keys.
+	sigs[0], sigs[3] = sigs[3], sigs[0]
+	require.Error(ts.T(), tbls.BatchVerify(pubkeys, datas, sigs))
+
+	errs, err = tbls.BatchVerifyEach(pubkeys, datas, sigs)
+	require.NoError(ts.T(), err)
+	for i, err := range errs {
+		if i == 0 || i == 3 {
+			require.Error(ts.T(), err)
+		} else {
+			require.NoError(ts.T(), err)
+		}
+	}
+}
+
 func runSuite(t *testing.T, i tbls.Implementation) {
 	t.Helper()
 	ts := NewTestSuite(i)
@@ -200,6 +251,7 @@ func runBenchmark(b *testing.B, impl tbls.Implementation) {
 		s.Test_Verify()
 		s.Test_Sign()
 		s.Test_VerifyAggregate()
+		s.Test_BatchVerify()
 	}
 }
 
@@ -207,6 +259,48 @@ func BenchmarkHerumiImplementation(b *testing.B) {
 	runBenchmark(b, tbls.Herumi{})
 }
 
+// BenchmarkBatchVerify compares individual and batch verification of a partial signature per validator
+// of a cluster, each signing different data.
+func BenchmarkBatchVerify(b *testing.B) {
+	for _, n := range []int{100, 500, 1000} {
+		var (
+			pubkeys []tbls.PublicKey
+			datas   [][]byte
+			sigs    []tbls.Signature
+		)
+		for i := 0; i < n; i++ {
+			secret, err := tbls.GenerateSecretKey()
+			require.NoError(b, err)
+
+			pubkey, err := tbls.SecretToPublicKey(secret)
+			require.NoError(b, err)
+
+			data := []byte(fmt.Sprintf("validator %d", i))
+
+			sig, err := tbls.Sign(secret, data)
+			require.NoError(b, err)
+
+			pubkeys = append(pubkeys, pubkey)
+			datas = append(datas, data)
+			sigs = append(sigs, sig)
+		}
+
+		b.Run(fmt.Sprintf("individual_%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < n; j++ {
+					require.NoError(b, tbls.Verify(pubkeys[j], datas[j], sigs[j]))
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("batch_%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				require.NoError(b, tbls.BatchVerify(pubkeys, datas, sigs))
+			}
+		})
+	}
+}
+
 func TestRandomized(t *testing.T) {
 	runSuite(t, randomizedImpl{
 		implementations: []tbls.Implementation{
@@ -312,6 +406,15 @@ func (r randomizedImpl) Verify(compressedPublicKey tbls.PublicKey, data []byte,
 	return impl.Verify(compressedPublicKey, data, signature)
 }
 
+func (r randomizedImpl) BatchVerify(compressedPublicKeys []tbls.PublicKey, data [][]byte, signatures []tbls.Signature) error {
+	impl, err := r.selectImpl()
+	if err != nil {
+		return err
+	}
+
+	return impl.BatchVerify(compressedPublicKeys, data, signatures)
+}
+
 func (r randomizedImpl) Sign(privateKey tbls.PrivateKey, data []byte) (tbls.Signature, error) {
 	impl, err := r.selectImpl()
 	if err != nil {
@@ -29,10 +29,13 @@ import (
 	"net/http"
 	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/jonboulle/clockwork"
 
@@ -113,21 +116,31 @@ type Mock struct {
 
 	ActiveValidatorsFunc                   func(ctx context.Context) (eth2wrap.ActiveValidators, error)
 	AttestationDataFunc                    func(context.Context, eth2p0.Slot, eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error)
+	AttestationDataVotesFunc               func(context.Context, eth2p0.Slot, eth2p0.CommitteeIndex) ([]eth2wrap.AttestationDataVote, error)
 	AttesterDutiesFunc                     func(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) ([]*eth2v1.AttesterDuty, error)
 	BlockAttestationsFunc                  func(ctx context.Context, stateID string) ([]*eth2p0.Attestation, error)
 	NodePeerCountFunc                      func(ctx context.Context) (int, error)
+	ValidatorLivenessFunc                  func(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) ([]*eth2wrap.ValidatorLiveness, error)
 	BlindedBeaconBlockProposalFunc         func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (*eth2api.VersionedBlindedBeaconBlock, error)
 	BeaconBlockProposalFunc                func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (*eth2spec.VersionedBeaconBlock, error)
+	BeaconBlockProposalsFunc               func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]eth2wrap.BeaconBlockProposal, error)
+	BlindedBeaconBlockProposalsFunc        func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]eth2wrap.BlindedBeaconBlockProposal, error)
+	BlockContentsProposalFunc              func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BeaconBlockProposal, error)
+	BlindedBlockContentsProposalFunc       func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BlindedBeaconBlockProposal, error)
 	SignedBeaconBlockFunc                  func(ctx context.Context, blockID string) (*eth2spec.VersionedSignedBeaconBlock, error)
 	ProposerDutiesFunc                     func(context.Context, eth2p0.Epoch, []eth2p0.ValidatorIndex) ([]*eth2v1.ProposerDuty, error)
 	SubmitAttestationsFunc                 func(context.Context, []*eth2p0.Attestation) error
 	SubmitBeaconBlockFunc                  func(context.Context, *eth2spec.VersionedSignedBeaconBlock) error
 	SubmitBlindedBeaconBlockFunc           func(context.Context, *eth2api.VersionedSignedBlindedBeaconBlock) error
+	SubmitBlockContentsFunc                func(context.Context, *eth2deneb.SignedBlockContents) error
+	SubmitBlindedBlockContentsFunc         func(context.Context, *eth2deneb.SignedBlindedBlockContents) error
 	SubmitVoluntaryExitFunc                func(context.Context, *eth2p0.SignedVoluntaryExit) error
+	SubmitBLSToExecutionChangesFunc        func(context.Context, []*capella.SignedBLSToExecutionChange) error
 	ValidatorsByPubKeyFunc                 func(context.Context, string, []eth2p0.BLSPubKey) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error)
 	ValidatorsFunc                         func(context.Context, string, []eth2p0.ValidatorIndex) (map[eth2p0.ValidatorIndex]*eth2v1.Validator, error)
 	GenesisTimeFunc                        func(context.Context) (time.Time, error)
 	NodeSyncingFunc                        func(context.Context) (*eth2v1.SyncState, error)
+	NodeSyncStateFunc                      func(context.Context) (*eth2wrap.NodeSyncState, error)
 	SubmitValidatorRegistrationsFunc       func(context.Context, []*eth2api.VersionedSignedValidatorRegistration) error
 	SlotsPerEpochFunc                      func(context.Context) (uint64, error)
 	AggregateBeaconCommitteeSelectionsFunc func(context.Context, []*eth2exp.BeaconCommitteeSelection) ([]*eth2exp.BeaconCommitteeSelection, error)
@@ -142,6 +155,7 @@ type Mock struct {
 	SubmitSyncCommitteeSubscriptionsFunc   func(ctx context.Context, subscriptions []*eth2v1.SyncCommitteeSubscription) error
 	SubmitProposalPreparationsFunc         func(ctx context.Context, preparations []*eth2v1.ProposalPreparation) error
 	ForkScheduleFunc                       func(context.Context) ([]*eth2p0.Fork, error)
+	EventsFunc                             func(context.Context, []string, eth2client.EventHandlerFunc) error
 	ProposerConfigFunc                     func(context.Context) (*eth2exp.ProposerConfigResponse, error)
 }
 
@@ -161,6 +175,91 @@ func (m Mock) NodePeerCount(ctx context.Context) (int, error) {
 	return m.NodePeerCountFunc(ctx)
 }
 
+func (m Mock) ValidatorLiveness(ctx context.Context, epoch eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*eth2wrap.ValidatorLiveness, error) {
+	return m.ValidatorLivenessFunc(ctx, epoch, indices)
+}
+
+// AttestationDataVotes returns the AttestationDataVotesFunc result if set, otherwise the AttestationDataFunc result as a single vote.
+func (m Mock) AttestationDataVotes(ctx context.Context, slot eth2p0.Slot, committeeIndex eth2p0.CommitteeIndex) ([]eth2wrap.AttestationDataVote, error) {
+	if m.AttestationDataVotesFunc != nil {
+		return m.AttestationDataVotesFunc(ctx, slot, committeeIndex)
+	}
+
+	data, err := m.AttestationDataFunc(ctx, slot, committeeIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return []eth2wrap.AttestationDataVote{{Address: m.Address(), Data: data}}, nil
+}
+
+// BeaconBlockProposals returns the BeaconBlockProposalsFunc result if set, otherwise the BeaconBlockProposalFunc result
+// as a single proposal without values.
+func (m Mock) BeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]eth2wrap.BeaconBlockProposal, error) {
+	if m.BeaconBlockProposalsFunc != nil {
+		return m.BeaconBlockProposalsFunc(ctx, slot, randaoReveal, graffiti)
+	}
+
+	block, err := m.BeaconBlockProposalFunc(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	return []eth2wrap.BeaconBlockProposal{{Address: m.Address(), Block: block}}, nil
+}
+
+// BlindedBeaconBlockProposals returns the BlindedBeaconBlockProposalsFunc result if set, otherwise the
+// BlindedBeaconBlockProposalFunc result as a single proposal without values.
+func (m Mock) BlindedBeaconBlockProposals(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) ([]eth2wrap.BlindedBeaconBlockProposal, error) {
+	if m.BlindedBeaconBlockProposalsFunc != nil {
+		return m.BlindedBeaconBlockProposalsFunc(ctx, slot, randaoReveal, graffiti)
+	}
+
+	block, err := m.BlindedBeaconBlockProposalFunc(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	return []eth2wrap.BlindedBeaconBlockProposal{{Address: m.Address(), Block: block}}, nil
+}
+
+// BlockContentsProposal returns the BlockContentsProposalFunc result if set, otherwise the first BeaconBlockProposals result.
+func (m Mock) BlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BeaconBlockProposal, error) {
+	if m.BlockContentsProposalFunc != nil {
+		return m.BlockContentsProposalFunc(ctx, slot, randaoReveal, graffiti)
+	}
+
+	proposals, err := m.BeaconBlockProposals(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return eth2wrap.BeaconBlockProposal{}, err
+	}
+
+	return proposals[0], nil
+}
+
+// BlindedBlockContentsProposal returns the BlindedBlockContentsProposalFunc result if set, otherwise the first
+// BlindedBeaconBlockProposals result.
+func (m Mock) BlindedBlockContentsProposal(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BlindedBeaconBlockProposal, error) {
+	if m.BlindedBlockContentsProposalFunc != nil {
+		return m.BlindedBlockContentsProposalFunc(ctx, slot, randaoReveal, graffiti)
+	}
+
+	proposals, err := m.BlindedBeaconBlockProposals(ctx, slot, randaoReveal, graffiti)
+	if err != nil {
+		return eth2wrap.BlindedBeaconBlockProposal{}, err
+	}
+
+	return proposals[0], nil
+}
+
+func (m Mock) SubmitBlockContents(ctx context.Context, contents *eth2deneb.SignedBlockContents) error {
+	return m.SubmitBlockContentsFunc(ctx, contents)
+}
+
+func (m Mock) SubmitBlindedBlockContents(ctx context.Context, contents *eth2deneb.SignedBlindedBlockContents) error {
+	return m.SubmitBlindedBlockContentsFunc(ctx, contents)
+}
+
 func (m Mock) SubmitAttestations(ctx context.Context, attestations []*eth2p0.Attestation) error {
 	return m.SubmitAttestationsFunc(ctx, attestations)
 }
@@ -177,6 +276,10 @@ func (m Mock) SubmitVoluntaryExit(ctx context.Context, exit *eth2p0.SignedVolunt
 	return m.SubmitVoluntaryExitFunc(ctx, exit)
 }
 
+func (m Mock) SubmitBLSToExecutionChanges(ctx context.Context, changes []*capella.SignedBLSToExecutionChange) error {
+	return m.SubmitBLSToExecutionChangesFunc(ctx, changes)
+}
+
 func (m Mock) AttestationData(ctx context.Context, slot eth2p0.Slot, committeeIndex eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
 	return m.AttestationDataFunc(ctx, slot, committeeIndex)
 }
@@ -213,6 +316,30 @@ func (m Mock) NodeSyncing(ctx context.Context) (*eth2v1.SyncState, error) {
 	return m.NodeSyncingFunc(ctx)
 }
 
+// NodeSyncState returns the NodeSyncStateFunc result if set, otherwise the NodeSyncingFunc result.
+func (m Mock) NodeSyncState(ctx context.Context) (*eth2wrap.NodeSyncState, error) {
+	if m.NodeSyncStateFunc != nil {
+		return m.NodeSyncStateFunc(ctx)
+	}
+
+	state, err := m.NodeSyncingFunc(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eth2wrap.NodeSyncState{
+		HeadSlot:     state.HeadSlot,
+		SyncDistance: state.SyncDistance,
+		IsSyncing:    state.IsSyncing,
+		IsOptimistic: state.IsOptimistic,
+	}, nil
+}
+
+// NodeHealth returns nil since the mock is not health scored.
+func (Mock) NodeHealth() []eth2wrap.NodeHealth {
+	return nil
+}
+
 func (m Mock) SubmitValidatorRegistrations(ctx context.Context, registrations []*eth2api.VersionedSignedValidatorRegistration) error {
 	return m.SubmitValidatorRegistrationsFunc(ctx, registrations)
 }
@@ -273,6 +400,10 @@ func (m Mock) ForkSchedule(ctx context.Context) ([]*eth2p0.Fork, error) {
 	return m.ForkScheduleFunc(ctx)
 }
 
+func (m Mock) Events(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+	return m.EventsFunc(ctx, topics, handler)
+}
+
 func (m Mock) ProposerConfig(ctx context.Context) (*eth2exp.ProposerConfigResponse, error) {
 	return m.ProposerConfigFunc(ctx)
 }
@@ -6,6 +6,7 @@ import (
 	"context"
 	"testing"
 
+	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/stretchr/testify/require"
 
@@ -66,3 +67,23 @@ func TestAttestationStore(t *testing.T) {
 	_, err = bmock.AggregateAttestation(ctx, 0, root) // Deleted.
 	require.Error(t, err)
 }
+
+func TestDenebBlocks(t *testing.T) {
+	bmock, err := beaconmock.New(beaconmock.WithDenebBlocks())
+	require.NoError(t, err)
+
+	const slot = 123
+	randao := testutil.RandomEth2Signature()
+
+	block, err := bmock.BeaconBlockProposal(context.Background(), slot, randao, nil)
+	require.NoError(t, err)
+	require.Equal(t, eth2spec.DataVersionDeneb, block.Version)
+	require.EqualValues(t, slot, block.Deneb.Slot)
+	require.Equal(t, randao, block.Deneb.Body.RANDAOReveal)
+
+	blinded, err := bmock.BlindedBeaconBlockProposal(context.Background(), slot, randao, nil)
+	require.NoError(t, err)
+	require.Equal(t, eth2spec.DataVersionDeneb, blinded.Version)
+	require.EqualValues(t, slot, blinded.Deneb.Slot)
+	require.Equal(t, randao, blinded.Deneb.Body.RANDAOReveal)
+}
@@ -22,8 +22,9 @@ import (
 )
 
 const (
-	topicHead  = "head"
-	topicBlock = "block"
+	topicHead       = "head"
+	topicBlock      = "block"
+	topicChainReorg = "chain_reorg" // Supported but never published.
 )
 
 func newHeadProducer() *headProducer {
@@ -37,8 +38,9 @@ func newHeadProducer() *headProducer {
 // headProducer is a stateful struct for providing deterministic block roots based on slot events.
 type headProducer struct {
 	// Immutable state
-	server *sse.Server
-	quit   chan struct{}
+	server        *sse.Server
+	quit          chan struct{}
+	slotsPerEpoch uint64
 
 	// Mutable state
 	mu             sync.Mutex
@@ -57,6 +59,10 @@ func (p *headProducer) Start(httpMock HTTPMock) error {
 	if err != nil {
 		return err
 	}
+	p.slotsPerEpoch, err = httpMock.SlotsPerEpoch(ctx)
+	if err != nil {
+		return err
+	}
 
 	startSlotTicker(p.quit, p.updateHead, genesisTime, slotDuration)
 
@@ -104,7 +110,7 @@ func (p *headProducer) setStreamIDs(topic string, streamID string) {
 
 // updateHead updates current head based on provided slot.
 func (p *headProducer) updateHead(slot eth2p0.Slot) {
-	currentHead := pseudoRandomHeadEvent(slot)
+	currentHead := pseudoRandomHeadEvent(slot, p.slotsPerEpoch)
 	p.setCurrentHead(currentHead)
 
 	currentBlock := &eth2v1.BlockEvent{
@@ -119,7 +125,7 @@ func (p *headProducer) updateHead(slot eth2p0.Slot) {
 		State:                     fmt.Sprintf("%#x", currentHead.State),
 		EpochTransition:           currentHead.EpochTransition,
 		CurrentDutyDependentRoot:  fmt.Sprintf("%#x", currentHead.CurrentDutyDependentRoot),
-		PreviousDutyDependentRoot: fmt.Sprintf("%#x", currentHead.CurrentDutyDependentRoot),
+		PreviousDutyDependentRoot: fmt.Sprintf("%#x", currentHead.PreviousDutyDependentRoot),
 		ExecutionOptmistic:        false,
 	}
 	headData, err := json.Marshal(headJSON)
@@ -230,7 +236,7 @@ func (p *headProducer) handleEvents(w http.ResponseWriter, r *http.Request) {
 	r.URL.RawQuery = query.Encode()
 
 	for _, topic := range query["topics"] {
-		if topic != topicHead && topic != topicBlock {
+		if topic != topicHead && topic != topicBlock && topic != topicChainReorg {
 			log.Warn(context.Background(), "Unsupported topic requested", nil, z.Str("topic", topic))
 			w.WriteHeader(http.StatusInternalServerError)
 			resp, err := json.Marshal(errorMsgJSON{
@@ -281,22 +287,42 @@ func startSlotTicker(quit chan struct{}, callback func(eth2p0.Slot), genesisTime
 	}()
 }
 
-func pseudoRandomHeadEvent(slot eth2p0.Slot) *eth2v1.HeadEvent {
-	r := rand.New(rand.NewSource(int64(slot))) //nolint:gosec
+// pseudoRandomHeadEvent returns a deterministic head event for the slot. The duty dependent roots
+// are the block roots of the last slots of the previous two epochs, so they only change per epoch.
+func pseudoRandomHeadEvent(slot eth2p0.Slot, slotsPerEpoch uint64) *eth2v1.HeadEvent {
+	block, state := pseudoRandomRoots(slot)
 
-	root := func() eth2p0.Root {
-		var root eth2p0.Root
-		_, _ = r.Read(root[:])
+	dependentRoot := func(epochsAgo uint64) eth2p0.Root {
+		epoch := uint64(slot) / slotsPerEpoch
+		if epoch < epochsAgo {
+			return eth2p0.Root{} // Genesis block root.
+		}
+
+		root, _ := pseudoRandomRoots(eth2p0.Slot((epoch-epochsAgo+1)*slotsPerEpoch) - 1)
 
 		return root
 	}
 
 	return &eth2v1.HeadEvent{
 		Slot:                      slot,
-		Block:                     root(),
-		State:                     root(),
+		Block:                     block,
+		State:                     state,
 		EpochTransition:           false,
-		CurrentDutyDependentRoot:  root(),
-		PreviousDutyDependentRoot: root(),
+		CurrentDutyDependentRoot:  dependentRoot(1),
+		PreviousDutyDependentRoot: dependentRoot(2),
+	}
+}
+
+// pseudoRandomRoots returns the deterministic block and state roots of the slot.
+func pseudoRandomRoots(slot eth2p0.Slot) (eth2p0.Root, eth2p0.Root) {
+	r := rand.New(rand.NewSource(int64(slot))) //nolint:gosec
+
+	root := func() eth2p0.Root {
+		var root eth2p0.Root
+		_, _ = r.Read(root[:])
+
+		return root
 	}
+
+	return root(), root()
 }
@@ -137,3 +137,22 @@ type StopBackOff struct{}
 func (b StopBackOff) Reset() {}
 
 func (b StopBackOff) NextBackOff() time.Duration { return Stop }
+
+func TestPseudoRandomHeadEvent(t *testing.T) {
+	const slotsPerEpoch = 4
+
+	// Dependent roots are the block roots of the last slots of the previous two epochs.
+	lastBlock := pseudoRandomHeadEvent(2*slotsPerEpoch-1, slotsPerEpoch).Block
+	for slot := eth2p0.Slot(2 * slotsPerEpoch); slot < 3*slotsPerEpoch; slot++ {
+		head := pseudoRandomHeadEvent(slot, slotsPerEpoch)
+		require.Equal(t, lastBlock, head.CurrentDutyDependentRoot)
+		require.Equal(t, pseudoRandomHeadEvent(slotsPerEpoch-1, slotsPerEpoch).Block, head.PreviousDutyDependentRoot)
+	}
+
+	// Next epoch's previous dependent root is this epoch's current dependent root.
+	require.Equal(t, lastBlock, pseudoRandomHeadEvent(3*slotsPerEpoch, slotsPerEpoch).PreviousDutyDependentRoot)
+
+	// Genesis dependent roots are zero.
+	require.Equal(t, eth2p0.Root{}, pseudoRandomHeadEvent(0, slotsPerEpoch).CurrentDutyDependentRoot)
+	require.Equal(t, eth2p0.Root{}, pseudoRandomHeadEvent(slotsPerEpoch, slotsPerEpoch).PreviousDutyDependentRoot)
+}
@@ -12,10 +12,14 @@ import (
 	"strings"
 	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
 	eth2api "github.com/attestantio/go-eth2-client/api"
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/jonboulle/clockwork"
 	"github.com/prysmaticlabs/go-bitfield"
@@ -456,6 +460,75 @@ func WithClock(clock clockwork.Clock) Option {
 	}
 }
 
+// WithDenebBlocks configures the mock to return deneb block and blinded block proposals, including blob sidecars.
+func WithDenebBlocks() Option {
+	return func(mock *Mock) {
+		mock.BeaconBlockProposalFunc = func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (*eth2spec.VersionedBeaconBlock, error) {
+			return denebBlock(slot, randaoReveal, graffiti), nil
+		}
+		mock.BlindedBeaconBlockProposalFunc = func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (*eth2api.VersionedBlindedBeaconBlock, error) {
+			return denebBlindedBlock(slot, randaoReveal, graffiti), nil
+		}
+		mock.BlockContentsProposalFunc = func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BeaconBlockProposal, error) {
+			block := denebBlock(slot, randaoReveal, graffiti)
+
+			var sidecars []*deneb.BlobSidecar
+			for i, commitment := range block.Deneb.Body.BlobKzgCommitments {
+				sidecar := testutil.RandomDenebBlobSidecar(deneb.BlobIndex(i))
+				sidecar.Slot = slot
+				sidecar.ProposerIndex = block.Deneb.ProposerIndex
+				sidecar.KzgCommitment = commitment
+				sidecars = append(sidecars, sidecar)
+			}
+
+			return eth2wrap.BeaconBlockProposal{Address: mock.Address(), Block: block, BlobSidecars: sidecars}, nil
+		}
+		mock.BlindedBlockContentsProposalFunc = func(ctx context.Context, slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) (eth2wrap.BlindedBeaconBlockProposal, error) {
+			block := denebBlindedBlock(slot, randaoReveal, graffiti)
+
+			var sidecars []*eth2deneb.BlindedBlobSidecar
+			for i, commitment := range block.Deneb.Body.BlobKzgCommitments {
+				sidecar := testutil.RandomDenebBlindedBlobSidecar(deneb.BlobIndex(i))
+				sidecar.Slot = slot
+				sidecar.ProposerIndex = block.Deneb.ProposerIndex
+				sidecar.KzgCommitment = commitment
+				sidecars = append(sidecars, sidecar)
+			}
+
+			return eth2wrap.BlindedBeaconBlockProposal{Address: mock.Address(), Block: block, BlindedBlobSidecars: sidecars}, nil
+		}
+		mock.SignedBeaconBlockFunc = func(_ context.Context, blockID string) (*eth2spec.VersionedSignedBeaconBlock, error) {
+			return testutil.RandomDenebVersionedSignedBeaconBlock(), nil // Note the slot is probably wrong.
+		}
+	}
+}
+
+// denebBlock returns a random deneb block for the provided slot, randao and graffiti.
+func denebBlock(slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) *eth2spec.VersionedBeaconBlock {
+	block := &eth2spec.VersionedBeaconBlock{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   testutil.RandomDenebBeaconBlock(),
+	}
+	block.Deneb.Slot = slot
+	block.Deneb.Body.RANDAOReveal = randaoReveal
+	block.Deneb.Body.Graffiti = array32(graffiti)
+
+	return block
+}
+
+// denebBlindedBlock returns a random deneb blinded block for the provided slot, randao and graffiti.
+func denebBlindedBlock(slot eth2p0.Slot, randaoReveal eth2p0.BLSSignature, graffiti []byte) *eth2api.VersionedBlindedBeaconBlock {
+	block := &eth2api.VersionedBlindedBeaconBlock{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb:   testutil.RandomDenebBlindedBeaconBlock(),
+	}
+	block.Deneb.Slot = slot
+	block.Deneb.Body.RANDAOReveal = randaoReveal
+	block.Deneb.Body.Graffiti = array32(graffiti)
+
+	return block
+}
+
 // defaultMock returns a minimum viable mock that doesn't panic and returns mostly empty responses.
 func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clock, headProducer *headProducer) Mock {
 	attStore := newAttestationStore(httpMock)
@@ -502,6 +575,14 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		NodePeerCountFunc: func(ctx context.Context) (int, error) {
 			return 80, nil
 		},
+		ValidatorLivenessFunc: func(_ context.Context, _ eth2p0.Epoch, indices []eth2p0.ValidatorIndex) ([]*eth2wrap.ValidatorLiveness, error) {
+			var resp []*eth2wrap.ValidatorLiveness
+			for _, index := range indices {
+				resp = append(resp, &eth2wrap.ValidatorLiveness{Index: index})
+			}
+
+			return resp, nil
+		},
 		AttestationDataFunc: func(ctx context.Context, slot eth2p0.Slot, index eth2p0.CommitteeIndex) (*eth2p0.AttestationData, error) {
 			return attStore.NewAttestationData(ctx, slot, index)
 		},
@@ -534,9 +615,18 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		SubmitBlindedBeaconBlockFunc: func(context.Context, *eth2api.VersionedSignedBlindedBeaconBlock) error {
 			return nil
 		},
+		SubmitBlockContentsFunc: func(context.Context, *eth2deneb.SignedBlockContents) error {
+			return nil
+		},
+		SubmitBlindedBlockContentsFunc: func(context.Context, *eth2deneb.SignedBlindedBlockContents) error {
+			return nil
+		},
 		SubmitVoluntaryExitFunc: func(context.Context, *eth2p0.SignedVoluntaryExit) error {
 			return nil
 		},
+		SubmitBLSToExecutionChangesFunc: func(context.Context, []*capella.SignedBLSToExecutionChange) error {
+			return nil
+		},
 		GenesisTimeFunc: func(ctx context.Context) (time.Time, error) {
 			return httpMock.GenesisTime(ctx)
 		},
@@ -588,6 +678,9 @@ func defaultMock(httpMock HTTPMock, httpServer *http.Server, clock clockwork.Clo
 		ForkScheduleFunc: func(ctx context.Context) ([]*eth2p0.Fork, error) {
 			return httpMock.ForkSchedule(ctx)
 		},
+		EventsFunc: func(ctx context.Context, topics []string, handler eth2client.EventHandlerFunc) error {
+			return httpMock.Events(ctx, topics, handler)
+		},
 		ProposerConfigFunc: func(ctx context.Context) (*eth2exp.ProposerConfigResponse, error) {
 			return nil, nil
 		},
@@ -32,15 +32,18 @@ import (
 	"github.com/obolnetwork/charon/tbls"
 	"github.com/obolnetwork/charon/testutil"
 	"github.com/obolnetwork/charon/testutil/beaconmock"
+	"github.com/obolnetwork/charon/testutil/web3signermock"
 )
 
 // vcType enumerates the different types of VCs.
 type vcType int
 
 const (
-	vcUnknown vcType = 0
-	vcVmock   vcType = 1
-	vcTeku    vcType = 2
+	vcUnknown    vcType = 0
+	vcVmock      vcType = 1
+	vcTeku       vcType = 2
+	vcEmbedded   vcType = 3
+	vcWeb3Signer vcType = 4
 )
 
 //go:generate go test . -integration -v -run=TestSimnetDuties
@@ -64,6 +67,18 @@ func TestSimnetDuties(t *testing.T) {
 			duties:        []core.DutyType{core.DutyPrepareAggregator, core.DutyAttester, core.DutyAggregator},
 			vcType:        vcVmock,
 		},
+		{
+			name:          "attester with embedded signer",
+			scheduledType: core.DutyAttester,
+			duties:        []core.DutyType{core.DutyPrepareAggregator, core.DutyAttester, core.DutyAggregator},
+			vcType:        vcEmbedded,
+		},
+		{
+			name:          "attester with web3signer",
+			scheduledType: core.DutyAttester,
+			duties:        []core.DutyType{core.DutyPrepareAggregator, core.DutyAttester, core.DutyAggregator},
+			vcType:        vcWeb3Signer,
+		},
 		{
 			name:          "attester with teku",
 			scheduledType: core.DutyAttester,
@@ -76,6 +91,18 @@ func TestSimnetDuties(t *testing.T) {
 			duties:        []core.DutyType{core.DutyProposer, core.DutyRandao},
 			vcType:        vcVmock,
 		},
+		{
+			name:          "proposer with embedded signer",
+			scheduledType: core.DutyProposer,
+			duties:        []core.DutyType{core.DutyProposer, core.DutyRandao},
+			vcType:        vcEmbedded,
+		},
+		{
+			name:          "proposer with web3signer",
+			scheduledType: core.DutyProposer,
+			duties:        []core.DutyType{core.DutyProposer, core.DutyRandao},
+			vcType:        vcWeb3Signer,
+		},
 		{
 			name:          "proposer with teku",
 			scheduledType: core.DutyProposer,
@@ -115,6 +142,18 @@ func TestSimnetDuties(t *testing.T) {
 			duties:        []core.DutyType{core.DutyPrepareSyncContribution, core.DutySyncMessage, core.DutySyncContribution},
 			vcType:        vcVmock,
 		},
+		{
+			name:          "sync committee with embedded signer",
+			scheduledType: core.DutySyncMessage,
+			duties:        []core.DutyType{core.DutyPrepareSyncContribution, core.DutySyncMessage, core.DutySyncContribution},
+			vcType:        vcEmbedded,
+		},
+		{
+			name:          "sync committee with web3signer",
+			scheduledType: core.DutySyncMessage,
+			duties:        []core.DutyType{core.DutyPrepareSyncContribution, core.DutySyncMessage, core.DutySyncContribution},
+			vcType:        vcWeb3Signer,
+		},
 		{
 			name:          "sync committee with teku",
 			scheduledType: core.DutySyncMessage,
@@ -150,6 +189,10 @@ func TestSimnetDuties(t *testing.T) {
 				}
 			} else if test.vcType == vcVmock {
 				args.VMocks = true
+			} else if test.vcType == vcEmbedded {
+				args.EmbeddedSigner = true
+			} else if test.vcType == vcWeb3Signer {
+				args.Web3Signer = true
 			}
 
 			if test.scheduledType != core.DutyAttester {
@@ -184,6 +227,8 @@ func TestSimnetDuties(t *testing.T) {
 type simnetArgs struct {
 	N                  int
 	VMocks             bool
+	EmbeddedSigner     bool
+	Web3Signer         bool
 	VAPIAddrs          []string
 	P2PKeys            []*k1.PrivateKey
 	SimnetKeys         []tbls.PrivateKey
@@ -343,6 +388,17 @@ func testSimnet(t *testing.T, args simnetArgs, expect *simnetExpect) {
 			SyntheticBlockProposals: args.SyntheticProposals,
 		}
 
+		if args.EmbeddedSigner {
+			conf.Signer = app.SignerEmbedded
+		} else if args.Web3Signer {
+			// The Web3Signer stand-in only uses its beaconmock for network config.
+			bmock, err := beaconmock.New(beaconmock.WithSlotsPerEpoch(1))
+			require.NoError(t, err)
+
+			conf.Signer = app.SignerWeb3Signer
+			conf.Web3SignerAddr = web3signermock.New(t, bmock, args.SimnetKeys[i])
+		}
+
 		eg.Go(func() error {
 			defer cancel()
 			return app.Run(ctx, conf)
@@ -17,12 +17,15 @@ import (
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 	k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/holiman/uint256"
 	"github.com/libp2p/go-libp2p"
 	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -256,6 +259,112 @@ func RandomCapellaExecutionPayload() *capella.ExecutionPayload {
 	}
 }
 
+func RandomDenebBeaconBlock() *deneb.BeaconBlock {
+	return &deneb.BeaconBlock{
+		Slot: RandomSlot(),
+		Body: RandomDenebBeaconBlockBody(),
+	}
+}
+
+func RandomDenebBeaconBlockBody() *deneb.BeaconBlockBody {
+	return &deneb.BeaconBlockBody{
+		RANDAOReveal: RandomEth2Signature(),
+		ETH1Data: &eth2p0.ETH1Data{
+			DepositRoot:  RandomRoot(),
+			DepositCount: 0,
+			BlockHash:    RandomBytes32(),
+		},
+		Graffiti:              RandomArray32(),
+		ProposerSlashings:     []*eth2p0.ProposerSlashing{},
+		AttesterSlashings:     []*eth2p0.AttesterSlashing{},
+		Attestations:          []*eth2p0.Attestation{RandomAttestation(), RandomAttestation()},
+		Deposits:              []*eth2p0.Deposit{},
+		VoluntaryExits:        []*eth2p0.SignedVoluntaryExit{},
+		SyncAggregate:         RandomSyncAggregate(),
+		ExecutionPayload:      RandomDenebExecutionPayload(),
+		BLSToExecutionChanges: []*capella.SignedBLSToExecutionChange{},
+		BlobKzgCommitments:    []deneb.KzgCommitment{RandomKZGCommitment(), RandomKZGCommitment()},
+	}
+}
+
+func RandomDenebExecutionPayload() *deneb.ExecutionPayload {
+	return &deneb.ExecutionPayload{
+		ParentHash:    RandomArray32(),
+		StateRoot:     RandomArray32(),
+		ReceiptsRoot:  RandomArray32(),
+		PrevRandao:    RandomArray32(),
+		ExtraData:     RandomBytes32(),
+		BaseFeePerGas: uint256.NewInt(rand.Uint64()),
+		BlockHash:     RandomArray32(),
+		Transactions:  []bellatrix.Transaction{},
+		Withdrawals:   RandomWithdrawals(),
+		BlobGasUsed:   rand.Uint64(),
+		ExcessBlobGas: rand.Uint64(),
+	}
+}
+
+// RandomKZGCommitment returns a random KZG commitment.
+func RandomKZGCommitment() deneb.KzgCommitment {
+	var resp deneb.KzgCommitment
+	_, _ = rand.Read(resp[:])
+
+	return resp
+}
+
+// RandomDenebBlobSidecar returns a random deneb blob sidecar with the index.
+func RandomDenebBlobSidecar(index deneb.BlobIndex) *deneb.BlobSidecar {
+	var (
+		blob  deneb.Blob
+		proof deneb.KzgProof
+	)
+	_, _ = rand.Read(blob[:])
+	_, _ = rand.Read(proof[:])
+
+	return &deneb.BlobSidecar{
+		BlockRoot:       RandomRoot(),
+		Index:           index,
+		Slot:            RandomSlot(),
+		BlockParentRoot: RandomRoot(),
+		ProposerIndex:   RandomVIdx(),
+		Blob:            blob,
+		KzgCommitment:   RandomKZGCommitment(),
+		KzgProof:        proof,
+	}
+}
+
+// RandomDenebSignedBlobSidecar returns a random signed deneb blob sidecar with the index.
+func RandomDenebSignedBlobSidecar(index deneb.BlobIndex) *deneb.SignedBlobSidecar {
+	return &deneb.SignedBlobSidecar{
+		Message:   RandomDenebBlobSidecar(index),
+		Signature: RandomEth2Signature(),
+	}
+}
+
+// RandomDenebBlindedBlobSidecar returns a random deneb blinded blob sidecar with the index.
+func RandomDenebBlindedBlobSidecar(index deneb.BlobIndex) *eth2deneb.BlindedBlobSidecar {
+	var proof deneb.KzgProof
+	_, _ = rand.Read(proof[:])
+
+	return &eth2deneb.BlindedBlobSidecar{
+		BlockRoot:       RandomRoot(),
+		Index:           index,
+		Slot:            RandomSlot(),
+		BlockParentRoot: RandomRoot(),
+		ProposerIndex:   RandomVIdx(),
+		BlobRoot:        RandomRoot(),
+		KzgCommitment:   RandomKZGCommitment(),
+		KzgProof:        proof,
+	}
+}
+
+// RandomDenebSignedBlindedBlobSidecar returns a random signed deneb blinded blob sidecar with the index.
+func RandomDenebSignedBlindedBlobSidecar(index deneb.BlobIndex) *eth2deneb.SignedBlindedBlobSidecar {
+	return &eth2deneb.SignedBlindedBlobSidecar{
+		Message:   RandomDenebBlindedBlobSidecar(index),
+		Signature: RandomEth2Signature(),
+	}
+}
+
 func RandomBellatrixCoreVersionedBeaconBlock() core.VersionedBeaconBlock {
 	return core.VersionedBeaconBlock{
 		VersionedBeaconBlock: eth2spec.VersionedBeaconBlock{
@@ -274,6 +383,15 @@ func RandomCapellaCoreVersionedBeaconBlock() core.VersionedBeaconBlock {
 	}
 }
 
+func RandomDenebCoreVersionedBeaconBlock() core.VersionedBeaconBlock {
+	return core.VersionedBeaconBlock{
+		VersionedBeaconBlock: eth2spec.VersionedBeaconBlock{
+			Version: eth2spec.DataVersionDeneb,
+			Deneb:   RandomDenebBeaconBlock(),
+		},
+	}
+}
+
 func RandomBellatrixCoreVersionedSignedBeaconBlock() core.VersionedSignedBeaconBlock {
 	return core.VersionedSignedBeaconBlock{
 		VersionedSignedBeaconBlock: eth2spec.VersionedSignedBeaconBlock{
@@ -298,6 +416,12 @@ func RandomCapellaCoreVersionedSignedBeaconBlock() core.VersionedSignedBeaconBlo
 	}
 }
 
+func RandomDenebCoreVersionedSignedBeaconBlock() core.VersionedSignedBeaconBlock {
+	return core.VersionedSignedBeaconBlock{
+		VersionedSignedBeaconBlock: *RandomDenebVersionedSignedBeaconBlock(),
+	}
+}
+
 // RandomCapellaVersionedSignedBeaconBlock returns a random signed capella beacon block.
 func RandomCapellaVersionedSignedBeaconBlock() *eth2spec.VersionedSignedBeaconBlock {
 	return &eth2spec.VersionedSignedBeaconBlock{
@@ -309,6 +433,17 @@ func RandomCapellaVersionedSignedBeaconBlock() *eth2spec.VersionedSignedBeaconBl
 	}
 }
 
+// RandomDenebVersionedSignedBeaconBlock returns a random signed deneb beacon block.
+func RandomDenebVersionedSignedBeaconBlock() *eth2spec.VersionedSignedBeaconBlock {
+	return &eth2spec.VersionedSignedBeaconBlock{
+		Version: eth2spec.DataVersionDeneb,
+		Deneb: &deneb.SignedBeaconBlock{
+			Message:   RandomDenebBeaconBlock(),
+			Signature: RandomEth2Signature(),
+		},
+	}
+}
+
 func RandomBellatrixBlindedBeaconBlock() *eth2bellatrix.BlindedBeaconBlock {
 	return &eth2bellatrix.BlindedBeaconBlock{
 		Slot:          RandomSlot(),
@@ -368,6 +503,37 @@ func RandomCapellaBlindedBeaconBlockBody() *eth2capella.BlindedBeaconBlockBody {
 	}
 }
 
+func RandomDenebBlindedBeaconBlock() *eth2deneb.BlindedBeaconBlock {
+	return &eth2deneb.BlindedBeaconBlock{
+		Slot:          RandomSlot(),
+		ProposerIndex: RandomVIdx(),
+		ParentRoot:    RandomRoot(),
+		StateRoot:     RandomRoot(),
+		Body:          RandomDenebBlindedBeaconBlockBody(),
+	}
+}
+
+func RandomDenebBlindedBeaconBlockBody() *eth2deneb.BlindedBeaconBlockBody {
+	return &eth2deneb.BlindedBeaconBlockBody{
+		RANDAOReveal: RandomEth2Signature(),
+		ETH1Data: &eth2p0.ETH1Data{
+			DepositRoot:  RandomRoot(),
+			DepositCount: 0,
+			BlockHash:    RandomBytes32(),
+		},
+		Graffiti:               RandomArray32(),
+		ProposerSlashings:      []*eth2p0.ProposerSlashing{},
+		AttesterSlashings:      []*eth2p0.AttesterSlashing{},
+		Attestations:           []*eth2p0.Attestation{RandomAttestation(), RandomAttestation()},
+		Deposits:               []*eth2p0.Deposit{},
+		VoluntaryExits:         []*eth2p0.SignedVoluntaryExit{},
+		SyncAggregate:          RandomSyncAggregate(),
+		ExecutionPayloadHeader: RandomDenebExecutionPayloadHeader(),
+		BLSToExecutionChanges:  []*capella.SignedBLSToExecutionChange{},
+		BlobKzgCommitments:     []deneb.KzgCommitment{RandomKZGCommitment(), RandomKZGCommitment()},
+	}
+}
+
 func RandomBellatrixVersionedBlindedBeaconBlock() core.VersionedBlindedBeaconBlock {
 	return core.VersionedBlindedBeaconBlock{
 		VersionedBlindedBeaconBlock: eth2api.VersionedBlindedBeaconBlock{
@@ -386,6 +552,15 @@ func RandomCapellaVersionedBlindedBeaconBlock() core.VersionedBlindedBeaconBlock
 	}
 }
 
+func RandomDenebVersionedBlindedBeaconBlock() core.VersionedBlindedBeaconBlock {
+	return core.VersionedBlindedBeaconBlock{
+		VersionedBlindedBeaconBlock: eth2api.VersionedBlindedBeaconBlock{
+			Version: eth2spec.DataVersionDeneb,
+			Deneb:   RandomDenebBlindedBeaconBlock(),
+		},
+	}
+}
+
 func RandomBellatrixVersionedSignedBlindedBeaconBlock() core.VersionedSignedBlindedBeaconBlock {
 	return core.VersionedSignedBlindedBeaconBlock{
 		VersionedSignedBlindedBeaconBlock: eth2api.VersionedSignedBlindedBeaconBlock{
@@ -410,6 +585,18 @@ func RandomCapellaVersionedSignedBlindedBeaconBlock() core.VersionedSignedBlinde
 	}
 }
 
+func RandomDenebVersionedSignedBlindedBeaconBlock() core.VersionedSignedBlindedBeaconBlock {
+	return core.VersionedSignedBlindedBeaconBlock{
+		VersionedSignedBlindedBeaconBlock: eth2api.VersionedSignedBlindedBeaconBlock{
+			Version: eth2spec.DataVersionDeneb,
+			Deneb: &eth2deneb.SignedBlindedBeaconBlock{
+				Message:   RandomDenebBlindedBeaconBlock(),
+				Signature: RandomEth2Signature(),
+			},
+		},
+	}
+}
+
 func RandomValidatorRegistration(t *testing.T) *eth2v1.ValidatorRegistration {
 	t.Helper()
 
@@ -622,6 +809,22 @@ func RandomCapellaExecutionPayloadHeader() *capella.ExecutionPayloadHeader {
 	}
 }
 
+func RandomDenebExecutionPayloadHeader() *deneb.ExecutionPayloadHeader {
+	return &deneb.ExecutionPayloadHeader{
+		ParentHash:       RandomArray32(),
+		StateRoot:        RandomArray32(),
+		ReceiptsRoot:     RandomArray32(),
+		PrevRandao:       RandomArray32(),
+		ExtraData:        RandomBytes32(),
+		BaseFeePerGas:    uint256.NewInt(rand.Uint64()),
+		BlockHash:        RandomArray32(),
+		TransactionsRoot: RandomArray32(),
+		WithdrawalsRoot:  RandomArray32(),
+		BlobGasUsed:      rand.Uint64(),
+		ExcessBlobGas:    rand.Uint64(),
+	}
+}
+
 func RandomAttestationDuty(t *testing.T) *eth2v1.AttesterDuty {
 	t.Helper()
 	return &eth2v1.AttesterDuty{
@@ -12,10 +12,12 @@ import (
 	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
 	eth2bellatrix "github.com/attestantio/go-eth2-client/api/v1/bellatrix"
 	eth2capella "github.com/attestantio/go-eth2-client/api/v1/capella"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
 	eth2spec "github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
 	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/obolnetwork/charon/app/errors"
@@ -55,7 +57,7 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 	}
 
 	var pubkey eth2p0.BLSPubKey
-	var block *eth2spec.VersionedBeaconBlock
+	var proposal eth2wrap.BeaconBlockProposal
 	for _, duty := range duties {
 		if duty.Slot != slot {
 			continue
@@ -79,7 +81,7 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 		}
 
 		// Get Unsigned beacon block with given randao and slot
-		block, err = eth2Cl.BeaconBlockProposal(ctx, slot, randao, nil)
+		proposal, err = eth2Cl.BlockContentsProposal(ctx, slot, randao, nil)
 		if err != nil {
 			return errors.Wrap(err, "vmock beacon block proposal")
 		}
@@ -88,6 +90,7 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 		break
 	}
 
+	block := proposal.Block
 	if block == nil {
 		return errors.New("block not found")
 	}
@@ -132,6 +135,21 @@ func ProposeBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc
 			Message:   block.Capella,
 			Signature: sig,
 		}
+	case eth2spec.DataVersionDeneb:
+		signedSidecars := []*deneb.SignedBlobSidecar{}
+		for _, sidecar := range proposal.BlobSidecars {
+			sidecarSig, err := signBlobSidecar(ctx, eth2Cl, signFunc, pubkey, epoch, sidecar)
+			if err != nil {
+				return err
+			}
+
+			signedSidecars = append(signedSidecars, &deneb.SignedBlobSidecar{Message: sidecar, Signature: sidecarSig})
+		}
+
+		return eth2Cl.SubmitBlockContents(ctx, &eth2deneb.SignedBlockContents{
+			SignedBlock:        &deneb.SignedBeaconBlock{Message: block.Deneb, Signature: sig},
+			SignedBlobSidecars: signedSidecars,
+		})
 	default:
 		return errors.New("invalid block")
 	}
@@ -166,7 +184,7 @@ func ProposeBlindedBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc S
 	}
 
 	var pubkey eth2p0.BLSPubKey
-	var block *eth2api.VersionedBlindedBeaconBlock
+	var proposal eth2wrap.BlindedBeaconBlockProposal
 	for _, duty := range duties {
 		if duty.Slot != slot {
 			continue
@@ -190,7 +208,7 @@ func ProposeBlindedBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc S
 		}
 
 		// Get Unsigned beacon block with given randao and slot
-		block, err = eth2Cl.BlindedBeaconBlockProposal(ctx, slot, randao, nil)
+		proposal, err = eth2Cl.BlindedBlockContentsProposal(ctx, slot, randao, nil)
 		if err != nil {
 			return errors.Wrap(err, "vmock blinded beacon block proposal")
 		}
@@ -199,6 +217,7 @@ func ProposeBlindedBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc S
 		break
 	}
 
+	block := proposal.Block
 	if block == nil {
 		return errors.New("block not found")
 	}
@@ -235,6 +254,21 @@ func ProposeBlindedBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc S
 			Message:   block.Capella,
 			Signature: sig,
 		}
+	case eth2spec.DataVersionDeneb:
+		signedSidecars := []*eth2deneb.SignedBlindedBlobSidecar{}
+		for _, sidecar := range proposal.BlindedBlobSidecars {
+			sidecarSig, err := signBlobSidecar(ctx, eth2Cl, signFunc, pubkey, epoch, sidecar)
+			if err != nil {
+				return err
+			}
+
+			signedSidecars = append(signedSidecars, &eth2deneb.SignedBlindedBlobSidecar{Message: sidecar, Signature: sidecarSig})
+		}
+
+		return eth2Cl.SubmitBlindedBlockContents(ctx, &eth2deneb.SignedBlindedBlockContents{
+			SignedBlindedBlock:        &eth2deneb.SignedBlindedBeaconBlock{Message: block.Deneb, Signature: sig},
+			SignedBlindedBlobSidecars: signedSidecars,
+		})
 	default:
 		return errors.New("invalid block")
 	}
@@ -242,6 +276,23 @@ func ProposeBlindedBlock(ctx context.Context, eth2Cl eth2wrap.Client, signFunc S
 	return eth2Cl.SubmitBlindedBeaconBlock(ctx, signedBlock)
 }
 
+// signBlobSidecar returns the signature of the (blinded) blob sidecar, which is the same for both since their roots are equal.
+func signBlobSidecar(ctx context.Context, eth2Cl eth2wrap.Client, signFunc SignFunc, pubkey eth2p0.BLSPubKey,
+	epoch eth2p0.Epoch, sidecar interface{ HashTreeRoot() ([32]byte, error) },
+) (eth2p0.BLSSignature, error) {
+	sigRoot, err := sidecar.HashTreeRoot()
+	if err != nil {
+		return eth2p0.BLSSignature{}, errors.Wrap(err, "blob sidecar root")
+	}
+
+	sigData, err := signing.GetDataRoot(ctx, eth2Cl, signing.DomainBlobSidecar, epoch, sigRoot)
+	if err != nil {
+		return eth2p0.BLSSignature{}, err
+	}
+
+	return signFunc(pubkey, sigData[:])
+}
+
 // RegistrationsFromProposerConfig returns all enabled builder-API registrations from upstream proposer config.
 func RegistrationsFromProposerConfig(ctx context.Context, eth2Cl eth2wrap.Client) (map[eth2p0.BLSPubKey]*eth2api.VersionedValidatorRegistration, error) {
 	confs, err := eth2Cl.ProposerConfig(ctx)
@@ -0,0 +1,299 @@
+// Copyright © 2022-2023 Obol Labs Inc. Licensed under the terms of a Business Source License 1.1
+
+// Package web3signermock provides an in-process Web3Signer stand-in for testing.
+package web3signermock
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	eth2v1 "github.com/attestantio/go-eth2-client/api/v1"
+	eth2deneb "github.com/attestantio/go-eth2-client/api/v1/deneb"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	eth2p0 "github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/obolnetwork/charon/app/errors"
+	"github.com/obolnetwork/charon/app/eth2wrap"
+	"github.com/obolnetwork/charon/eth2util/signing"
+	"github.com/obolnetwork/charon/eth2util/web3signer"
+	"github.com/obolnetwork/charon/tbls"
+)
+
+const signPath = "/api/v1/eth2/sign/"
+
+// signRequest is the Web3Signer eth2 sign request as documented by the Web3Signer API. It is defined independently of
+// the web3signer package types, with quantities as decimal strings, so that encoding mismatches are detected.
+type signRequest struct {
+	Type                        string                        `json:"type"`
+	ForkInfo                    *forkInfo                     `json:"fork_info"`
+	SigningRoot                 *eth2p0.Root                  `json:"signingRoot"`
+	Attestation                 *eth2p0.AttestationData       `json:"attestation"`
+	BeaconBlock                 *beaconBlock                  `json:"beacon_block"`
+	RandaoReveal                *randaoReveal                 `json:"randao_reveal"`
+	AggregationSlot             *aggregationSlot              `json:"aggregation_slot"`
+	AggregateAndProof           *eth2p0.AggregateAndProof     `json:"aggregate_and_proof"`
+	SyncCommitteeMessage        *syncCommitteeMessage         `json:"sync_committee_message"`
+	SyncAggregatorSelectionData *syncAggregatorSelectionData  `json:"sync_aggregator_selection_data"`
+	ContributionAndProof        *altair.ContributionAndProof  `json:"contribution_and_proof"`
+	VoluntaryExit               *eth2p0.VoluntaryExit         `json:"voluntary_exit"`
+	ValidatorRegistration       *eth2v1.ValidatorRegistration `json:"validator_registration"`
+	BlobSidecar                 *eth2deneb.BlindedBlobSidecar `json:"blob_sidecar"`
+}
+
+type forkInfo struct {
+	Fork                  *eth2p0.Fork `json:"fork"`
+	GenesisValidatorsRoot eth2p0.Root  `json:"genesis_validators_root"`
+}
+
+type beaconBlock struct {
+	Version     string                    `json:"version"`
+	Block       json.RawMessage           `json:"block"`
+	BlockHeader *eth2p0.BeaconBlockHeader `json:"block_header"`
+}
+
+type randaoReveal struct {
+	Epoch string `json:"epoch"`
+}
+
+type aggregationSlot struct {
+	Slot string `json:"slot"`
+}
+
+type syncCommitteeMessage struct {
+	BeaconBlockRoot eth2p0.Root `json:"beacon_block_root"`
+	Slot            string      `json:"slot"`
+}
+
+type syncAggregatorSelectionData struct {
+	Slot              string `json:"slot"`
+	SubcommitteeIndex string `json:"subcommittee_index"`
+}
+
+// New starts and returns the address of a Web3Signer stand-in signing with the provided secrets.
+// Like Web3Signer, it calculates the signing root from the typed request data and the fork info
+// and only uses the eth2 client for network config. Requests with mismatching signing roots are refused.
+func New(t *testing.T, eth2Cl eth2wrap.Client, secrets ...tbls.PrivateKey) string {
+	t.Helper()
+
+	keys := make(map[string]tbls.PrivateKey)
+	for _, secret := range secrets {
+		pubkey, err := tbls.SecretToPublicKey(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keys["0x"+hex.EncodeToString(pubkey[:])] = secret
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		secret, ok := keys[strings.TrimPrefix(r.URL.Path, signPath)]
+		if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, signPath) || !ok {
+			http.Error(w, "key not found", http.StatusNotFound)
+			return
+		}
+
+		var req signRequest
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		sigRoot, err := signingRoot(r.Context(), eth2Cl, req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		} else if req.SigningRoot != nil && *req.SigningRoot != sigRoot {
+			http.Error(w, "signing root mismatch", http.StatusBadRequest)
+			return
+		}
+
+		sig, err := tbls.Sign(secret, sigRoot[:])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"signature": "0x" + hex.EncodeToString(sig[:])})
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+// signingRoot returns the signing root of the request.
+func signingRoot(ctx context.Context, eth2Cl eth2wrap.Client, req signRequest) (eth2p0.Root, error) {
+	slotsPerEpoch, err := eth2Cl.SlotsPerEpoch(ctx)
+	if err != nil {
+		return eth2p0.Root{}, err
+	}
+
+	epochOf := func(slot eth2p0.Slot) eth2p0.Epoch {
+		return eth2p0.Epoch(uint64(slot) / slotsPerEpoch)
+	}
+
+	var (
+		domain  signing.DomainName
+		epoch   eth2p0.Epoch
+		msgRoot [32]byte
+	)
+	switch {
+	case req.Type == string(web3signer.TypeAttestation) && req.Attestation != nil:
+		domain, epoch = signing.DomainBeaconAttester, req.Attestation.Target.Epoch
+		msgRoot, err = req.Attestation.HashTreeRoot()
+	case req.Type == string(web3signer.TypeBlockV2) && req.BeaconBlock != nil:
+		domain = signing.DomainBeaconProposer
+		epoch, msgRoot, err = blockRoot(req.BeaconBlock, epochOf)
+	case req.Type == string(web3signer.TypeRandaoReveal) && req.RandaoReveal != nil:
+		var val uint64
+		val, err = parseUint(req.RandaoReveal.Epoch)
+		domain, epoch, msgRoot = signing.DomainRandao, eth2p0.Epoch(val), uint64Root(val)
+	case req.Type == string(web3signer.TypeAggregationSlot) && req.AggregationSlot != nil:
+		var val uint64
+		val, err = parseUint(req.AggregationSlot.Slot)
+		domain, epoch, msgRoot = signing.DomainSelectionProof, epochOf(eth2p0.Slot(val)), uint64Root(val)
+	case req.Type == string(web3signer.TypeAggregateAndProof) && req.AggregateAndProof != nil:
+		domain, epoch = signing.DomainAggregateAndProof, epochOf(req.AggregateAndProof.Aggregate.Data.Slot)
+		msgRoot, err = req.AggregateAndProof.HashTreeRoot()
+	case req.Type == string(web3signer.TypeSyncCommitteeMessage) && req.SyncCommitteeMessage != nil:
+		var val uint64
+		val, err = parseUint(req.SyncCommitteeMessage.Slot)
+		domain, epoch = signing.DomainSyncCommittee, epochOf(eth2p0.Slot(val))
+		msgRoot = req.SyncCommitteeMessage.BeaconBlockRoot
+	case req.Type == string(web3signer.TypeSyncCommitteeSelectionProof) && req.SyncAggregatorSelectionData != nil:
+		var slot, subcommIdx uint64
+		if slot, err = parseUint(req.SyncAggregatorSelectionData.Slot); err != nil {
+			break
+		} else if subcommIdx, err = parseUint(req.SyncAggregatorSelectionData.SubcommitteeIndex); err != nil {
+			break
+		}
+		domain, epoch = signing.DomainSyncCommitteeSelectionProof, epochOf(eth2p0.Slot(slot))
+		msgRoot, err = (&altair.SyncAggregatorSelectionData{
+			Slot:              eth2p0.Slot(slot),
+			SubcommitteeIndex: subcommIdx,
+		}).HashTreeRoot()
+	case req.Type == string(web3signer.TypeSyncCommitteeContributionAndProof) && req.ContributionAndProof != nil:
+		domain, epoch = signing.DomainContributionAndProof, epochOf(req.ContributionAndProof.Contribution.Slot)
+		msgRoot, err = req.ContributionAndProof.HashTreeRoot()
+	case req.Type == string(web3signer.TypeVoluntaryExit) && req.VoluntaryExit != nil:
+		domain, epoch = signing.DomainExit, req.VoluntaryExit.Epoch
+		msgRoot, err = req.VoluntaryExit.HashTreeRoot()
+	case req.Type == string(web3signer.TypeValidatorRegistration) && req.ValidatorRegistration != nil:
+		domain = signing.DomainApplicationBuilder
+		msgRoot, err = req.ValidatorRegistration.HashTreeRoot()
+	case req.Type == string(web3signer.TypeBlobSidecar) && req.BlobSidecar != nil:
+		domain, epoch = signing.DomainBlobSidecar, epochOf(req.BlobSidecar.Slot)
+		msgRoot, err = req.BlobSidecar.HashTreeRoot()
+	default:
+		return eth2p0.Root{}, errors.New("unsupported request type")
+	}
+	if err != nil {
+		return eth2p0.Root{}, err
+	}
+
+	domainRoot, err := domainFromForkInfo(ctx, eth2Cl, domain, epoch, req.ForkInfo)
+	if err != nil {
+		return eth2p0.Root{}, err
+	}
+
+	return (&eth2p0.SigningData{ObjectRoot: msgRoot, Domain: domainRoot}).HashTreeRoot()
+}
+
+// blockRoot returns the epoch and root of the block.
+func blockRoot(block *beaconBlock, epochOf func(eth2p0.Slot) eth2p0.Epoch) (eth2p0.Epoch, [32]byte, error) {
+	if block.BlockHeader != nil {
+		root, err := block.BlockHeader.HashTreeRoot()
+		return epochOf(block.BlockHeader.Slot), root, err
+	}
+
+	switch block.Version {
+	case "PHASE0":
+		b := new(eth2p0.BeaconBlock)
+		if err := json.Unmarshal(block.Block, b); err != nil {
+			return 0, [32]byte{}, errors.Wrap(err, "unmarshal phase0 block")
+		}
+		root, err := b.HashTreeRoot()
+
+		return epochOf(b.Slot), root, err
+	case "ALTAIR":
+		b := new(altair.BeaconBlock)
+		if err := json.Unmarshal(block.Block, b); err != nil {
+			return 0, [32]byte{}, errors.Wrap(err, "unmarshal altair block")
+		}
+		root, err := b.HashTreeRoot()
+
+		return epochOf(b.Slot), root, err
+	default:
+		return 0, [32]byte{}, errors.New("block header required")
+	}
+}
+
+// domainFromForkInfo returns the signing domain using the fork info, or the genesis fork version for the builder domain.
+func domainFromForkInfo(ctx context.Context, eth2Cl eth2wrap.Client, name signing.DomainName, epoch eth2p0.Epoch,
+	forkInfo *forkInfo,
+) (eth2p0.Domain, error) {
+	spec, err := eth2Cl.Spec(ctx)
+	if err != nil {
+		return eth2p0.Domain{}, err
+	}
+
+	domainType, ok := spec[string(name)].(eth2p0.DomainType)
+	if !ok {
+		return eth2p0.Domain{}, errors.New("invalid domain type")
+	}
+
+	var forkData eth2p0.ForkData
+	if name == signing.DomainApplicationBuilder {
+		genesisVersion, ok := spec["GENESIS_FORK_VERSION"].(eth2p0.Version)
+		if !ok {
+			return eth2p0.Domain{}, errors.New("invalid genesis fork version")
+		}
+		forkData.CurrentVersion = genesisVersion
+	} else if forkInfo == nil || forkInfo.Fork == nil {
+		return eth2p0.Domain{}, errors.New("missing fork info")
+	} else {
+		forkData.CurrentVersion = forkInfo.Fork.CurrentVersion
+		if epoch < forkInfo.Fork.Epoch {
+			forkData.CurrentVersion = forkInfo.Fork.PreviousVersion
+		}
+		forkData.GenesisValidatorsRoot = forkInfo.GenesisValidatorsRoot
+	}
+
+	forkDataRoot, err := forkData.HashTreeRoot()
+	if err != nil {
+		return eth2p0.Domain{}, err
+	}
+
+	var domain eth2p0.Domain
+	copy(domain[:], domainType[:])
+	copy(domain[4:], forkDataRoot[:28])
+
+	return domain, nil
+}
+
+// parseUint returns the uint64 of the decimal string quantity.
+func parseUint(val string) (uint64, error) {
+	resp, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "parse quantity")
+	}
+
+	return resp, nil
+}
+
+// uint64Root returns the SSZ hash tree root of the uint64.
+func uint64Root(val uint64) [32]byte {
+	var root [32]byte
+	binary.LittleEndian.PutUint64(root[:], val)
+
+	return root
+}